@@ -18,39 +18,54 @@ import (
 )
 
 // CreateBackup fetches blockchain data with the specific range via gRPC
-// and save this data as binary archive to given path
+// and save this data as binary archive to given path.
+// If withState is set, the archive includes the state snapshot of the last block.
+// If resume is set, the blocks following the existing archive are appended to it.
+// It returns the range of the blocks in the archive
 func CreateBackup(
 	conn *grpc.ClientConn,
 	logger hclog.Logger,
 	from uint64,
 	to *uint64,
 	outPath string,
+	withState bool,
+	resume bool,
 ) (uint64, uint64, error) {
-	// always create new file, throw error if the file exists
-	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if withState && resume {
+		return 0, 0, ErrResumeWithState
+	}
+
+	writer, resumed, err := openArchiveWriter(outPath, resume)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	closeFile := func() error {
-		if err := fs.Close(); err != nil {
+	// clean up function for the archive when error occurs in the middle of function,
+	// a new archive is removed and a resumed one keeps its previous blocks
+	closeOnError := func() {
+		if resumed {
+			if err := writer.close(types.ZeroHash); err != nil {
+				logger.Error("an error occurred while closing archive", "err", err)
+			}
+
+			return
+		}
+
+		if err := writer.abort(); err != nil {
 			logger.Error("an error occurred while closing file", "err", err)
 
-			return err
+			return
 		}
 
-		return nil
-	}
-	removeFile := func() {
 		if err := os.Remove(outPath); err != nil {
 			logger.Error("an error occurred while removing file", "err", err)
 		}
 	}
-	// clean up function for the file when error occurs in the middle of function
-	closeAndRemoveFile := func() {
-		if err := closeFile(); err == nil {
-			removeFile()
-		}
+
+	if writer.last != nil {
+		from = writer.last.Number + 1
+
+		logger.Info("Resuming backup", "from", from)
 	}
 
 	signalCh := common.GetTerminationSignalCh()
@@ -68,41 +83,105 @@ func CreateBackup(
 
 	reqTo, reqToHash, err := determineTo(ctx, clt, to)
 	if err != nil {
-		closeAndRemoveFile()
+		closeOnError()
 
 		return 0, 0, err
 	}
 
+	if writer.last != nil && from > reqTo {
+		logger.Info("Backup is up to date", "latest", writer.last.Number)
+
+		return finishArchive(writer, logger, types.ZeroHash)
+	}
+
+	var stateRoot types.Hash
+
+	// the state snapshot precedes the blocks
+	if withState {
+		if stateRoot, err = determineStateRoot(ctx, clt, reqTo, reqToHash); err != nil {
+			closeOnError()
+
+			return 0, 0, err
+		}
+
+		stateStream, err := clt.ExportState(ctx, &proto.ExportStateRequest{
+			Number: reqTo,
+		})
+		if err != nil {
+			closeOnError()
+
+			return 0, 0, err
+		}
+
+		if err := processExportStateStream(stateStream, logger, writer); err != nil {
+			closeOnError()
+
+			return 0, 0, err
+		}
+	}
+
 	stream, err := clt.Export(ctx, &proto.ExportRequest{
 		From: from,
 		To:   reqTo,
 	})
 	if err != nil {
-		closeAndRemoveFile()
+		closeOnError()
 
 		return 0, 0, err
 	}
 
-	if err := writeMetadata(fs, logger, reqTo, reqToHash); err != nil {
-		closeAndRemoveFile()
+	if _, _, err := processExportStream(stream, logger, writer, from, reqTo); err != nil {
+		closeOnError()
 
 		return 0, 0, err
 	}
 
-	resFrom, resTo, err := processExportStream(stream, logger, fs, from, reqTo)
-	if err != nil {
-		closeAndRemoveFile()
+	// the state snapshot is only usable with the block it belongs to
+	if withState && writer.last.Number != reqTo {
+		closeOnError()
 
-		return 0, 0, err
+		return 0, 0, fmt.Errorf("backup ended at block %d before block %d of the state snapshot", writer.last.Number, reqTo)
+	}
+
+	return finishArchive(writer, logger, stateRoot)
+}
+
+// openArchiveWriter creates the archive, or opens the existing one if resume is set.
+// It returns true if the archive is resumed
+func openArchiveWriter(path string, resume bool) (*archiveWriter, bool, error) {
+	if resume {
+		writer, err := resumeArchive(path)
+		if err == nil {
+			return writer, true, nil
+		}
+
+		// nothing to resume, start a new archive
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, false, err
+		}
 	}
 
-	if err := closeFile(); err != nil {
-		removeFile()
+	// always create new file, throw error if the file exists
+	writer, err := createArchive(path)
+
+	return writer, false, err
+}
 
+// finishArchive writes the index of the archive and returns the range of its blocks
+func finishArchive(writer *archiveWriter, logger hclog.Logger, stateRoot types.Hash) (uint64, uint64, error) {
+	if err := writer.close(stateRoot); err != nil {
 		return 0, 0, err
 	}
 
-	return *resFrom, *resTo, nil
+	logger.Info(
+		"Wrote index to backup",
+		"latest", writer.last.Number,
+		"hash", writer.last.Hash,
+		"chunks", len(writer.index.Chunks),
+		"state", stateRoot,
+	)
+
+	return writer.first.Number, writer.last.Number, nil
 }
 
 func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint64, types.Hash, error) {
@@ -127,27 +206,65 @@ func determineTo(ctx context.Context, clt proto.SystemClient, to *uint64) (uint6
 	return uint64(status.Current.Number), types.StringToHash(status.Current.Hash), nil
 }
 
-// writeMetadata writes the latest block height and the block hash to the writer
-func writeMetadata(writer io.Writer, logger hclog.Logger, to uint64, toHash types.Hash) error {
-	metadata := Metadata{
-		Latest:     to,
-		LatestHash: toHash,
+// determineStateRoot returns the state root of the block with the given height and hash
+func determineStateRoot(
+	ctx context.Context,
+	clt proto.SystemClient,
+	number uint64,
+	hash types.Hash,
+) (types.Hash, error) {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: number})
+	if err != nil {
+		return types.Hash{}, err
 	}
 
-	_, err := writer.Write(metadata.MarshalRLP())
-	if err != nil {
-		return err
+	block := types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return types.Hash{}, err
+	}
+
+	if block.Hash() != hash {
+		return types.Hash{}, fmt.Errorf("block %d has changed, expected %s but got %s", number, hash, block.Hash())
+	}
+
+	return block.Header.StateRoot, nil
+}
+
+// processExportStateStream writes the state chunks from the stream to the writer
+func processExportStateStream(stream proto.System_ExportStateClient, logger hclog.Logger, writer *archiveWriter) error {
+	var chunks int
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(io.EOF, err) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := writer.writeState(event.Data); err != nil {
+			return err
+		}
+
+		chunks++
+	}
+
+	// the snapshot always ends with the empty chunk
+	if chunks == 0 {
+		return errors.New("couldn't get the state snapshot")
 	}
 
-	logger.Info("Wrote metadata to backup", "latest", to, "hash", toHash)
+	logger.Info("Wrote state snapshot to backup", "chunks", chunks)
 
-	return err
+	return nil
 }
 
 func processExportStream(
 	stream proto.System_ExportClient,
 	logger hclog.Logger,
-	writer io.Writer,
+	writer *archiveWriter,
 	targetFrom, targetTo uint64,
 ) (*uint64, *uint64, error) {
 	var from, to *uint64
@@ -193,7 +310,7 @@ func processExportStream(
 			return nil, nil, err
 		}
 
-		if _, err := writer.Write(event.Data); err != nil {
+		if err := writer.writeBlocks(event.From, event.To, event.Data); err != nil {
 			return nil, nil, err
 		}
 
@@ -1,10 +1,10 @@
 package archive
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"io"
+	"path/filepath"
 	"testing"
 
 	"github.com/ExzoNetwork/ExzoCoin/server/proto"
@@ -66,8 +66,13 @@ var (
 func init() {
 	genesis.Header.ComputeHash()
 
+	parent := genesis.Header
+
 	for _, b := range blocks {
+		b.Header.ParentHash = parent.Hash
 		b.Header.ComputeHash()
+
+		parent = b.Header
 	}
 }
 
@@ -251,16 +256,23 @@ func Test_processExportStream(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var buffer bytes.Buffer
-			from, to, err := processExportStream(tt.mockSystemExportClient, hclog.NewNullLogger(), &buffer, 0, 0)
+			path := filepath.Join(t.TempDir(), "backup")
+
+			writer, err := createArchive(path)
+			assert.NoError(t, err)
+
+			from, to, err := processExportStream(tt.mockSystemExportClient, hclog.NewNullLogger(), writer, 0, 0)
 
 			assert.Equal(t, tt.err, err)
 			if err != nil {
+				assert.NoError(t, writer.abort())
+
 				return
 			}
 
 			assert.Equal(t, tt.from, *from)
 			assert.Equal(t, tt.to, *to)
+			assert.NoError(t, writer.close(types.ZeroHash))
 
 			// create expected data
 			expectedData := make([]byte, 0)
@@ -270,7 +282,7 @@ func Test_processExportStream(t *testing.T) {
 				}
 				expectedData = append(expectedData, rv.event.Data...)
 			}
-			assert.Equal(t, expectedData, buffer.Bytes())
+			assert.Equal(t, expectedData, readArchiveBlocks(t, path))
 		})
 	}
 }
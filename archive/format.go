@@ -0,0 +1,728 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The archive starts with the magic and the format version, followed by the chunks.
+// Every chunk is framed as
+//
+//	kind (1 byte) | from (8 bytes) | to (8 bytes) | size (4 bytes) | checksum (4 bytes) | payload
+//
+// where the payload is the zstd compressed RLP data and the checksum is the CRC32-C of the
+// frame header and the payload. The state chunks come first, then the block chunks, and the
+// index chunk closes the archive, followed by the footer with the offset of the index chunk
+const (
+	archiveMagic   = "EXZOARCH"
+	archiveVersion = uint16(1)
+
+	archiveHeaderSize = len(archiveMagic) + 2
+	chunkHeaderSize   = 1 + 8 + 8 + 4 + 4
+	footerSize        = 8 + len(archiveMagic)
+
+	// maxChunkPayloadSize limits the size of the chunks read from the archive
+	maxChunkPayloadSize = 64 * 1024 * 1024
+)
+
+type chunkKind byte
+
+const (
+	chunkKindState chunkKind = iota + 1
+	chunkKindBlocks
+	chunkKindIndex
+)
+
+func (k chunkKind) String() string {
+	switch k {
+	case chunkKindState:
+		return "state"
+	case chunkKindBlocks:
+		return "blocks"
+	case chunkKindIndex:
+		return "index"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+var (
+	ErrLegacyArchive           = errors.New("archive has the legacy format")
+	ErrUnsupportedVersion      = errors.New("unsupported archive version")
+	ErrArchiveIncomplete       = errors.New("archive is incomplete, the index is missing")
+	ErrChunkChecksum           = errors.New("checksum of the chunk doesn't match")
+	ErrResumeWithState         = errors.New("backup with state snapshot can't be resumed")
+	errChunkTruncated          = errors.New("chunk is truncated")
+	errUnexpectedChunkKind     = errors.New("unexpected chunk kind")
+	errBrokenHashChain         = errors.New("blocks don't form a chain")
+	errChunkRangeMismatch      = errors.New("blocks of the chunk don't match its range")
+	errIndexMismatch           = errors.New("archive index doesn't match the chunks")
+	errMetadataMismatch        = errors.New("archive metadata doesn't match the latest block")
+	errStateSnapshotIncomplete = errors.New("state snapshot is incomplete")
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// chunkHeader is the frame header of a chunk
+type chunkHeader struct {
+	kind     chunkKind
+	from     uint64
+	to       uint64
+	size     uint32
+	checksum uint32
+}
+
+func (h *chunkHeader) marshal() []byte {
+	buf := make([]byte, chunkHeaderSize)
+
+	buf[0] = byte(h.kind)
+	binary.BigEndian.PutUint64(buf[1:9], h.from)
+	binary.BigEndian.PutUint64(buf[9:17], h.to)
+	binary.BigEndian.PutUint32(buf[17:21], h.size)
+	binary.BigEndian.PutUint32(buf[21:25], h.checksum)
+
+	return buf
+}
+
+func (h *chunkHeader) unmarshal(buf []byte) {
+	h.kind = chunkKind(buf[0])
+	h.from = binary.BigEndian.Uint64(buf[1:9])
+	h.to = binary.BigEndian.Uint64(buf[9:17])
+	h.size = binary.BigEndian.Uint32(buf[17:21])
+	h.checksum = binary.BigEndian.Uint32(buf[21:25])
+}
+
+// computeChecksum returns the checksum of the frame header, excluding the checksum itself, and the payload
+func (h *chunkHeader) computeChecksum(payload []byte) uint32 {
+	checksum := crc32.Checksum(h.marshal()[:chunkHeaderSize-4], castagnoliTable)
+
+	return crc32.Update(checksum, castagnoliTable, payload)
+}
+
+// archiveWriter writes the chunks of the archive into a file
+type archiveWriter struct {
+	file    *os.File
+	encoder *zstd.Encoder
+	offset  int64
+
+	index *ArchiveIndex
+	first *types.Header // the first block in the archive
+	last  *types.Header // the last block in the archive
+}
+
+// createArchive creates a new archive at path, throws error if the file exists
+func createArchive(path string) (*archiveWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, archiveHeaderSize)
+	copy(header, archiveMagic)
+	binary.BigEndian.PutUint16(header[len(archiveMagic):], archiveVersion)
+
+	if _, err := file.Write(header); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return newArchiveWriter(file, int64(archiveHeaderSize), &ArchiveIndex{})
+}
+
+// resumeArchive opens the archive at path to append blocks. The index is removed until
+// the writer is closed, and the chunks after a truncation of an interrupted backup are dropped
+func resumeArchive(path string) (*archiveWriter, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := recoverArchive(file)
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func recoverArchive(file *os.File) (*archiveWriter, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := newArchiveReader(file, info.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.close()
+
+	index := reader.index
+	end := reader.end
+
+	if index != nil && index.Metadata.HasState() {
+		return nil, ErrResumeWithState
+	}
+
+	if index == nil {
+		// the backup has been interrupted, keep the valid chunks
+		index = &ArchiveIndex{}
+
+		if end, err = reader.scanChunks(func(offset int64, header *chunkHeader, _ []byte) error {
+			switch header.kind {
+			case chunkKindState:
+				return ErrResumeWithState
+			case chunkKindBlocks:
+				index.Chunks = append(index.Chunks, &IndexEntry{From: header.from, To: header.to, Offset: uint64(offset)})
+			}
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// the chain continues from the last block of the archive
+	var first, last *types.Header
+
+	if len(index.Chunks) > 0 {
+		firstBlocks, err := reader.readBlocks(int64(index.Chunks[0].Offset))
+		if err != nil {
+			return nil, err
+		}
+
+		lastBlocks, err := reader.readBlocks(int64(index.Chunks[len(index.Chunks)-1].Offset))
+		if err != nil {
+			return nil, err
+		}
+
+		first, last = firstBlocks[0].Header, lastBlocks[len(lastBlocks)-1].Header
+	}
+
+	if err := file.Truncate(end); err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	w, err := newArchiveWriter(file, end, index)
+	if err != nil {
+		return nil, err
+	}
+
+	w.first, w.last = first, last
+
+	return w, nil
+}
+
+func newArchiveWriter(file *os.File, offset int64, index *ArchiveIndex) (*archiveWriter, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &archiveWriter{
+		file:    file,
+		encoder: encoder,
+		offset:  offset,
+		index:   index,
+	}, nil
+}
+
+// writeState writes the RLP encoded state chunk to the archive
+func (w *archiveWriter) writeState(data []byte) error {
+	if len(w.index.Chunks) > 0 {
+		return fmt.Errorf("%w: state after blocks", errUnexpectedChunkKind)
+	}
+
+	return w.writeChunk(chunkKindState, 0, 0, data)
+}
+
+// writeBlocks writes the RLP encoded blocks with the given range to the archive.
+// The blocks must continue the chain of the archive
+func (w *archiveWriter) writeBlocks(from, to uint64, data []byte) error {
+	blocks, err := decodeBlocks(data)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyBlocks(w.last, from, to, blocks); err != nil {
+		return err
+	}
+
+	offset := w.offset
+
+	if err := w.writeChunk(chunkKindBlocks, from, to, data); err != nil {
+		return err
+	}
+
+	w.index.Chunks = append(w.index.Chunks, &IndexEntry{From: from, To: to, Offset: uint64(offset)})
+
+	if w.first == nil {
+		w.first = blocks[0].Header
+	}
+
+	w.last = blocks[len(blocks)-1].Header
+
+	return nil
+}
+
+func (w *archiveWriter) writeChunk(kind chunkKind, from, to uint64, data []byte) error {
+	payload := w.encoder.EncodeAll(data, nil)
+
+	header := &chunkHeader{
+		kind: kind,
+		from: from,
+		to:   to,
+		size: uint32(len(payload)),
+	}
+	header.checksum = header.computeChecksum(payload)
+
+	if _, err := w.file.Write(append(header.marshal(), payload...)); err != nil {
+		return err
+	}
+
+	w.offset += int64(chunkHeaderSize + len(payload))
+
+	return nil
+}
+
+// close writes the index with the metadata of the archive and closes the file
+func (w *archiveWriter) close(stateRoot types.Hash) error {
+	defer w.abort()
+
+	if w.last == nil {
+		return errors.New("archive has no blocks")
+	}
+
+	w.index.Metadata = Metadata{
+		Latest:     w.last.Number,
+		LatestHash: w.last.Hash,
+		StateRoot:  stateRoot,
+	}
+
+	// drop any partially written chunk
+	if err := w.file.Truncate(w.offset); err != nil {
+		return err
+	}
+
+	if _, err := w.file.Seek(w.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	indexOffset := w.offset
+
+	if err := w.writeChunk(chunkKindIndex, 0, 0, w.index.MarshalRLP()); err != nil {
+		return err
+	}
+
+	footer := make([]byte, footerSize)
+	binary.BigEndian.PutUint64(footer[:8], uint64(indexOffset))
+	copy(footer[8:], archiveMagic)
+
+	if _, err := w.file.Write(footer); err != nil {
+		return err
+	}
+
+	return w.file.Sync()
+}
+
+// abort closes the file without writing the index
+func (w *archiveWriter) abort() error {
+	w.encoder.Close()
+
+	return w.file.Close()
+}
+
+// archiveReader reads the chunks of the archive
+type archiveReader struct {
+	input   io.ReaderAt
+	decoder *zstd.Decoder
+
+	// index is nil if the archive is incomplete
+	index *ArchiveIndex
+	// end is the offset of the index chunk, or the size of the incomplete archive
+	end int64
+}
+
+// newArchiveReader checks the header of the archive and reads its index
+func newArchiveReader(input io.ReaderAt, size int64) (*archiveReader, error) {
+	header := make([]byte, archiveHeaderSize)
+	if _, err := input.ReadAt(header, 0); err != nil || string(header[:len(archiveMagic)]) != archiveMagic {
+		return nil, ErrLegacyArchive
+	}
+
+	if version := binary.BigEndian.Uint16(header[len(archiveMagic):]); version != archiveVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &archiveReader{
+		input:   input,
+		decoder: decoder,
+		end:     size,
+	}
+
+	r.index, r.end = r.readIndex(size)
+
+	return r, nil
+}
+
+// readIndex returns the index of the archive and its offset, or nil if the archive is incomplete
+func (r *archiveReader) readIndex(size int64) (*ArchiveIndex, int64) {
+	if size < int64(archiveHeaderSize+footerSize) {
+		return nil, size
+	}
+
+	footer := make([]byte, footerSize)
+	if _, err := r.input.ReadAt(footer, size-int64(footerSize)); err != nil || string(footer[8:]) != archiveMagic {
+		return nil, size
+	}
+
+	offset := int64(binary.BigEndian.Uint64(footer[:8]))
+	if offset < int64(archiveHeaderSize) || offset >= size {
+		return nil, size
+	}
+
+	header, payload, _, err := r.readChunkAt(offset)
+	if err != nil || header.kind != chunkKindIndex {
+		return nil, size
+	}
+
+	index := &ArchiveIndex{}
+	if err := index.UnmarshalRLP(payload); err != nil {
+		return nil, size
+	}
+
+	return index, offset
+}
+
+// readChunkAt reads and verifies the chunk at offset, and returns its header, the decompressed
+// payload and the offset of the next chunk
+func (r *archiveReader) readChunkAt(offset int64) (*chunkHeader, []byte, int64, error) {
+	buf := make([]byte, chunkHeaderSize)
+	if n, err := r.input.ReadAt(buf, offset); n < chunkHeaderSize {
+		if err == nil || errors.Is(err, io.EOF) {
+			err = errChunkTruncated
+		}
+
+		return nil, nil, 0, err
+	}
+
+	header := &chunkHeader{}
+	header.unmarshal(buf)
+
+	if header.size > maxChunkPayloadSize {
+		return nil, nil, 0, fmt.Errorf("chunk at %d is too large: %d bytes", offset, header.size)
+	}
+
+	payload := make([]byte, header.size)
+	if n, err := r.input.ReadAt(payload, offset+int64(chunkHeaderSize)); n < len(payload) {
+		if err == nil || errors.Is(err, io.EOF) {
+			err = errChunkTruncated
+		}
+
+		return nil, nil, 0, err
+	}
+
+	if header.computeChecksum(payload) != header.checksum {
+		return nil, nil, 0, fmt.Errorf("%w: chunk at %d", ErrChunkChecksum, offset)
+	}
+
+	data, err := r.decoder.DecodeAll(payload, nil)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to decompress chunk at %d: %w", offset, err)
+	}
+
+	return header, data, offset + int64(chunkHeaderSize) + int64(header.size), nil
+}
+
+// readBlocks returns the blocks of the chunk at offset
+func (r *archiveReader) readBlocks(offset int64) ([]*types.Block, error) {
+	header, data, _, err := r.readChunkAt(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.kind != chunkKindBlocks {
+		return nil, fmt.Errorf("%w: %s at %d", errUnexpectedChunkKind, header.kind, offset)
+	}
+
+	return decodeBlocks(data)
+}
+
+// scanChunks passes the valid chunks before the index to fn. It stops at the first
+// truncated chunk and returns the offset the valid chunks end at
+func (r *archiveReader) scanChunks(fn func(offset int64, header *chunkHeader, data []byte) error) (int64, error) {
+	offset := int64(archiveHeaderSize)
+
+	for offset < r.end {
+		header, data, next, err := r.readChunkAt(offset)
+		if errors.Is(err, errChunkTruncated) || errors.Is(err, ErrChunkChecksum) {
+			break
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if header.kind == chunkKindIndex {
+			break
+		}
+
+		if err := fn(offset, header, data); err != nil {
+			return 0, err
+		}
+
+		offset = next
+	}
+
+	return offset, nil
+}
+
+// blocksFrom returns the offset of the chunk with the given block, or of the first chunk if no chunk has it
+func (r *archiveReader) blocksFrom(number uint64) int64 {
+	offset := r.end
+
+	for i, entry := range r.index.Chunks {
+		if i > 0 && entry.From > number {
+			break
+		}
+
+		offset = int64(entry.Offset)
+	}
+
+	return offset
+}
+
+// stateOffset returns the offset of the first state chunk
+func (r *archiveReader) stateOffset() int64 {
+	return int64(archiveHeaderSize)
+}
+
+// stream returns the reader of the payloads of the consecutive chunks of the kind from offset
+func (r *archiveReader) stream(offset int64, kind chunkKind) io.Reader {
+	return &chunkStream{
+		reader: r,
+		kind:   kind,
+		offset: offset,
+	}
+}
+
+func (r *archiveReader) close() {
+	r.decoder.Close()
+}
+
+// chunkStream reads the decompressed payloads of consecutive chunks of the same kind
+type chunkStream struct {
+	reader *archiveReader
+	kind   chunkKind
+	offset int64
+	buf    []byte
+}
+
+func (s *chunkStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.offset >= s.reader.end {
+			return 0, io.EOF
+		}
+
+		header, data, next, err := s.reader.readChunkAt(s.offset)
+		if err != nil {
+			return 0, err
+		}
+
+		if header.kind != s.kind {
+			return 0, io.EOF
+		}
+
+		s.buf, s.offset = data, next
+	}
+
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+
+	return n, nil
+}
+
+// decodeBlocks decodes the RLP encoded blocks
+func decodeBlocks(data []byte) ([]*types.Block, error) {
+	stream := newBlockStream(bytes.NewReader(data))
+	blocks := []*types.Block{}
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if block == nil {
+			break
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if len(blocks) == 0 {
+		return nil, errors.New("chunk has no blocks")
+	}
+
+	return blocks, nil
+}
+
+// verifyBlocks checks that the blocks have the given range and continue the chain from parent, if any
+func verifyBlocks(parent *types.Header, from, to uint64, blocks []*types.Block) error {
+	if blocks[0].Number() != from || blocks[len(blocks)-1].Number() != to {
+		return fmt.Errorf(
+			"%w: expected %d-%d, got %d-%d",
+			errChunkRangeMismatch, from, to, blocks[0].Number(), blocks[len(blocks)-1].Number(),
+		)
+	}
+
+	for _, block := range blocks {
+		if parent != nil && (block.Number() != parent.Number+1 || block.ParentHash() != parent.Hash) {
+			return fmt.Errorf("%w: block %d (%s) doesn't follow block %d (%s)",
+				errBrokenHashChain, block.Number(), block.Hash(), parent.Number, parent.Hash)
+		}
+
+		parent = block.Header
+	}
+
+	return nil
+}
+
+// ArchiveSummary describes a verified archive
+type ArchiveSummary struct {
+	Version     uint16
+	From        uint64
+	To          uint64
+	Chunks      int
+	StateChunks int
+	StateRoot   types.Hash
+}
+
+// VerifyArchive checks the checksums of all the chunks in the archive, the hash chain of
+// its blocks, the state chunks and the index, without access to a node
+func VerifyArchive(path string) (*ArchiveSummary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := newArchiveReader(file, info.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.close()
+
+	if reader.index == nil {
+		return nil, ErrArchiveIncomplete
+	}
+
+	var (
+		summary = &ArchiveSummary{
+			Version:   archiveVersion,
+			StateRoot: reader.index.Metadata.StateRoot,
+		}
+
+		entries   = reader.index.Chunks
+		last      *types.Header
+		stateDone bool
+	)
+
+	offset := int64(archiveHeaderSize)
+
+	for offset < reader.end {
+		header, data, next, err := reader.readChunkAt(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.kind {
+		case chunkKindState:
+			if stateDone || summary.Chunks > 0 {
+				return nil, fmt.Errorf("%w: state at %d", errUnexpectedChunkKind, offset)
+			}
+
+			chunk := &StateChunk{}
+			if err := chunk.UnmarshalRLP(data); err != nil {
+				return nil, err
+			}
+
+			if hash := chunk.ComputeHash(); hash != chunk.Hash {
+				return nil, fmt.Errorf("%w: expected %s, got %s", ErrStateChunkMismatch, chunk.Hash, hash)
+			}
+
+			stateDone = len(chunk.Accounts) == 0
+			summary.StateChunks++
+		case chunkKindBlocks:
+			if summary.Chunks >= len(entries) || entries[summary.Chunks].Offset != uint64(offset) ||
+				entries[summary.Chunks].From != header.from || entries[summary.Chunks].To != header.to {
+				return nil, fmt.Errorf("%w: chunk at %d", errIndexMismatch, offset)
+			}
+
+			blocks, err := decodeBlocks(data)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := verifyBlocks(last, header.from, header.to, blocks); err != nil {
+				return nil, err
+			}
+
+			if last == nil {
+				summary.From = header.from
+			}
+
+			last = blocks[len(blocks)-1].Header
+			summary.Chunks++
+		default:
+			return nil, fmt.Errorf("%w: %s at %d", errUnexpectedChunkKind, header.kind, offset)
+		}
+
+		offset = next
+	}
+
+	if summary.Chunks != len(entries) || last == nil {
+		return nil, fmt.Errorf("%w: %d chunks indexed, %d found", errIndexMismatch, len(entries), summary.Chunks)
+	}
+
+	metadata := reader.index.Metadata
+	if metadata.Latest != last.Number || metadata.LatestHash != last.Hash {
+		return nil, errMetadataMismatch
+	}
+
+	if metadata.HasState() && !stateDone {
+		return nil, errStateSnapshotIncomplete
+	}
+
+	summary.To = last.Number
+
+	return summary, nil
+}
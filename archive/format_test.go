@@ -0,0 +1,285 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// readArchiveBlocks returns the RLP encoded blocks of the archive
+func readArchiveBlocks(t *testing.T, path string) []byte {
+	t.Helper()
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	assert.NoError(t, err)
+
+	reader, err := newArchiveReader(file, info.Size())
+	assert.NoError(t, err)
+
+	defer reader.close()
+
+	data := []byte{}
+
+	for _, entry := range reader.index.Chunks {
+		blocks, err := reader.readBlocks(int64(entry.Offset))
+		assert.NoError(t, err)
+
+		for _, b := range blocks {
+			data = append(data, b.MarshalRLP()...)
+		}
+	}
+
+	return data
+}
+
+func encodeBlocks(blocks ...*types.Block) []byte {
+	data := []byte{}
+
+	for _, b := range blocks {
+		data = append(data, b.MarshalRLP()...)
+	}
+
+	return data
+}
+
+// newTestArchive writes genesis and the test blocks into two chunks
+func newTestArchive(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "backup")
+
+	writer, err := createArchive(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, writer.writeBlocks(0, 1, encodeBlocks(genesis, blocks[0])))
+	assert.NoError(t, writer.writeBlocks(2, 3, encodeBlocks(blocks[1], blocks[2])))
+	assert.NoError(t, writer.close(types.ZeroHash))
+
+	return path
+}
+
+func TestArchive_Verify(t *testing.T) {
+	t.Parallel()
+
+	path := newTestArchive(t)
+
+	summary, err := VerifyArchive(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &ArchiveSummary{
+		Version: archiveVersion,
+		From:    0,
+		To:      3,
+		Chunks:  2,
+	}, summary)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	t.Run("corrupted chunk", func(t *testing.T) {
+		t.Parallel()
+
+		corrupted := append([]byte{}, data...)
+		corrupted[archiveHeaderSize+chunkHeaderSize] ^= 0xff
+
+		corruptedPath := filepath.Join(t.TempDir(), "backup")
+		assert.NoError(t, os.WriteFile(corruptedPath, corrupted, 0600))
+
+		_, err := VerifyArchive(corruptedPath)
+		assert.True(t, errors.Is(err, ErrChunkChecksum))
+	})
+
+	t.Run("truncated archive", func(t *testing.T) {
+		t.Parallel()
+
+		truncatedPath := filepath.Join(t.TempDir(), "backup")
+		assert.NoError(t, os.WriteFile(truncatedPath, data[:len(data)-10], 0600))
+
+		_, err := VerifyArchive(truncatedPath)
+		assert.True(t, errors.Is(err, ErrArchiveIncomplete))
+	})
+
+	t.Run("legacy archive", func(t *testing.T) {
+		t.Parallel()
+
+		legacyPath := filepath.Join(t.TempDir(), "backup")
+		assert.NoError(t, os.WriteFile(legacyPath, append(metadata.MarshalRLP(), genesis.MarshalRLP()...), 0600))
+
+		_, err := VerifyArchive(legacyPath)
+		assert.True(t, errors.Is(err, ErrLegacyArchive))
+	})
+}
+
+func TestArchive_BrokenHashChain(t *testing.T) {
+	t.Parallel()
+
+	writer, err := createArchive(filepath.Join(t.TempDir(), "backup"))
+	assert.NoError(t, err)
+
+	defer writer.abort()
+
+	assert.NoError(t, writer.writeBlocks(0, 1, encodeBlocks(genesis, blocks[0])))
+	assert.True(t, errors.Is(writer.writeBlocks(3, 3, encodeBlocks(blocks[2])), errBrokenHashChain))
+	assert.True(t, errors.Is(writer.writeBlocks(2, 3, encodeBlocks(blocks[1])), errChunkRangeMismatch))
+}
+
+func TestArchive_Resume(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complete archive", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "backup")
+
+		writer, err := createArchive(path)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.writeBlocks(0, 1, encodeBlocks(genesis, blocks[0])))
+		assert.NoError(t, writer.close(types.ZeroHash))
+
+		writer, err = resumeArchive(path)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[0].Hash(), writer.last.Hash)
+		assert.NoError(t, writer.writeBlocks(2, 3, encodeBlocks(blocks[1], blocks[2])))
+		assert.NoError(t, writer.close(types.ZeroHash))
+
+		summary, err := VerifyArchive(path)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), summary.From)
+		assert.Equal(t, uint64(3), summary.To)
+	})
+
+	t.Run("interrupted archive", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "backup")
+
+		writer, err := createArchive(path)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.writeBlocks(0, 1, encodeBlocks(genesis, blocks[0])))
+		assert.NoError(t, writer.writeBlocks(2, 2, encodeBlocks(blocks[1])))
+		assert.NoError(t, writer.abort())
+
+		// the last chunk is cut in the middle
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.NoError(t, os.Truncate(path, info.Size()-5))
+
+		writer, err = resumeArchive(path)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[0].Hash(), writer.last.Hash)
+		assert.NoError(t, writer.writeBlocks(2, 3, encodeBlocks(blocks[1], blocks[2])))
+		assert.NoError(t, writer.close(types.ZeroHash))
+
+		summary, err := VerifyArchive(path)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), summary.To)
+		assert.Equal(t, 2, summary.Chunks)
+	})
+
+	t.Run("archive with state", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "backup")
+
+		writer, err := createArchive(path)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.writeState((&StateChunk{}).MarshalRLP()))
+		assert.NoError(t, writer.writeBlocks(0, 1, encodeBlocks(genesis, blocks[0])))
+		assert.NoError(t, writer.close(types.StringToHash("1")))
+
+		_, err = resumeArchive(path)
+		assert.True(t, errors.Is(err, ErrResumeWithState))
+	})
+}
+
+func TestArchive_Restore(t *testing.T) {
+	t.Parallel()
+
+	path := newTestArchive(t)
+
+	t.Run("should write all blocks", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{genesis: genesis}
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+		assert.NoError(t, RestoreChain(chain, nil, path, progression))
+		assert.Equal(t, []*types.Block{blocks[0], blocks[1], blocks[2]}, chain.blocks)
+	})
+
+	t.Run("should start from the chunk with the head", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{genesis: genesis, blocks: []*types.Block{blocks[0], blocks[1]}}
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+		assert.NoError(t, RestoreChain(chain, nil, path, progression))
+		assert.Equal(t, []*types.Block{blocks[0], blocks[1], blocks[2]}, chain.blocks)
+	})
+
+	t.Run("should restore the legacy archive", func(t *testing.T) {
+		t.Parallel()
+
+		legacyPath := filepath.Join(t.TempDir(), "backup")
+		legacy := (&Metadata{Latest: 3, LatestHash: blocks[2].Hash()}).MarshalRLP()
+		legacy = append(legacy, encodeBlocks(genesis, blocks[0], blocks[1], blocks[2])...)
+		assert.NoError(t, os.WriteFile(legacyPath, legacy, 0600))
+
+		chain := &mockChain{genesis: genesis}
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+		assert.NoError(t, RestoreChain(chain, nil, legacyPath, progression))
+		assert.Equal(t, []*types.Block{blocks[0], blocks[1], blocks[2]}, chain.blocks)
+	})
+}
+
+func TestArchive_RestoreWithState(t *testing.T) {
+	t.Parallel()
+
+	st, root := newTestState(t, 10)
+	chunks := exportTestState(t, st, root, 256)
+
+	stateBlock := &types.Block{Header: &types.Header{Number: 1, ParentHash: genesis.Hash(), StateRoot: root}}
+	stateBlock.Header.ComputeHash()
+
+	path := filepath.Join(t.TempDir(), "backup")
+
+	writer, err := createArchive(path)
+	assert.NoError(t, err)
+
+	for _, data := range chunks {
+		assert.NoError(t, writer.writeState(data))
+	}
+
+	assert.NoError(t, writer.writeBlocks(0, 1, encodeBlocks(genesis, stateBlock)))
+	assert.NoError(t, writer.close(root))
+
+	summary, err := VerifyArchive(path)
+	assert.NoError(t, err)
+	assert.Equal(t, len(chunks), summary.StateChunks)
+	assert.Equal(t, root, summary.StateRoot)
+
+	chain := &mockChain{genesis: genesis}
+	storage := itrie.NewMemoryStorage()
+	progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+	// the chain which can't restore the state refuses the archive
+	err = RestoreChain(&mockChain{genesis: genesis}, nil, path, progression)
+	assert.True(t, errors.Is(err, ErrStateNotSupported))
+
+	assert.NoError(t, RestoreChain(chain, storage, path, progression))
+	assert.Equal(t, []*types.Block{stateBlock}, chain.blocks)
+
+	_, err = itrie.NewState(storage).NewSnapshotAt(root)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,148 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	// ManifestFile is the name of the manifest in the directory of the incremental backups
+	ManifestFile = "manifest.json"
+
+	manifestVersion = 1
+)
+
+var (
+	ErrManifestBroken    = errors.New("increments of the manifest don't form a chain")
+	ErrIncrementChecksum = errors.New("checksum of the increment doesn't match the manifest")
+)
+
+// Manifest describes the chain of the incremental backups in a directory.
+// Every increment is an archive with the blocks following the previous increment
+type Manifest struct {
+	Version    int          `json:"version"`
+	Increments []*Increment `json:"increments"`
+}
+
+// Increment is an archive of the incremental backups
+type Increment struct {
+	File       string     `json:"file"`
+	From       uint64     `json:"from"`
+	To         uint64     `json:"to"`
+	ParentHash types.Hash `json:"parent_hash"` // parent hash of the first block
+	Hash       types.Hash `json:"hash"`        // hash of the last block
+	Checksum   string     `json:"sha256"`      // checksum of the archive file
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// last returns the last increment, nil if the manifest is empty
+func (m *Manifest) last() *Increment {
+	if len(m.Increments) == 0 {
+		return nil
+	}
+
+	return m.Increments[len(m.Increments)-1]
+}
+
+// verify checks that the increments form a chain
+func (m *Manifest) verify() error {
+	for i, inc := range m.Increments {
+		if inc.To < inc.From {
+			return fmt.Errorf("%w: increment %s has invalid range %d-%d", ErrManifestBroken, inc.File, inc.From, inc.To)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		prev := m.Increments[i-1]
+		if inc.From != prev.To+1 || inc.ParentHash != prev.Hash {
+			return fmt.Errorf("%w: increment %s doesn't follow %s", ErrManifestBroken, inc.File, prev.File)
+		}
+	}
+
+	return nil
+}
+
+// ReadManifest reads the manifest in the directory, or returns an empty manifest if there is none
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Manifest{Version: manifestVersion}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+
+	if err := manifest.verify(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// writeManifest replaces the manifest in the directory
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so the manifest is never partially written
+	path := filepath.Join(dir, ManifestFile)
+	tmpPath := path + tmpSuffix
+
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of the file
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// verifyIncrement checks the checksum of the increment file in the directory
+func verifyIncrement(dir string, inc *Increment) error {
+	checksum, err := fileChecksum(filepath.Join(dir, inc.File))
+	if err != nil {
+		return err
+	}
+
+	if checksum != inc.Checksum {
+		return fmt.Errorf("%w: %s", ErrIncrementChecksum, inc.File)
+	}
+
+	return nil
+}
@@ -6,10 +6,12 @@ import (
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
 	"github.com/ExzoNetwork/ExzoCoin/helper/common"
 	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 )
 
@@ -22,26 +24,117 @@ type blockchainInterface interface {
 	Genesis() types.Hash
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	GetHashByNumber(uint64) types.Hash
+	Header() *types.Header
 	WriteBlock(*types.Block, string) error
+	WriteBlockWithoutExecution(*types.Block, string) error
 	VerifyFinalizedBlock(*types.Block) error
+	VerifyFinalizedBlockWithoutExecution(*types.Block) error
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
-func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
-	fp, err := os.Open(filePath)
+// RestoreChain reads blocks from the archive and write to the chain.
+// The state snapshot of the archive, if any, is imported into stateStorage, the archive is refused
+// without stateStorage. The blocks below the snapshot are written without the receipts, since they
+// aren't executed.
+// The path can also be the directory of the incremental backups, or their manifest
+func RestoreChain(
+	chain blockchainInterface,
+	stateStorage itrie.Storage,
+	filePath string,
+	progression *progress.ProgressionWrapper,
+) error {
+	info, err := os.Stat(filePath)
 	if err != nil {
 		return err
 	}
 
-	blockStream := newBlockStream(fp)
+	if info.IsDir() {
+		return restoreIncrements(chain, filePath, progression)
+	}
 
-	return importBlocks(chain, blockStream, progression)
+	if filepath.Base(filePath) == ManifestFile {
+		return restoreIncrements(chain, filepath.Dir(filePath), progression)
+	}
+
+	return restoreArchive(chain, stateStorage, filePath, progression)
 }
 
-// import blocks scans all blocks from stream and write them to chain
-func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
-	shutdownCh := common.GetTerminationSignalCh()
+// restoreIncrements restores the increments of the manifest in the directory in order,
+// skipping the ones already in the chain
+func restoreIncrements(chain blockchainInterface, dir string, progression *progress.ProgressionWrapper) error {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(manifest.Increments) == 0 {
+		return fmt.Errorf("no increments in %s", filepath.Join(dir, ManifestFile))
+	}
+
+	for _, inc := range manifest.Increments {
+		if chain.GetHashByNumber(inc.To) == inc.Hash {
+			continue
+		}
 
+		if err := verifyIncrement(dir, inc); err != nil {
+			return err
+		}
+
+		if err := restoreArchive(chain, nil, filepath.Join(dir, inc.File), progression); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", inc.File, err)
+		}
+	}
+
+	return nil
+}
+
+// restoreArchive reads blocks from the archive file and write to the chain
+func restoreArchive(
+	chain blockchainInterface,
+	stateStorage itrie.Storage,
+	filePath string,
+	progression *progress.ProgressionWrapper,
+) error {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	info, err := fp.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader, err := newArchiveReader(fp, info.Size())
+	if errors.Is(err, ErrLegacyArchive) {
+		return importBlocks(chain, stateStorage, newBlockStream(fp), progression)
+	} else if err != nil {
+		return err
+	}
+
+	defer reader.close()
+
+	if reader.index == nil {
+		return ErrArchiveIncomplete
+	}
+
+	// start from the chunk with the local head, the blocks below are already in the chain
+	var (
+		stateStream = newBlockStream(reader.stream(reader.stateOffset(), chunkKindState))
+		blockStream = newBlockStream(reader.stream(reader.blocksFrom(chain.Header().Number), chunkKindBlocks))
+	)
+
+	return importArchive(chain, stateStorage, &reader.index.Metadata, stateStream, blockStream, progression)
+}
+
+// import blocks scans all blocks from the legacy archive stream and write them to chain
+func importBlocks(
+	chain blockchainInterface,
+	stateStorage itrie.Storage,
+	blockStream *blockStream,
+	progression *progress.ProgressionWrapper,
+) error {
 	metadata, err := blockStream.getMetadata()
 	if err != nil {
 		return err
@@ -51,6 +144,27 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 		return errors.New("expected metadata in archive but doesn't exist")
 	}
 
+	return importArchive(chain, stateStorage, metadata, blockStream, blockStream, progression)
+}
+
+// importArchive imports the state snapshot, if any, and writes the blocks to chain
+func importArchive(
+	chain blockchainInterface,
+	stateStorage itrie.Storage,
+	metadata *Metadata,
+	stateStream *blockStream,
+	blockStream *blockStream,
+	progression *progress.ProgressionWrapper,
+) error {
+	shutdownCh := common.GetTerminationSignalCh()
+
+	// the state snapshot precedes the blocks
+	if metadata.HasState() {
+		if err := importState(stateStorage, stateStream, metadata.StateRoot); err != nil {
+			return err
+		}
+	}
+
 	// check whether the local chain has the latest block already
 	latestBlock, ok := chain.GetBlockByNumber(metadata.Latest, false)
 	if ok && latestBlock.Hash() == metadata.LatestHash {
@@ -75,14 +189,27 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	// Set the goal
 	progression.UpdateHighestProgression(metadata.Latest)
 
+	// the blocks below an imported state can't be executed
+	verifyBlock, writeBlock := chain.VerifyFinalizedBlock, chain.WriteBlock
+	if metadata.HasState() {
+		verifyBlock, writeBlock = chain.VerifyFinalizedBlockWithoutExecution, chain.WriteBlockWithoutExecution
+	}
+
 	nextBlock := firstBlock
 
 	for {
-		if err := chain.VerifyFinalizedBlock(nextBlock); err != nil {
+		// the state snapshot must belong to the latest block before it's written
+		if metadata.HasState() && nextBlock.Number() == metadata.Latest {
+			if err := verifyStateBlock(nextBlock, metadata); err != nil {
+				return err
+			}
+		}
+
+		if err := verifyBlock(nextBlock); err != nil {
 			return err
 		}
 
-		if err := chain.WriteBlock(nextBlock, restore); err != nil {
+		if err := writeBlock(nextBlock, restore); err != nil {
 			return err
 		}
 
@@ -107,6 +234,60 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	return nil
 }
 
+// importState imports the state snapshot from stream and verifies its root
+func importState(stateStorage itrie.Storage, blockStream *blockStream, root types.Hash) error {
+	if stateStorage == nil {
+		return ErrStateNotSupported
+	}
+
+	importer := newStateImporter(stateStorage)
+
+	for {
+		chunk, err := blockStream.nextStateChunk()
+		if err != nil {
+			return err
+		}
+
+		if chunk == nil {
+			return errors.New("unexpected end of the state snapshot in archive")
+		}
+
+		if len(chunk.Accounts) == 0 {
+			break
+		}
+
+		if err := importer.importChunk(chunk); err != nil {
+			return err
+		}
+	}
+
+	return importer.commit(root)
+}
+
+// verifyStateBlock checks that the latest block of the archive is the one the state snapshot belongs to
+func verifyStateBlock(block *types.Block, metadata *Metadata) error {
+	if block.Hash() != metadata.LatestHash {
+		return fmt.Errorf(
+			"the hash of block %d (%s) does not match archive metadata (%s)",
+			block.Number(),
+			block.Hash(),
+			metadata.LatestHash,
+		)
+	}
+
+	if block.Header.StateRoot != metadata.StateRoot {
+		return fmt.Errorf(
+			"%w: block %d has state root %s, archive has %s",
+			ErrStateRootMismatch,
+			block.Number(),
+			block.Header.StateRoot,
+			metadata.StateRoot,
+		)
+	}
+
+	return nil
+}
+
 // consumeCommonBlocks consumes blocks in blockstream to latest block in chain or different hash
 // returns the first block to be written into chain
 func consumeCommonBlocks(
@@ -175,6 +356,25 @@ func (b *blockStream) getMetadata() (*Metadata, error) {
 	return b.parseMetadata(size)
 }
 
+// nextStateChunk consumes some bytes from input and returns parsed state chunk
+func (b *blockStream) nextStateChunk() (*StateChunk, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	chunk := &StateChunk{}
+	if err := chunk.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return chunk, nil
+}
+
 // nextBlock consumes some bytes from input and returns parsed block
 func (b *blockStream) nextBlock() (*types.Block, error) {
 	size, err := b.loadRLPArray()
@@ -241,17 +441,17 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
+		n, err := io.ReadFull(b.input, payloadSizeBytes)
 
-		if err != nil {
-			return 0, 0, err
-		}
-
-		if uint64(n) < payloadSizeSize {
+		if uint64(n) < payloadSizeSize && (err == nil || errors.Is(err, io.ErrUnexpectedEOF)) {
 			// couldn't load required amount of bytes
 			return 0, 0, io.EOF
 		}
 
+		if err != nil {
+			return 0, 0, err
+		}
+
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
 
 		return payloadSizeSize + 1, uint64(payloadSize), nil
@@ -265,7 +465,7 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
@@ -49,16 +49,32 @@ func (m *mockChain) GetHashByNumber(num uint64) types.Hash {
 	return b.Hash()
 }
 
+func (m *mockChain) Header() *types.Header {
+	if l := len(m.blocks); l != 0 {
+		return m.blocks[l-1].Header
+	}
+
+	return m.genesis.Header
+}
+
 func (m *mockChain) WriteBlock(block *types.Block, _ string) error {
 	m.blocks = append(m.blocks, block)
 
 	return nil
 }
 
+func (m *mockChain) WriteBlockWithoutExecution(block *types.Block, source string) error {
+	return m.WriteBlock(block, source)
+}
+
 func (m *mockChain) VerifyFinalizedBlock(block *types.Block) error {
 	return nil
 }
 
+func (m *mockChain) VerifyFinalizedBlockWithoutExecution(block *types.Block) error {
+	return nil
+}
+
 func (m *mockChain) SubscribeEvents() blockchain.Subscription {
 	return blockchain.NewMockSubscription()
 }
@@ -115,7 +131,7 @@ func Test_importBlocks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
 			blockStream := newTestBlockStream(tt.metadata, tt.archiveBlocks...)
-			err := importBlocks(tt.chain, blockStream, progression)
+			err := importBlocks(tt.chain, nil, blockStream, progression)
 
 			assert.Equal(t, tt.err, err)
 			latestBlock := getLatestBlockFromMockChain(tt.chain)
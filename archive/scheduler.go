@@ -0,0 +1,399 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// tmpSuffix is the suffix of the files being written in the backup directory
+	tmpSuffix = ".tmp"
+
+	incrementPrefix = "increment-"
+	incrementSuffix = ".exzo"
+
+	// maxIncrementChunkSize is the size of the RLP encoded blocks of a chunk in the increments
+	maxIncrementChunkSize = 512 * 1024
+)
+
+// BackupConfig is the configuration of the incremental backups
+type BackupConfig struct {
+	Dir string
+
+	// IntervalBlocks is the number of blocks after which an increment is written, 0 disables it
+	IntervalBlocks uint64
+
+	// Interval is the time after which an increment is written, 0 disables it
+	Interval time.Duration
+
+	// Retention is the maximum number of increments, the oldest increments are merged
+	// when it's exceeded. 0 keeps all the increments
+	Retention uint64
+}
+
+// backupChain is the blockchain the increments are written from
+type backupChain interface {
+	Header() *types.Header
+	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// BackupScheduler writes the incremental backups of the chain into a directory
+type BackupScheduler struct {
+	logger hclog.Logger
+	chain  backupChain
+	config *BackupConfig
+
+	manifest *Manifest
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewBackupScheduler creates the scheduler of the incremental backups in the configured directory,
+// which continue the chain of the increments already in its manifest
+func NewBackupScheduler(logger hclog.Logger, chain backupChain, config *BackupConfig) (*BackupScheduler, error) {
+	if config.IntervalBlocks == 0 && config.Interval == 0 {
+		return nil, errors.New("backup interval is not set")
+	}
+
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	manifest, err := ReadManifest(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &BackupScheduler{
+		logger:   logger.Named("backup"),
+		chain:    chain,
+		config:   config,
+		manifest: manifest,
+		closeCh:  make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+
+	if err := s.removeStaleFiles(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// removeStaleFiles removes the files left by an interrupted backup or merge
+func (s *BackupScheduler) removeStaleFiles() error {
+	entries, err := os.ReadDir(s.config.Dir)
+	if err != nil {
+		return err
+	}
+
+	referenced := make(map[string]bool, len(s.manifest.Increments))
+	for _, inc := range s.manifest.Increments {
+		referenced[inc.File] = true
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		isStale := strings.HasSuffix(name, tmpSuffix) ||
+			(strings.HasPrefix(name, incrementPrefix) && strings.HasSuffix(name, incrementSuffix) && !referenced[name])
+
+		if entry.IsDir() || !isStale {
+			continue
+		}
+
+		s.logger.Info("removing stale backup file", "file", name)
+
+		if err := os.Remove(filepath.Join(s.config.Dir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start starts writing the increments in the background
+func (s *BackupScheduler) Start() {
+	go s.run()
+}
+
+// Close stops the scheduler, waiting for the increment being written
+func (s *BackupScheduler) Close() {
+	close(s.closeCh)
+	<-s.doneCh
+}
+
+func (s *BackupScheduler) run() {
+	defer close(s.doneCh)
+
+	sub := s.chain.SubscribeEvents()
+	defer sub.Close()
+
+	eventCh := sub.GetEventCh()
+
+	// the timer channel is nil if the time interval is disabled
+	var (
+		timer   *time.Timer
+		timerCh <-chan time.Time
+	)
+
+	if s.config.Interval > 0 {
+		timer = time.NewTimer(s.untilNextBackup())
+		timerCh = timer.C
+
+		defer timer.Stop()
+	}
+
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case <-eventCh:
+			if !s.blocksDue() {
+				continue
+			}
+		case <-timerCh:
+		}
+
+		if err := s.Backup(); err != nil {
+			s.logger.Error("failed to write backup increment", "err", err)
+		}
+
+		if timer != nil {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+
+			timer.Reset(s.untilNextBackup())
+		}
+	}
+}
+
+// nextBlock returns the first block of the next increment
+func (s *BackupScheduler) nextBlock() uint64 {
+	if last := s.manifest.last(); last != nil {
+		return last.To + 1
+	}
+
+	return 0
+}
+
+// blocksDue returns true if the chain has grown by the block interval since the last increment
+func (s *BackupScheduler) blocksDue() bool {
+	if s.config.IntervalBlocks == 0 {
+		return false
+	}
+
+	return s.chain.Header().Number+1 >= s.nextBlock()+s.config.IntervalBlocks
+}
+
+// untilNextBackup returns the time until the next increment is due by the time interval
+func (s *BackupScheduler) untilNextBackup() time.Duration {
+	last := s.manifest.last()
+	if last == nil {
+		return s.config.Interval
+	}
+
+	if wait := time.Until(last.CreatedAt.Add(s.config.Interval)); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
+
+// Backup writes the blocks following the last increment up to the head into a new increment,
+// and merges the oldest increments if the retention is exceeded
+func (s *BackupScheduler) Backup() error {
+	from, to := s.nextBlock(), s.chain.Header().Number
+	if from > to {
+		return nil
+	}
+
+	inc, err := s.writeIncrement(from, to)
+	if err != nil {
+		return err
+	}
+
+	if last := s.manifest.last(); last != nil && inc.ParentHash != last.Hash {
+		os.Remove(filepath.Join(s.config.Dir, inc.File))
+
+		return fmt.Errorf("block %d doesn't follow the last increment %s", from, last.File)
+	}
+
+	s.manifest.Increments = append(s.manifest.Increments, inc)
+
+	if err := writeManifest(s.config.Dir, s.manifest); err != nil {
+		return err
+	}
+
+	s.logger.Info("wrote backup increment", "file", inc.File, "from", inc.From, "to", inc.To)
+
+	for s.config.Retention > 0 && uint64(len(s.manifest.Increments)) > s.config.Retention {
+		if err := s.mergeOldest(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeIncrement writes the blocks in the range into a new increment
+func (s *BackupScheduler) writeIncrement(from, to uint64) (*Increment, error) {
+	return s.writeArchive(from, to, func(writer *archiveWriter) error {
+		data := []byte{}
+		chunkFrom := from
+
+		for i := from; i <= to; i++ {
+			block, ok := s.chain.GetBlockByNumber(i, true)
+			if !ok {
+				return fmt.Errorf("block %d not found", i)
+			}
+
+			data = append(data, block.MarshalRLP()...)
+
+			if len(data) >= maxIncrementChunkSize || i == to {
+				if err := writer.writeBlocks(chunkFrom, i, data); err != nil {
+					return err
+				}
+
+				data, chunkFrom = data[:0], i+1
+			}
+		}
+
+		return nil
+	})
+}
+
+// mergeOldest merges the two oldest increments into one
+func (s *BackupScheduler) mergeOldest() error {
+	first, second := s.manifest.Increments[0], s.manifest.Increments[1]
+
+	merged, err := s.writeArchive(first.From, second.To, func(writer *archiveWriter) error {
+		for _, inc := range []*Increment{first, second} {
+			if err := copyIncrement(writer, filepath.Join(s.config.Dir, inc.File)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the merged increment keeps the time of the newer one for the time interval
+	merged.CreatedAt = second.CreatedAt
+
+	s.manifest.Increments = append([]*Increment{merged}, s.manifest.Increments[2:]...)
+
+	if err := writeManifest(s.config.Dir, s.manifest); err != nil {
+		return err
+	}
+
+	// the merged increments are removed only once the manifest doesn't refer to them
+	for _, inc := range []*Increment{first, second} {
+		if err := os.Remove(filepath.Join(s.config.Dir, inc.File)); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("merged backup increments", "file", merged.File, "from", merged.From, "to", merged.To)
+
+	return nil
+}
+
+// writeArchive writes the archive of the increment with the range through fn
+func (s *BackupScheduler) writeArchive(from, to uint64, fn func(writer *archiveWriter) error) (*Increment, error) {
+	name := fmt.Sprintf("%s%012d-%012d%s", incrementPrefix, from, to, incrementSuffix)
+	path := filepath.Join(s.config.Dir, name)
+	tmpPath := path + tmpSuffix
+
+	writer, err := createArchive(tmpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(writer); err != nil {
+		writer.abort()
+		os.Remove(tmpPath)
+
+		return nil, err
+	}
+
+	inc := &Increment{
+		File:       name,
+		From:       from,
+		To:         to,
+		ParentHash: writer.first.ParentHash,
+		Hash:       writer.last.Hash,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := writer.close(types.ZeroHash); err != nil {
+		os.Remove(tmpPath)
+
+		return nil, err
+	}
+
+	if inc.Checksum, err = fileChecksum(tmpPath); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, err
+	}
+
+	return inc, nil
+}
+
+// copyIncrement appends the blocks of the increment to the writer
+func copyIncrement(writer *archiveWriter, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader, err := newArchiveReader(file, info.Size())
+	if err != nil {
+		return err
+	}
+
+	defer reader.close()
+
+	if reader.index == nil {
+		return ErrArchiveIncomplete
+	}
+
+	for _, entry := range reader.index.Chunks {
+		_, data, _, err := reader.readChunkAt(int64(entry.Offset))
+		if err != nil {
+			return err
+		}
+
+		if err := writer.writeBlocks(entry.From, entry.To, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScheduler(t *testing.T, chain *mockChain, dir string, retention uint64) *BackupScheduler {
+	t.Helper()
+
+	scheduler, err := NewBackupScheduler(hclog.NewNullLogger(), chain, &BackupConfig{
+		Dir:            dir,
+		IntervalBlocks: 1,
+		Retention:      retention,
+	})
+	assert.NoError(t, err)
+
+	return scheduler
+}
+
+// writeTestIncrements writes an increment after every block of the test chain
+func writeTestIncrements(t *testing.T, dir string, retention uint64) {
+	t.Helper()
+
+	chain := &mockChain{genesis: genesis, blocks: []*types.Block{genesis, blocks[0]}}
+	scheduler := newTestScheduler(t, chain, dir, retention)
+
+	assert.NoError(t, scheduler.Backup())
+
+	for _, b := range blocks[1:] {
+		chain.blocks = append(chain.blocks, b)
+
+		assert.True(t, scheduler.blocksDue())
+		assert.NoError(t, scheduler.Backup())
+	}
+
+	// nothing is written without new blocks
+	assert.False(t, scheduler.blocksDue())
+	assert.NoError(t, scheduler.Backup())
+}
+
+func TestBackupScheduler_Backup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestIncrements(t, dir, 0)
+
+	manifest, err := ReadManifest(dir)
+	assert.NoError(t, err)
+	assert.Len(t, manifest.Increments, 3)
+
+	for i, r := range [][2]uint64{{0, 1}, {2, 2}, {3, 3}} {
+		inc := manifest.Increments[i]
+
+		assert.Equal(t, r[0], inc.From)
+		assert.Equal(t, r[1], inc.To)
+		assert.NoError(t, verifyIncrement(dir, inc))
+	}
+
+	assert.Equal(t, blocks[0].Hash(), manifest.Increments[1].ParentHash)
+	assert.Equal(t, blocks[2].Hash(), manifest.Increments[2].Hash)
+}
+
+func TestBackupScheduler_Retention(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestIncrements(t, dir, 2)
+
+	manifest, err := ReadManifest(dir)
+	assert.NoError(t, err)
+	assert.Len(t, manifest.Increments, 2)
+
+	merged := manifest.Increments[0]
+	assert.Equal(t, uint64(0), merged.From)
+	assert.Equal(t, uint64(2), merged.To)
+	assert.Equal(t, encodeBlocks(genesis, blocks[0], blocks[1]), readArchiveBlocks(t, filepath.Join(dir, merged.File)))
+
+	// the merged increments are removed
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestBackupScheduler_RemoveStaleFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestIncrements(t, dir, 0)
+
+	stale := []string{
+		incrementPrefix + "000000000004-000000000004" + incrementSuffix + tmpSuffix,
+		incrementPrefix + "000000000004-000000000004" + incrementSuffix,
+		ManifestFile + tmpSuffix,
+	}
+
+	for _, name := range stale {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{0x1}, 0600))
+	}
+
+	newTestScheduler(t, &mockChain{genesis: genesis}, dir, 0)
+
+	for _, name := range stale {
+		_, err := os.Stat(filepath.Join(dir, name))
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	}
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
+
+func TestRestoreChain_Increments(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTestIncrements(t, dir, 0)
+
+	t.Run("should restore all increments from the directory", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{genesis: genesis}
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+		assert.NoError(t, RestoreChain(chain, nil, dir, progression))
+		assert.Equal(t, []*types.Block{blocks[0], blocks[1], blocks[2]}, chain.blocks)
+	})
+
+	t.Run("should skip the increments in the chain", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &mockChain{genesis: genesis, blocks: []*types.Block{blocks[0], blocks[1]}}
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+		assert.NoError(t, RestoreChain(chain, nil, filepath.Join(dir, ManifestFile), progression))
+		assert.Equal(t, []*types.Block{blocks[0], blocks[1], blocks[2]}, chain.blocks)
+	})
+
+	t.Run("should fail if an increment is corrupted", func(t *testing.T) {
+		t.Parallel()
+
+		corruptedDir := t.TempDir()
+		writeTestIncrements(t, corruptedDir, 0)
+
+		manifest, err := ReadManifest(corruptedDir)
+		assert.NoError(t, err)
+
+		path := filepath.Join(corruptedDir, manifest.Increments[1].File)
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+
+		data[archiveHeaderSize+chunkHeaderSize] ^= 0xff
+		assert.NoError(t, os.WriteFile(path, data, 0600))
+
+		chain := &mockChain{genesis: genesis}
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+
+		err = RestoreChain(chain, nil, corruptedDir, progression)
+		assert.True(t, errors.Is(err, ErrIncrementChecksum))
+		assert.Equal(t, []*types.Block{blocks[0]}, chain.blocks)
+	})
+}
+
+func TestManifest_Verify(t *testing.T) {
+	t.Parallel()
+
+	manifest := &Manifest{
+		Version: manifestVersion,
+		Increments: []*Increment{
+			{File: "a", From: 0, To: 1, Hash: blocks[0].Hash()},
+			{File: "b", From: 2, To: 3, ParentHash: blocks[0].Hash(), Hash: blocks[2].Hash()},
+		},
+	}
+	assert.NoError(t, manifest.verify())
+
+	manifest.Increments[1].ParentHash = types.StringToHash("1")
+	assert.True(t, errors.Is(manifest.verify(), ErrManifestBroken))
+
+	manifest.Increments[1].ParentHash = blocks[0].Hash()
+	manifest.Increments[1].From = 3
+	assert.True(t, errors.Is(manifest.verify(), ErrManifestBroken))
+}
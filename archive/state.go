@@ -0,0 +1,263 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+var (
+	ErrStateRootMismatch  = errors.New("state root of the snapshot doesn't match")
+	ErrStateChunkMismatch = errors.New("hash of the state chunk doesn't match")
+	ErrStateNotSupported  = errors.New("state snapshot of the archive can't be restored on this chain")
+)
+
+// emptyCodeHash is the code hash of the accounts without code
+var emptyCodeHash = types.BytesToHash(keccak.Keccak256(nil, nil))
+
+// hasCode returns true if the code hash of the account refers to a code
+func hasCode(codeHash types.Hash) bool {
+	return codeHash != emptyCodeHash && codeHash != types.ZeroHash
+}
+
+// StateReader reads the entries of the state tries
+type StateReader interface {
+	Iterate(root types.Hash, start []byte, fn func(key, value []byte) bool) error
+	GetCode(hash types.Hash) ([]byte, bool)
+	GetPreimage(hash types.Hash) ([]byte, bool)
+}
+
+// ExportState splits the state with the given root into chunks of about maxChunkSize bytes
+// and passes their encoding to fn, ending with the empty chunk
+func ExportState(reader StateReader, root types.Hash, maxChunkSize uint64, fn func(data []byte) error) error {
+	w := &stateChunkWriter{
+		reader:  reader,
+		maxSize: maxChunkSize,
+		fn:      fn,
+	}
+
+	var exportErr error
+
+	if err := reader.Iterate(root, nil, func(key, value []byte) bool {
+		exportErr = w.appendAccount(types.BytesToHash(key), value)
+
+		return exportErr == nil
+	}); err != nil {
+		return err
+	}
+
+	if exportErr != nil {
+		return exportErr
+	}
+
+	if len(w.accounts) > 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+
+	// the closing chunk
+	return w.flush()
+}
+
+// stateChunkWriter collects the entries of the state tries into chunks
+type stateChunkWriter struct {
+	reader  StateReader
+	maxSize uint64
+	fn      func(data []byte) error
+
+	accounts []*StateAccount
+	size     uint64
+}
+
+// appendAccount adds the account with its code and storage to the chunks
+func (w *stateChunkWriter) appendAccount(hash types.Hash, value []byte) error {
+	var account state.Account
+	if err := account.UnmarshalRlp(value); err != nil {
+		return err
+	}
+
+	// the values are copied, as they may be reused by the iteration
+	stateAccount := &StateAccount{
+		Hash:    hash,
+		Account: append([]byte{}, value...),
+	}
+
+	stateAccount.Preimage, _ = w.reader.GetPreimage(hash)
+
+	if codeHash := types.BytesToHash(account.CodeHash); hasCode(codeHash) {
+		code, ok := w.reader.GetCode(codeHash)
+		if !ok {
+			return fmt.Errorf("code %s of account %s not found", codeHash, hash)
+		}
+
+		stateAccount.Code = code
+	}
+
+	if err := w.append(stateAccount, uint64(len(value)+len(stateAccount.Code)+len(stateAccount.Preimage))); err != nil {
+		return err
+	}
+
+	var storageErr error
+
+	if err := w.reader.Iterate(account.Root, nil, func(key, value []byte) bool {
+		slot := &StateSlot{
+			Hash:  types.BytesToHash(key),
+			Value: append([]byte{}, value...),
+		}
+
+		slot.Preimage, _ = w.reader.GetPreimage(slot.Hash)
+
+		storageErr = w.appendSlot(hash, slot)
+
+		return storageErr == nil
+	}); err != nil {
+		return err
+	}
+
+	return storageErr
+}
+
+// appendSlot adds the storage slot to the last account, which continues
+// in a new chunk if the previous one was full
+func (w *stateChunkWriter) appendSlot(accountHash types.Hash, slot *StateSlot) error {
+	if len(w.accounts) == 0 {
+		if err := w.append(&StateAccount{Hash: accountHash}, 0); err != nil {
+			return err
+		}
+	}
+
+	account := w.accounts[len(w.accounts)-1]
+	account.Storage = append(account.Storage, slot)
+
+	return w.grow(uint64(len(slot.Value) + len(slot.Preimage) + types.HashLength))
+}
+
+// append adds the account to the current chunk
+func (w *stateChunkWriter) append(account *StateAccount, size uint64) error {
+	w.accounts = append(w.accounts, account)
+
+	return w.grow(size + types.HashLength)
+}
+
+// grow accounts for the added data and flushes the chunk once it's full
+func (w *stateChunkWriter) grow(size uint64) error {
+	w.size += size
+
+	if w.size < w.maxSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+// flush passes the current chunk to the callback
+func (w *stateChunkWriter) flush() error {
+	chunk := &StateChunk{Accounts: w.accounts}
+	chunk.Hash = chunk.ComputeHash()
+
+	w.accounts = nil
+	w.size = 0
+
+	return w.fn(chunk.MarshalRLP())
+}
+
+// stateImporter rebuilds the state tries in the storage from the state chunks
+type stateImporter struct {
+	storage     itrie.Storage
+	accountTrie *itrie.TrieBuilder
+
+	// the account whose storage may continue in the next chunk
+	current        *StateAccount
+	currentAccount state.Account
+	storageTrie    *itrie.TrieBuilder
+}
+
+func newStateImporter(storage itrie.Storage) *stateImporter {
+	return &stateImporter{
+		storage:     storage,
+		accountTrie: itrie.NewTrieBuilder(storage),
+	}
+}
+
+// importChunk adds the accounts of the chunk to the state
+func (i *stateImporter) importChunk(chunk *StateChunk) error {
+	if hash := chunk.ComputeHash(); hash != chunk.Hash {
+		return fmt.Errorf("%w: expected %s, got %s", ErrStateChunkMismatch, chunk.Hash, hash)
+	}
+
+	for _, account := range chunk.Accounts {
+		if i.current == nil || account.Hash != i.current.Hash {
+			if err := i.startAccount(account); err != nil {
+				return err
+			}
+		}
+
+		for _, slot := range account.Storage {
+			i.storageTrie.Insert(slot.Hash.Bytes(), slot.Value, slot.Preimage)
+		}
+	}
+
+	return nil
+}
+
+// startAccount completes the current account and starts the given one
+func (i *stateImporter) startAccount(account *StateAccount) error {
+	if err := i.commitAccount(); err != nil {
+		return err
+	}
+
+	if i.current != nil && bytes.Compare(account.Hash.Bytes(), i.current.Hash.Bytes()) <= 0 {
+		return fmt.Errorf("account %s out of order", account.Hash)
+	}
+
+	if err := i.currentAccount.UnmarshalRlp(account.Account); err != nil {
+		return fmt.Errorf("invalid account %s: %w", account.Hash, err)
+	}
+
+	if codeHash := types.BytesToHash(i.currentAccount.CodeHash); hasCode(codeHash) {
+		if hash := types.BytesToHash(keccak.Keccak256(nil, account.Code)); hash != codeHash {
+			return fmt.Errorf("code of account %s doesn't match its hash", account.Hash)
+		}
+
+		i.storage.SetCode(codeHash, account.Code)
+	}
+
+	i.current = account
+	i.storageTrie = itrie.NewTrieBuilder(i.storage)
+
+	return nil
+}
+
+// commitAccount writes the storage trie of the current account and adds the account to the account trie
+func (i *stateImporter) commitAccount() error {
+	if i.current == nil {
+		return nil
+	}
+
+	if root := i.storageTrie.Commit(); root != i.currentAccount.Root {
+		return fmt.Errorf("%w: storage of account %s", ErrStateRootMismatch, i.current.Hash)
+	}
+
+	i.accountTrie.Insert(i.current.Hash.Bytes(), i.current.Account, i.current.Preimage)
+
+	return nil
+}
+
+// commit writes the account trie and verifies the state root
+func (i *stateImporter) commit(expectedRoot types.Hash) error {
+	if err := i.commitAccount(); err != nil {
+		return err
+	}
+
+	if root := i.accountTrie.Commit(); root != expectedRoot {
+		return fmt.Errorf("%w: expected %s, got %s", ErrStateRootMismatch, expectedRoot, root)
+	}
+
+	return nil
+}
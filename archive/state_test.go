@@ -0,0 +1,241 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
+	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestState(t *testing.T, numAccounts int) (*itrie.State, types.Hash) {
+	t.Helper()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	objs := make([]*state.Object, numAccounts)
+
+	for i := range objs {
+		objs[i] = &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i * 100)),
+			Nonce:    uint64(i),
+			CodeHash: emptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%2 == 0 {
+			for j := 0; j < 10; j++ {
+				objs[i].Storage = append(objs[i].Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j + 1)).Bytes()).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i*100 + j)).Bytes()).Bytes(),
+				})
+			}
+		}
+
+		if i%3 == 0 {
+			objs[i].Code = []byte{0x60, byte(i)}
+			objs[i].CodeHash = types.BytesToHash(keccak.Keccak256(nil, objs[i].Code))
+			objs[i].DirtyCode = true
+		}
+	}
+
+	_, root := st.NewSnapshot().Commit(objs)
+
+	return st, types.BytesToHash(root)
+}
+
+// exportTestState returns the encoded chunks of the state
+func exportTestState(t *testing.T, st *itrie.State, root types.Hash, maxChunkSize uint64) [][]byte {
+	t.Helper()
+
+	chunks := [][]byte{}
+
+	assert.NoError(t, ExportState(st, root, maxChunkSize, func(data []byte) error {
+		chunks = append(chunks, data)
+
+		return nil
+	}))
+
+	return chunks
+}
+
+// importTestState imports the encoded chunks into the storage
+func importTestState(storage itrie.Storage, chunks [][]byte, root types.Hash) error {
+	var buf bytes.Buffer
+
+	for _, data := range chunks {
+		buf.Write(data)
+	}
+
+	return importState(storage, newBlockStream(&buf), root)
+}
+
+func TestExportImportState(t *testing.T) {
+	t.Parallel()
+
+	for _, maxChunkSize := range []uint64{64, 1024, 1024 * 1024} {
+		st, root := newTestState(t, 20)
+		chunks := exportTestState(t, st, root, maxChunkSize)
+
+		// the snapshot ends with the empty chunk
+		last := &StateChunk{}
+		assert.NoError(t, last.UnmarshalRLP(chunks[len(chunks)-1]))
+		assert.Len(t, last.Accounts, 0)
+
+		storage := itrie.NewMemoryStorage()
+		assert.NoError(t, importTestState(storage, chunks, root))
+
+		// the imported state matches the original one
+		imported := itrie.NewState(storage)
+
+		snap, err := imported.NewSnapshotAt(root)
+		assert.NoError(t, err)
+
+		txn := state.NewTxn(imported, snap)
+
+		for i := 0; i < 20; i++ {
+			addr := types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes())
+
+			assert.Equal(t, uint64(i), txn.GetNonce(addr))
+
+			if i%2 == 0 {
+				key := types.BytesToHash(big.NewInt(5).Bytes())
+				assert.Equal(t, types.BytesToHash(big.NewInt(int64(i*100+4)).Bytes()), txn.GetState(addr, key))
+			}
+
+			if i%3 == 0 {
+				assert.Equal(t, []byte{0x60, byte(i)}, txn.GetCode(addr))
+			}
+		}
+	}
+}
+
+func TestImportState_Errors(t *testing.T) {
+	t.Parallel()
+
+	st, root := newTestState(t, 10)
+	chunks := exportTestState(t, st, root, 256)
+
+	t.Run("root mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		err := importTestState(itrie.NewMemoryStorage(), chunks, types.StringToHash("1"))
+		assert.True(t, errors.Is(err, ErrStateRootMismatch))
+	})
+
+	t.Run("tampered chunk", func(t *testing.T) {
+		t.Parallel()
+
+		chunk := &StateChunk{}
+		assert.NoError(t, chunk.UnmarshalRLP(chunks[0]))
+
+		chunk.Accounts[0].Account = append([]byte{}, chunk.Accounts[0].Account...)
+		chunk.Accounts[0].Account[len(chunk.Accounts[0].Account)-1] ^= 0xff
+
+		tampered := append([][]byte{chunk.MarshalRLP()}, chunks[1:]...)
+
+		err := importTestState(itrie.NewMemoryStorage(), tampered, root)
+		assert.True(t, errors.Is(err, ErrStateChunkMismatch))
+	})
+
+	t.Run("truncated snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		err := importTestState(itrie.NewMemoryStorage(), chunks[:len(chunks)-1], root)
+		assert.Error(t, err)
+	})
+}
+
+func TestMetadata_StateRoot(t *testing.T) {
+	t.Parallel()
+
+	for _, m := range []*Metadata{
+		{Latest: 10, LatestHash: types.StringToHash("10")},
+		{Latest: 10, LatestHash: types.StringToHash("10"), StateRoot: types.StringToHash("20")},
+	} {
+		res := &Metadata{}
+		assert.NoError(t, res.UnmarshalRLP(m.MarshalRLP()))
+		assert.Equal(t, m, res)
+	}
+}
+
+func Test_importBlocksWithState(t *testing.T) {
+	t.Parallel()
+
+	st, root := newTestState(t, 10)
+	chunks := exportTestState(t, st, root, 256)
+
+	newStateBlocks := func(stateRoot types.Hash) []*types.Block {
+		stateBlocks := []*types.Block{
+			{Header: &types.Header{Number: 1}},
+			{Header: &types.Header{Number: 2, StateRoot: stateRoot}},
+		}
+
+		for _, b := range stateBlocks {
+			b.Header.ComputeHash()
+		}
+
+		return stateBlocks
+	}
+
+	newTestStream := func(stateBlocks []*types.Block) *blockStream {
+		var buf bytes.Buffer
+
+		latest := stateBlocks[len(stateBlocks)-1]
+
+		buf.Write((&Metadata{
+			Latest:     latest.Number(),
+			LatestHash: latest.Hash(),
+			StateRoot:  root,
+		}).MarshalRLP())
+
+		for _, data := range chunks {
+			buf.Write(data)
+		}
+
+		buf.Write(genesis.MarshalRLP())
+
+		for _, b := range stateBlocks {
+			buf.Write(b.MarshalRLP())
+		}
+
+		return newBlockStream(&buf)
+	}
+
+	t.Run("should import the state and write all blocks", func(t *testing.T) {
+		t.Parallel()
+
+		stateBlocks := newStateBlocks(root)
+		chain := &mockChain{genesis: genesis}
+		storage := itrie.NewMemoryStorage()
+
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+		assert.NoError(t, importBlocks(chain, storage, newTestStream(stateBlocks), progression))
+
+		assert.Equal(t, stateBlocks[1], getLatestBlockFromMockChain(chain))
+
+		_, err := itrie.NewState(storage).NewSnapshotAt(root)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail if the latest block doesn't match the state", func(t *testing.T) {
+		t.Parallel()
+
+		stateBlocks := newStateBlocks(types.StringToHash("1"))
+		chain := &mockChain{genesis: genesis}
+
+		progression := progress.NewProgressionWrapper(progress.ChainSyncRestore)
+		err := importBlocks(chain, itrie.NewMemoryStorage(), newTestStream(stateBlocks), progression)
+
+		assert.True(t, errors.Is(err, ErrStateRootMismatch))
+
+		// the latest block isn't written to chain
+		assert.Equal(t, stateBlocks[0], getLatestBlockFromMockChain(chain))
+	})
+}
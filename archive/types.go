@@ -3,6 +3,7 @@ package archive
 import (
 	"fmt"
 
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/umbracle/fastrlp"
 )
@@ -11,6 +12,15 @@ import (
 type Metadata struct {
 	Latest     uint64
 	LatestHash types.Hash
+
+	// StateRoot is the state root of the latest block if the backup includes
+	// the state snapshot, which follows the metadata. Zero otherwise
+	StateRoot types.Hash
+}
+
+// HasState returns true if the backup includes the state snapshot
+func (m *Metadata) HasState() bool {
+	return m.StateRoot != types.ZeroHash
 }
 
 // MarshalRLP returns RLP encoded bytes
@@ -30,6 +40,11 @@ func (m *Metadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewUint(m.Latest))
 	vv.Set(arena.NewBytes(m.LatestHash.Bytes()))
 
+	// the backups without state keep the original format
+	if m.HasState() {
+		vv.Set(arena.NewBytes(m.StateRoot.Bytes()))
+	}
+
 	return vv
 }
 
@@ -57,5 +72,308 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
+	if len(elems) > 2 {
+		if err = elems[2].GetHash(m.StateRoot[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StateSlot is a storage slot of an account in the state snapshot of the backup
+type StateSlot struct {
+	Hash     types.Hash // hash of the slot key, the key of the storage trie
+	Preimage []byte     // slot key, empty if unknown
+	Value    []byte     // value as stored in the storage trie
+}
+
+// StateAccount is an account in the state snapshot of the backup. The storage of an account
+// which doesn't fit in a chunk continues in the following chunks, with the same hash and no account data
+type StateAccount struct {
+	Hash     types.Hash // hash of the address, the key of the account trie
+	Preimage []byte     // address, empty if unknown
+	Account  []byte     // account as stored in the account trie, empty in the continuations
+	Code     []byte
+	Storage  []*StateSlot
+}
+
+// StateChunk is a part of the state snapshot stored after the metadata of the backup.
+// The snapshot ends with an empty chunk
+type StateChunk struct {
+	Accounts []*StateAccount
+	Hash     types.Hash // keccak256 hash of the encoded accounts
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (c *StateChunk) MarshalRLP() []byte {
+	return c.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (c *StateChunk) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(c.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (c *StateChunk) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(c.marshalAccountsWith(arena))
+	vv.Set(arena.NewBytes(c.Hash.Bytes()))
+
+	return vv
+}
+
+// marshalAccountsWith encodes the accounts of the chunk, whose hash is the hash of the chunk
+func (c *StateChunk) marshalAccountsWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if len(c.Accounts) == 0 {
+		return arena.NewNullArray()
+	}
+
+	accounts := arena.NewArray()
+
+	for _, account := range c.Accounts {
+		vv := arena.NewArray()
+
+		vv.Set(arena.NewBytes(account.Hash.Bytes()))
+		vv.Set(arena.NewCopyBytes(account.Preimage))
+		vv.Set(arena.NewCopyBytes(account.Account))
+		vv.Set(arena.NewCopyBytes(account.Code))
+
+		if len(account.Storage) == 0 {
+			vv.Set(arena.NewNullArray())
+		} else {
+			slots := arena.NewArray()
+
+			for _, slot := range account.Storage {
+				vs := arena.NewArray()
+
+				vs.Set(arena.NewBytes(slot.Hash.Bytes()))
+				vs.Set(arena.NewCopyBytes(slot.Preimage))
+				vs.Set(arena.NewCopyBytes(slot.Value))
+
+				slots.Set(vs)
+			}
+
+			vv.Set(slots)
+		}
+
+		accounts.Set(vv)
+	}
+
+	return accounts
+}
+
+// ComputeHash computes the hash of the accounts of the chunk
+func (c *StateChunk) ComputeHash() types.Hash {
+	arena := &fastrlp.Arena{}
+
+	return types.BytesToHash(keccak.Keccak256Rlp(nil, c.marshalAccountsWith(arena)))
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (c *StateChunk) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(c.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (c *StateChunk) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 2 {
+		return fmt.Errorf("incorrect number of elements to decode StateChunk, expected 2 but found %d", len(elems))
+	}
+
+	accounts, err := elems[0].GetElems()
+	if err != nil {
+		return err
+	}
+
+	c.Accounts = make([]*StateAccount, len(accounts))
+
+	for i, accountValue := range accounts {
+		if c.Accounts[i], err = unmarshalStateAccount(accountValue); err != nil {
+			return err
+		}
+	}
+
+	return elems[1].GetHash(c.Hash[:])
+}
+
+// unmarshalStateAccount decodes the account of the state chunk
+func unmarshalStateAccount(v *fastrlp.Value) (*StateAccount, error) {
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elems) != 5 {
+		return nil, fmt.Errorf("incorrect number of elements to decode StateAccount, expected 5 but found %d", len(elems))
+	}
+
+	account := &StateAccount{}
+
+	if err := elems[0].GetHash(account.Hash[:]); err != nil {
+		return nil, err
+	}
+
+	if account.Preimage, err = elems[1].GetBytes(nil); err != nil {
+		return nil, err
+	}
+
+	if account.Account, err = elems[2].GetBytes(nil); err != nil {
+		return nil, err
+	}
+
+	if account.Code, err = elems[3].GetBytes(nil); err != nil {
+		return nil, err
+	}
+
+	slots, err := elems[4].GetElems()
+	if err != nil {
+		return nil, err
+	}
+
+	account.Storage = make([]*StateSlot, len(slots))
+
+	for i, slotValue := range slots {
+		slotElems, err := slotValue.GetElems()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(slotElems) != 3 {
+			return nil, fmt.Errorf("incorrect number of elements to decode StateSlot, expected 3 but found %d", len(slotElems))
+		}
+
+		slot := &StateSlot{}
+
+		if err := slotElems[0].GetHash(slot.Hash[:]); err != nil {
+			return nil, err
+		}
+
+		if slot.Preimage, err = slotElems[1].GetBytes(nil); err != nil {
+			return nil, err
+		}
+
+		if slot.Value, err = slotElems[2].GetBytes(nil); err != nil {
+			return nil, err
+		}
+
+		account.Storage[i] = slot
+	}
+
+	return account, nil
+}
+
+// IndexEntry locates a chunk of blocks in the archive
+type IndexEntry struct {
+	From   uint64
+	To     uint64
+	Offset uint64 // offset of the chunk from the start of the archive
+}
+
+// ArchiveIndex is stored at the end of the archive, it holds the metadata and the
+// locations of the chunks of blocks
+type ArchiveIndex struct {
+	Metadata Metadata
+	Chunks   []*IndexEntry
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (i *ArchiveIndex) MarshalRLP() []byte {
+	return i.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (i *ArchiveIndex) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(i.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (i *ArchiveIndex) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(i.Metadata.MarshalRLPWith(arena))
+
+	if len(i.Chunks) == 0 {
+		vv.Set(arena.NewNullArray())
+	} else {
+		chunks := arena.NewArray()
+
+		for _, entry := range i.Chunks {
+			ve := arena.NewArray()
+
+			ve.Set(arena.NewUint(entry.From))
+			ve.Set(arena.NewUint(entry.To))
+			ve.Set(arena.NewUint(entry.Offset))
+
+			chunks.Set(ve)
+		}
+
+		vv.Set(chunks)
+	}
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (i *ArchiveIndex) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(i.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (i *ArchiveIndex) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 2 {
+		return fmt.Errorf("incorrect number of elements to decode ArchiveIndex, expected 2 but found %d", len(elems))
+	}
+
+	if err := i.Metadata.UnmarshalRLPFrom(p, elems[0]); err != nil {
+		return err
+	}
+
+	chunks, err := elems[1].GetElems()
+	if err != nil {
+		return err
+	}
+
+	i.Chunks = make([]*IndexEntry, len(chunks))
+
+	for j, chunkValue := range chunks {
+		entryElems, err := chunkValue.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(entryElems) != 3 {
+			return fmt.Errorf("incorrect number of elements to decode IndexEntry, expected 3 but found %d", len(entryElems))
+		}
+
+		entry := &IndexEntry{}
+
+		if entry.From, err = entryElems[0].GetUint64(); err != nil {
+			return err
+		}
+
+		if entry.To, err = entryElems[1].GetUint64(); err != nil {
+			return err
+		}
+
+		if entry.Offset, err = entryElems[2].GetUint64(); err != nil {
+			return err
+		}
+
+		i.Chunks[j] = entry
+	}
+
 	return nil
 }
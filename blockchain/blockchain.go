@@ -4,12 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"path/filepath"
 	"sync"
 	"sync/atomic"
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
-	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
 	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/memory"
 	"github.com/ExzoNetwork/ExzoCoin/chain"
 	"github.com/ExzoNetwork/ExzoCoin/helper/common"
@@ -24,6 +22,7 @@ import (
 const (
 	BlockGasTargetDivisor uint64 = 1024 // The bound divisor of the gas limit, used in update calculations
 	defaultCacheSize      int    = 100  // The default size for Blockchain LRU cache structures
+	freezeBatchSize       uint64 = 1000 // The number of the blocks moved to the ancient store at once
 )
 
 var (
@@ -73,6 +72,12 @@ type Blockchain struct {
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
 	writeLock sync.Mutex
+
+	// The canonical blocks deeper than the threshold are moved to the ancient store
+	freezeThreshold uint64
+	freezeCh        chan struct{} // Triggers the freezing of the blocks, nil if the freezer is disabled
+	freezerDoneCh   chan struct{}
+	closeCh         chan struct{}
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -181,7 +186,7 @@ func (b *Blockchain) GetAvgGasPrice() *big.Int {
 // NewBlockchain creates a new blockchain object
 func NewBlockchain(
 	logger hclog.Logger,
-	dataDir string,
+	db storage.Storage,
 	config *chain.Chain,
 	consensus Verifier,
 	executor Executor,
@@ -196,24 +201,16 @@ func NewBlockchain(
 			price: big.NewInt(0),
 			count: big.NewInt(0),
 		},
+		closeCh: make(chan struct{}),
 	}
 
-	var (
-		db  storage.Storage
-		err error
-	)
+	// the blockchain is kept in memory if no storage is given
+	if db == nil {
+		var err error
 
-	if dataDir == "" {
 		if db, err = memory.NewMemoryStorage(nil); err != nil {
 			return nil, err
 		}
-	} else {
-		if db, err = leveldb.NewLevelDBStorage(
-			filepath.Join(dataDir, "blockchain"),
-			logger,
-		); err != nil {
-			return nil, err
-		}
 	}
 
 	b.db = db
@@ -377,7 +374,7 @@ func (b *Blockchain) CalculateGasLimit(number uint64) (uint64, error) {
 func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
 	// The gas limit cannot move more than 1/1024 * parentGasLimit
 	// in either direction per block
-	blockGasTarget := b.Config().BlockGasTarget
+	blockGasTarget := b.Config().GetBlockGasTarget()
 
 	// Check if the gas limit target has been set
 	if blockGasTarget == 0 {
@@ -755,11 +752,64 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 	return nil
 }
 
+// VerifyFinalizedBlockWithoutExecution verifies the sealed block like VerifyFinalizedBlock,
+// except for the execution of its transactions. It's meant for the blocks below a state
+// imported from a snapshot, which can't be executed
+func (b *Blockchain) VerifyFinalizedBlockWithoutExecution(block *types.Block) error {
+	if block == nil {
+		return ErrNoBlock
+	}
+
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	if err := b.verifyBlockParent(block); err != nil {
+		return err
+	}
+
+	return b.verifyBlockRoots(block)
+}
+
+// VerifyFinalizedHeader verifies the sealed header and its parent without the body of the block.
+// It's meant for the light mode, which keeps only the headers
+func (b *Blockchain) VerifyFinalizedHeader(header *types.Header) error {
+	if header == nil {
+		return ErrNoBlock
+	}
+
+	if err := b.consensus.VerifyHeader(header); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	return b.verifyBlockParent(&types.Block{Header: header})
+}
+
 // verifyBlockBody verifies that the block body is valid. This means checking:
 // - The trie roots match up (state, transactions, receipts, uncles)
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) error {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return nil
+}
+
+// verifyBlockRoots verifies that the uncles and transactions roots of the header match the block body
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -782,17 +832,6 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) error {
 		return ErrInvalidTxRoot
 	}
 
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return fmt.Errorf("unable to execute block transactions, %w", executeErr)
-	}
-
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return fmt.Errorf("unable to verify block execution result, %w", err)
-	}
-
 	return nil
 }
 
@@ -862,6 +901,46 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 // WriteBlock writes a single block to the local blockchain.
 // It doesn't do any kind of verification, only commits the block to the DB
 func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
+	return b.writeBlock(block, source, true)
+}
+
+// WriteBlockWithoutExecution writes the block without executing its transactions,
+// so the block has no receipts. It's meant for the blocks below a state imported from a snapshot
+func (b *Blockchain) WriteBlockWithoutExecution(block *types.Block, source string) error {
+	return b.writeBlock(block, source, false)
+}
+
+// WriteFinalizedHeader writes the header as the new head without the body of the block.
+// It doesn't do any kind of verification, and it's meant for the light mode
+func (b *Blockchain) WriteFinalizedHeader(header *types.Header, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if header.Number <= b.Header().Number {
+		b.logger.Info("header already inserted", "header", header.Number, "source", source)
+
+		return nil
+	}
+
+	evnt := &Event{Source: source}
+	if err := b.writeHeaderImpl(evnt, header); err != nil {
+		return err
+	}
+
+	// update snapshot
+	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+		return err
+	}
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("new header", "number", header.Number, "hash", header.Hash, "parent", header.ParentHash)
+
+	return nil
+}
+
+// writeBlock commits the block to the DB, along with its receipts if requested
+func (b *Blockchain) writeBlock(block *types.Block, source string, withReceipts bool) error {
 	b.writeLock.Lock()
 	defer b.writeLock.Unlock()
 
@@ -883,17 +962,19 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 		return err
 	}
 
-	// Fetch the block receipts
-	blockReceipts, receiptsErr := b.extractBlockReceipts(block)
-	if receiptsErr != nil {
-		return receiptsErr
-	}
+	if withReceipts {
+		// Fetch the block receipts
+		blockReceipts, receiptsErr := b.extractBlockReceipts(block)
+		if receiptsErr != nil {
+			return receiptsErr
+		}
 
-	// write the receipts, do it only after the header has been written.
-	// Otherwise, a client might ask for a header once the receipt is valid,
-	// but before it is written into the storage
-	if err := b.db.WriteReceipts(block.Hash(), blockReceipts); err != nil {
-		return err
+		// write the receipts, do it only after the header has been written.
+		// Otherwise, a client might ask for a header once the receipt is valid,
+		// but before it is written into the storage
+		if err := b.db.WriteReceipts(block.Hash(), blockReceipts); err != nil {
+			return err
+		}
 	}
 
 	// update snapshot
@@ -903,6 +984,8 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 
 	b.dispatchEvent(evnt)
 
+	b.triggerFreezer()
+
 	// Update the average gas price
 	b.updateGasPriceAvgWithBlock(block)
 
@@ -1165,6 +1248,7 @@ func (b *Blockchain) handleReorg(
 	newChainHead := newHeader
 	oldChainHead := oldHeader
 
+	// the headers of both branches above the common ancestor, from the heads down
 	oldChain := []*types.Header{}
 	newChain := []*types.Header{}
 
@@ -1172,47 +1256,46 @@ func (b *Blockchain) handleReorg(
 
 	// Fill up the old headers array
 	for oldHeader.Number > newHeader.Number {
+		oldChain = append(oldChain, oldHeader)
+
 		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", oldChain[len(oldChain)-1].ParentHash.String())
 		}
-
-		oldChain = append(oldChain, oldHeader)
 	}
 
 	// Fill up the new headers array
 	for newHeader.Number > oldHeader.Number {
+		newChain = append(newChain, newHeader)
+
 		newHeader, ok = b.readHeader(newHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", newChain[len(newChain)-1].ParentHash.String())
 		}
-
-		newChain = append(newChain, newHeader)
 	}
 
 	for oldHeader.Hash != newHeader.Hash {
+		oldChain = append(oldChain, oldHeader)
+		newChain = append(newChain, newHeader)
+
 		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", oldChain[len(oldChain)-1].ParentHash.String())
 		}
 
 		newHeader, ok = b.readHeader(newHeader.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+			return fmt.Errorf("header '%s' not found", newChain[len(newChain)-1].ParentHash.String())
 		}
-
-		oldChain = append(oldChain, oldHeader)
 	}
 
-	for _, b := range oldChain[:len(oldChain)-1] {
-		evnt.AddOldHeader(b)
+	// the event lists the headers in the ascending order of the numbers
+	for i := len(oldChain) - 1; i >= 0; i-- {
+		evnt.AddOldHeader(oldChain[i])
 	}
 
-	evnt.AddOldHeader(oldChainHead)
-	evnt.AddNewHeader(newChainHead)
-
-	for _, b := range newChain {
-		evnt.AddNewHeader(b)
+	for i := len(newChain) - 1; i >= 0; i-- {
+		evnt.AddNewHeader(newChain[i])
 	}
 
 	if err := b.writeFork(oldChainHead); err != nil {
@@ -1286,7 +1369,108 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 	return b.GetBlockByHash(blockHash, full)
 }
 
-// Close closes the DB connection
+// EnableFreezer starts moving the canonical blocks which are deeper than
+// the threshold below the head from the storage to its ancient store
+func (b *Blockchain) EnableFreezer(threshold uint64) error {
+	if threshold == 0 {
+		return errors.New("freezer threshold has to be positive")
+	}
+
+	b.freezeThreshold = threshold
+	b.freezeCh = make(chan struct{}, 1)
+	b.freezerDoneCh = make(chan struct{})
+
+	go b.runFreezer()
+
+	// catch up with the current head
+	b.triggerFreezer()
+
+	return nil
+}
+
+// triggerFreezer signals the freezer to check for the blocks to freeze, without blocking
+func (b *Blockchain) triggerFreezer() {
+	if b.freezeCh == nil {
+		return
+	}
+
+	select {
+	case b.freezeCh <- struct{}{}:
+	default:
+	}
+}
+
+// runFreezer freezes the blocks in the background until the blockchain is closed
+func (b *Blockchain) runFreezer() {
+	defer close(b.freezerDoneCh)
+
+	for {
+		select {
+		case <-b.freezeCh:
+			if err := b.freeze(); err != nil {
+				b.logger.Error("failed to freeze blocks", "err", err)
+			}
+		case <-b.closeCh:
+			return
+		}
+	}
+}
+
+// freeze moves the blocks deeper than the threshold to the ancient store, in batches
+func (b *Blockchain) freeze() error {
+	for {
+		done, err := b.freezeBatch()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-b.closeCh:
+			return nil
+		default:
+		}
+	}
+}
+
+// freezeBatch freezes the next batch of blocks and returns true if there is nothing left to freeze.
+// It holds the write lock, so that the head can't be rewound below the blocks being frozen
+func (b *Blockchain) freezeBatch() (bool, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	head := b.Header()
+	if head == nil || head.Number < b.freezeThreshold {
+		return true, nil
+	}
+
+	limit := head.Number - b.freezeThreshold
+
+	from := b.db.Ancients()
+	if from > limit {
+		return true, nil
+	}
+
+	to := limit
+	if to-from >= freezeBatchSize {
+		to = from + freezeBatchSize - 1
+	}
+
+	if err := b.db.Freeze(to); err != nil {
+		return false, err
+	}
+
+	b.logger.Debug("froze blocks", "from", from, "to", to)
+
+	return false, nil
+}
+
+// Close stops the freezer and closes the DB connection
 func (b *Blockchain) Close() error {
+	close(b.closeCh)
+
+	if b.freezerDoneCh != nil {
+		<-b.freezerDoneCh
+	}
+
 	return b.db.Close()
 }
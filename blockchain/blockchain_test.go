@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ExzoNetwork/ExzoCoin/state"
 
@@ -13,8 +14,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/ancient"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
 	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/memory"
 	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
 )
 
 func TestGenesis(t *testing.T) {
@@ -403,9 +407,9 @@ func TestInsertHeaders(t *testing.T) {
 					header: mock(0x4).Parent(0x2).Diff(10),
 					event: &evnt{
 						NewChain: []*header{
-							mock(0x4).Parent(0x2).Diff(10),
-							mock(0x2),
 							mock(0x1),
+							mock(0x2),
+							mock(0x4).Parent(0x2).Diff(10),
 						},
 						OldChain: []*header{
 							mock(0x3).Parent(0x0).Diff(5),
@@ -427,6 +431,67 @@ func TestInsertHeaders(t *testing.T) {
 			},
 			TD: 0 + 1 + 2 + 10,
 		},
+		{
+			Name: "Reorg to a branch of the same height",
+			History: []*headerEvnt{
+				{
+					header: mock(0x0),
+				},
+				{
+					header: mock(0x1),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x1),
+						},
+						Diff: big.NewInt(1),
+					},
+				},
+				{
+					header: mock(0x2),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x2),
+						},
+						Diff: big.NewInt(1 + 2),
+					},
+				},
+				{
+					// fork 1. 0x0 -> 0x3
+					header: mock(0x3).Parent(0x0).Diff(1),
+					event: &evnt{
+						OldChain: []*header{
+							mock(0x3).Parent(0x0).Diff(1),
+						},
+					},
+				},
+				{
+					// the whole branch of the same height replaces 0x1 and 0x2
+					header: mock(0x4).Parent(0x3).Diff(10).Number(2),
+					event: &evnt{
+						NewChain: []*header{
+							mock(0x3).Parent(0x0).Diff(1),
+							mock(0x4).Parent(0x3).Diff(10).Number(2),
+						},
+						OldChain: []*header{
+							mock(0x1),
+							mock(0x2),
+						},
+						Diff: big.NewInt(1 + 10),
+					},
+				},
+			},
+			Head: mock(0x4).Parent(0x3).Diff(10).Number(2),
+			Forks: []*header{
+				mock(0x3).Parent(0x0).Diff(1),
+				mock(0x2),
+			},
+			Chain: []*header{
+				mock(0x0),
+				mock(0x3).Parent(0x0).Diff(1),
+				mock(0x4).Parent(0x3).Diff(10).Number(2),
+			},
+			TD: 0 + 1 + 10,
+		},
 	}
 
 	for _, cc := range cases {
@@ -998,3 +1063,214 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, blockchain.verifyBlockBody(block), errUnableToExecute)
 	})
 }
+
+func TestBlockchain_Freezer(t *testing.T) {
+	t.Parallel()
+
+	freezer, err := ancient.NewFreezer(t.TempDir(), true, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	db, err := leveldb.Factory(map[string]interface{}{
+		"path":                    t.TempDir(),
+		storage.AncientsConfigKey: freezer,
+	}, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), db, &chain.Chain{
+		Genesis: &chain.Genesis{},
+		Params: &chain.Params{
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}, &MockVerifier{}, &mockExecutor{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, b.ComputeGenesis())
+
+	headers := NewTestHeadersWithSeed(b.Header(), 11, 0)
+	assert.NoError(t, b.WriteHeaders(headers[1:]))
+
+	// the freezer catches up with the head of block 10
+	assert.Error(t, b.EnableFreezer(0))
+	assert.NoError(t, b.EnableFreezer(3))
+
+	assert.Eventually(t, func() bool {
+		return db.Ancients() == 8
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, header := range headers {
+		h, err := db.ReadHeader(header.Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, header.Number, h.Number)
+	}
+
+	header, ok := b.GetHeaderByNumber(5)
+	assert.True(t, ok)
+	assert.Equal(t, headers[5].Hash, header.Hash)
+
+	assert.NoError(t, b.Close())
+}
+
+func TestBlockchain_WriteBlockWithoutExecution(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), nil, &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit: defaultBlockGasTarget,
+		},
+		Params: &chain.Params{
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}, &MockVerifier{}, &mockExecutor{})
+	assert.NoError(t, err)
+
+	// the executor fails, so the blocks can't be executed
+	b.executor.(*mockExecutor).HookProcessBlock(func(
+		hash types.Hash,
+		block *types.Block,
+		address types.Address,
+	) (*state.Transition, error) {
+		return nil, errors.New("no state")
+	})
+
+	assert.NoError(t, b.ComputeGenesis())
+
+	headers := NewTestHeadersWithSeed(b.Header(), 4, defaultBlockGasTarget)
+
+	for _, header := range headers[1:] {
+		block := &types.Block{Header: header}
+
+		assert.Error(t, b.VerifyFinalizedBlock(block))
+		assert.NoError(t, b.VerifyFinalizedBlockWithoutExecution(block))
+		assert.NoError(t, b.WriteBlockWithoutExecution(block, "test"))
+
+		// the block has no receipts
+		_, err := b.GetReceiptsByHash(header.Hash)
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, headers[3].Hash, b.Header().Hash)
+
+	// the roots of the block are still verified
+	invalid := &types.Block{Header: headers[3].Copy()}
+	invalid.Header.TxRoot = types.ZeroHash
+	assert.ErrorIs(t, b.VerifyFinalizedBlockWithoutExecution(invalid), ErrInvalidTxRoot)
+}
+
+func TestBlockchain_WriteFinalizedHeader(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), nil, &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit: defaultBlockGasTarget,
+		},
+		Params: &chain.Params{
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}, &MockVerifier{}, &mockExecutor{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, b.ComputeGenesis())
+
+	headers := NewTestHeadersWithSeed(b.Header(), 4, defaultBlockGasTarget)
+
+	// the header can't be verified without its parent
+	assert.ErrorIs(t, b.VerifyFinalizedHeader(headers[2]), ErrParentNotFound)
+
+	sub := b.SubscribeEvents()
+	defer sub.Close()
+
+	for _, header := range headers[1:] {
+		assert.NoError(t, b.VerifyFinalizedHeader(header))
+		assert.NoError(t, b.WriteFinalizedHeader(header, "test"))
+
+		evnt := <-sub.GetEventCh()
+		assert.Equal(t, EventHead, evnt.Type)
+		assert.Equal(t, header.Hash, evnt.Header().Hash)
+
+		// the block has no body
+		_, ok := b.GetBodyByHash(header.Hash)
+		assert.False(t, ok)
+	}
+
+	assert.Equal(t, headers[3].Hash, b.Header().Hash)
+
+	// the header below the head is skipped
+	assert.NoError(t, b.WriteFinalizedHeader(headers[2], "test"))
+	assert.Equal(t, headers[3].Hash, b.Header().Hash)
+}
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBlockchain(hclog.NewNullLogger(), nil, &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit: defaultBlockGasTarget,
+		},
+		Params: &chain.Params{
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}, &MockVerifier{}, &mockExecutor{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, b.ComputeGenesis())
+
+	headers := NewTestHeadersWithSeed(b.Header(), 5, defaultBlockGasTarget)
+	txs := make([]*types.Transaction, len(headers))
+
+	for i, header := range headers[1:] {
+		tx := (&types.Transaction{Nonce: uint64(i), GasPrice: big.NewInt(1), Value: big.NewInt(0)}).ComputeHash()
+		txs[i+1] = tx
+
+		block := &types.Block{Header: header, Transactions: []*types.Transaction{tx}}
+		assert.NoError(t, b.WriteBlockWithoutExecution(block, "test"))
+		assert.NoError(t, b.db.WriteReceipts(header.Hash, []*types.Receipt{{TxHash: tx.Hash}}))
+	}
+
+	sub := b.SubscribeEvents()
+	defer sub.Close()
+
+	assert.ErrorIs(t, b.SetHead(5), ErrSetHeadAboveHead)
+
+	// the head is already at the block
+	assert.NoError(t, b.SetHead(4))
+	assert.Equal(t, headers[4].Hash, b.Header().Hash)
+
+	assert.NoError(t, b.SetHead(2))
+	assert.Equal(t, headers[2].Hash, b.Header().Hash)
+
+	td, ok := b.GetTD(headers[2].Hash)
+	assert.True(t, ok)
+	assert.Equal(t, td, b.CurrentTD())
+
+	for _, header := range headers[3:] {
+		_, ok := b.GetHeaderByNumber(header.Number)
+		assert.False(t, ok)
+
+		_, ok = b.ReadTxLookup(txs[header.Number].Hash)
+		assert.False(t, ok)
+
+		_, err := b.GetReceiptsByHash(header.Hash)
+		assert.Error(t, err)
+	}
+
+	_, ok = b.ReadTxLookup(txs[2].Hash)
+	assert.True(t, ok)
+
+	evnt := sub.GetEvent()
+	assert.Equal(t, EventReorg, evnt.Type)
+	assert.Len(t, evnt.NewChain, 0)
+	assert.Len(t, evnt.OldChain, 2)
+	assert.Equal(t, headers[3].Hash, evnt.OldChain[0].Hash)
+	assert.Equal(t, headers[4].Hash, evnt.OldChain[1].Hash)
+
+	// the deleted receipts are delivered with the event
+	assert.Len(t, evnt.OldReceipts, 2)
+
+	for _, header := range headers[3:] {
+		assert.Equal(t, txs[header.Number].Hash, evnt.OldReceipts[header.Hash][0].TxHash)
+	}
+
+	// the chain can advance again from the new head
+	assert.NoError(t, b.WriteBlockWithoutExecution(&types.Block{Header: headers[3]}, "test"))
+	assert.Equal(t, headers[3].Hash, b.Header().Hash)
+}
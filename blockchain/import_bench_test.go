@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/memory"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/pebble"
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// benchTxsPerBlock is the number of the transactions of the imported blocks
+const benchTxsPerBlock = 100
+
+// newBenchBlocks returns the blocks following the head of the chain,
+// with their receipts which the executor would return
+func newBenchBlocks(b *testing.B, bc *Blockchain, n int) ([]*types.Block, [][]*types.Receipt) {
+	b.Helper()
+
+	to := types.StringToAddress("1")
+	headers := NewTestHeadersWithSeed(bc.Header(), n+1, 0)[1:]
+	blocks := make([]*types.Block, n)
+	receipts := make([][]*types.Receipt, n)
+
+	for i, header := range headers {
+		block := &types.Block{Header: header}
+
+		for j := 0; j < benchTxsPerBlock; j++ {
+			tx := &types.Transaction{
+				Nonce:    uint64(i*benchTxsPerBlock + j),
+				GasPrice: big.NewInt(1),
+				Gas:      21000,
+				To:       &to,
+				Value:    big.NewInt(1),
+				V:        big.NewInt(27),
+				R:        big.NewInt(1),
+				S:        big.NewInt(1),
+			}
+			tx.ComputeHash()
+
+			block.Transactions = append(block.Transactions, tx)
+
+			status := types.ReceiptSuccess
+			receipts[i] = append(receipts[i], &types.Receipt{
+				Status:            &status,
+				CumulativeGasUsed: uint64(j+1) * 21000,
+				GasUsed:           21000,
+				TxHash:            tx.Hash,
+				Logs: []*types.Log{
+					{Address: types.ZeroAddress, Topics: []types.Hash{tx.Hash}, Data: tx.Hash.Bytes()},
+				},
+			})
+		}
+
+		blocks[i] = block
+	}
+
+	return blocks, receipts
+}
+
+// benchmarkBlockImport measures the import of a block to the chain on the given storage
+func benchmarkBlockImport(b *testing.B, factory storage.Factory) {
+	b.Helper()
+
+	db, err := factory(map[string]interface{}{"path": b.TempDir()}, hclog.NewNullLogger())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	bc, err := NewBlockchain(hclog.NewNullLogger(), db, &chain.Chain{
+		Genesis: &chain.Genesis{},
+		Params: &chain.Params{
+			BlockGasTarget: defaultBlockGasTarget,
+		},
+	}, &MockVerifier{}, &mockExecutor{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer func() {
+		_ = bc.Close()
+	}()
+
+	if err := bc.ComputeGenesis(); err != nil {
+		b.Fatal(err)
+	}
+
+	blocks, receipts := newBenchBlocks(b, bc, b.N)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i, block := range blocks {
+		// the receipts are cached by the block verification before the import
+		bc.receiptsCache.Add(block.Hash(), receipts[i])
+
+		if err := bc.WriteBlock(block, "bench"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlockImport_Memory(b *testing.B) {
+	benchmarkBlockImport(b, func(_ map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+		return memory.NewMemoryStorage(logger)
+	})
+}
+
+func BenchmarkBlockImport_LevelDB(b *testing.B) {
+	benchmarkBlockImport(b, leveldb.Factory)
+}
+
+func BenchmarkBlockImport_Pebble(b *testing.B) {
+	benchmarkBlockImport(b, pebble.Factory)
+}
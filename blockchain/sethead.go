@@ -0,0 +1,193 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+// SetHeadSource is the source of the reorg events dispatched by SetHead
+const SetHeadSource = "sethead"
+
+var (
+	ErrSetHeadAboveHead = errors.New("target block is above the head")
+	ErrSetHeadFrozen    = errors.New("target block is below the ancient store")
+	ErrSetHeadNoState   = errors.New("state of the target block not found")
+)
+
+// stateReader is implemented by the executors which can open the state at a root
+type stateReader interface {
+	StateAt(root types.Hash) (state.Snapshot, error)
+}
+
+// SetHead rolls the canonical chain back to the block with the number, whose state has to be
+// available. The canonical mappings, the transaction lookups and the receipts of the blocks above
+// it are deleted, while their headers and bodies stay in the DB. A reorg event with the removed
+// blocks as the old chain is dispatched, so that the txpool takes their transactions back.
+// The receipts of the removed blocks are read before they are deleted and delivered with the event,
+// so that the filters report their logs as removed
+func (b *Blockchain) SetHead(number uint64) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	head := b.Header()
+	if number > head.Number {
+		return fmt.Errorf("%w: %d > %d", ErrSetHeadAboveHead, number, head.Number)
+	}
+
+	if number == head.Number {
+		return nil
+	}
+
+	target, ok := b.GetHeaderByNumber(number)
+	if !ok {
+		return fmt.Errorf("header of block %d not found", number)
+	}
+
+	td, ok := b.readTotalDifficulty(target.Hash)
+	if !ok {
+		return fmt.Errorf("total difficulty of block %d not found", number)
+	}
+
+	if reader, ok := b.executor.(stateReader); ok {
+		if _, err := reader.StateAt(target.StateRoot); err != nil {
+			return fmt.Errorf("%w: block %d: %v", ErrSetHeadNoState, number, err)
+		}
+	}
+
+	oldReceipts, err := b.readCanonicalReceipts(number+1, head.Number)
+	if err != nil {
+		return err
+	}
+
+	removed, err := RewindStorage(b.db, number)
+	if err != nil {
+		return err
+	}
+
+	for _, header := range removed {
+		b.receiptsCache.Remove(header.Hash)
+	}
+
+	b.setCurrentHeader(target, td)
+
+	evnt := &Event{
+		Type:        EventReorg,
+		Source:      SetHeadSource,
+		OldReceipts: oldReceipts,
+	}
+
+	for _, header := range removed {
+		evnt.AddOldHeader(header)
+	}
+
+	evnt.SetDifficulty(td)
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("head set", "number", number, "hash", target.Hash, "removed", len(removed))
+
+	return nil
+}
+
+// readCanonicalReceipts returns the receipts of the canonical blocks in the range by the block hashes.
+// The blocks written without execution have no receipts
+func (b *Blockchain) readCanonicalReceipts(from, to uint64) (map[types.Hash][]*types.Receipt, error) {
+	receipts := make(map[types.Hash][]*types.Receipt, to-from+1)
+
+	for n := from; n <= to; n++ {
+		hash, ok := b.db.ReadCanonicalHash(n)
+		if !ok {
+			return nil, fmt.Errorf("canonical hash of block %d not found", n)
+		}
+
+		blockReceipts, err := b.db.ReadReceipts(hash)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("receipts of block %d: %w", n, err)
+		}
+
+		receipts[hash] = blockReceipts
+	}
+
+	return receipts, nil
+}
+
+// RewindStorage deletes the canonical mappings, the transaction lookups and the receipts of the
+// canonical blocks above the number, and writes the block with the number as the head.
+// It returns the headers of the removed blocks, in the ascending order of the numbers
+func RewindStorage(db storage.Storage, number uint64) ([]*types.Header, error) {
+	headNumber, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, errors.New("head number not found")
+	}
+
+	if number > headNumber {
+		return nil, fmt.Errorf("%w: %d > %d", ErrSetHeadAboveHead, number, headNumber)
+	}
+
+	// the frozen blocks can't be removed from the ancient store
+	if ancients := db.Ancients(); number+1 < ancients {
+		return nil, fmt.Errorf("%w: %d < %d", ErrSetHeadFrozen, number, ancients-1)
+	}
+
+	target, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	removed := make([]*types.Header, headNumber-number)
+
+	// the head is moved first, so that an interrupted rewind leaves a consistent chain
+	if err := db.WriteHeadHash(target); err != nil {
+		return nil, err
+	}
+
+	if err := db.WriteHeadNumber(number); err != nil {
+		return nil, err
+	}
+
+	for n := headNumber; n > number; n-- {
+		hash, ok := db.ReadCanonicalHash(n)
+		if !ok {
+			return nil, fmt.Errorf("canonical hash of block %d not found", n)
+		}
+
+		header, err := db.ReadHeader(hash)
+		if err != nil {
+			return nil, fmt.Errorf("header of block %d: %w", n, err)
+		}
+
+		// the decoded header has the hash of the default hashing, not the one of the consensus
+		header.Hash = hash
+
+		// the blocks written without a body have no transactions
+		body, err := db.ReadBody(hash)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("body of block %d: %w", n, err)
+		}
+
+		if body != nil {
+			for _, tx := range body.Transactions {
+				if err := db.DeleteTxLookup(tx.Hash); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := db.DeleteReceipts(hash); err != nil {
+			return nil, err
+		}
+
+		if err := db.DeleteCanonicalHash(n); err != nil {
+			return nil, err
+		}
+
+		removed[n-number-1] = header
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,187 @@
+package ancient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	ErrOutOfBounds = errors.New("block not in the ancient store")
+	ErrUnknownKind = errors.New("unknown ancient kind")
+)
+
+// Freezer is a flat-file ancient store, which keeps every kind of the block data
+// in its own append-only table
+type Freezer struct {
+	logger hclog.Logger
+
+	lock   sync.RWMutex
+	tables map[string]*table
+	items  uint64 // number of the blocks in the store
+}
+
+// IsCompressed returns true if the ancient store in the directory was created with compression
+func IsCompressed(path string) bool {
+	index, _ := tableFileNames(storage.AncientHashes, true)
+
+	info, err := os.Stat(filepath.Join(path, index))
+
+	return err == nil && info.Size() > 0
+}
+
+// NewFreezer opens the ancient store in the directory. The compression
+// setting has to be the one the store was created with
+func NewFreezer(path string, compress bool, logger hclog.Logger) (*Freezer, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{
+		logger: logger.Named("ancient"),
+		tables: make(map[string]*table, len(storage.AncientKinds)),
+	}
+
+	for _, kind := range storage.AncientKinds {
+		t, err := openTable(path, kind, compress)
+		if err != nil {
+			_ = f.Close()
+
+			return nil, err
+		}
+
+		f.tables[kind] = t
+	}
+
+	if err := f.repair(); err != nil {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	f.logger.Info("Opened ancient store", "path", path, "blocks", f.items, "compress", compress)
+
+	return f, nil
+}
+
+// repair truncates the tables to the blocks which were appended to all of them
+func (f *Freezer) repair() error {
+	var items uint64
+
+	for i, kind := range storage.AncientKinds {
+		if t := f.tables[kind]; i == 0 || t.items < items {
+			items = t.items
+		}
+	}
+
+	for _, t := range f.tables {
+		if t.items == items {
+			continue
+		}
+
+		f.logger.Warn("Truncating ancient table", "table", t.name, "from", t.items, "to", items)
+
+		if err := t.truncate(items); err != nil {
+			return err
+		}
+	}
+
+	f.items = items
+
+	return nil
+}
+
+// Ancients returns the number of the blocks in the store
+func (f *Freezer) Ancients() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.items
+}
+
+// AppendAncient appends the block to the end of the store
+func (f *Freezer) AppendAncient(number uint64, hash types.Hash, header, body, receipts []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if number != f.items {
+		return fmt.Errorf("appending block %d to the ancient store of %d blocks", number, f.items)
+	}
+
+	items := map[string][]byte{
+		storage.AncientHashes:   hash.Bytes(),
+		storage.AncientHeaders:  header,
+		storage.AncientBodies:   body,
+		storage.AncientReceipts: receipts,
+	}
+
+	for _, kind := range storage.AncientKinds {
+		if err := f.tables[kind].append(items[kind]); err != nil {
+			// keep the tables aligned by dropping the partially appended block
+			for _, t := range f.tables {
+				if truncateErr := t.truncate(f.items); truncateErr != nil {
+					f.logger.Error("failed to truncate ancient table", "table", t.name, "err", truncateErr)
+				}
+			}
+
+			return err
+		}
+	}
+
+	f.items++
+
+	return nil
+}
+
+// ReadAncient returns the entry of the given kind of the block
+func (f *Freezer) ReadAncient(kind string, number uint64) ([]byte, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	t, ok := f.tables[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+
+	if number >= f.items {
+		return nil, ErrOutOfBounds
+	}
+
+	return t.retrieve(number)
+}
+
+// Sync flushes the appended blocks to the disk
+func (f *Freezer) Sync() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, kind := range storage.AncientKinds {
+		if err := f.tables[kind].sync(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the table files
+func (f *Freezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var closeErr error
+
+	for _, t := range f.tables {
+		if err := t.close(); err != nil {
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
@@ -0,0 +1,243 @@
+package ancient
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezer_AppendAndRead(t *testing.T) {
+	t.Parallel()
+
+	for _, compress := range []bool{false, true} {
+		compress := compress
+
+		t.Run(map[bool]string{false: "raw", true: "compressed"}[compress], func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			f, err := NewFreezer(dir, compress, hclog.NewNullLogger())
+			assert.NoError(t, err)
+
+			for i := uint64(0); i < 3; i++ {
+				// the first block has no body and receipts, like the genesis
+				var body, receipts []byte
+				if i > 0 {
+					body, receipts = []byte{byte(i), 1}, []byte{byte(i), 2, 2}
+				}
+
+				assert.NoError(t, f.AppendAncient(i, types.BytesToHash([]byte{byte(i)}), []byte{byte(i)}, body, receipts))
+			}
+
+			// the blocks are appended in order
+			assert.Error(t, f.AppendAncient(4, types.Hash{}, nil, nil, nil))
+			assert.NoError(t, f.Sync())
+			assert.NoError(t, f.Close())
+
+			f, err = NewFreezer(dir, compress, hclog.NewNullLogger())
+			assert.NoError(t, err)
+
+			defer f.Close()
+
+			assert.Equal(t, uint64(3), f.Ancients())
+
+			hash, err := f.ReadAncient(storage.AncientHashes, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, types.BytesToHash([]byte{2}).Bytes(), hash)
+
+			receipts, err := f.ReadAncient(storage.AncientReceipts, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte{1, 2, 2}, receipts)
+
+			body, err := f.ReadAncient(storage.AncientBodies, 0)
+			assert.NoError(t, err)
+			assert.Empty(t, body)
+
+			_, err = f.ReadAncient(storage.AncientHeaders, 3)
+			assert.ErrorIs(t, err, ErrOutOfBounds)
+
+			_, err = f.ReadAncient("unknown", 0)
+			assert.ErrorIs(t, err, ErrUnknownKind)
+		})
+	}
+}
+
+func TestFreezer_Repair(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := NewFreezer(dir, false, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	for i := uint64(0); i < 2; i++ {
+		assert.NoError(t, f.AppendAncient(i, types.Hash{}, []byte{1, 2, 3}, []byte{4}, []byte{5}))
+	}
+
+	assert.NoError(t, f.Close())
+
+	// the data of the last header didn't reach the disk
+	assert.NoError(t, os.Truncate(filepath.Join(dir, storage.AncientHeaders+".rdat"), 4))
+
+	f, err = NewFreezer(dir, false, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	defer f.Close()
+
+	// the block is dropped from all the tables
+	assert.Equal(t, uint64(1), f.Ancients())
+
+	for _, kind := range storage.AncientKinds {
+		assert.Equal(t, uint64(1), f.tables[kind].items)
+	}
+
+	assert.NoError(t, f.AppendAncient(1, types.Hash{}, []byte{6}, nil, nil))
+
+	header, err := f.ReadAncient(storage.AncientHeaders, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{6}, header)
+}
+
+func TestFreezer_CompressionMismatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f, err := NewFreezer(dir, true, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.AppendAncient(0, types.Hash{}, []byte{1}, nil, nil))
+	assert.NoError(t, f.Close())
+
+	_, err = NewFreezer(dir, false, hclog.NewNullLogger())
+	assert.ErrorIs(t, err, errCompressionMismatch)
+}
+
+// writeTestBlocks writes the canonical blocks with a transaction and a receipt each
+func writeTestBlocks(t *testing.T, s storage.Storage, n int) []*types.Header {
+	t.Helper()
+
+	headers := make([]*types.Header, n)
+	to := types.StringToAddress("1")
+
+	for i := range headers {
+		header := &types.Header{Number: uint64(i), ExtraData: []byte{}}
+		header.ComputeHash()
+
+		tx := &types.Transaction{Nonce: uint64(i), To: &to, Value: big.NewInt(1), GasPrice: big.NewInt(1), V: big.NewInt(1)}
+		tx.ComputeHash()
+
+		status := types.ReceiptSuccess
+
+		assert.NoError(t, s.WriteCanonicalHeader(header, big.NewInt(int64(i))))
+		assert.NoError(t, s.WriteBody(header.Hash, &types.Body{Transactions: []*types.Transaction{tx}}))
+		assert.NoError(t, s.WriteReceipts(header.Hash, []*types.Receipt{{Status: &status, TxHash: tx.Hash}}))
+
+		headers[i] = header
+	}
+
+	return headers
+}
+
+func TestStorage_Freeze(t *testing.T) {
+	t.Parallel()
+
+	dbPath, ancientPath := t.TempDir(), t.TempDir()
+
+	openStorage := func(withAncients bool) storage.Storage {
+		config := map[string]interface{}{"path": dbPath}
+
+		if withAncients {
+			f, err := NewFreezer(ancientPath, false, hclog.NewNullLogger())
+			assert.NoError(t, err)
+
+			config[storage.AncientsConfigKey] = f
+		}
+
+		s, err := leveldb.Factory(config, hclog.NewNullLogger())
+		assert.NoError(t, err)
+
+		return s
+	}
+
+	s := openStorage(true)
+	headers := writeTestBlocks(t, s, 4)
+
+	assert.NoError(t, s.Freeze(2))
+	assert.Equal(t, uint64(3), s.Ancients())
+
+	// the frozen blocks are served from the ancient store
+	for _, header := range headers {
+		h, err := s.ReadHeader(header.Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, header.Hash, h.Hash)
+
+		body, err := s.ReadBody(header.Hash)
+		assert.NoError(t, err)
+		assert.Len(t, body.Transactions, 1)
+
+		receipts, err := s.ReadReceipts(header.Hash)
+		assert.NoError(t, err)
+		assert.Len(t, receipts, 1)
+	}
+
+	// the frozen blocks aren't frozen again
+	assert.NoError(t, s.Freeze(1))
+	assert.Equal(t, uint64(3), s.Ancients())
+	assert.NoError(t, s.Close())
+
+	// the frozen blocks are removed from the kv database
+	s = openStorage(false)
+
+	_, err := s.ReadHeader(headers[2].Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	_, err = s.ReadHeader(headers[3].Hash)
+	assert.NoError(t, err)
+
+	_, err = s.ReadBody(headers[0].Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assert.ErrorIs(t, s.Freeze(3), storage.ErrNoAncients)
+	assert.NoError(t, s.Close())
+}
+
+func TestStorage_FreezeRecovery(t *testing.T) {
+	t.Parallel()
+
+	dbPath, ancientPath := t.TempDir(), t.TempDir()
+
+	s, err := leveldb.NewLevelDBStorage(dbPath, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	headers := writeTestBlocks(t, s, 2)
+
+	// the block reached the ancient store, but the node crashed before removing it from the kv database
+	f, err := NewFreezer(ancientPath, false, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	header := headers[0].MarshalRLPTo(nil)
+	assert.NoError(t, f.AppendAncient(0, headers[0].Hash, header, nil, nil))
+	assert.NoError(t, f.Sync())
+
+	s, err = storage.AttachAncients(s, map[string]interface{}{storage.AncientsConfigKey: f})
+	assert.NoError(t, err)
+
+	h, err := s.ReadHeader(headers[0].Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, headers[0].Hash, h.Hash)
+
+	// the ancient store is authoritative once the freezing is completed
+	_, err = s.ReadBody(headers[0].Hash)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,221 @@
+package ancient
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/snappy"
+)
+
+// indexEntrySize is the size of an entry of the index file, the end offset of the item in the data file
+const indexEntrySize = 8
+
+var (
+	errCompressionMismatch = errors.New("ancient table was created with a different compression setting")
+)
+
+// table is an append-only flat file of the items of one kind, addressed by their position.
+// The index file holds the end offset of every item in the data file
+type table struct {
+	name     string
+	compress bool
+
+	index *os.File
+	data  *os.File
+
+	items    uint64 // number of the items in the table
+	dataSize uint64 // end offset of the last item in the data file
+}
+
+// tableFileNames returns the names of the index and the data file of the table
+func tableFileNames(name string, compress bool) (string, string) {
+	if compress {
+		return name + ".cidx", name + ".cdat"
+	}
+
+	return name + ".ridx", name + ".rdat"
+}
+
+// openTable opens the table in the directory, and drops the items
+// which were not completely written before a crash
+func openTable(dir, name string, compress bool) (*table, error) {
+	// the compression can't be changed for an existing table
+	otherIndex, _ := tableFileNames(name, !compress)
+	if info, err := os.Stat(filepath.Join(dir, otherIndex)); err == nil && info.Size() > 0 {
+		return nil, fmt.Errorf("%w: %s", errCompressionMismatch, name)
+	}
+
+	indexName, dataName := tableFileNames(name, compress)
+
+	index, err := os.OpenFile(filepath.Join(dir, indexName), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.OpenFile(filepath.Join(dir, dataName), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		_ = index.Close()
+
+		return nil, err
+	}
+
+	t := &table{
+		name:     name,
+		compress: compress,
+		index:    index,
+		data:     data,
+	}
+
+	if err := t.repair(); err != nil {
+		_ = t.close()
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// repair truncates the table to the last item which is fully present in both files
+func (t *table) repair() error {
+	indexInfo, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataInfo, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	items := uint64(indexInfo.Size()) / indexEntrySize
+
+	for items > 0 {
+		end, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		if end <= uint64(dataInfo.Size()) {
+			break
+		}
+
+		items--
+	}
+
+	return t.truncate(items)
+}
+
+// truncate drops the items from the given position onwards
+func (t *table) truncate(items uint64) error {
+	var dataSize uint64
+
+	if items > 0 {
+		end, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		dataSize = end
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(dataSize)); err != nil {
+		return err
+	}
+
+	t.items = items
+	t.dataSize = dataSize
+
+	return nil
+}
+
+// readOffset reads the end offset of the item from the index file
+func (t *table) readOffset(item uint64) (uint64, error) {
+	buf := make([]byte, indexEntrySize)
+
+	if _, err := t.index.ReadAt(buf, int64(item*indexEntrySize)); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// append writes the item at the end of the table
+func (t *table) append(item []byte) error {
+	if t.compress {
+		item = snappy.Encode(nil, item)
+	}
+
+	if _, err := t.data.WriteAt(item, int64(t.dataSize)); err != nil {
+		return err
+	}
+
+	end := t.dataSize + uint64(len(item))
+
+	buf := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint64(buf, end)
+
+	if _, err := t.index.WriteAt(buf, int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+
+	t.items++
+	t.dataSize = end
+
+	return nil
+}
+
+// retrieve reads the item at the given position
+func (t *table) retrieve(item uint64) ([]byte, error) {
+	var start uint64
+
+	if item > 0 {
+		offset, err := t.readOffset(item - 1)
+		if err != nil {
+			return nil, err
+		}
+
+		start = offset
+	}
+
+	end, err := t.readOffset(item)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, end-start)
+	if _, err := t.data.ReadAt(buf, int64(start)); err != nil {
+		return nil, err
+	}
+
+	if t.compress {
+		return snappy.Decode(nil, buf)
+	}
+
+	return buf, nil
+}
+
+// sync flushes the table files to the disk, the data first so the index never points past it
+func (t *table) sync() error {
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+
+	return t.index.Sync()
+}
+
+func (t *table) close() error {
+	dataErr := t.data.Close()
+
+	if err := t.index.Close(); err != nil {
+		return err
+	}
+
+	return dataErr
+}
@@ -0,0 +1,158 @@
+package dbtool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/ancient"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/pebble"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Database engines of the data directory
+const (
+	EngineLevelDB = "leveldb"
+	EnginePebble  = "pebble"
+)
+
+const (
+	blockchainDir = "blockchain"
+	trieDir       = "trie"
+)
+
+var (
+	ErrUnknownEngine = errors.New("unknown database engine")
+	ErrDBNotFound    = errors.New("database not found")
+)
+
+// Databases are the blockchain and the trie databases of a stopped node
+type Databases struct {
+	Blockchain *storage.KeyValueStorage
+	Trie       itrie.Storage
+}
+
+// Open opens the databases in the data directory of a stopped node. The ancient store
+// in ancientDir is attached to the blockchain database if it exists
+func Open(dataDir, engine, ancientDir string, logger hclog.Logger) (*Databases, error) {
+	if engine != EngineLevelDB && engine != EnginePebble {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+
+	// the storages create the missing databases, which would hide a wrong data directory
+	for _, name := range []string{blockchainDir, trieDir} {
+		if _, err := os.Stat(filepath.Join(dataDir, name)); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrDBNotFound, filepath.Join(dataDir, name))
+		}
+	}
+
+	if err := CheckEngine(dataDir, engine); err != nil {
+		return nil, err
+	}
+
+	chainStorage, err := openBlockchain(filepath.Join(dataDir, blockchainDir), engine, ancientDir, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	trieStorage, err := openTrie(filepath.Join(dataDir, trieDir), engine, logger)
+	if err != nil {
+		_ = chainStorage.Close()
+
+		return nil, err
+	}
+
+	return &Databases{
+		Blockchain: chainStorage,
+		Trie:       trieStorage,
+	}, nil
+}
+
+// openBlockchain opens the blockchain database with the ancient store, if any
+func openBlockchain(path, engine, ancientDir string, logger hclog.Logger) (*storage.KeyValueStorage, error) {
+	var (
+		s   storage.Storage
+		err error
+	)
+
+	if engine == EnginePebble {
+		s, err = pebble.NewPebbleStorage(path, logger)
+	} else {
+		s, err = leveldb.NewLevelDBStorage(path, logger)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s, make sure the node is stopped: %w", path, err)
+	}
+
+	kv, ok := s.(*storage.KeyValueStorage)
+	if !ok {
+		_ = s.Close()
+
+		return nil, errors.New("blockchain storage isn't a kv storage")
+	}
+
+	// the tools can't read the databases written by a newer node
+	if err := kv.CheckSchema(); err != nil {
+		_ = kv.Close()
+
+		return nil, err
+	}
+
+	if _, err := os.Stat(ancientDir); err != nil {
+		return kv, nil
+	}
+
+	freezer, err := ancient.NewFreezer(ancientDir, ancient.IsCompressed(ancientDir), logger)
+	if err != nil {
+		_ = kv.Close()
+
+		return nil, err
+	}
+
+	if _, err := storage.AttachAncients(kv, map[string]interface{}{
+		storage.AncientsConfigKey: freezer,
+	}); err != nil {
+		_ = freezer.Close()
+		_ = kv.Close()
+
+		return nil, err
+	}
+
+	return kv, nil
+}
+
+// openTrie opens the trie database
+func openTrie(path, engine string, logger hclog.Logger) (itrie.Storage, error) {
+	var (
+		s   itrie.Storage
+		err error
+	)
+
+	if engine == EnginePebble {
+		s, err = itrie.NewPebbleStorage(path, logger)
+	} else {
+		s, err = itrie.NewLevelDBStorage(path, logger)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s, make sure the node is stopped: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Close closes the databases
+func (d *Databases) Close() error {
+	trieErr := d.Trie.Close()
+
+	if err := d.Blockchain.Close(); err != nil {
+		return err
+	}
+
+	return trieErr
+}
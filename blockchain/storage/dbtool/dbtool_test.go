@@ -0,0 +1,324 @@
+package dbtool
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/pebble"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// testChain holds the canonical headers and the transactions written by newTestDatabases
+type testChain struct {
+	headers []*types.Header
+	txs     []*types.Transaction
+}
+
+// newTestDatabases writes a canonical chain of the given length into the leveldb databases
+// of a data directory, with a transaction in every block but the genesis and the state of the last two blocks
+func newTestDatabases(t *testing.T, length uint64) (string, *testChain) {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	logger := hclog.NewNullLogger()
+
+	chainStorage, err := leveldb.NewLevelDBStorage(filepath.Join(dataDir, blockchainDir), logger)
+	assert.NoError(t, err)
+
+	trieStorage, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, trieDir), logger)
+	assert.NoError(t, err)
+
+	chain := &testChain{}
+	td := big.NewInt(0)
+
+	for n := uint64(0); n < length; n++ {
+		header := &types.Header{
+			Number:     n,
+			Difficulty: n + 1,
+			StateRoot:  types.StringToHash(string(rune('a' + n))),
+		}
+
+		if n > 0 {
+			header.ParentHash = chain.headers[n-1].Hash
+
+			tx := (&types.Transaction{Nonce: n, GasPrice: big.NewInt(1), Value: big.NewInt(0)}).ComputeHash()
+			assert.NoError(t, chainStorage.WriteBody(header.ComputeHash().Hash, &types.Body{
+				Transactions: []*types.Transaction{tx},
+			}))
+
+			chain.txs = append(chain.txs, tx)
+		}
+
+		header.ComputeHash()
+		td.Add(td, new(big.Int).SetUint64(header.Difficulty))
+
+		assert.NoError(t, chainStorage.WriteCanonicalHeader(header, new(big.Int).Set(td)))
+
+		chain.headers = append(chain.headers, header)
+	}
+
+	// the state of the head and the block before it
+	for _, header := range chain.headers[length-2:] {
+		trieStorage.Put(header.StateRoot.Bytes(), []byte{0x1})
+	}
+
+	assert.NoError(t, chainStorage.Close())
+	assert.NoError(t, trieStorage.Close())
+
+	return dataDir, chain
+}
+
+func openTestDatabases(t *testing.T, dataDir string) *Databases {
+	t.Helper()
+
+	dbs, err := Open(dataDir, EngineLevelDB, filepath.Join(dataDir, "ancient"), hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, dbs.Close())
+	})
+
+	return dbs
+}
+
+func TestOpen_Missing(t *testing.T) {
+	t.Parallel()
+
+	_, err := Open(t.TempDir(), EngineLevelDB, "", hclog.NewNullLogger())
+	assert.True(t, errors.Is(err, ErrDBNotFound))
+
+	_, err = Open(t.TempDir(), "rocksdb", "", hclog.NewNullLogger())
+	assert.True(t, errors.Is(err, ErrUnknownEngine))
+}
+
+func TestOpen_EngineMismatch(t *testing.T) {
+	t.Parallel()
+
+	dataDir, _ := newTestDatabases(t, 2)
+
+	// the databases written before the engine was recorded are detected by their files
+	engine, err := ReadEngine(dataDir)
+	assert.NoError(t, err)
+	assert.Equal(t, EngineLevelDB, engine)
+
+	_, err = Open(dataDir, EnginePebble, "", hclog.NewNullLogger())
+	assert.True(t, errors.Is(err, ErrEngineMismatch))
+
+	assert.NoError(t, WriteEngine(dataDir, EnginePebble))
+
+	_, err = Open(dataDir, EngineLevelDB, "", hclog.NewNullLogger())
+	assert.True(t, errors.Is(err, ErrEngineMismatch))
+}
+
+func TestReadEngine(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+
+	engine, err := ReadEngine(dataDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "", engine)
+
+	s, err := pebble.NewPebbleStorage(filepath.Join(dataDir, blockchainDir), hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.NoError(t, s.Close())
+
+	engine, err = ReadEngine(dataDir)
+	assert.NoError(t, err)
+	assert.Equal(t, EnginePebble, engine)
+	assert.NoError(t, CheckEngine(dataDir, EnginePebble))
+}
+
+func TestOpen_SchemaTooNew(t *testing.T) {
+	t.Parallel()
+
+	dataDir, _ := newTestDatabases(t, 2)
+
+	dbs, err := Open(dataDir, EngineLevelDB, filepath.Join(dataDir, "ancient"), hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.NoError(t, dbs.Blockchain.WriteSchemaVersion(storage.SchemaVersion+1))
+	assert.NoError(t, dbs.Close())
+
+	_, err = Open(dataDir, EngineLevelDB, filepath.Join(dataDir, "ancient"), hclog.NewNullLogger())
+	assert.True(t, errors.Is(err, storage.ErrSchemaTooNew))
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	dataDir, _ := newTestDatabases(t, 5)
+	dbs := openTestDatabases(t, dataDir)
+
+	stats, err := BlockchainStats(dbs.Blockchain)
+	assert.NoError(t, err)
+
+	entries := map[string]uint64{}
+	for _, s := range stats {
+		entries[s.Name] = s.Entries
+	}
+
+	assert.Equal(t, uint64(5), entries["headers"])
+	assert.Equal(t, uint64(4), entries["bodies"])
+	assert.Equal(t, uint64(5), entries["canonical hashes"])
+	assert.Equal(t, uint64(5), entries["total difficulties"])
+	assert.Equal(t, uint64(2), entries["head"])
+	assert.Equal(t, uint64(0), entries[otherKeySpace])
+
+	trieStats, err := TrieStats(dbs.Trie)
+	assert.NoError(t, err)
+	assert.Equal(t, nodesKeySpace, trieStats[len(trieStats)-1].Name)
+	assert.Equal(t, uint64(2), trieStats[len(trieStats)-1].Entries)
+}
+
+func TestVerifyChain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("consistent chain", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, chain := newTestDatabases(t, 5)
+		dbs := openTestDatabases(t, dataDir)
+
+		report, err := VerifyChain(dbs.Blockchain, dbs.Trie)
+		assert.NoError(t, err)
+		assert.NoError(t, report.Err)
+		assert.Equal(t, uint64(4), report.Head)
+		assert.Equal(t, chain.headers[4].Hash, report.HeadHash)
+		assert.Equal(t, uint64(5), report.Verified)
+		assert.True(t, report.HasState)
+	})
+
+	t.Run("broken hash link", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, _ := newTestDatabases(t, 5)
+		dbs := openTestDatabases(t, dataDir)
+
+		assert.NoError(t, dbs.Blockchain.WriteCanonicalHash(2, types.StringToHash("2")))
+
+		report, err := VerifyChain(dbs.Blockchain, dbs.Trie)
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(report.Err, ErrChainBroken))
+		assert.Equal(t, uint64(2), report.Verified)
+	})
+
+	t.Run("wrong total difficulty", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, chain := newTestDatabases(t, 5)
+		dbs := openTestDatabases(t, dataDir)
+
+		assert.NoError(t, dbs.Blockchain.WriteTotalDifficulty(chain.headers[3].Hash, big.NewInt(1)))
+
+		report, err := VerifyChain(dbs.Blockchain, dbs.Trie)
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(report.Err, ErrTotalDifficulty))
+		assert.Equal(t, uint64(3), report.Verified)
+	})
+
+	t.Run("missing head state", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, chain := newTestDatabases(t, 5)
+		dbs := openTestDatabases(t, dataDir)
+
+		header := &types.Header{
+			Number:     5,
+			ParentHash: chain.headers[4].Hash,
+			Difficulty: 6,
+			StateRoot:  types.StringToHash("f"),
+		}
+		header.ComputeHash()
+
+		assert.NoError(t, dbs.Blockchain.WriteCanonicalHeader(header, big.NewInt(21)))
+
+		report, err := VerifyChain(dbs.Blockchain, dbs.Trie)
+		assert.NoError(t, err)
+		assert.False(t, report.HasState)
+		assert.True(t, errors.Is(report.Err, ErrStateMissing))
+		assert.Equal(t, uint64(6), report.Verified)
+	})
+}
+
+func TestRewind(t *testing.T) {
+	t.Parallel()
+
+	dataDir, chain := newTestDatabases(t, 5)
+	dbs := openTestDatabases(t, dataDir)
+
+	_, err := RebuildTxLookup(dbs.Blockchain)
+	assert.NoError(t, err)
+
+	_, _, err = Rewind(dbs.Blockchain, dbs.Trie, 5)
+	assert.True(t, errors.Is(err, ErrRewindAboveHead))
+
+	// the state of block 2 isn't available
+	_, _, err = Rewind(dbs.Blockchain, dbs.Trie, 2)
+	assert.True(t, errors.Is(err, ErrStateMissing))
+
+	head, removed, err := Rewind(dbs.Blockchain, dbs.Trie, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, chain.headers[3].Hash, head.Hash)
+	assert.Equal(t, uint64(1), removed)
+
+	number, _ := dbs.Blockchain.ReadHeadNumber()
+	hash, _ := dbs.Blockchain.ReadHeadHash()
+
+	assert.Equal(t, uint64(3), number)
+	assert.Equal(t, chain.headers[3].Hash, hash)
+
+	// the block above the head isn't canonical anymore
+	_, ok := dbs.Blockchain.ReadCanonicalHash(4)
+	assert.False(t, ok)
+
+	_, ok = dbs.Blockchain.ReadTxLookup(chain.txs[3].Hash)
+	assert.False(t, ok)
+
+	_, ok = dbs.Blockchain.ReadTxLookup(chain.txs[2].Hash)
+	assert.True(t, ok)
+
+	report, err := VerifyChain(dbs.Blockchain, dbs.Trie)
+	assert.NoError(t, err)
+	assert.NoError(t, report.Err)
+	assert.Equal(t, uint64(4), report.Verified)
+}
+
+func TestRebuildTxLookup(t *testing.T) {
+	t.Parallel()
+
+	dataDir, chain := newTestDatabases(t, 5)
+	dbs := openTestDatabases(t, dataDir)
+
+	// lookup of a transaction which isn't in the canonical chain
+	stale := types.StringToHash("stale")
+	assert.NoError(t, dbs.Blockchain.WriteTxLookup(stale, types.StringToHash("1")))
+
+	count, err := RebuildTxLookup(dbs.Blockchain)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), count)
+
+	for i, tx := range chain.txs {
+		blockHash, ok := dbs.Blockchain.ReadTxLookup(tx.Hash)
+		assert.True(t, ok)
+		assert.Equal(t, chain.headers[i+1].Hash, blockHash)
+	}
+
+	_, ok := dbs.Blockchain.ReadTxLookup(stale)
+	assert.False(t, ok)
+
+	var entries int
+
+	assert.NoError(t, dbs.Blockchain.Iterate(storage.TX_LOOKUP_PREFIX, func(_, _ []byte) error {
+		entries++
+
+		return nil
+	}))
+	assert.Equal(t, 4, entries)
+}
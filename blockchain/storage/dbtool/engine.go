@@ -0,0 +1,74 @@
+package dbtool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// engineFile is the file in the data directory which records the database engine
+const engineFile = "db_engine"
+
+var ErrEngineMismatch = errors.New("database engine mismatch")
+
+// ReadEngine returns the database engine the data directory was written with,
+// or an empty string if the data directory has no databases yet
+func ReadEngine(dataDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, engineFile))
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	// the data directories written before the engine was recorded
+	return detectEngine(filepath.Join(dataDir, blockchainDir))
+}
+
+// detectEngine returns the engine of the database by its files. Only pebble writes the OPTIONS
+// files, both engines write the CURRENT file once the database is created
+func detectEngine(path string) (string, error) {
+	options, err := filepath.Glob(filepath.Join(path, "OPTIONS-*"))
+	if err != nil {
+		return "", err
+	}
+
+	if len(options) > 0 {
+		return EnginePebble, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err == nil {
+		return EngineLevelDB, nil
+	}
+
+	return "", nil
+}
+
+// CheckEngine returns an error if the data directory was written with another database engine
+func CheckEngine(dataDir, engine string) error {
+	current, err := ReadEngine(dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to read the database engine: %w", err)
+	}
+
+	if current != "" && current != engine {
+		return fmt.Errorf(
+			"%w: the data directory %s was written with %s, not %s",
+			ErrEngineMismatch,
+			dataDir,
+			current,
+			engine,
+		)
+	}
+
+	return nil
+}
+
+// WriteEngine records the database engine of the data directory
+func WriteEngine(dataDir, engine string) error {
+	return os.WriteFile(filepath.Join(dataDir, engineFile), []byte(engine+"\n"), 0600)
+}
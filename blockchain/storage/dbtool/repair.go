@@ -0,0 +1,92 @@
+package dbtool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+var (
+	ErrRewindAboveHead = errors.New("rewind target is above the head")
+)
+
+// Rewind sets the head of the chain to the canonical block with the number, whose state
+// has to be in the trie. The canonical mappings, the transaction lookups and the receipts
+// of the blocks above it are deleted. It returns the new head and the number of the removed blocks
+func Rewind(db storage.Storage, trie itrie.Storage, number uint64) (*types.Header, uint64, error) {
+	head, err := readHead(db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if number > head.Number {
+		return nil, 0, fmt.Errorf("%w: %d > %d", ErrRewindAboveHead, number, head.Number)
+	}
+
+	header, err := readCanonicalHeader(db, number)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, ok := db.ReadTotalDifficulty(header.Hash); !ok {
+		return nil, 0, fmt.Errorf("%w: block %d", ErrTotalDifficulty, number)
+	}
+
+	if !hasState(trie, header.StateRoot) {
+		return nil, 0, fmt.Errorf("%w: block %d", ErrStateMissing, number)
+	}
+
+	removed, err := blockchain.RewindStorage(db, number)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return header, uint64(len(removed)), nil
+}
+
+// RebuildTxLookup drops the transaction lookups and indexes the transactions of the canonical
+// chain up to the head again. It returns the number of the indexed transactions
+func RebuildTxLookup(db *storage.KeyValueStorage) (uint64, error) {
+	head, err := readHead(db)
+	if err != nil {
+		return 0, err
+	}
+
+	// the iteration works on a snapshot of the database, so the entries can be removed meanwhile
+	if err := db.Iterate(storage.TX_LOOKUP_PREFIX, func(key, _ []byte) error {
+		return db.DeleteTxLookup(types.BytesToHash(key[len(storage.TX_LOOKUP_PREFIX):]))
+	}); err != nil {
+		return 0, err
+	}
+
+	var count uint64
+
+	for n := uint64(0); n <= head.Number; n++ {
+		hash, ok := db.ReadCanonicalHash(n)
+		if !ok {
+			return count, fmt.Errorf("%w: canonical hash of block %d not found", ErrChainBroken, n)
+		}
+
+		// the blocks written without a body, like the genesis, have no transactions
+		body, err := db.ReadBody(hash)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return count, fmt.Errorf("body of block %d: %w", n, err)
+		}
+
+		for _, tx := range body.Transactions {
+			if err := db.WriteTxLookup(tx.Hash, hash); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,94 @@
+package dbtool
+
+import (
+	"bytes"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+)
+
+// KeySpace is the kind of the entries with a key prefix
+type KeySpace struct {
+	Name   string
+	Prefix []byte
+}
+
+// BlockchainKeySpaces are the key spaces of the blockchain database
+var BlockchainKeySpaces = []KeySpace{
+	{"headers", storage.HEADER},
+	{"bodies", storage.BODY},
+	{"receipts", storage.RECEIPTS},
+	{"total difficulties", storage.DIFFICULTY},
+	{"canonical hashes", storage.CANONICAL},
+	{"tx lookups", storage.TX_LOOKUP_PREFIX},
+	{"snapshots", storage.SNAPSHOTS},
+	{"ancient index", storage.ANCIENT},
+	{"head", storage.HEAD},
+	{"forks", storage.FORK},
+	{"schema version", storage.SCHEMA},
+	{"migration checkpoints", storage.MIGRATION},
+}
+
+// TrieKeySpaces are the key spaces of the trie database, besides the trie nodes
+var TrieKeySpaces = []KeySpace{
+	{"code", itrie.EntryPrefixes["code"]},
+	{"preimages", itrie.EntryPrefixes["preimage"]},
+}
+
+const (
+	otherKeySpace = "other"
+	nodesKeySpace = "trie nodes"
+)
+
+// KeySpaceStats are the statistics of the entries of a key space
+type KeySpaceStats struct {
+	Name    string `json:"name"`
+	Entries uint64 `json:"entries"`
+	Size    uint64 `json:"size"` // size of the keys and the values in bytes
+}
+
+// BlockchainStats returns the statistics of the key spaces of the blockchain database.
+// The entries with an unknown prefix are counted in the other key space
+func BlockchainStats(db storage.KVIterator) ([]*KeySpaceStats, error) {
+	return keySpaceStats(db, BlockchainKeySpaces, otherKeySpace)
+}
+
+// TrieStats returns the statistics of the key spaces of the trie database.
+// The entries without a prefix are the trie nodes
+func TrieStats(trie itrie.Storage) ([]*KeySpaceStats, error) {
+	db, ok := trie.(storage.KVIterator)
+	if !ok {
+		return nil, storage.ErrNoIterator
+	}
+
+	return keySpaceStats(db, TrieKeySpaces, nodesKeySpace)
+}
+
+// keySpaceStats walks over the database once, counting the entries in the key space of their prefix
+func keySpaceStats(db storage.KVIterator, spaces []KeySpace, rest string) ([]*KeySpaceStats, error) {
+	stats := make([]*KeySpaceStats, len(spaces)+1)
+	for i, space := range spaces {
+		stats[i] = &KeySpaceStats{Name: space.Name}
+	}
+
+	stats[len(spaces)] = &KeySpaceStats{Name: rest}
+
+	err := db.Iterate(nil, func(key, value []byte) error {
+		entry := stats[len(spaces)]
+
+		for i, space := range spaces {
+			if bytes.HasPrefix(key, space.Prefix) {
+				entry = stats[i]
+
+				break
+			}
+		}
+
+		entry.Entries++
+		entry.Size += uint64(len(key) + len(value))
+
+		return nil
+	})
+
+	return stats, err
+}
@@ -0,0 +1,150 @@
+package dbtool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+var (
+	ErrNoHead          = errors.New("head of the chain not found")
+	ErrChainBroken     = errors.New("canonical chain is broken")
+	ErrTotalDifficulty = errors.New("total difficulty mismatch")
+	ErrStateMissing    = errors.New("state root not found in the trie")
+)
+
+// ChainReport is the result of the verification of the canonical chain
+type ChainReport struct {
+	Head     uint64
+	HeadHash types.Hash
+
+	// Verified is the number of the consistent blocks from the genesis
+	Verified uint64
+
+	// StateRoot is the state root of the head, and HasState whether it's in the trie
+	StateRoot types.Hash
+	HasState  bool
+
+	// Err is the first inconsistency found, nil if the chain is consistent
+	Err error
+}
+
+// VerifyChain walks over the canonical chain from the genesis to the head, checking that
+// every block links to its parent and has the right total difficulty, and that the state
+// of the head is in the trie. The block hashes aren't recomputed, as they depend on the consensus
+func VerifyChain(db storage.Storage, trie itrie.Storage) (*ChainReport, error) {
+	head, err := readHead(db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ChainReport{
+		Head:      head.Number,
+		HeadHash:  head.Hash,
+		StateRoot: head.StateRoot,
+		HasState:  hasState(trie, head.StateRoot),
+	}
+
+	if hash, ok := db.ReadCanonicalHash(head.Number); !ok || hash != head.Hash {
+		report.Err = fmt.Errorf("%w: head %d isn't canonical", ErrChainBroken, head.Number)
+
+		return report, nil
+	}
+
+	var (
+		parentHash types.Hash
+		parentTD   = big.NewInt(0)
+	)
+
+	for n := uint64(0); n <= head.Number; n++ {
+		header, err := readCanonicalHeader(db, n)
+		if err != nil {
+			report.Err = err
+
+			break
+		}
+
+		if n > 0 && header.ParentHash != parentHash {
+			report.Err = fmt.Errorf("%w: block %d doesn't link to its parent", ErrChainBroken, n)
+
+			break
+		}
+
+		expectedTD := new(big.Int).Add(parentTD, new(big.Int).SetUint64(header.Difficulty))
+
+		td, ok := db.ReadTotalDifficulty(header.Hash)
+		if !ok || td.Cmp(expectedTD) != 0 {
+			report.Err = fmt.Errorf("%w: block %d", ErrTotalDifficulty, n)
+
+			break
+		}
+
+		parentHash, parentTD = header.Hash, td
+		report.Verified++
+	}
+
+	if report.Err == nil && !report.HasState {
+		report.Err = fmt.Errorf("%w: head %d", ErrStateMissing, head.Number)
+	}
+
+	return report, nil
+}
+
+// readHead returns the header of the head of the chain
+func readHead(db storage.Storage) (*types.Header, error) {
+	hash, ok := db.ReadHeadHash()
+	if !ok {
+		return nil, ErrNoHead
+	}
+
+	number, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, ErrNoHead
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header %s: %v", ErrNoHead, hash, err)
+	}
+
+	header.Number, header.Hash = number, hash
+
+	return header, nil
+}
+
+// readCanonicalHeader returns the header of the canonical block with the number
+func readCanonicalHeader(db storage.Storage, number uint64) (*types.Header, error) {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("%w: canonical hash of block %d not found", ErrChainBroken, number)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header of block %d: %v", ErrChainBroken, number, err)
+	}
+
+	if header.Number != number {
+		return nil, fmt.Errorf("%w: header of block %d has number %d", ErrChainBroken, number, header.Number)
+	}
+
+	// the decoded header has the hash of the default hashing, not the one of the consensus
+	header.Hash = hash
+
+	return header, nil
+}
+
+// hasState returns true if the trie has the root node of the state
+func hasState(trie itrie.Storage, root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok := trie.Get(root.Bytes())
+
+	return ok
+}
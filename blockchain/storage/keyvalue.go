@@ -3,6 +3,7 @@ package storage
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -39,6 +40,9 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// ANCIENT is the prefix for the numbers of the blocks moved to the ancient store
+	ANCIENT = []byte("a")
 )
 
 // Sub-prefixes
@@ -55,19 +59,54 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	Delete(p []byte) error
+}
+
+// KVIterator is implemented by the kv storages whose entries can be walked over
+type KVIterator interface {
+	// Iterate calls fn with the entries whose key starts with the prefix in the order of their keys,
+	// until fn returns an error
+	Iterate(prefix []byte, fn func(key, value []byte) error) error
 }
 
+var (
+	ErrNoAncients = errors.New("ancient store not enabled")
+	ErrNoIterator = errors.New("storage doesn't support iteration")
+)
+
 // KeyValueStorage is a generic storage for kv databases
 type KeyValueStorage struct {
 	logger hclog.Logger
 	db     KV
 	Db     KV
+
+	// ancients holds the finalized blocks moved out of the kv database, if enabled
+	ancients Ancients
 }
 
 func NewKeyValueStorage(logger hclog.Logger, db KV) Storage {
 	return &KeyValueStorage{logger: logger, db: db}
 }
 
+// AttachAncients attaches the ancient store given in the factory config, if any, to the storage
+func AttachAncients(s Storage, config map[string]interface{}) (Storage, error) {
+	ancients, ok := config[AncientsConfigKey].(Ancients)
+	if !ok {
+		return s, nil
+	}
+
+	kv, ok := s.(*KeyValueStorage)
+	if !ok {
+		return nil, errors.New("storage doesn't support the ancient store")
+	}
+
+	if err := kv.setAncients(ancients); err != nil {
+		return nil, err
+	}
+
+	return kv, nil
+}
+
 func (s *KeyValueStorage) encodeUint(n uint64) []byte {
 	b := make([]byte, 8)
 	binary.BigEndian.PutUint64(b[:], n)
@@ -96,6 +135,11 @@ func (s *KeyValueStorage) WriteCanonicalHash(n uint64, hash types.Hash) error {
 	return s.set(CANONICAL, s.encodeUint(n), hash.Bytes())
 }
 
+// DeleteCanonicalHash removes the hash of the number from the canonical chain
+func (s *KeyValueStorage) DeleteCanonicalHash(n uint64) error {
+	return s.delete(CANONICAL, s.encodeUint(n))
+}
+
 // HEAD //
 
 // ReadHeadHash returns the hash of the head
@@ -178,6 +222,10 @@ func (s *KeyValueStorage) ReadHeader(hash types.Hash) (*types.Header, error) {
 	header := &types.Header{}
 	err := s.readRLP(HEADER, hash.Bytes(), header)
 
+	if errors.Is(err, ErrNotFound) {
+		err = s.readAncientRLP(AncientHeaders, hash, header)
+	}
+
 	return header, err
 }
 
@@ -218,6 +266,10 @@ func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 	body := &types.Body{}
 	err := s.readRLP(BODY, hash.Bytes(), body)
 
+	if errors.Is(err, ErrNotFound) {
+		err = s.readAncientRLP(AncientBodies, hash, body)
+	}
+
 	return body, err
 }
 
@@ -252,9 +304,18 @@ func (s *KeyValueStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error
 	receipts := &types.Receipts{}
 	err := s.readRLP(RECEIPTS, hash.Bytes(), receipts)
 
+	if errors.Is(err, ErrNotFound) {
+		err = s.readAncientRLP(AncientReceipts, hash, receipts)
+	}
+
 	return *receipts, err
 }
 
+// DeleteReceipts removes the receipts of the block from the kv database
+func (s *KeyValueStorage) DeleteReceipts(hash types.Hash) error {
+	return s.delete(RECEIPTS, hash.Bytes())
+}
+
 // TX LOOKUP //
 
 // WriteTxLookup maps the transaction hash to the block hash
@@ -284,6 +345,150 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// DeleteTxLookup removes the mapping of the transaction hash
+func (s *KeyValueStorage) DeleteTxLookup(hash types.Hash) error {
+	return s.delete(TX_LOOKUP_PREFIX, hash.Bytes())
+}
+
+// ITERATION //
+
+// Iterate walks over the raw entries of the kv database whose key starts with the prefix.
+// The blocks in the ancient store aren't visited
+func (s *KeyValueStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter, ok := s.db.(KVIterator)
+	if !ok {
+		return ErrNoIterator
+	}
+
+	return iter.Iterate(prefix, fn)
+}
+
+// ANCIENTS //
+
+// setAncients attaches the ancient store and completes the freezing interrupted by a crash,
+// whose blocks are already in the ancient store but still in the kv database
+func (s *KeyValueStorage) setAncients(ancients Ancients) error {
+	s.ancients = ancients
+
+	// the blocks are frozen in ascending order, so the first frozen block
+	// found from the top marks the end of the interrupted freezing
+	for n := ancients.Ancients(); n > 0; n-- {
+		hashBytes, err := ancients.ReadAncient(AncientHashes, n-1)
+		if err != nil {
+			return err
+		}
+
+		hash := types.BytesToHash(hashBytes)
+
+		if _, ok := s.get(ANCIENT, hash.Bytes()); ok {
+			break
+		}
+
+		if err := s.removeFrozenBlock(n-1, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Ancients returns the number of the blocks in the ancient store
+func (s *KeyValueStorage) Ancients() uint64 {
+	if s.ancients == nil {
+		return 0
+	}
+
+	return s.ancients.Ancients()
+}
+
+// Freeze moves the canonical blocks from the end of the ancient store
+// up to the given number (included) from the kv database to the ancient store
+func (s *KeyValueStorage) Freeze(to uint64) error {
+	if s.ancients == nil {
+		return ErrNoAncients
+	}
+
+	from := s.ancients.Ancients()
+	if from > to {
+		return nil
+	}
+
+	hashes := make([]types.Hash, 0, to-from+1)
+
+	for n := from; n <= to; n++ {
+		hash, ok := s.ReadCanonicalHash(n)
+		if !ok {
+			return fmt.Errorf("canonical hash of block %d not found", n)
+		}
+
+		header, ok := s.get(HEADER, hash.Bytes())
+		if !ok {
+			return fmt.Errorf("header of block %d not found", n)
+		}
+
+		// the blocks written without a body, like the genesis, have empty entries
+		body, _ := s.get(BODY, hash.Bytes())
+		receipts, _ := s.get(RECEIPTS, hash.Bytes())
+
+		if err := s.ancients.AppendAncient(n, hash, header, body, receipts); err != nil {
+			return err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	// the blocks are removed from the kv database only once they are safe on the disk
+	if err := s.ancients.Sync(); err != nil {
+		return err
+	}
+
+	for i, hash := range hashes {
+		if err := s.removeFrozenBlock(from+uint64(i), hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeFrozenBlock indexes the frozen block by its hash and removes it from the kv database
+func (s *KeyValueStorage) removeFrozenBlock(number uint64, hash types.Hash) error {
+	if err := s.set(ANCIENT, hash.Bytes(), s.encodeUint(number)); err != nil {
+		return err
+	}
+
+	for _, prefix := range [][]byte{HEADER, BODY, RECEIPTS} {
+		if err := s.delete(prefix, hash.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readAncientRLP reads the entry of the block from the ancient store
+func (s *KeyValueStorage) readAncientRLP(kind string, hash types.Hash, raw types.RLPUnmarshaler) error {
+	if s.ancients == nil {
+		return ErrNotFound
+	}
+
+	data, ok := s.get(ANCIENT, hash.Bytes())
+	if !ok || len(data) != 8 {
+		return ErrNotFound
+	}
+
+	entry, err := s.ancients.ReadAncient(kind, s.decodeUint(data))
+	if err != nil {
+		return err
+	}
+
+	if len(entry) == 0 {
+		return ErrNotFound
+	}
+
+	return s.unmarshalRLP(entry, raw)
+}
+
 // WRITE OPERATIONS //
 
 func (s *KeyValueStorage) writeRLP(p, k []byte, raw types.RLPMarshaler) error {
@@ -311,6 +516,10 @@ func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
 		return ErrNotFound
 	}
 
+	return s.unmarshalRLP(data, raw)
+}
+
+func (s *KeyValueStorage) unmarshalRLP(data []byte, raw types.RLPUnmarshaler) error {
 	if obj, ok := raw.(types.RLPStoreUnmarshaler); ok {
 		// decode in the store format
 		if err := obj.UnmarshalStoreRLP(data); err != nil {
@@ -363,7 +572,19 @@ func (s *KeyValueStorage) get(p []byte, k []byte) ([]byte, bool) {
 	return data, ok
 }
 
-// Close closes the connection with the db
+func (s *KeyValueStorage) delete(p []byte, k []byte) error {
+	p = append(p, k...)
+
+	return s.db.Delete(p)
+}
+
+// Close closes the connection with the db and the ancient store
 func (s *KeyValueStorage) Close() error {
+	if s.ancients != nil {
+		if err := s.ancients.Close(); err != nil {
+			s.logger.Error("failed to close the ancient store", "err", err)
+		}
+	}
+
 	return s.db.Close()
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // Factory creates a leveldb storage
@@ -20,7 +21,12 @@ func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storag
 		return nil, fmt.Errorf("path is not a string")
 	}
 
-	return NewLevelDBStorage(pathStr, logger)
+	s, err := NewLevelDBStorage(pathStr, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.AttachAncients(s, config)
 }
 
 // NewLevelDBStorage creates the new storage reference with leveldb
@@ -59,6 +65,25 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Delete removes the key from leveldb storage
+func (l *levelDBKV) Delete(p []byte) error {
+	return l.db.Delete(p, nil)
+}
+
+// Iterate walks over the entries whose key starts with the prefix in leveldb storage
+func (l *levelDBKV) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter := l.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
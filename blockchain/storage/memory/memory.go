@@ -1,6 +1,9 @@
 package memory
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
 	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
 	"github.com/hashicorp/go-hclog"
@@ -33,6 +36,39 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) Delete(p []byte) error {
+	delete(m.db, hex.EncodeToHex(p))
+
+	return nil
+}
+
+func (m *memoryKV) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	keys := make([][]byte, 0, len(m.db))
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if bytes.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	for _, key := range keys {
+		if err := fn(key, m.db[hex.EncodeToHex(key)]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
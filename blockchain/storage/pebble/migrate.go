@@ -0,0 +1,84 @@
+package pebble
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// migrationBatchSize is the size of the entries written to pebble at once during the migration
+const migrationBatchSize = 16 * 1024 * 1024
+
+// CopyFromLevelDB copies all the entries of the leveldb database at srcPath
+// to a new pebble database at dstPath. It returns the number of the copied entries
+func CopyFromLevelDB(srcPath, dstPath string) (uint64, error) {
+	if _, err := os.Stat(dstPath); !os.IsNotExist(err) {
+		return 0, fmt.Errorf("destination %s already exists", dstPath)
+	}
+
+	src, err := leveldb.OpenFile(srcPath, &opt.Options{
+		ErrorIfMissing: true,
+		ReadOnly:       true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	defer src.Close()
+
+	dst, err := pebble.Open(dstPath, &pebble.Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := copyEntries(src, dst)
+	if err != nil {
+		_ = dst.Close()
+
+		return count, err
+	}
+
+	// the whole database is synced once at the end
+	if err := dst.Flush(); err != nil {
+		_ = dst.Close()
+
+		return count, err
+	}
+
+	return count, dst.Close()
+}
+
+// copyEntries copies the entries of the leveldb database to the pebble database in batches
+func copyEntries(src *leveldb.DB, dst *pebble.DB) (uint64, error) {
+	var count uint64
+
+	iter := src.NewIterator(nil, nil)
+	defer iter.Release()
+
+	batch := dst.NewBatch()
+
+	for iter.Next() {
+		if err := batch.Set(iter.Key(), iter.Value(), nil); err != nil {
+			return count, err
+		}
+
+		count++
+
+		if len(batch.Repr()) >= migrationBatchSize {
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return count, err
+			}
+
+			batch = dst.NewBatch()
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return count, err
+	}
+
+	return count, batch.Commit(pebble.NoSync)
+}
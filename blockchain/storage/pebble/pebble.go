@@ -0,0 +1,100 @@
+package pebble
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Factory creates a pebble storage
+func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+	path, ok := config["path"]
+	if !ok {
+		return nil, fmt.Errorf("path not found")
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("path is not a string")
+	}
+
+	s, err := NewPebbleStorage(pathStr, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.AttachAncients(s, config)
+}
+
+// NewPebbleStorage creates the new storage reference with pebble
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	kv := &pebbleKV{db}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), kv), nil
+}
+
+// pebbleKV is the pebble implementation of the kv storage
+type pebbleKV struct {
+	db *pebble.DB
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, pebble.NoSync)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	// the value is valid until the closer is closed
+	res := make([]byte, len(data))
+	copy(res, data)
+
+	if err := closer.Close(); err != nil {
+		return nil, false, err
+	}
+
+	return res, true, nil
+}
+
+// Delete removes the key from pebble storage
+func (p *pebbleKV) Delete(k []byte) error {
+	return p.db.Delete(k, pebble.NoSync)
+}
+
+// Iterate walks over the entries whose key starts with the prefix in pebble storage
+func (p *pebbleKV) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter := p.db.NewIter(&pebble.IterOptions{LowerBound: prefix})
+
+	for iter.SeekGE(prefix); iter.Valid() && bytes.HasPrefix(iter.Key(), prefix); iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			_ = iter.Close()
+
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
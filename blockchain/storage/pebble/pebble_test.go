@@ -0,0 +1,89 @@
+package pebble
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStorage(t *testing.T) (storage.Storage, func()) {
+	t.Helper()
+
+	path, err := ioutil.TempDir("/tmp", "minimal_storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewPebbleStorage(path, hclog.NewNullLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closeFn := func() {
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, closeFn
+}
+
+func TestStorage(t *testing.T) {
+	storage.TestStorage(t, newStorage)
+}
+
+func TestCopyFromLevelDB(t *testing.T) {
+	t.Parallel()
+
+	srcPath := filepath.Join(t.TempDir(), "leveldb")
+	dstPath := filepath.Join(t.TempDir(), "pebble")
+
+	src, err := leveldb.NewLevelDBStorage(srcPath, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	headers := make([]*types.Header, 3)
+
+	for i := range headers {
+		headers[i] = &types.Header{Number: uint64(i), ExtraData: []byte{}}
+		headers[i].ComputeHash()
+
+		assert.NoError(t, src.WriteHeader(headers[i]))
+		assert.NoError(t, src.WriteCanonicalHash(headers[i].Number, headers[i].Hash))
+	}
+
+	assert.NoError(t, src.Close())
+
+	count, err := CopyFromLevelDB(srcPath, dstPath)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2*len(headers)), count)
+
+	dst, err := NewPebbleStorage(dstPath, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	defer dst.Close()
+
+	for _, header := range headers {
+		hash, ok := dst.ReadCanonicalHash(header.Number)
+		assert.True(t, ok)
+		assert.Equal(t, header.Hash, hash)
+
+		res, err := dst.ReadHeader(header.Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, header.Hash, res.Hash)
+	}
+
+	// the destination must not exist
+	_, err = CopyFromLevelDB(srcPath, dstPath)
+	assert.Error(t, err)
+}
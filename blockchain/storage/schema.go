@@ -0,0 +1,282 @@
+//nolint:stylecheck
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// SchemaVersion is the version of the key layout and the encodings of the blockchain database.
+// Every change of either bumps it and adds the migration from the previous version to schemaMigrations
+const SchemaVersion uint64 = 1
+
+// legacySchemaVersion is the version of the databases created before the versioning
+const legacySchemaVersion uint64 = 0
+
+var (
+	// SCHEMA is the prefix for the schema version of the database
+	SCHEMA = []byte("v")
+
+	// MIGRATION is the prefix for the checkpoints of the interrupted migrations
+	MIGRATION = []byte("m")
+
+	// VERSION is the sub-prefix of the schema version
+	VERSION = []byte("version")
+)
+
+var (
+	ErrSchemaTooNew     = errors.New("database schema is newer than the supported one, upgrade the node")
+	ErrMigrationMissing = errors.New("migration of the database schema not found")
+)
+
+const (
+	// migrationCheckpointInterval is the number of migrated entries between the checkpoints
+	migrationCheckpointInterval = 10000
+
+	// migrationReportInterval is the minimum interval between the progress reports
+	migrationReportInterval = 8 * time.Second
+)
+
+// Migration upgrades the database schema from the previous version to Version.
+// Run continues from the checkpoint of an interrupted run, so the entries migrated
+// after the last saved checkpoint are migrated again and the migration has to be idempotent
+type Migration struct {
+	Version uint64
+	Name    string
+	Run     func(s *KeyValueStorage, ctx *MigrationContext) error
+}
+
+// SchemaUpgrade is the result of the schema upgrade
+type SchemaUpgrade struct {
+	From       uint64
+	To         uint64
+	Migrations []string
+}
+
+// ReadSchemaVersion returns the schema version of the database,
+// false for the databases created before the versioning
+func (s *KeyValueStorage) ReadSchemaVersion() (uint64, bool) {
+	data, ok := s.get(SCHEMA, VERSION)
+	if !ok {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// WriteSchemaVersion writes the schema version of the database
+func (s *KeyValueStorage) WriteSchemaVersion(version uint64) error {
+	return s.set(SCHEMA, VERSION, s.encodeUint(version))
+}
+
+// UpgradeSchema migrates the database to the current schema version. A new database
+// is marked with the current version and a database of a newer version is refused
+func (s *KeyValueStorage) UpgradeSchema(logger hclog.Logger) (*SchemaUpgrade, error) {
+	return s.upgradeSchema(schemaMigrations, SchemaVersion, logger)
+}
+
+// CheckSchema returns an error if the database has a newer schema version than the supported one
+func (s *KeyValueStorage) CheckSchema() error {
+	if version, ok := s.ReadSchemaVersion(); ok && version > SchemaVersion {
+		return fmt.Errorf("%w: database has version %d, supported is %d", ErrSchemaTooNew, version, SchemaVersion)
+	}
+
+	return nil
+}
+
+func (s *KeyValueStorage) upgradeSchema(
+	migrations []*Migration,
+	target uint64,
+	logger hclog.Logger,
+) (*SchemaUpgrade, error) {
+	version, ok := s.ReadSchemaVersion()
+	if !ok {
+		if _, hasHead := s.ReadHeadHash(); !hasHead {
+			// the database is created now, it has the current schema
+			if err := s.WriteSchemaVersion(target); err != nil {
+				return nil, err
+			}
+
+			return &SchemaUpgrade{From: target, To: target}, nil
+		}
+
+		version = legacySchemaVersion
+	}
+
+	if version > target {
+		return nil, fmt.Errorf("%w: database has version %d, supported is %d", ErrSchemaTooNew, version, target)
+	}
+
+	upgrade := &SchemaUpgrade{From: version, To: version}
+
+	// the migrations run in the order of the versions, one per version
+	for next := version + 1; next <= target; next++ {
+		migration := findMigration(migrations, next)
+		if migration == nil {
+			return nil, fmt.Errorf("%w: version %d", ErrMigrationMissing, next)
+		}
+
+		if err := s.runMigration(migration, logger); err != nil {
+			return nil, fmt.Errorf("migration to version %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+
+		upgrade.To = migration.Version
+		upgrade.Migrations = append(upgrade.Migrations, migration.Name)
+	}
+
+	return upgrade, nil
+}
+
+func findMigration(migrations []*Migration, version uint64) *Migration {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+// runMigration runs the migration from its checkpoint and bumps the schema version once it completes
+func (s *KeyValueStorage) runMigration(migration *Migration, logger hclog.Logger) error {
+	checkpointKey := s.encodeUint(migration.Version)
+
+	checkpoint, _ := s.get(MIGRATION, checkpointKey)
+
+	logger.Info(
+		"Migrating database schema",
+		"version", migration.Version,
+		"migration", migration.Name,
+		"resumed", checkpoint != nil,
+	)
+
+	ctx := &MigrationContext{
+		storage:       s,
+		logger:        logger,
+		migration:     migration,
+		checkpointKey: checkpointKey,
+		checkpoint:    checkpoint,
+		started:       time.Now(),
+	}
+
+	if err := migration.Run(s, ctx); err != nil {
+		return err
+	}
+
+	if err := s.WriteSchemaVersion(migration.Version); err != nil {
+		return err
+	}
+
+	if err := s.delete(MIGRATION, checkpointKey); err != nil {
+		return err
+	}
+
+	logger.Info(
+		"Migrated database schema",
+		"version", migration.Version,
+		"migration", migration.Name,
+		"entries", ctx.done,
+		"elapsed", time.Since(ctx.started).Round(time.Second),
+	)
+
+	return nil
+}
+
+// MigrationContext keeps the checkpoint and reports the progress of a running migration
+type MigrationContext struct {
+	storage       *KeyValueStorage
+	logger        hclog.Logger
+	migration     *Migration
+	checkpointKey []byte
+	checkpoint    []byte
+
+	started    time.Time
+	lastReport time.Time
+	done       uint64
+	total      uint64
+}
+
+// Checkpoint returns the key of the last migrated entry of the interrupted run, nil if none
+func (c *MigrationContext) Checkpoint() []byte {
+	return c.checkpoint
+}
+
+// SetTotal sets the number of the entries to migrate, including the ones before the checkpoint
+func (c *MigrationContext) SetTotal(done, total uint64) {
+	c.done, c.total = done, total
+}
+
+// Migrated records the migrated entry with the key, the checkpoint is saved periodically
+func (c *MigrationContext) Migrated(key []byte) error {
+	c.done++
+
+	if c.done%migrationCheckpointInterval != 0 {
+		return nil
+	}
+
+	c.checkpoint = append(c.checkpoint[:0], key...)
+
+	if err := c.storage.set(MIGRATION, c.checkpointKey, c.checkpoint); err != nil {
+		return err
+	}
+
+	if time.Since(c.lastReport) >= migrationReportInterval {
+		c.lastReport = time.Now()
+
+		total := c.total
+		if total == 0 {
+			total = 1
+		}
+
+		c.logger.Info(
+			"Migrating database schema",
+			"version", c.migration.Version,
+			"migration", c.migration.Name,
+			"entries", c.done,
+			"total", c.total,
+			"progress", fmt.Sprintf("%.2f%%", float64(c.done)*100/float64(total)),
+		)
+	}
+
+	return nil
+}
+
+// iteratePrefix calls fn with the entries of the prefix after the checkpoint of the migration.
+// The number of all the entries and the ones up to the checkpoint are set as the progress first
+func (c *MigrationContext) iteratePrefix(prefix []byte, fn func(key, value []byte) error) error {
+	var done, total uint64
+
+	if err := c.storage.Iterate(prefix, func(key, _ []byte) error {
+		if c.checkpoint != nil && bytes.Compare(key, c.checkpoint) <= 0 {
+			done++
+		}
+
+		total++
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	c.SetTotal(done, total)
+
+	return c.storage.Iterate(prefix, func(key, value []byte) error {
+		if c.checkpoint != nil && bytes.Compare(key, c.checkpoint) <= 0 {
+			return nil
+		}
+
+		// the iterator may reuse the slices
+		key = append([]byte{}, key...)
+		value = append([]byte{}, value...)
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+
+		return c.Migrated(key)
+	})
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// schemaMigrations are the migrations of the schema versions, version 0 is the unversioned database
+var schemaMigrations = []*Migration{
+	{
+		Version: 1,
+		Name:    "receipt transaction hashes",
+		Run:     migrateReceiptTxHashes,
+	},
+}
+
+// legacyReceiptElems is the number of the elements of the stored receipts without the transaction hash
+const legacyReceiptElems = 3
+
+// migrateReceiptTxHashes re-encodes the receipts stored without the transaction hash
+// with the hashes of the transactions of the block. The receipts of the blocks in the
+// ancient store aren't rewritten, they are decoded in both the encodings
+func migrateReceiptTxHashes(s *KeyValueStorage, ctx *MigrationContext) error {
+	parser := &fastrlp.Parser{}
+
+	return ctx.iteratePrefix(RECEIPTS, func(key, value []byte) error {
+		if len(key) != len(RECEIPTS)+types.HashLength {
+			return nil
+		}
+
+		// the undecodable entries are left to the readers
+		if !hasLegacyReceipts(parser, value) {
+			return nil
+		}
+
+		hash := types.BytesToHash(key[len(RECEIPTS):])
+
+		body, err := s.ReadBody(hash)
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		receipts := types.Receipts{}
+		if receipts.UnmarshalStoreRLP(value) != nil {
+			return nil
+		}
+
+		for i, receipt := range receipts {
+			if i < len(body.Transactions) {
+				receipt.TxHash = body.Transactions[i].Hash
+			}
+		}
+
+		return s.WriteReceipts(hash, receipts)
+	})
+}
+
+// hasLegacyReceipts returns true if any of the stored receipts has no transaction hash
+func hasLegacyReceipts(parser *fastrlp.Parser, data []byte) bool {
+	v, err := parser.Parse(data)
+	if err != nil {
+		return false
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return false
+	}
+
+	for _, elem := range elems {
+		if elem.Elems() == legacyReceiptElems {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
+)
+
+// sortedKV is an in memory kv storage iterated in the order of the keys
+type sortedKV struct {
+	entries map[string][]byte
+}
+
+func newTestKeyValueStorage() *KeyValueStorage {
+	return &KeyValueStorage{
+		logger: hclog.NewNullLogger(),
+		db:     &sortedKV{entries: map[string][]byte{}},
+	}
+}
+
+func (m *sortedKV) Set(p []byte, v []byte) error {
+	m.entries[string(p)] = append([]byte{}, v...)
+
+	return nil
+}
+
+func (m *sortedKV) Get(p []byte) ([]byte, bool, error) {
+	v, ok := m.entries[string(p)]
+
+	return v, ok, nil
+}
+
+func (m *sortedKV) Delete(p []byte) error {
+	delete(m.entries, string(p))
+
+	return nil
+}
+
+func (m *sortedKV) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	keys := []string{}
+
+	for k := range m.entries {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := fn([]byte(k), m.entries[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *sortedKV) Close() error {
+	return nil
+}
+
+// marshalLegacyReceipts encodes the receipts without the transaction hashes
+func marshalLegacyReceipts(receipts []*types.Receipt) []byte {
+	ar := &fastrlp.Arena{}
+	vv := ar.NewArray()
+
+	for _, receipt := range receipts {
+		v := ar.NewArray()
+		v.Set(receipt.MarshalRLPWith(ar))
+		v.Set(ar.NewBytes([]byte{}))
+		v.Set(ar.NewUint(receipt.GasUsed))
+		vv.Set(v)
+	}
+
+	return vv.MarshalTo(nil)
+}
+
+func TestUpgradeSchema_NewDatabase(t *testing.T) {
+	t.Parallel()
+
+	s := newTestKeyValueStorage()
+
+	upgrade, err := s.UpgradeSchema(hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, &SchemaUpgrade{From: SchemaVersion, To: SchemaVersion}, upgrade)
+
+	version, ok := s.ReadSchemaVersion()
+	assert.True(t, ok)
+	assert.Equal(t, SchemaVersion, version)
+}
+
+func TestUpgradeSchema_Legacy(t *testing.T) {
+	t.Parallel()
+
+	s := newTestKeyValueStorage()
+	assert.NoError(t, s.WriteHeadHash(types.StringToHash("0x1")))
+
+	tx := (&types.Transaction{Nonce: 1, GasPrice: big.NewInt(1), Value: big.NewInt(0)}).ComputeHash()
+	hash := types.StringToHash("0x2")
+
+	receipt := &types.Receipt{CumulativeGasUsed: 21000, GasUsed: 21000, Logs: []*types.Log{}}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	assert.NoError(t, s.WriteBody(hash, &types.Body{Transactions: []*types.Transaction{tx}}))
+	assert.NoError(t, s.set(RECEIPTS, hash.Bytes(), marshalLegacyReceipts([]*types.Receipt{receipt})))
+
+	// the receipts of the block without body stay as they are
+	orphan := types.StringToHash("0x3")
+	assert.NoError(t, s.set(RECEIPTS, orphan.Bytes(), marshalLegacyReceipts([]*types.Receipt{receipt})))
+
+	upgrade, err := s.UpgradeSchema(hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, legacySchemaVersion, upgrade.From)
+	assert.Equal(t, SchemaVersion, upgrade.To)
+	assert.Equal(t, []string{"receipt transaction hashes"}, upgrade.Migrations)
+
+	receipts, err := s.ReadReceipts(hash)
+	assert.NoError(t, err)
+	assert.Len(t, receipts, 1)
+	assert.Equal(t, tx.Hash, receipts[0].TxHash)
+
+	receipts, err = s.ReadReceipts(orphan)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ZeroHash, receipts[0].TxHash)
+
+	version, _ := s.ReadSchemaVersion()
+	assert.Equal(t, SchemaVersion, version)
+
+	// nothing is left to migrate
+	upgrade, err = s.UpgradeSchema(hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion, upgrade.From)
+	assert.Empty(t, upgrade.Migrations)
+}
+
+func TestUpgradeSchema_TooNew(t *testing.T) {
+	t.Parallel()
+
+	s := newTestKeyValueStorage()
+	assert.NoError(t, s.WriteSchemaVersion(SchemaVersion+1))
+
+	_, err := s.UpgradeSchema(hclog.NewNullLogger())
+	assert.ErrorIs(t, err, ErrSchemaTooNew)
+	assert.ErrorIs(t, s.CheckSchema(), ErrSchemaTooNew)
+
+	assert.NoError(t, s.WriteSchemaVersion(SchemaVersion))
+	assert.NoError(t, s.CheckSchema())
+}
+
+func TestUpgradeSchema_Order(t *testing.T) {
+	t.Parallel()
+
+	s := newTestKeyValueStorage()
+	assert.NoError(t, s.WriteSchemaVersion(1))
+
+	runs := []uint64{}
+	migration := func(version uint64) *Migration {
+		return &Migration{
+			Version: version,
+			Name:    "test",
+			Run: func(s *KeyValueStorage, ctx *MigrationContext) error {
+				runs = append(runs, version)
+
+				return nil
+			},
+		}
+	}
+
+	// the migrations run in the order of the versions, the ones of the older versions are skipped
+	upgrade, err := s.upgradeSchema([]*Migration{migration(3), migration(1), migration(2)}, 3, hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2, 3}, runs)
+	assert.Equal(t, uint64(3), upgrade.To)
+
+	// a gap in the versions
+	_, err = s.upgradeSchema([]*Migration{migration(5)}, 5, hclog.NewNullLogger())
+	assert.ErrorIs(t, err, ErrMigrationMissing)
+}
+
+func TestUpgradeSchema_Resume(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s          = newTestKeyValueStorage()
+		prefix     = []byte("t")
+		numEntries = uint64(migrationCheckpointInterval + migrationCheckpointInterval/2)
+		errAbort   = errors.New("abort")
+	)
+
+	assert.NoError(t, s.WriteSchemaVersion(1))
+
+	for i := uint64(0); i < numEntries; i++ {
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, i)
+
+		assert.NoError(t, s.set(prefix, key, []byte{0x1}))
+	}
+
+	var (
+		interrupted = uint64(migrationCheckpointInterval + 100)
+		failAt      = interrupted
+		migrated    = map[uint64]int{}
+		done        uint64
+	)
+
+	migrations := []*Migration{{
+		Version: 2,
+		Name:    "test",
+		Run: func(s *KeyValueStorage, ctx *MigrationContext) error {
+			return ctx.iteratePrefix(prefix, func(key, _ []byte) error {
+				n := binary.BigEndian.Uint64(key[len(prefix):])
+				if n == failAt {
+					failAt = numEntries
+
+					return errAbort
+				}
+
+				migrated[n]++
+				done = ctx.done
+
+				return nil
+			})
+		},
+	}}
+
+	// the interrupted migration keeps the version and the checkpoint
+	_, err := s.upgradeSchema(migrations, 2, hclog.NewNullLogger())
+	assert.ErrorIs(t, err, errAbort)
+
+	version, _ := s.ReadSchemaVersion()
+	assert.Equal(t, uint64(1), version)
+
+	_, ok := s.get(MIGRATION, s.encodeUint(2))
+	assert.True(t, ok)
+
+	// the migration continues after the checkpoint
+	upgrade, err := s.upgradeSchema(migrations, 2, hclog.NewNullLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), upgrade.To)
+
+	for i := uint64(0); i < numEntries; i++ {
+		expected := 1
+		if i >= migrationCheckpointInterval && i < interrupted {
+			// migrated after the checkpoint before the interruption
+			expected = 2
+		}
+
+		assert.Equal(t, expected, migrated[i], i)
+	}
+
+	// the progress counts the entries before the checkpoint
+	assert.Equal(t, numEntries-1, done)
+
+	_, ok = s.get(MIGRATION, s.encodeUint(2))
+	assert.False(t, ok)
+}
@@ -11,6 +11,7 @@ import (
 type Storage interface {
 	ReadCanonicalHash(n uint64) (types.Hash, bool)
 	WriteCanonicalHash(n uint64, hash types.Hash) error
+	DeleteCanonicalHash(n uint64) error
 
 	ReadHeadHash() (types.Hash, bool)
 	ReadHeadNumber() (uint64, bool)
@@ -36,12 +37,51 @@ type Storage interface {
 
 	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
 	ReadReceipts(hash types.Hash) ([]*types.Receipt, error)
+	DeleteReceipts(hash types.Hash) error
 
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
+	DeleteTxLookup(hash types.Hash) error
+
+	Ancients() uint64
+	Freeze(to uint64) error
 
 	Close() error
 }
 
+// Kinds of the data kept in the ancient store
+const (
+	AncientHashes   = "hashes"
+	AncientHeaders  = "headers"
+	AncientBodies   = "bodies"
+	AncientReceipts = "receipts"
+)
+
+// AncientKinds are the kinds of the data kept in the ancient store
+var AncientKinds = []string{
+	AncientHashes,
+	AncientHeaders,
+	AncientBodies,
+	AncientReceipts,
+}
+
+// Ancients is an append-only store of the finalized canonical blocks, indexed by number.
+// The headers, bodies and receipts are kept in their encoded form, an empty entry
+// means that the block has no such data
+type Ancients interface {
+	// Ancients returns the number of the blocks in the store
+	Ancients() uint64
+	// AppendAncient appends the block, whose number has to be the number of the blocks in the store
+	AppendAncient(number uint64, hash types.Hash, header, body, receipts []byte) error
+	// ReadAncient returns the entry of the given kind of the block
+	ReadAncient(kind string, number uint64) ([]byte, error)
+	// Sync flushes the appended blocks to the disk
+	Sync() error
+	Close() error
+}
+
 // Factory is a factory method to create a blockchain storage
 type Factory func(config map[string]interface{}, logger hclog.Logger) (Storage, error)
+
+// AncientsConfigKey is the key of the optional ancient store in the config of the storage factories
+const AncientsConfigKey = "ancients"
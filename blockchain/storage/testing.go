@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"encoding/binary"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -48,6 +50,9 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testIterate(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -195,6 +200,35 @@ func testHead(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testIterate(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	kv, ok := s.(*KeyValueStorage)
+	if !ok {
+		t.Skip("not a kv storage")
+	}
+
+	for i := uint64(3); i > 0; i-- {
+		assert.NoError(t, s.WriteCanonicalHash(i, types.StringToHash(fmt.Sprintf("%d", i))))
+	}
+
+	assert.NoError(t, s.WriteHeadNumber(3))
+
+	numbers := []uint64{}
+
+	assert.NoError(t, kv.Iterate(CANONICAL, func(key, value []byte) error {
+		numbers = append(numbers, binary.BigEndian.Uint64(key[len(CANONICAL):]))
+
+		return nil
+	}))
+
+	// only the entries with the prefix are visited, in the order of their keys
+	assert.Equal(t, []uint64{1, 2, 3}, numbers)
+}
+
 func testForks(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -450,6 +484,7 @@ func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 
 type readCanonicalHashDelegate func(uint64) (types.Hash, bool)
 type writeCanonicalHashDelegate func(uint64, types.Hash) error
+type deleteCanonicalHashDelegate func(uint64) error
 type readHeadHashDelegate func() (types.Hash, bool)
 type readHeadNumberDelegate func() (uint64, bool)
 type writeHeadHashDelegate func(types.Hash) error
@@ -467,13 +502,18 @@ type writeSnapshotDelegate func(types.Hash, []byte) error
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
 type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
+type deleteReceiptsDelegate func(types.Hash) error
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type deleteTxLookupDelegate func(types.Hash) error
+type ancientsDelegate func() uint64
+type freezeDelegate func(uint64) error
 type closeDelegate func() error
 
 type MockStorage struct {
 	readCanonicalHashFn    readCanonicalHashDelegate
 	writeCanonicalHashFn   writeCanonicalHashDelegate
+	deleteCanonicalHashFn  deleteCanonicalHashDelegate
 	readHeadHashFn         readHeadHashDelegate
 	readHeadNumberFn       readHeadNumberDelegate
 	writeHeadHashFn        writeHeadHashDelegate
@@ -491,8 +531,12 @@ type MockStorage struct {
 	readSnapshotFn         readSnapshotDelegate
 	writeReceiptsFn        writeReceiptsDelegate
 	readReceiptsFn         readReceiptsDelegate
+	deleteReceiptsFn       deleteReceiptsDelegate
 	writeTxLookupFn        writeTxLookupDelegate
 	readTxLookupFn         readTxLookupDelegate
+	deleteTxLookupFn       deleteTxLookupDelegate
+	ancientsFn             ancientsDelegate
+	freezeFn               freezeDelegate
 	closeFn                closeDelegate
 }
 
@@ -524,6 +568,18 @@ func (m *MockStorage) HookWriteCanonicalHash(fn writeCanonicalHashDelegate) {
 	m.writeCanonicalHashFn = fn
 }
 
+func (m *MockStorage) DeleteCanonicalHash(n uint64) error {
+	if m.deleteCanonicalHashFn != nil {
+		return m.deleteCanonicalHashFn(n)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteCanonicalHash(fn deleteCanonicalHashDelegate) {
+	m.deleteCanonicalHashFn = fn
+}
+
 func (m *MockStorage) ReadHeadHash() (types.Hash, bool) {
 	if m.readHeadHashFn != nil {
 		return m.readHeadHashFn()
@@ -728,6 +784,18 @@ func (m *MockStorage) HookReadReceipts(fn readReceiptsDelegate) {
 	m.readReceiptsFn = fn
 }
 
+func (m *MockStorage) DeleteReceipts(hash types.Hash) error {
+	if m.deleteReceiptsFn != nil {
+		return m.deleteReceiptsFn(hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteReceipts(fn deleteReceiptsDelegate) {
+	m.deleteReceiptsFn = fn
+}
+
 func (m *MockStorage) WriteTxLookup(hash types.Hash, blockHash types.Hash) error {
 	if m.writeTxLookupFn != nil {
 		return m.writeTxLookupFn(hash, blockHash)
@@ -752,6 +820,42 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) DeleteTxLookup(hash types.Hash) error {
+	if m.deleteTxLookupFn != nil {
+		return m.deleteTxLookupFn(hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteTxLookup(fn deleteTxLookupDelegate) {
+	m.deleteTxLookupFn = fn
+}
+
+func (m *MockStorage) Ancients() uint64 {
+	if m.ancientsFn != nil {
+		return m.ancientsFn()
+	}
+
+	return 0
+}
+
+func (m *MockStorage) HookAncients(fn ancientsDelegate) {
+	m.ancientsFn = fn
+}
+
+func (m *MockStorage) Freeze(to uint64) error {
+	if m.freezeFn != nil {
+		return m.freezeFn(to)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookFreeze(fn freezeDelegate) {
+	m.freezeFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
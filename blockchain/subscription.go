@@ -102,12 +102,16 @@ const (
 
 // Event is the blockchain event that gets passed to the listeners
 type Event struct {
-	// Old chain (removed headers) if there was a reorg
+	// Old chain (removed headers) if there was a reorg, in ascending order
 	OldChain []*types.Header
 
-	// New part of the chain (or a fork)
+	// New part of the chain (or a fork), in ascending order
 	NewChain []*types.Header
 
+	// OldReceipts are the receipts of the old chain by the block hashes. They are set
+	// when the receipts are deleted along with the removed blocks, as by SetHead
+	OldReceipts map[types.Hash][]*types.Receipt
+
 	// Difficulty is the new difficulty created with this event
 	Difficulty *big.Int
 
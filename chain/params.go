@@ -2,6 +2,7 @@ package chain
 
 import (
 	"math/big"
+	"sync"
 
 	"github.com/ExzoNetwork/ExzoCoin/types"
 )
@@ -13,6 +14,36 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	Whitelists     *Whitelists            `json:"whitelists,omitempty"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+
+	// governedLock guards Forks and BlockGasTarget
+	// which on-chain governance updates while the chain is running
+	governedLock sync.RWMutex
+}
+
+// GetForks returns the forks of the chain
+// The returned Forks mustn't be modified as it may be shared with other readers
+func (p *Params) GetForks() *Forks {
+	p.governedLock.RLock()
+	defer p.governedLock.RUnlock()
+
+	return p.Forks
+}
+
+// GetBlockGasTarget returns the block gas target of the chain
+func (p *Params) GetBlockGasTarget() uint64 {
+	p.governedLock.RLock()
+	defer p.governedLock.RUnlock()
+
+	return p.BlockGasTarget
+}
+
+// SetGovernedParams replaces the forks and the block gas target of the chain
+func (p *Params) SetGovernedParams(forks *Forks, blockGasTarget uint64) {
+	p.governedLock.Lock()
+	defer p.governedLock.Unlock()
+
+	p.Forks = forks
+	p.BlockGasTarget = blockGasTarget
 }
 
 func (p *Params) GetEngine() string {
@@ -41,6 +72,28 @@ type Forks struct {
 	EIP155         *Fork `json:"EIP155,omitempty"`
 }
 
+// Copy returns a deep copy of the forks
+func (f *Forks) Copy() *Forks {
+	copyFork := func(ff *Fork) *Fork {
+		if ff == nil {
+			return nil
+		}
+
+		return NewFork(uint64(*ff))
+	}
+
+	return &Forks{
+		Homestead:      copyFork(f.Homestead),
+		Byzantium:      copyFork(f.Byzantium),
+		Constantinople: copyFork(f.Constantinople),
+		Petersburg:     copyFork(f.Petersburg),
+		Istanbul:       copyFork(f.Istanbul),
+		EIP150:         copyFork(f.EIP150),
+		EIP158:         copyFork(f.EIP158),
+		EIP155:         copyFork(f.EIP155),
+	}
+}
+
 func (f *Forks) active(ff *Fork, block uint64) bool {
 	if ff == nil {
 		return false
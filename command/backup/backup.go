@@ -2,6 +2,7 @@ package backup
 
 import (
 	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/backup/verify"
 	"github.com/spf13/cobra"
 
 	"github.com/ExzoNetwork/ExzoCoin/command/helper"
@@ -20,9 +21,18 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
+	registerSubcommands(backupCmd)
+
 	return backupCmd
 }
 
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// backup verify
+		verify.GetCommand(),
+	)
+}
+
 func setFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(
 		&params.out,
@@ -44,6 +54,24 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.withState,
+		withStateFlag,
+		false,
+		"include the state snapshot of the end height in backup, "+
+			"the restored node doesn't need to re-execute the blocks. "+
+			"The restored blocks have no receipts, so their receipts and logs aren't served, "+
+			"and the snapshot can't be restored on the chains with PoS forks",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.resume,
+		resumeFlag,
+		false,
+		"append the blocks following the existing backup at the export path, "+
+			"from is ignored if the backup exists",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -14,6 +14,9 @@ const (
 	outFlag  = "out"
 	fromFlag = "from"
 	toFlag   = "to"
+
+	withStateFlag = "with-state"
+	resumeFlag    = "resume"
 )
 
 var (
@@ -23,10 +26,13 @@ var (
 var (
 	errDecodeRange  = errors.New("unable to decode range value")
 	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errResumeState  = errors.New(`"with-state" can't be used with "resume"`)
 )
 
 type backupParams struct {
-	out string
+	out       string
+	withState bool
+	resume    bool
 
 	fromRaw string
 	toRaw   string
@@ -41,6 +47,10 @@ type backupParams struct {
 func (p *backupParams) validateFlags() error {
 	var parseErr error
 
+	if p.withState && p.resume {
+		return errResumeState
+	}
+
 	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
 		return errDecodeRange
 	}
@@ -76,7 +86,8 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		return err
 	}
 
-	// resFrom and resTo represents the range of blocks that can be included in the file
+	// resFrom and resTo represents the range of blocks that can be included in the file,
+	// including the blocks written before if the backup is resumed
 	resFrom, resTo, err := archive.CreateBackup(
 		connection,
 		hclog.New(&hclog.LoggerOptions{
@@ -86,6 +97,8 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		p.from,
 		p.to,
 		p.out,
+		p.withState,
+		p.resume,
 	)
 	if err != nil {
 		return err
@@ -99,8 +112,9 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 
 func (p *backupParams) getResult() command.CommandResult {
 	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+		From:  p.resFrom,
+		To:    p.resTo,
+		Out:   p.out,
+		State: p.withState,
 	}
 }
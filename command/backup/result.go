@@ -8,9 +8,10 @@ import (
 )
 
 type BackupResult struct {
-	From uint64 `json:"from"`
-	To   uint64 `json:"to"`
-	Out  string `json:"out"`
+	From  uint64 `json:"from"`
+	To    uint64 `json:"to"`
+	Out   string `json:"out"`
+	State bool   `json:"state"`
 }
 
 func (r *BackupResult) GetOutput() string {
@@ -22,6 +23,7 @@ func (r *BackupResult) GetOutput() string {
 		fmt.Sprintf("File|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("State|%t", r.State),
 	}))
 
 	return buffer.String()
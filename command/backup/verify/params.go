@@ -0,0 +1,70 @@
+package verify
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ExzoNetwork/ExzoCoin/archive"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	pathFlag = "path"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+var (
+	errBackupMissing = errors.New("backup file does not exist")
+)
+
+type verifyParams struct {
+	path string
+
+	summary *archive.ArchiveSummary
+}
+
+func (p *verifyParams) validateFlags() error {
+	if _, err := os.Stat(p.path); err != nil {
+		return errBackupMissing
+	}
+
+	return nil
+}
+
+func (p *verifyParams) getRequiredFlags() []string {
+	return []string{
+		pathFlag,
+	}
+}
+
+func (p *verifyParams) verify() error {
+	summary, err := archive.VerifyArchive(p.path)
+	if err != nil {
+		return err
+	}
+
+	p.summary = summary
+
+	return nil
+}
+
+func (p *verifyParams) getResult() command.CommandResult {
+	result := &VerifyResult{
+		Path:        p.path,
+		Version:     p.summary.Version,
+		From:        p.summary.From,
+		To:          p.summary.To,
+		Chunks:      p.summary.Chunks,
+		StateChunks: p.summary.StateChunks,
+	}
+
+	if p.summary.StateRoot != types.ZeroHash {
+		result.StateRoot = p.summary.StateRoot.String()
+	}
+
+	return result
+}
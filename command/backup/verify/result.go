@@ -0,0 +1,45 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type VerifyResult struct {
+	Path        string `json:"path"`
+	Version     uint16 `json:"version"`
+	From        uint64 `json:"from"`
+	To          uint64 `json:"to"`
+	Chunks      int    `json:"chunks"`
+	StateChunks int    `json:"state_chunks"`
+	StateRoot   string `json:"state_root,omitempty"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BACKUP VERIFY]\n")
+	buffer.WriteString("Verified backup file successfully:\n")
+
+	vals := []string{
+		fmt.Sprintf("File|%s", r.Path),
+		fmt.Sprintf("Version|%d", r.Version),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Chunks|%d", r.Chunks),
+	}
+
+	if r.StateRoot != "" {
+		vals = append(vals,
+			fmt.Sprintf("State root|%s", r.StateRoot),
+			fmt.Sprintf("State chunks|%d", r.StateChunks),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
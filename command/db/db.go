@@ -0,0 +1,39 @@
+package db
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command/db/inspect"
+	"github.com/ExzoNetwork/ExzoCoin/command/db/migrate"
+	"github.com/ExzoNetwork/ExzoCoin/command/db/rebuildtxlookup"
+	"github.com/ExzoNetwork/ExzoCoin/command/db/rewind"
+	"github.com/ExzoNetwork/ExzoCoin/command/db/upgrade"
+	"github.com/ExzoNetwork/ExzoCoin/command/db/verify"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Top level command for maintaining the local databases of the node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db migrate
+		migrate.GetCommand(),
+		// db inspect
+		inspect.GetCommand(),
+		// db verify
+		verify.GetCommand(),
+		// db rewind
+		rewind.GetCommand(),
+		// db rebuild-txlookup
+		rebuildtxlookup.GetCommand(),
+		// db upgrade
+		upgrade.GetCommand(),
+	)
+}
@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/command/server/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DataDirFlag    = "data-dir"
+	DBEngineFlag   = "db-engine"
+	AncientDirFlag = "ancient-dir"
+)
+
+var (
+	ErrDataDirMissing = errors.New("data directory does not exist")
+	ErrInvalidEngine  = errors.New("invalid database engine specified")
+)
+
+// DatabaseFlags are the flags locating the databases of a stopped node
+type DatabaseFlags struct {
+	DataDir    string
+	DBEngine   string
+	AncientDir string
+}
+
+// RegisterFlags registers the flags of the databases on the command
+func (f *DatabaseFlags) RegisterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&f.DataDir,
+		DataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&f.DBEngine,
+		DBEngineFlag,
+		config.DefaultDBEngine,
+		"the database engine of the data directory, leveldb or pebble",
+	)
+
+	cmd.Flags().StringVar(
+		&f.AncientDir,
+		AncientDirFlag,
+		"",
+		"the directory of the ancient store of the node (default <data-dir>/ancient)",
+	)
+}
+
+// RequiredFlags returns the flags which have to be set
+func (f *DatabaseFlags) RequiredFlags() []string {
+	return []string{
+		DataDirFlag,
+	}
+}
+
+// ValidateFlags checks the data directory and the database engine
+func (f *DatabaseFlags) ValidateFlags() error {
+	if _, err := os.Stat(f.DataDir); err != nil {
+		return ErrDataDirMissing
+	}
+
+	if f.DBEngine != dbtool.EngineLevelDB && f.DBEngine != dbtool.EnginePebble {
+		return ErrInvalidEngine
+	}
+
+	return nil
+}
+
+// Open opens the databases of the node
+func (f *DatabaseFlags) Open() (*dbtool.Databases, error) {
+	ancientDir := f.AncientDir
+	if ancientDir == "" {
+		ancientDir = filepath.Join(f.DataDir, config.DefaultAncientDir)
+	}
+
+	return dbtool.Open(f.DataDir, f.DBEngine, ancientDir, hclog.NewNullLogger())
+}
@@ -0,0 +1,43 @@
+package inspect
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	inspectCmd := &cobra.Command{
+		Use: "inspect",
+		Short: "Prints the number and the size of the entries of the blockchain and the trie databases " +
+			"of the stopped node by key prefix",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(inspectCmd)
+	helper.SetRequiredFlags(inspectCmd, params.getRequiredFlags())
+
+	return inspectCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	params.db.RegisterFlags(cmd)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.inspect(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,65 @@
+package inspect
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	dbHelper "github.com/ExzoNetwork/ExzoCoin/command/db/helper"
+)
+
+var (
+	params = &inspectParams{}
+)
+
+type inspectParams struct {
+	db dbHelper.DatabaseFlags
+
+	blockchainStats []*dbtool.KeySpaceStats
+	trieStats       []*dbtool.KeySpaceStats
+	ancients        uint64
+	schemaVersion   uint64
+}
+
+func (p *inspectParams) validateFlags() error {
+	return p.db.ValidateFlags()
+}
+
+func (p *inspectParams) getRequiredFlags() []string {
+	return p.db.RequiredFlags()
+}
+
+func (p *inspectParams) inspect() error {
+	dbs, err := p.db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer dbs.Close()
+
+	if p.blockchainStats, err = dbtool.BlockchainStats(dbs.Blockchain); err != nil {
+		return err
+	}
+
+	if p.trieStats, err = dbtool.TrieStats(dbs.Trie); err != nil {
+		return err
+	}
+
+	p.ancients = dbs.Blockchain.Ancients()
+
+	// the databases created before the versioning have the version 0
+	p.schemaVersion, _ = dbs.Blockchain.ReadSchemaVersion()
+
+	return nil
+}
+
+func (p *inspectParams) getResult() command.CommandResult {
+	return &InspectResult{
+		DataDir:    p.db.DataDir,
+		Blockchain: p.blockchainStats,
+		Trie:       p.trieStats,
+		Ancients:   p.ancients,
+
+		SchemaVersion:          p.schemaVersion,
+		SupportedSchemaVersion: storage.SchemaVersion,
+	}
+}
@@ -0,0 +1,78 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type InspectResult struct {
+	DataDir    string                  `json:"data_dir"`
+	Blockchain []*dbtool.KeySpaceStats `json:"blockchain"`
+	Trie       []*dbtool.KeySpaceStats `json:"trie"`
+	Ancients   uint64                  `json:"ancient_blocks"`
+
+	SchemaVersion          uint64 `json:"schema_version"`
+	SupportedSchemaVersion uint64 `json:"supported_schema_version"`
+}
+
+func (r *InspectResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB INSPECT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data directory|%s", r.DataDir),
+		fmt.Sprintf("Schema version|%d", r.SchemaVersion),
+		fmt.Sprintf("Supported schema version|%d", r.SupportedSchemaVersion),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[BLOCKCHAIN]\n")
+	writeStats(&buffer, r.Blockchain)
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Blocks in the ancient store|%d", r.Ancients),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[TRIE]\n")
+	writeStats(&buffer, r.Trie)
+
+	return buffer.String()
+}
+
+func writeStats(buffer *bytes.Buffer, stats []*dbtool.KeySpaceStats) {
+	rows := []string{"Key space|Entries|Size"}
+
+	var entries, size uint64
+
+	for _, s := range stats {
+		rows = append(rows, fmt.Sprintf("%s|%d|%s", s.Name, s.Entries, formatSize(s.Size)))
+
+		entries += s.Entries
+		size += s.Size
+	}
+
+	rows = append(rows, fmt.Sprintf("total|%d|%s", entries, formatSize(size)))
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+}
+
+// formatSize formats the size in bytes with a binary unit
+func formatSize(size uint64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.2f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/pebble"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+const (
+	// pebbleSuffix is the suffix of the pebble databases while they are being populated
+	pebbleSuffix = "-pebble"
+
+	// levelDBSuffix is the suffix of the original leveldb databases after the migration
+	levelDBSuffix = "-leveldb"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errDataDirMissing = errors.New("data directory does not exist")
+)
+
+// databases are the names of the database directories in the data directory
+var databases = []string{
+	"blockchain",
+	"trie",
+}
+
+type migrateParams struct {
+	dataDir string
+
+	entries map[string]uint64
+}
+
+func (p *migrateParams) validateFlags() error {
+	if _, err := os.Stat(p.dataDir); err != nil {
+		return errDataDirMissing
+	}
+
+	return nil
+}
+
+func (p *migrateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+// migrate copies all the databases to pebble first, and swaps the directories
+// only when every copy succeeded, so a failed migration leaves the node untouched
+func (p *migrateParams) migrate() error {
+	if err := dbtool.CheckEngine(p.dataDir, dbtool.EngineLevelDB); err != nil {
+		return err
+	}
+
+	p.entries = make(map[string]uint64, len(databases))
+
+	for _, name := range databases {
+		path := filepath.Join(p.dataDir, name)
+
+		if _, err := os.Stat(path + levelDBSuffix); !os.IsNotExist(err) {
+			return fmt.Errorf("backup directory %s already exists", path+levelDBSuffix)
+		}
+
+		// leftovers of an interrupted migration
+		if err := os.RemoveAll(path + pebbleSuffix); err != nil {
+			return err
+		}
+
+		// the copy fails if the database is locked by a running node
+		count, err := pebble.CopyFromLevelDB(path, path+pebbleSuffix)
+		if err != nil {
+			return fmt.Errorf("unable to migrate %s, %w", name, err)
+		}
+
+		p.entries[name] = count
+	}
+
+	for _, name := range databases {
+		path := filepath.Join(p.dataDir, name)
+
+		if err := os.Rename(path, path+levelDBSuffix); err != nil {
+			return err
+		}
+
+		if err := os.Rename(path+pebbleSuffix, path); err != nil {
+			return err
+		}
+	}
+
+	return dbtool.WriteEngine(p.dataDir, dbtool.EnginePebble)
+}
+
+func (p *migrateParams) getResult() command.CommandResult {
+	result := &MigrateResult{
+		DataDir: p.dataDir,
+		Entries: make([]DatabaseEntries, 0, len(databases)),
+	}
+
+	for _, name := range databases {
+		result.Entries = append(result.Entries, DatabaseEntries{
+			Name:    name,
+			Entries: p.entries[name],
+			Backup:  filepath.Join(p.dataDir, name+levelDBSuffix),
+		})
+	}
+
+	return result
+}
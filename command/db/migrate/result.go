@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type DatabaseEntries struct {
+	Name    string `json:"name"`
+	Entries uint64 `json:"entries"`
+	Backup  string `json:"backup"`
+}
+
+type MigrateResult struct {
+	DataDir string            `json:"data_dir"`
+	Entries []DatabaseEntries `json:"databases"`
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB MIGRATE]\n")
+	buffer.WriteString("Migrated the databases to Pebble successfully, start the server with --db-engine pebble\n")
+
+	for _, db := range r.Entries {
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Database|%s", db.Name),
+			fmt.Sprintf("Entries|%d", db.Entries),
+			fmt.Sprintf("LevelDB backup|%s", db.Backup),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
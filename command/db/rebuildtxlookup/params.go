@@ -0,0 +1,44 @@
+package rebuildtxlookup
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	dbHelper "github.com/ExzoNetwork/ExzoCoin/command/db/helper"
+)
+
+var (
+	params = &rebuildParams{}
+)
+
+type rebuildParams struct {
+	db dbHelper.DatabaseFlags
+
+	transactions uint64
+}
+
+func (p *rebuildParams) validateFlags() error {
+	return p.db.ValidateFlags()
+}
+
+func (p *rebuildParams) getRequiredFlags() []string {
+	return p.db.RequiredFlags()
+}
+
+func (p *rebuildParams) rebuild() error {
+	dbs, err := p.db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer dbs.Close()
+
+	p.transactions, err = dbtool.RebuildTxLookup(dbs.Blockchain)
+
+	return err
+}
+
+func (p *rebuildParams) getResult() command.CommandResult {
+	return &RebuildResult{
+		Transactions: p.transactions,
+	}
+}
@@ -0,0 +1,43 @@
+package rebuildtxlookup
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	rebuildCmd := &cobra.Command{
+		Use: "rebuild-txlookup",
+		Short: "Drops the transaction lookup index of the stopped node " +
+			"and rebuilds it from the canonical chain",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(rebuildCmd)
+	helper.SetRequiredFlags(rebuildCmd, params.getRequiredFlags())
+
+	return rebuildCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	params.db.RegisterFlags(cmd)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rebuild(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,25 @@
+package rebuildtxlookup
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type RebuildResult struct {
+	Transactions uint64 `json:"transactions"`
+}
+
+func (r *RebuildResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB REBUILD TX LOOKUP]\n")
+	buffer.WriteString("Rebuilt the transaction lookup index successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Indexed transactions|%d", r.Transactions),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,62 @@
+package rewind
+
+import (
+	"path/filepath"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	dbHelper "github.com/ExzoNetwork/ExzoCoin/command/db/helper"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/fork"
+	"github.com/ExzoNetwork/ExzoCoin/secrets"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	blockFlag = "block"
+)
+
+var (
+	params = &rewindParams{}
+)
+
+type rewindParams struct {
+	db    dbHelper.DatabaseFlags
+	block uint64
+
+	head    *types.Header
+	removed uint64
+}
+
+func (p *rewindParams) validateFlags() error {
+	return p.db.ValidateFlags()
+}
+
+func (p *rewindParams) getRequiredFlags() []string {
+	return append(p.db.RequiredFlags(), blockFlag)
+}
+
+func (p *rewindParams) rewind() error {
+	dbs, err := p.db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer dbs.Close()
+
+	p.head, p.removed, err = dbtool.Rewind(dbs.Blockchain, dbs.Trie, p.block)
+	if err != nil {
+		return err
+	}
+
+	// the IBFT snapshots of the removed blocks would be taken as the validators of the new blocks
+	return fork.RewindSnapshotFiles(filepath.Join(p.db.DataDir, secrets.ConsensusFolderLocal), p.block)
+}
+
+func (p *rewindParams) getResult() command.CommandResult {
+	return &RewindResult{
+		Head:      p.head.Number,
+		HeadHash:  p.head.Hash.String(),
+		StateRoot: p.head.StateRoot.String(),
+		Removed:   p.removed,
+	}
+}
@@ -0,0 +1,31 @@
+package rewind
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type RewindResult struct {
+	Head      uint64 `json:"head"`
+	HeadHash  string `json:"head_hash"`
+	StateRoot string `json:"state_root"`
+	Removed   uint64 `json:"removed"`
+}
+
+func (r *RewindResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB REWIND]\n")
+	buffer.WriteString("Rewound the head of the chain successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Removed blocks|%d", r.Removed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,55 @@
+package upgrade
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	dbHelper "github.com/ExzoNetwork/ExzoCoin/command/db/helper"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	params = &upgradeParams{}
+)
+
+type upgradeParams struct {
+	db dbHelper.DatabaseFlags
+
+	result *storage.SchemaUpgrade
+}
+
+func (p *upgradeParams) validateFlags() error {
+	return p.db.ValidateFlags()
+}
+
+func (p *upgradeParams) getRequiredFlags() []string {
+	return p.db.RequiredFlags()
+}
+
+func (p *upgradeParams) upgrade() error {
+	dbs, err := p.db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer dbs.Close()
+
+	p.result, err = dbs.Blockchain.UpgradeSchema(hclog.New(&hclog.LoggerOptions{
+		Name:  "db-upgrade",
+		Level: hclog.LevelFromString("INFO"),
+	}))
+
+	return err
+}
+
+func (p *upgradeParams) getResult() command.CommandResult {
+	migrations := p.result.Migrations
+	if migrations == nil {
+		migrations = []string{}
+	}
+
+	return &UpgradeResult{
+		From:       p.result.From,
+		To:         p.result.To,
+		Migrations: migrations,
+	}
+}
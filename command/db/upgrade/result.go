@@ -0,0 +1,41 @@
+package upgrade
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type UpgradeResult struct {
+	From       uint64   `json:"from"`
+	To         uint64   `json:"to"`
+	Migrations []string `json:"migrations"`
+}
+
+func (r *UpgradeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB UPGRADE]\n")
+
+	if len(r.Migrations) == 0 {
+		buffer.WriteString("The database has the current schema version:\n")
+	} else {
+		buffer.WriteString("Migrated the database schema successfully:\n")
+	}
+
+	vals := []string{
+		fmt.Sprintf("From version|%d", r.From),
+		fmt.Sprintf("To version|%d", r.To),
+	}
+
+	if len(r.Migrations) > 0 {
+		vals = append(vals, fmt.Sprintf("Migrations|%s", strings.Join(r.Migrations, ", ")))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,43 @@
+package upgrade
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	upgradeCmd := &cobra.Command{
+		Use: "upgrade",
+		Short: "Migrates the blockchain database of the stopped node to the current schema version, " +
+			"the node runs the same migrations on startup",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(upgradeCmd)
+	helper.SetRequiredFlags(upgradeCmd, params.getRequiredFlags())
+
+	return upgradeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	params.db.RegisterFlags(cmd)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.upgrade(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
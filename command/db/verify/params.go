@@ -0,0 +1,54 @@
+package verify
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	dbHelper "github.com/ExzoNetwork/ExzoCoin/command/db/helper"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+type verifyParams struct {
+	db dbHelper.DatabaseFlags
+
+	report *dbtool.ChainReport
+}
+
+func (p *verifyParams) validateFlags() error {
+	return p.db.ValidateFlags()
+}
+
+func (p *verifyParams) getRequiredFlags() []string {
+	return p.db.RequiredFlags()
+}
+
+func (p *verifyParams) verify() error {
+	dbs, err := p.db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer dbs.Close()
+
+	p.report, err = dbtool.VerifyChain(dbs.Blockchain, dbs.Trie)
+
+	return err
+}
+
+func (p *verifyParams) getResult() command.CommandResult {
+	result := &VerifyResult{
+		Head:      p.report.Head,
+		HeadHash:  p.report.HeadHash.String(),
+		Verified:  p.report.Verified,
+		StateRoot: p.report.StateRoot.String(),
+		HasState:  p.report.HasState,
+	}
+
+	if p.report.Err != nil {
+		result.Error = p.report.Err.Error()
+	}
+
+	return result
+}
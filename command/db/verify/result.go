@@ -0,0 +1,46 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type VerifyResult struct {
+	Head      uint64 `json:"head"`
+	HeadHash  string `json:"head_hash"`
+	Verified  uint64 `json:"verified_blocks"`
+	StateRoot string `json:"state_root"`
+	HasState  bool   `json:"has_state"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB VERIFY]\n")
+
+	if r.Error == "" {
+		buffer.WriteString("Verified the canonical chain successfully:\n")
+	} else {
+		buffer.WriteString("The database is inconsistent, the head can be rewound to a verified block:\n")
+	}
+
+	vals := []string{
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("Verified blocks|%d", r.Verified),
+		fmt.Sprintf("Head state root|%s", r.StateRoot),
+		fmt.Sprintf("Head state available|%t", r.HasState),
+	}
+
+	if r.Error != "" {
+		vals = append(vals, fmt.Sprintf("Error|%s", r.Error))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
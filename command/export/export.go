@@ -0,0 +1,99 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/export"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the blocks, transactions, receipts, logs and token transfers of a range " +
+			"into partitioned Parquet or CSV files, from the running node or the data directory of a stopped node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(exportCmd)
+
+	setFlags(exportCmd)
+	helper.SetRequiredFlags(exportCmd, params.getRequiredFlags())
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dir,
+		dirFlag,
+		"",
+		"the output directory, the files of a table are written into the subdirectory with its name",
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		export.FormatParquet,
+		"the format of the files, parquet or csv",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the first block of the export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block of the export (default the latest block)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.partitionSize,
+		partitionSizeFlag,
+		export.DefaultPartitionSize,
+		"the number of blocks of a file, the partitions are aligned to it",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.tables,
+		tablesFlag,
+		export.TableNames(),
+		fmt.Sprintf("the exported tables, any of %s", strings.Join(export.TableNames(), ",")),
+	)
+
+	// offline export from the databases of the stopped node
+	params.db.RegisterFlags(cmd)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain, used for the chain ID with data-dir",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.export(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,201 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	dbHelper "github.com/ExzoNetwork/ExzoCoin/command/db/helper"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/export"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	dirFlag           = "dir"
+	formatFlag        = "format"
+	fromFlag          = "from"
+	toFlag            = "to"
+	partitionSizeFlag = "partition-size"
+	tablesFlag        = "tables"
+	chainFlag         = "chain"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+)
+
+type exportParams struct {
+	dir           string
+	format        string
+	partitionSize uint64
+	tables        []string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	// offline export if the data directory is set
+	db          dbHelper.DatabaseFlags
+	genesisPath string
+
+	result *export.Result
+}
+
+func (p *exportParams) validateFlags() error {
+	var parseErr error
+
+	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = types.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	if p.partitionSize == 0 {
+		return export.ErrInvalidPartitionSize
+	}
+
+	if _, err := export.ParseFormat(p.format); err != nil {
+		return err
+	}
+
+	if p.isOffline() {
+		return p.db.ValidateFlags()
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		dirFlag,
+	}
+}
+
+func (p *exportParams) isOffline() bool {
+	return p.db.DataDir != ""
+}
+
+func (p *exportParams) export(grpcAddress string) error {
+	if p.isOffline() {
+		return p.exportOffline()
+	}
+
+	return p.exportOnline(grpcAddress)
+}
+
+// exportOffline exports the blocks from the databases of the stopped node
+func (p *exportParams) exportOffline() error {
+	genesisConfig, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.genesisPath, err)
+	}
+
+	dbs, err := p.db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer dbs.Close()
+
+	source, err := export.NewStorageSource(dbs.Blockchain, p.from, p.to)
+	if err != nil {
+		return err
+	}
+
+	return p.run(source, uint64(genesisConfig.Params.ChainID))
+}
+
+// exportOnline exports the blocks streamed by the running node
+func (p *exportParams) exportOnline(grpcAddress string) error {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	status, err := client.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+
+	source, err := export.NewGRPCSource(ctx, client, p.from, p.to)
+	if err != nil {
+		return err
+	}
+
+	return p.run(source, uint64(status.Network))
+}
+
+func (p *exportParams) run(source export.Source, chainID uint64) error {
+	defer source.Close()
+
+	var err error
+
+	p.result, err = export.Export(
+		source,
+		&export.Config{
+			Dir:           p.dir,
+			Format:        p.format,
+			Tables:        p.tables,
+			PartitionSize: p.partitionSize,
+			ChainID:       chainID,
+		},
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "export",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+	)
+
+	return err
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	result := &ExportResult{
+		Dir:    p.dir,
+		Format: p.format,
+		From:   p.result.From,
+		To:     p.result.To,
+		Blocks: p.result.Blocks,
+		Tables: make([]TableResult, 0, len(p.result.Rows)),
+	}
+
+	for _, table := range export.Tables {
+		rows, ok := p.result.Rows[table.Name]
+		if !ok {
+			continue
+		}
+
+		result.Tables = append(result.Tables, TableResult{
+			Name:  table.Name,
+			Rows:  rows,
+			Files: len(p.result.Files[table.Name]),
+		})
+	}
+
+	return result
+}
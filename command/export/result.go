@@ -0,0 +1,52 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type TableResult struct {
+	Name  string `json:"name"`
+	Rows  uint64 `json:"rows"`
+	Files int    `json:"files"`
+}
+
+type ExportResult struct {
+	Dir    string        `json:"dir"`
+	Format string        `json:"format"`
+	From   uint64        `json:"from"`
+	To     uint64        `json:"to"`
+	Blocks uint64        `json:"blocks"`
+	Tables []TableResult `json:"tables"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[EXPORT]\n")
+	buffer.WriteString("Exported blocks successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("Format|%s", r.Format),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Blocks|%d", r.Blocks),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[TABLES]\n")
+
+	rows := make([]string, 0, len(r.Tables)+1)
+	rows = append(rows, "Table|Rows|Files")
+
+	for _, table := range r.Tables {
+		rows = append(rows, fmt.Sprintf("%s|%d|%d", table.Name, table.Rows, table.Files))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -142,6 +142,27 @@ func setFlags(cmd *cobra.Command) {
 			"the maximum number of validators in the validator set for PoS",
 		)
 	}
+
+	// Governance
+	{
+		cmd.Flags().BoolVar(
+			&params.isGovernance,
+			governanceFlag,
+			false,
+			"the flag indicating that the governance contract should be predeployed "+
+				"and parameter changes approved by validators should be applied by IBFT",
+		)
+	}
+
+	// Key rotation
+	{
+		cmd.Flags().BoolVar(
+			&params.isKeyRotation,
+			keyRotationFlag,
+			false,
+			"the flag indicating that the PoA validators can register their successor keys in the headers",
+		)
+	}
 }
 
 // setLegacyFlags sets the legacy flags to preserve backwards compatibility
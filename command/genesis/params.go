@@ -10,6 +10,7 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft"
 	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/fork"
 	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
 	"github.com/ExzoNetwork/ExzoCoin/contracts/staking"
 	stakingHelper "github.com/ExzoNetwork/ExzoCoin/helper/staking"
 	"github.com/ExzoNetwork/ExzoCoin/server"
@@ -25,6 +26,8 @@ const (
 	epochSizeFlag     = "epoch-size"
 	blockGasLimitFlag = "block-gas-limit"
 	posFlag           = "pos"
+	governanceFlag    = "governance"
+	keyRotationFlag   = "key-rotation"
 	minValidatorCount = "min-validator-count"
 	maxValidatorCount = "max-validator-count"
 )
@@ -59,6 +62,8 @@ type genesisParams struct {
 	epochSize     uint64
 	blockGasLimit uint64
 	isPos         bool
+	isGovernance  bool
+	isKeyRotation bool
 
 	minNumValidators uint64
 	maxNumValidators uint64
@@ -260,12 +265,22 @@ func (p *genesisParams) initConsensusEngineConfig() {
 }
 
 func (p *genesisParams) initIBFTEngineMap(ibftType fork.IBFTType) {
+	ibftConfig := map[string]interface{}{
+		fork.KeyType:          ibftType,
+		fork.KeyValidatorType: p.ibftValidatorType,
+		ibft.KeyEpochSize:     p.epochSize,
+	}
+
+	if p.isGovernance {
+		ibftConfig[fork.KeyGovernance] = &fork.GovernanceConfig{}
+	}
+
+	if p.isKeyRotation {
+		ibftConfig[fork.KeyKeyRotation] = &fork.KeyRotationConfig{}
+	}
+
 	p.consensusEngineConfig = map[string]interface{}{
-		string(server.IBFTConsensus): map[string]interface{}{
-			fork.KeyType:          ibftType,
-			fork.KeyValidatorType: p.ibftValidatorType,
-			ibft.KeyEpochSize:     p.epochSize,
-		},
+		string(server.IBFTConsensus): ibftConfig,
 	}
 }
 
@@ -312,6 +327,11 @@ func (p *genesisParams) initGenesisConfig() error {
 		chainConfig.Genesis.Alloc[staking.AddrStakingContract] = stakingAccount
 	}
 
+	// Predeploy governance smart contract if needed
+	if p.shouldPredeployGovernanceSC() {
+		chainConfig.Genesis.Alloc[governance.AddrGovernanceContract] = governance.PredeployGovernanceSC()
+	}
+
 	// Premine accounts
 	if err := fillPremineMap(chainConfig.Genesis.Alloc, p.premine); err != nil {
 		return err
@@ -328,6 +348,11 @@ func (p *genesisParams) shouldPredeployStakingSC() bool {
 	return p.isPos && (p.consensus == server.IBFTConsensus || p.consensus == server.DevConsensus)
 }
 
+func (p *genesisParams) shouldPredeployGovernanceSC() bool {
+	// Governance is driven by the IBFT hooks
+	return p.isGovernance && p.consensus == server.IBFTConsensus
+}
+
 func (p *genesisParams) predeployStakingSC() (*chain.GenesisAccount, error) {
 	stakingAccount, predeployErr := stakingHelper.PredeployStakingSC(
 		p.ibftValidators,
@@ -0,0 +1,33 @@
+package governance
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command/governance/list"
+	"github.com/ExzoNetwork/ExzoCoin/command/governance/propose"
+	"github.com/ExzoNetwork/ExzoCoin/command/governance/vote"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	governanceCmd := &cobra.Command{
+		Use:   "governance",
+		Short: "Top level command for interacting with the on-chain governance. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(governanceCmd)
+
+	registerSubcommands(governanceCmd)
+
+	return governanceCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// governance propose
+		propose.GetCommand(),
+		// governance vote
+		vote.GetCommand(),
+		// governance list
+		list.GetCommand(),
+	)
+}
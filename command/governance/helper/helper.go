@@ -0,0 +1,137 @@
+package helper
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	secretsHelper "github.com/ExzoNetwork/ExzoCoin/secrets/helper"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	DataDirFlag = "data-dir"
+
+	// Gas limit of the transaction to the governance contract
+	callGasLimit uint64 = 100000
+)
+
+var (
+	ErrDataDirNotSet = errors.New("the directory of the validator secrets must be specified")
+)
+
+// NewJSONRPCClient creates a JSON-RPC client for the given address
+func NewJSONRPCClient(jsonrpcAddress string) (*jsonrpc.Client, error) {
+	if _, err := helper.ParseJSONRPCAddress(jsonrpcAddress); err != nil {
+		return nil, err
+	}
+
+	return jsonrpc.NewClient(jsonrpcAddress)
+}
+
+// SendGovernanceTx signs the call to the governance contract by the validator key in the data directory
+// and sends it to the node. It returns the address of the validator and the transaction hash
+func SendGovernanceTx(
+	jsonrpcAddress string,
+	dataDir string,
+	input []byte,
+) (types.Address, types.Hash, error) {
+	if dataDir == "" {
+		return types.ZeroAddress, types.ZeroHash, ErrDataDirNotSet
+	}
+
+	secretsManager, err := secretsHelper.SetupLocalSecretsManager(dataDir)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	key, err := crypto.ReadConsensusKey(secretsManager)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	client, err := NewJSONRPCClient(jsonrpcAddress)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	defer client.Close()
+
+	from := crypto.PubKeyToAddress(&key.PublicKey)
+
+	chainID, err := client.Eth().ChainID()
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	nonce, err := client.Eth().GetNonce(ethgo.Address(from), ethgo.Pending)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	gasPrice, err := client.Eth().GasPrice()
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	to := governance.AddrGovernanceContract
+
+	signedTx, err := crypto.NewEIP155Signer(chainID.Uint64()).SignTx(&types.Transaction{
+		Nonce:    nonce,
+		From:     from,
+		To:       &to,
+		Gas:      callGasLimit,
+		GasPrice: new(big.Int).SetUint64(gasPrice),
+		Value:    big.NewInt(0),
+		Input:    input,
+	}, key)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	hash, err := client.Eth().SendRawTransaction(signedTx.MarshalRLP())
+	if err != nil {
+		return types.ZeroAddress, types.ZeroHash, err
+	}
+
+	return from, types.Hash(hash), nil
+}
+
+// StorageReader reads the storage of the governance contract via JSON-RPC
+type StorageReader struct {
+	client *jsonrpc.Client
+	err    error
+}
+
+// NewStorageReader is a constructor of StorageReader
+func NewStorageReader(client *jsonrpc.Client) *StorageReader {
+	return &StorageReader{
+		client: client,
+	}
+}
+
+// GetState returns the storage value at the latest block
+// The first error is kept in the reader and subsequent calls return empty values
+func (r *StorageReader) GetState(addr types.Address, key types.Hash) types.Hash {
+	if r.err != nil {
+		return types.ZeroHash
+	}
+
+	value, err := r.client.Eth().GetStorageAt(ethgo.Address(addr), ethgo.Hash(key), ethgo.Latest)
+	if err != nil {
+		r.err = err
+
+		return types.ZeroHash
+	}
+
+	return types.Hash(value)
+}
+
+// Err returns the first error occurred in GetState
+func (r *StorageReader) Err() error {
+	return r.err
+}
@@ -0,0 +1,49 @@
+package list
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	govHelper "github.com/ExzoNetwork/ExzoCoin/command/governance/helper"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the proposals recorded in the governance contract",
+		Run:   runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	proposals, err := getProposals(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newGovernanceListResult(proposals))
+}
+
+func getProposals(jsonrpcAddress string) ([]*governance.Proposal, error) {
+	client, err := govHelper.NewJSONRPCClient(jsonrpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	defer client.Close()
+
+	reader := govHelper.NewStorageReader(client)
+	proposals := governance.GetProposals(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
@@ -0,0 +1,87 @@
+package list
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+)
+
+type Proposal struct {
+	ID         uint64 `json:"id"`
+	Kind       string `json:"kind"`
+	Fork       string `json:"fork,omitempty"`
+	Value      uint64 `json:"value"`
+	Proposer   string `json:"proposer"`
+	Votes      uint64 `json:"votes"`
+	Status     string `json:"status"`
+	ProposedAt uint64 `json:"proposed_at"`
+}
+
+type GovernanceListResult struct {
+	Proposals []Proposal `json:"proposals"`
+}
+
+func newGovernanceListResult(proposals []*governance.Proposal) *GovernanceListResult {
+	res := &GovernanceListResult{
+		Proposals: make([]Proposal, len(proposals)),
+	}
+
+	for i, p := range proposals {
+		res.Proposals[i] = Proposal{
+			ID:         p.ID,
+			Kind:       p.Kind.String(),
+			Value:      p.Value,
+			Proposer:   p.Proposer.String(),
+			Votes:      p.Votes,
+			Status:     p.Status.String(),
+			ProposedAt: p.ProposedAt,
+		}
+
+		if p.Kind == governance.KindFork {
+			res.Proposals[i].Fork = p.ForkName()
+		}
+	}
+
+	return res
+}
+
+func (r *GovernanceListResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GOVERNANCE PROPOSALS]\n")
+
+	if len(r.Proposals) == 0 {
+		buffer.WriteString("No proposals found")
+		buffer.WriteString("\n")
+
+		return buffer.String()
+	}
+
+	rows := make([]string, len(r.Proposals)+1)
+	rows[0] = "ID|Kind|Value|Proposer|Votes|Status|Proposed At"
+
+	for i, p := range r.Proposals {
+		kind := p.Kind
+		if p.Fork != "" {
+			kind = fmt.Sprintf("%s (%s)", p.Kind, p.Fork)
+		}
+
+		rows[i+1] = fmt.Sprintf(
+			"%d|%s|%d|%s|%d|%s|%d",
+			p.ID,
+			kind,
+			p.Value,
+			p.Proposer,
+			p.Votes,
+			p.Status,
+			p.ProposedAt,
+		)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
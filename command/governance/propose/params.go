@@ -0,0 +1,104 @@
+package propose
+
+import (
+	"errors"
+
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	govHelper "github.com/ExzoNetwork/ExzoCoin/command/governance/helper"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	kindFlag  = "kind"
+	valueFlag = "value"
+	forkFlag  = "fork"
+)
+
+var (
+	errForkNotSpecified   = errors.New("fork name must be specified for fork proposal")
+	errForkNotExpected    = errors.New("fork name can be specified only for fork proposal")
+	errUnknownFork        = errors.New("unknown fork name")
+	errZeroProposalValue  = errors.New("proposal value must be greater than 0")
+	errInvalidKindFlagVal = errors.New("invalid proposal kind")
+)
+
+var (
+	params = &proposeParams{}
+)
+
+type proposeParams struct {
+	dataDir  string
+	kindRaw  string
+	forkName string
+	value    uint64
+
+	kind governance.ProposalKind
+
+	from   types.Address
+	txHash types.Hash
+}
+
+func (p *proposeParams) getRequiredFlags() []string {
+	return []string{
+		govHelper.DataDirFlag,
+		kindFlag,
+		valueFlag,
+	}
+}
+
+func (p *proposeParams) validateFlags() error {
+	kind, err := governance.ParseProposalKind(p.kindRaw)
+	if err != nil {
+		return errInvalidKindFlagVal
+	}
+
+	p.kind = kind
+
+	if p.value == 0 {
+		return errZeroProposalValue
+	}
+
+	if p.kind != governance.KindFork {
+		if p.forkName != "" {
+			return errForkNotExpected
+		}
+
+		return nil
+	}
+
+	if p.forkName == "" {
+		return errForkNotSpecified
+	}
+
+	if !governance.IsKnownFork(p.forkName) {
+		return errUnknownFork
+	}
+
+	return nil
+}
+
+func (p *proposeParams) propose(jsonrpcAddress string) error {
+	input, err := governance.EncodeProposeCall(
+		p.kind,
+		governance.ForkNameToKey(p.forkName),
+		p.value,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.from, p.txHash, err = govHelper.SendGovernanceTx(jsonrpcAddress, p.dataDir, input)
+
+	return err
+}
+
+func (p *proposeParams) getResult() command.CommandResult {
+	return &GovernanceProposeResult{
+		Proposer: p.from.String(),
+		Kind:     p.kind.String(),
+		Fork:     p.forkName,
+		Value:    p.value,
+		TxHash:   p.txHash.String(),
+	}
+}
@@ -0,0 +1,83 @@
+package propose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	govHelper "github.com/ExzoNetwork/ExzoCoin/command/governance/helper"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	proposeCmd := &cobra.Command{
+		Use:     "propose",
+		Short:   "Submits a proposal to change a chain parameter to the governance contract",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(proposeCmd)
+
+	helper.SetRequiredFlags(proposeCmd, params.getRequiredFlags())
+
+	return proposeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		govHelper.DataDirFlag,
+		"",
+		"the directory for the validator data, the proposal is signed by its validator key",
+	)
+
+	cmd.Flags().StringVar(
+		&params.kindRaw,
+		kindFlag,
+		"",
+		fmt.Sprintf(
+			"the kind of the proposal. Possible values: [%s]",
+			strings.Join([]string{
+				governance.KindBlockGasTarget.String(),
+				governance.KindMinValidatorCount.String(),
+				governance.KindMaxValidatorCount.String(),
+				governance.KindFork.String(),
+				governance.KindEpochSize.String(),
+			}, ", "),
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.value,
+		valueFlag,
+		0,
+		"the new value of the parameter, or the activation block for fork proposal",
+	)
+
+	cmd.Flags().StringVar(
+		&params.forkName,
+		forkFlag,
+		"",
+		"the name of the fork to be scheduled, only for fork proposal",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.propose(helper.GetJSONRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
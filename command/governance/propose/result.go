@@ -0,0 +1,41 @@
+package propose
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type GovernanceProposeResult struct {
+	Proposer string `json:"proposer"`
+	Kind     string `json:"kind"`
+	Fork     string `json:"fork,omitempty"`
+	Value    uint64 `json:"value"`
+	TxHash   string `json:"tx_hash"`
+}
+
+func (r *GovernanceProposeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Proposer|%s", r.Proposer),
+		fmt.Sprintf("Kind|%s", r.Kind),
+	}
+
+	if r.Fork != "" {
+		vals = append(vals, fmt.Sprintf("Fork|%s", r.Fork))
+	}
+
+	vals = append(
+		vals,
+		fmt.Sprintf("Value|%d", r.Value),
+		fmt.Sprintf("Transaction Hash|%s", r.TxHash),
+	)
+
+	buffer.WriteString("\n[GOVERNANCE PROPOSE]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
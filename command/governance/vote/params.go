@@ -0,0 +1,50 @@
+package vote
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	govHelper "github.com/ExzoNetwork/ExzoCoin/command/governance/helper"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	idFlag = "id"
+)
+
+var (
+	params = &voteParams{}
+)
+
+type voteParams struct {
+	dataDir    string
+	proposalID uint64
+
+	from   types.Address
+	txHash types.Hash
+}
+
+func (p *voteParams) getRequiredFlags() []string {
+	return []string{
+		govHelper.DataDirFlag,
+		idFlag,
+	}
+}
+
+func (p *voteParams) vote(jsonrpcAddress string) error {
+	input, err := governance.EncodeVoteCall(p.proposalID)
+	if err != nil {
+		return err
+	}
+
+	p.from, p.txHash, err = govHelper.SendGovernanceTx(jsonrpcAddress, p.dataDir, input)
+
+	return err
+}
+
+func (p *voteParams) getResult() command.CommandResult {
+	return &GovernanceVoteResult{
+		Voter:      p.from.String(),
+		ProposalID: p.proposalID,
+		TxHash:     p.txHash.String(),
+	}
+}
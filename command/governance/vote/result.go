@@ -0,0 +1,28 @@
+package vote
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type GovernanceVoteResult struct {
+	Voter      string `json:"voter"`
+	ProposalID uint64 `json:"proposal_id"`
+	TxHash     string `json:"tx_hash"`
+}
+
+func (r *GovernanceVoteResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GOVERNANCE VOTE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Voter|%s", r.Voter),
+		fmt.Sprintf("Proposal ID|%d", r.ProposalID),
+		fmt.Sprintf("Transaction Hash|%s", r.TxHash),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,51 @@
+package vote
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	govHelper "github.com/ExzoNetwork/ExzoCoin/command/governance/helper"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	voteCmd := &cobra.Command{
+		Use:   "vote",
+		Short: "Votes for the pending proposal in the governance contract",
+		Run:   runCommand,
+	}
+
+	setFlags(voteCmd)
+
+	helper.SetRequiredFlags(voteCmd, params.getRequiredFlags())
+
+	return voteCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		govHelper.DataDirFlag,
+		"",
+		"the directory for the validator data, the vote is signed by its validator key",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.proposalID,
+		idFlag,
+		0,
+		"the ID of the proposal to vote for",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.vote(helper.GetJSONRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
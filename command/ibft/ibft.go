@@ -4,7 +4,9 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/command/helper"
 	"github.com/ExzoNetwork/ExzoCoin/command/ibft/candidates"
 	"github.com/ExzoNetwork/ExzoCoin/command/ibft/propose"
+	"github.com/ExzoNetwork/ExzoCoin/command/ibft/protection"
 	"github.com/ExzoNetwork/ExzoCoin/command/ibft/quorum"
+	"github.com/ExzoNetwork/ExzoCoin/command/ibft/rotatekey"
 	"github.com/ExzoNetwork/ExzoCoin/command/ibft/snapshot"
 	"github.com/ExzoNetwork/ExzoCoin/command/ibft/status"
 	_switch "github.com/ExzoNetwork/ExzoCoin/command/ibft/switch"
@@ -38,5 +40,9 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		_switch.GetCommand(),
 		// ibft quorum
 		quorum.GetCommand(),
+		// ibft protection
+		protection.GetCommand(),
+		// ibft rotate-key
+		rotatekey.GetCommand(),
 	)
 }
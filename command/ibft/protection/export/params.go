@@ -0,0 +1,73 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	protectionHelper "github.com/ExzoNetwork/ExzoCoin/command/ibft/protection/helper"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+)
+
+const (
+	outFlag = "out"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errOutNotSet = errors.New("the output file must be specified")
+)
+
+type exportParams struct {
+	dataDir string
+	out     string
+
+	validators int
+	records    int
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		protectionHelper.DataDirFlag,
+		outFlag,
+	}
+}
+
+func (p *exportParams) validateFlags() error {
+	if p.dataDir == "" {
+		return protectionHelper.ErrDataDirNotSet
+	}
+
+	if p.out == "" {
+		return errOutNotSet
+	}
+
+	return nil
+}
+
+func (p *exportParams) exportData() error {
+	data, err := signer.ReadSlashingProtectionData(
+		protectionHelper.GetSlashingProtectionPath(p.dataDir),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := signer.WriteSlashingProtectionData(p.out, data); err != nil {
+		return err
+	}
+
+	p.validators, p.records = protectionHelper.CountRecords(data)
+
+	return nil
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		Out:        p.out,
+		Validators: p.validators,
+		Records:    p.records,
+	}
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type ExportResult struct {
+	Out        string `json:"out"`
+	Validators int    `json:"validators"`
+	Records    int    `json:"records"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SLASHING PROTECTION EXPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Out|%s", r.Out),
+		fmt.Sprintf("Validators|%d", r.Validators),
+		fmt.Sprintf("Records|%d", r.Records),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/secrets"
+)
+
+const (
+	DataDirFlag = "data-dir"
+)
+
+var (
+	ErrDataDirNotSet = errors.New("the data directory of the node must be specified")
+)
+
+// GetSlashingProtectionPath returns the path of the slashing protection database in the data directory
+func GetSlashingProtectionPath(dataDir string) string {
+	return filepath.Join(dataDir, secrets.ConsensusFolderLocal, signer.SlashingProtectionFileName)
+}
+
+// CountRecords returns the number of validators and records in the slashing protection data
+func CountRecords(data *signer.SlashingProtectionData) (int, int) {
+	records := 0
+
+	for _, validatorRecords := range data.Validators {
+		records += len(validatorRecords)
+	}
+
+	return len(data.Validators), records
+}
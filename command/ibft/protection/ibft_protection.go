@@ -0,0 +1,28 @@
+package protection
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command/ibft/protection/export"
+	_import "github.com/ExzoNetwork/ExzoCoin/command/ibft/protection/import"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	protectionCmd := &cobra.Command{
+		Use: "protection",
+		Short: "Manages the slashing protection database of the validators in the data directory. " +
+			"Only accepts subcommands.",
+	}
+
+	registerSubcommands(protectionCmd)
+
+	return protectionCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// ibft protection export
+		export.GetCommand(),
+		// ibft protection import
+		_import.GetCommand(),
+	)
+}
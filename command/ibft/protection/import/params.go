@@ -0,0 +1,87 @@
+package protectionimport
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	protectionHelper "github.com/ExzoNetwork/ExzoCoin/command/ibft/protection/helper"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+)
+
+const (
+	fileFlag = "file"
+)
+
+var (
+	params = &importParams{}
+)
+
+var (
+	errFileNotSet = errors.New("the file to import must be specified")
+)
+
+type importParams struct {
+	dataDir string
+	file    string
+
+	validators int
+	records    int
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		protectionHelper.DataDirFlag,
+		fileFlag,
+	}
+}
+
+func (p *importParams) validateFlags() error {
+	if p.dataDir == "" {
+		return protectionHelper.ErrDataDirNotSet
+	}
+
+	if p.file == "" {
+		return errFileNotSet
+	}
+
+	return nil
+}
+
+// importData merges the given data into the slashing protection database in the data directory
+func (p *importParams) importData() error {
+	// ReadSlashingProtectionData returns empty data for missing file
+	if _, err := os.Stat(p.file); err != nil {
+		return err
+	}
+
+	imported, err := signer.ReadSlashingProtectionData(p.file)
+	if err != nil {
+		return err
+	}
+
+	path := protectionHelper.GetSlashingProtectionPath(p.dataDir)
+
+	data, err := signer.ReadSlashingProtectionData(path)
+	if err != nil {
+		return err
+	}
+
+	data.Merge(imported)
+
+	if err := signer.WriteSlashingProtectionData(path, data); err != nil {
+		return err
+	}
+
+	p.validators, p.records = protectionHelper.CountRecords(imported)
+
+	return nil
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &ImportResult{
+		File:       p.file,
+		Validators: p.validators,
+		Records:    p.records,
+	}
+}
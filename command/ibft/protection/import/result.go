@@ -0,0 +1,28 @@
+package protectionimport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type ImportResult struct {
+	File       string `json:"file"`
+	Validators int    `json:"validators"`
+	Records    int    `json:"records"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SLASHING PROTECTION IMPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Validators|%d", r.Validators),
+		fmt.Sprintf("Records|%d", r.Records),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
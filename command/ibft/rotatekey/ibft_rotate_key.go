@@ -0,0 +1,45 @@
+package rotatekey
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftRotateKeyCmd := &cobra.Command{
+		Use: "rotate-key",
+		Short: "Schedules the validator key to be switched to the successor key from the given height. " +
+			"The successor keys are created in the secrets manager if they don't exist",
+		Run: runCommand,
+	}
+
+	setFlags(ibftRotateKeyCmd)
+
+	helper.SetRequiredFlags(ibftRotateKeyCmd, params.getRequiredFlags())
+
+	return ibftRotateKeyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.height,
+		heightFlag,
+		0,
+		"the height from which the validator signs with the successor key. "+
+			"The validator keeps its key if the successor hasn't been registered by the height",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rotateKey(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,65 @@
+package rotatekey
+
+import (
+	"context"
+
+	"github.com/ExzoNetwork/ExzoCoin/command"
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	ibftOp "github.com/ExzoNetwork/ExzoCoin/consensus/ibft/proto"
+	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+)
+
+const (
+	heightFlag = "height"
+)
+
+var (
+	params = &rotateKeyParams{}
+)
+
+type rotateKeyParams struct {
+	height uint64
+
+	keyRotation *ibftOp.KeyRotation
+}
+
+func (p *rotateKeyParams) getRequiredFlags() []string {
+	return []string{
+		heightFlag,
+	}
+}
+
+func (p *rotateKeyParams) rotateKey(grpcAddress string) error {
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	keyRotation, err := ibftClient.RotateKey(
+		context.Background(),
+		&ibftOp.KeyRotationReq{
+			Height: p.height,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.keyRotation = keyRotation
+
+	return nil
+}
+
+func (p *rotateKeyParams) getResult() command.CommandResult {
+	res := &IBFTRotateKeyResult{
+		Validator: p.keyRotation.Validator,
+		Successor: p.keyRotation.Successor,
+		Height:    p.keyRotation.Height,
+	}
+
+	if len(p.keyRotation.BlsPubkey) > 0 {
+		res.BLSPublicKey = hex.EncodeToHex(p.keyRotation.BlsPubkey)
+	}
+
+	return res
+}
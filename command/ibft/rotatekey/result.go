@@ -0,0 +1,37 @@
+package rotatekey
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+)
+
+type IBFTRotateKeyResult struct {
+	Validator    string `json:"validator"`
+	Successor    string `json:"successor"`
+	BLSPublicKey string `json:"bls_pubkey,omitempty"`
+	Height       uint64 `json:"height"`
+}
+
+func (r *IBFTRotateKeyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[IBFT ROTATE KEY]\n")
+
+	vals := []string{
+		fmt.Sprintf("Validator|%s", r.Validator),
+		fmt.Sprintf("Successor|%s", r.Successor),
+	}
+
+	if r.BLSPublicKey != "" {
+		vals = append(vals, fmt.Sprintf("BLS Public Key|%s", r.BLSPublicKey))
+	}
+
+	vals = append(vals, fmt.Sprintf("Height|%d", r.Height))
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
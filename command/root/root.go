@@ -5,7 +5,10 @@ import (
 	"os"
 
 	"github.com/ExzoNetwork/ExzoCoin/command/backup"
+	"github.com/ExzoNetwork/ExzoCoin/command/db"
+	"github.com/ExzoNetwork/ExzoCoin/command/export"
 	"github.com/ExzoNetwork/ExzoCoin/command/genesis"
+	"github.com/ExzoNetwork/ExzoCoin/command/governance"
 	"github.com/ExzoNetwork/ExzoCoin/command/helper"
 	"github.com/ExzoNetwork/ExzoCoin/command/ibft"
 	"github.com/ExzoNetwork/ExzoCoin/command/license"
@@ -49,7 +52,10 @@ func (rc *RootCommand) registerSubCommands() {
 		monitor.GetCommand(),
 		loadbot.GetCommand(),
 		ibft.GetCommand(),
+		governance.GetCommand(),
 		backup.GetCommand(),
+		db.GetCommand(),
+		export.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
 		whitelist.GetCommand(),
@@ -14,23 +14,44 @@ import (
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
-	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
-	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
-	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
-	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
-	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
-	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
-	Network                  *Network   `json:"network" yaml:"network"`
-	ShouldSeal               bool       `json:"seal" yaml:"seal"`
-	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
-	LogLevel                 string     `json:"log_level" yaml:"log_level"`
-	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
-	BlockTime                uint64     `json:"block_time_s" yaml:"block_time_s"`
-	Headers                  *Headers   `json:"headers" yaml:"headers"`
-	LogFilePath              string     `json:"log_to" yaml:"log_to"`
-	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
-	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	GenesisPath              string         `json:"chain_config" yaml:"chain_config"`
+	SecretsConfigPath        string         `json:"secrets_config" yaml:"secrets_config"`
+	DataDir                  string         `json:"data_dir" yaml:"data_dir"`
+	DBEngine                 string         `json:"db_engine" yaml:"db_engine"`
+	AncientDir               string         `json:"ancient_dir" yaml:"ancient_dir"`
+	AncientThreshold         uint64         `json:"ancient_threshold" yaml:"ancient_threshold"`
+	AncientCompression       bool           `json:"ancient_compression" yaml:"ancient_compression"`
+	BlockGasTarget           string         `json:"block_gas_target" yaml:"block_gas_target"`
+	GRPCAddr                 string         `json:"grpc_addr" yaml:"grpc_addr"`
+	JSONRPCAddr              string         `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	Telemetry                *Telemetry     `json:"telemetry" yaml:"telemetry"`
+	Network                  *Network       `json:"network" yaml:"network"`
+	ShouldSeal               bool           `json:"seal" yaml:"seal"`
+	Light                    bool           `json:"light" yaml:"light"`
+	RecordPreimages          bool           `json:"record_preimages" yaml:"record_preimages"`
+	TxPool                   *TxPool        `json:"tx_pool" yaml:"tx_pool"`
+	LogLevel                 string         `json:"log_level" yaml:"log_level"`
+	RestoreFile              string         `json:"restore_file" yaml:"restore_file"`
+	BackupDir                string         `json:"backup_dir" yaml:"backup_dir"`
+	BackupIntervalBlocks     uint64         `json:"backup_interval_blocks" yaml:"backup_interval_blocks"`
+	BackupIntervalHours      uint64         `json:"backup_interval_h" yaml:"backup_interval_h"`
+	BackupRetention          uint64         `json:"backup_retention" yaml:"backup_retention"`
+	BlockTime                uint64         `json:"block_time_s" yaml:"block_time_s"`
+	Headers                  *Headers       `json:"headers" yaml:"headers"`
+	LogFilePath              string         `json:"log_to" yaml:"log_to"`
+	JSONRPCBatchRequestLimit uint64         `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
+	JSONRPCBlockRangeLimit   uint64         `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCAccess            *JSONRPCAccess `json:"json_rpc_access,omitempty" yaml:"json_rpc_access,omitempty"`
+	JSONRPCAdminAddr         string         `json:"json_rpc_admin_addr" yaml:"json_rpc_admin_addr"`
+	JSONRPCJWTSecretPath     string         `json:"json_rpc_jwt_secret" yaml:"json_rpc_jwt_secret"`
+	GraphQL                  bool           `json:"graphql" yaml:"graphql"`
+	GraphQLMaxDepth          uint64         `json:"graphql_max_depth" yaml:"graphql_max_depth"`
+	GraphQLMaxComplexity     uint64         `json:"graphql_max_complexity" yaml:"graphql_max_complexity"`
+	WSSendQueueSize          uint64         `json:"ws_send_queue_size" yaml:"ws_send_queue_size"`
+	WSDisconnectSlow         bool           `json:"ws_disconnect_slow" yaml:"ws_disconnect_slow"`
+	WSPingInterval           uint64         `json:"ws_ping_interval_s" yaml:"ws_ping_interval_s"`
+	RemoteSignerURL          string         `json:"remote_signer" yaml:"remote_signer"`
+	RemoteSignerAddress      string         `json:"remote_signer_address" yaml:"remote_signer_address"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -56,12 +77,46 @@ type TxPool struct {
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
 }
 
+// JSONRPCAccess defines the rate limits and method access of the JSON-RPC server
+type JSONRPCAccess struct {
+	IPRateLimit       *RateLimit                  `json:"ip_rate_limit" yaml:"ip_rate_limit"`
+	APIKeys           map[string]*RateLimit       `json:"api_keys" yaml:"api_keys"`
+	RequireAPIKey     bool                        `json:"require_api_key" yaml:"require_api_key"`
+	MethodCosts       map[string]int              `json:"method_costs" yaml:"method_costs"`
+	Namespaces        map[string]*NamespaceAccess `json:"namespaces" yaml:"namespaces"`
+	TrustForwardedFor bool                        `json:"trust_forwarded_for" yaml:"trust_forwarded_for"`
+}
+
+// RateLimit defines the token bucket of the requests, rate is the tokens refilled per second
+type RateLimit struct {
+	Rate  float64 `json:"rate" yaml:"rate"`
+	Burst int     `json:"burst" yaml:"burst"`
+}
+
+// NamespaceAccess defines the allowed and denied methods in a JSON-RPC namespace
+type NamespaceAccess struct {
+	Allow []string `json:"allow" yaml:"allow"`
+	Deny  []string `json:"deny" yaml:"deny"`
+}
+
 // Headers defines the HTTP response headers required to enable CORS.
 type Headers struct {
 	AccessControlAllowOrigins []string `json:"access_control_allow_origins" yaml:"access_control_allow_origins"`
 }
 
 const (
+	// DefaultDBEngine is the database engine of the blockchain and the state
+	DefaultDBEngine = "leveldb"
+
+	// DefaultAncientDir is the directory of the ancient store of the finalized blocks in the data directory
+	DefaultAncientDir = "ancient"
+
+	// DefaultAncientThreshold is the depth below the head from which the blocks are moved to the ancient store
+	DefaultAncientThreshold uint64 = 90000
+
+	// DefaultBackupIntervalHours interval of the incremental backups in hours
+	DefaultBackupIntervalHours uint64 = 24
+
 	// DefaultBlockTime minimum block generation time in seconds
 	DefaultBlockTime uint64 = 3
 
@@ -75,6 +130,22 @@ const (
 	// DefaultJSONRPCBlockRangeLimit maximum block range allowed for json_rpc
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
+
+	// DefaultJSONRPCJWTSecretFile is the file name of the JWT secret of the admin listener in the data directory
+	DefaultJSONRPCJWTSecretFile = "jwt.hex"
+
+	// DefaultGraphQLMaxDepth maximum nesting of the selection sets allowed for graphql queries
+	DefaultGraphQLMaxDepth uint64 = 12
+
+	// DefaultGraphQLMaxComplexity maximum number of the fields resolved by a graphql query,
+	// counting the fields of every list item
+	DefaultGraphQLMaxComplexity uint64 = 50000
+
+	// DefaultWSSendQueueSize number of the outgoing messages buffered per websocket connection
+	DefaultWSSendQueueSize uint64 = 256
+
+	// DefaultWSPingInterval interval of the keepalive pings of the websocket connections in seconds
+	DefaultWSPingInterval uint64 = 30
 )
 
 // DefaultConfig returns the default server configuration
@@ -82,9 +153,12 @@ func DefaultConfig() *Config {
 	defaultNetworkConfig := network.DefaultConfig()
 
 	return &Config{
-		GenesisPath:    "./genesis.json",
-		DataDir:        "",
-		BlockGasTarget: "0x0", // Special value signaling the parent gas limit should be applied
+		GenesisPath:         "./genesis.json",
+		DataDir:             "",
+		DBEngine:            DefaultDBEngine,
+		AncientThreshold:    DefaultAncientThreshold,
+		BackupIntervalHours: DefaultBackupIntervalHours,
+		BlockGasTarget:      "0x0", // Special value signaling the parent gas limit should be applied
 		Network: &Network{
 			NoDiscover:       defaultNetworkConfig.NoDiscover,
 			MaxPeers:         defaultNetworkConfig.MaxPeers,
@@ -111,6 +185,10 @@ func DefaultConfig() *Config {
 		LogFilePath:              "",
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
+		GraphQLMaxDepth:          DefaultGraphQLMaxDepth,
+		GraphQLMaxComplexity:     DefaultGraphQLMaxComplexity,
+		WSSendQueueSize:          DefaultWSSendQueueSize,
+		WSPingInterval:           DefaultWSPingInterval,
 	}
 }
 
@@ -12,6 +12,8 @@ import (
 
 	"github.com/ExzoNetwork/ExzoCoin/chain"
 	"github.com/ExzoNetwork/ExzoCoin/command/helper"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/jsonrpc"
 	"github.com/ExzoNetwork/ExzoCoin/network"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
 	"github.com/ExzoNetwork/ExzoCoin/server"
@@ -21,6 +23,9 @@ import (
 var (
 	errInvalidBlockTime       = errors.New("invalid block time specified")
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errInvalidDBEngine        = errors.New("invalid database engine specified")
+	errInvalidBackupInterval  = errors.New("backup directory specified without a backup interval")
+	errLightModeConflict      = errors.New("light mode can't be used with dev mode, restore or backups")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -42,6 +47,10 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initRemoteSignerConfig(); err != nil {
+		return err
+	}
+
 	if err := p.initGenesisConfig(); err != nil {
 		return err
 	}
@@ -50,14 +59,30 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initDBEngine(); err != nil {
+		return err
+	}
+
 	if err := p.initBlockTime(); err != nil {
 		return err
 	}
 
+	if err := p.initBackupInterval(); err != nil {
+		return err
+	}
+
+	if err := p.initLightMode(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
 
+	if err := p.initJSONRPCAccess(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
@@ -72,6 +97,30 @@ func (p *serverParams) initBlockTime() error {
 	return nil
 }
 
+func (p *serverParams) initBackupInterval() error {
+	if p.rawConfig.BackupDir != "" &&
+		p.rawConfig.BackupIntervalBlocks == 0 && p.rawConfig.BackupIntervalHours == 0 {
+		return errInvalidBackupInterval
+	}
+
+	return nil
+}
+
+// initLightMode turns off the sealing of the light node, which has no state to build the blocks on
+func (p *serverParams) initLightMode() error {
+	if !p.rawConfig.Light {
+		return nil
+	}
+
+	if p.isDevMode || p.rawConfig.RestoreFile != "" || p.rawConfig.BackupDir != "" {
+		return errLightModeConflict
+	}
+
+	p.rawConfig.ShouldSeal = false
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -80,6 +129,14 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initDBEngine() error {
+	if !server.DBEngineSupported(p.rawConfig.DBEngine) {
+		return errInvalidDBEngine
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -114,6 +171,89 @@ func (p *serverParams) initSecretsConfig() error {
 	return nil
 }
 
+func (p *serverParams) initRemoteSignerConfig() error {
+	if !p.isRemoteSignerSet() {
+		return nil
+	}
+
+	p.remoteSigner = &signer.RemoteSignerConfig{
+		URL: p.rawConfig.RemoteSignerURL,
+	}
+
+	if p.rawConfig.RemoteSignerAddress != "" {
+		if err := p.remoteSigner.Address.UnmarshalText(
+			[]byte(p.rawConfig.RemoteSignerAddress),
+		); err != nil {
+			return fmt.Errorf("invalid remote signer address, %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCAccess() error {
+	raw := p.rawConfig.JSONRPCAccess
+	if raw == nil {
+		return nil
+	}
+
+	access := &jsonrpc.AccessConfig{
+		APIKeys:           make(map[string]*jsonrpc.RateLimit, len(raw.APIKeys)),
+		RequireAPIKey:     raw.RequireAPIKey,
+		MethodCosts:       raw.MethodCosts,
+		Namespaces:        make(map[string]*jsonrpc.NamespaceAccess, len(raw.Namespaces)),
+		TrustForwardedFor: raw.TrustForwardedFor,
+	}
+
+	var err error
+
+	if access.IPLimit, err = toJSONRPCRateLimit(raw.IPRateLimit); err != nil {
+		return fmt.Errorf("invalid JSON-RPC IP rate limit, %w", err)
+	}
+
+	for key, limit := range raw.APIKeys {
+		if access.APIKeys[key], err = toJSONRPCRateLimit(limit); err != nil {
+			return fmt.Errorf("invalid JSON-RPC rate limit of API key, %w", err)
+		}
+	}
+
+	for method, cost := range raw.MethodCosts {
+		if cost < 1 {
+			return fmt.Errorf("invalid JSON-RPC cost of %s, cost must be positive", method)
+		}
+	}
+
+	for namespace, lists := range raw.Namespaces {
+		if lists == nil {
+			continue
+		}
+
+		access.Namespaces[namespace] = &jsonrpc.NamespaceAccess{
+			Allow: lists.Allow,
+			Deny:  lists.Deny,
+		}
+	}
+
+	p.jsonRPCAccess = access
+
+	return nil
+}
+
+func toJSONRPCRateLimit(limit *config.RateLimit) (*jsonrpc.RateLimit, error) {
+	if limit == nil {
+		return nil, nil
+	}
+
+	if limit.Rate <= 0 || limit.Burst < 1 {
+		return nil, errors.New("rate and burst must be positive")
+	}
+
+	return &jsonrpc.RateLimit{
+		Rate:  limit.Rate,
+		Burst: limit.Burst,
+	}, nil
+}
+
 func (p *serverParams) initGenesisConfig() error {
 	var parseErr error
 
@@ -229,6 +369,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initJSONRPCAdminAddress(); err != nil {
+		return err
+	}
+
 	return p.initGRPCAddress()
 }
 
@@ -305,6 +449,23 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+func (p *serverParams) initJSONRPCAdminAddress() error {
+	if !p.isJSONRPCAdminAddrSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.jsonRPCAdminAddr, parseErr = helper.ResolveAddr(
+		p.rawConfig.JSONRPCAdminAddr,
+		helper.LocalHostBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
@@ -3,9 +3,14 @@ package server
 import (
 	"errors"
 	"net"
+	"path/filepath"
+	"time"
 
+	"github.com/ExzoNetwork/ExzoCoin/archive"
 	"github.com/ExzoNetwork/ExzoCoin/chain"
 	"github.com/ExzoNetwork/ExzoCoin/command/server/config"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/jsonrpc"
 	"github.com/ExzoNetwork/ExzoCoin/network"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
 	"github.com/ExzoNetwork/ExzoCoin/server"
@@ -17,27 +22,47 @@ const (
 	configFlag                   = "config"
 	genesisPathFlag              = "chain"
 	dataDirFlag                  = "data-dir"
+	dbEngineFlag                 = "db-engine"
+	ancientDirFlag               = "ancient-dir"
+	ancientThresholdFlag         = "ancient-threshold"
+	ancientCompressionFlag       = "ancient-compression"
 	libp2pAddressFlag            = "libp2p"
 	prometheusAddressFlag        = "prometheus"
 	natFlag                      = "nat"
 	dnsFlag                      = "dns"
 	sealFlag                     = "seal"
+	lightFlag                    = "light"
+	recordPreimagesFlag          = "record-preimages"
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCAdminFlag             = "json-rpc-admin"
+	jsonRPCJWTSecretFlag         = "json-rpc-jwt-secret"
+	graphQLFlag                  = "graphql"
+	graphQLMaxDepthFlag          = "graphql-max-depth"
+	graphQLMaxComplexityFlag     = "graphql-max-complexity"
+	wsSendQueueSizeFlag          = "ws-send-queue-size"
+	wsDisconnectSlowFlag         = "ws-disconnect-slow"
+	wsPingIntervalFlag           = "ws-ping-interval"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
+	backupDirFlag                = "backup-dir"
+	backupIntervalBlocksFlag     = "backup-interval-blocks"
+	backupIntervalHoursFlag      = "backup-interval-hours"
+	backupRetentionFlag          = "backup-retention"
 	blockTimeFlag                = "block-time"
 	devIntervalFlag              = "dev-interval"
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
+	remoteSignerFlag             = "remote-signer"
+	remoteSignerAddressFlag      = "remote-signer-address"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -74,17 +99,20 @@ type serverParams struct {
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	jsonRPCAdminAddr  *net.TCPAddr
 
 	blockGasTarget uint64
 	devInterval    uint64
 	isDevMode      bool
 
 	corsAllowedOrigins []string
+	jsonRPCAccess      *jsonrpc.AccessConfig
 
 	ibftBaseTimeoutLegacy uint64
 
 	genesisConfig *chain.Chain
 	secretsConfig *secrets.SecretsManagerConfig
+	remoteSigner  *signer.RemoteSignerConfig
 
 	logFileLocation string
 }
@@ -102,6 +130,10 @@ func (p *serverParams) isSecretsConfigPathSet() bool {
 	return p.rawConfig.SecretsConfigPath != ""
 }
 
+func (p *serverParams) isRemoteSignerSet() bool {
+	return p.rawConfig.RemoteSignerURL != ""
+}
+
 func (p *serverParams) isPrometheusAddressSet() bool {
 	return p.rawConfig.Telemetry.PrometheusAddr != ""
 }
@@ -114,6 +146,10 @@ func (p *serverParams) isDNSAddressSet() bool {
 	return p.rawConfig.Network.DNSAddr != ""
 }
 
+func (p *serverParams) isJSONRPCAdminAddrSet() bool {
+	return p.rawConfig.JSONRPCAdminAddr != ""
+}
+
 func (p *serverParams) isLogFileLocationSet() bool {
 	return p.rawConfig.LogFilePath != ""
 }
@@ -130,6 +166,66 @@ func (p *serverParams) getRestoreFilePath() *string {
 	return nil
 }
 
+// getJWTSecretPath returns the path of the JWT secret of the admin listener,
+// which defaults to the file in the data directory
+func (p *serverParams) getJWTSecretPath() string {
+	if p.rawConfig.JSONRPCJWTSecretPath != "" {
+		return p.rawConfig.JSONRPCJWTSecretPath
+	}
+
+	return filepath.Join(p.rawConfig.DataDir, config.DefaultJSONRPCJWTSecretFile)
+}
+
+// getAncientConfig returns the configuration of the ancient store,
+// whose directory defaults to the one in the data directory
+func (p *serverParams) getAncientConfig() *server.Ancient {
+	dir := p.rawConfig.AncientDir
+	if dir == "" {
+		dir = filepath.Join(p.rawConfig.DataDir, config.DefaultAncientDir)
+	}
+
+	return &server.Ancient{
+		Dir:       dir,
+		Threshold: p.rawConfig.AncientThreshold,
+		Compress:  p.rawConfig.AncientCompression,
+	}
+}
+
+// getBackupConfig returns the configuration of the incremental backups, nil if they're disabled
+func (p *serverParams) getBackupConfig() *archive.BackupConfig {
+	if p.rawConfig.BackupDir == "" {
+		return nil
+	}
+
+	return &archive.BackupConfig{
+		Dir:            p.rawConfig.BackupDir,
+		IntervalBlocks: p.rawConfig.BackupIntervalBlocks,
+		Interval:       time.Duration(p.rawConfig.BackupIntervalHours) * time.Hour,
+		Retention:      p.rawConfig.BackupRetention,
+	}
+}
+
+// getGraphQLConfig returns the configuration of the GraphQL endpoint, nil if it's disabled
+func (p *serverParams) getGraphQLConfig() *jsonrpc.GraphQLConfig {
+	if !p.rawConfig.GraphQL {
+		return nil
+	}
+
+	return &jsonrpc.GraphQLConfig{
+		MaxDepth:      int(p.rawConfig.GraphQLMaxDepth),
+		MaxComplexity: int(p.rawConfig.GraphQLMaxComplexity),
+	}
+}
+
+// getWebSocketConfig returns the configuration of the websocket connections of the JSON-RPC server
+func (p *serverParams) getWebSocketConfig() *jsonrpc.WebSocketConfig {
+	return &jsonrpc.WebSocketConfig{
+		SendQueueSize:           int(p.rawConfig.WSSendQueueSize),
+		DisconnectSlowConsumers: p.rawConfig.WSDisconnectSlow,
+		PingInterval:            time.Duration(p.rawConfig.WSPingInterval) * time.Second,
+	}
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -146,6 +242,11 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			Access:                   p.jsonRPCAccess,
+			AdminAddr:                p.jsonRPCAdminAddr,
+			JWTSecretPath:            p.getJWTSecretPath(),
+			GraphQL:                  p.getGraphQLConfig(),
+			WebSocket:                p.getWebSocketConfig(),
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -164,12 +265,18 @@ func (p *serverParams) generateConfig() *server.Config {
 			Chain:            p.genesisConfig,
 		},
 		DataDir:            p.rawConfig.DataDir,
+		DBEngine:           server.DBEngine(p.rawConfig.DBEngine),
+		Ancient:            p.getAncientConfig(),
 		Seal:               p.rawConfig.ShouldSeal,
+		Light:              p.rawConfig.Light,
+		RecordPreimages:    p.rawConfig.RecordPreimages,
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
 		SecretsManager:     p.secretsConfig,
+		RemoteSigner:       p.remoteSigner,
 		RestoreFile:        p.getRestoreFilePath(),
+		Backup:             p.getBackupConfig(),
 		BlockTime:          p.rawConfig.BlockTime,
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
 		LogFilePath:        p.logFileLocation,
@@ -67,6 +67,36 @@ func setFlags(cmd *cobra.Command) {
 		"the data directory used for storing Exzocoin client data",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.DBEngine,
+		dbEngineFlag,
+		defaultConfig.DBEngine,
+		"the database engine of the blockchain and the state, leveldb or pebble",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.AncientDir,
+		ancientDirFlag,
+		"",
+		"the directory of the ancient store of the finalized blocks, "+
+			"which can be on a cheaper storage (default <data-dir>/ancient)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.AncientThreshold,
+		ancientThresholdFlag,
+		defaultConfig.AncientThreshold,
+		"the depth below the head from which the blocks are moved to the ancient store, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.AncientCompression,
+		ancientCompressionFlag,
+		false,
+		"compress the blocks in the ancient store with snappy, can't be changed once the store is created",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.Libp2pAddr,
 		libp2pAddressFlag,
@@ -111,11 +141,56 @@ func setFlags(cmd *cobra.Command) {
 			"If omitted, the local FS secrets manager is used",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerURL,
+		remoteSignerFlag,
+		"",
+		"the URL of the remote signer holding the validator keys. "+
+			"If omitted, the validator keys are loaded from the SecretsManager",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerAddress,
+		remoteSignerAddressFlag,
+		"",
+		"the validator address to use from the remote signer. Required if the remote signer holds multiple keys",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
 		"",
-		"the path to the archive blockchain data to restore on initialization",
+		"the path to the archive blockchain data to restore on initialization, "+
+			"or to the directory or manifest of the incremental backups",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.BackupDir,
+		backupDirFlag,
+		"",
+		"the directory of the scheduled incremental backups of the blockchain, empty value disables them",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.BackupIntervalBlocks,
+		backupIntervalBlocksFlag,
+		0,
+		"the number of blocks after which a backup increment is written, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.BackupIntervalHours,
+		backupIntervalHoursFlag,
+		defaultConfig.BackupIntervalHours,
+		"the number of hours after which a backup increment is written, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.BackupRetention,
+		backupRetentionFlag,
+		0,
+		"the max number of backup increments, the oldest ones are merged when it's exceeded, "+
+			"value of 0 keeps all of them",
 	)
 
 	cmd.Flags().BoolVar(
@@ -125,6 +200,22 @@ func setFlags(cmd *cobra.Command) {
 		"the flag indicating that the client should seal blocks",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Light,
+		lightFlag,
+		false,
+		"run as a light client which syncs and verifies only the headers, "+
+			"and requests the state proofs from the full peers",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.RecordPreimages,
+		recordPreimagesFlag,
+		false,
+		"record the addresses and the storage slots of the state changes, which the debug state dumps "+
+			"report by their hashes otherwise. The state written before isn't covered",
+	)
+
 	cmd.Flags().BoolVar(
 		&params.rawConfig.Network.NoDiscover,
 		command.NoDiscoverFlag,
@@ -214,6 +305,66 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAdminAddr,
+		jsonRPCAdminFlag,
+		"",
+		"the address and port for the JWT-authenticated admin JSON-RPC server (address:port). "+
+			"If only port is defined (:port) it will bind to 127.0.0.1:port. If omitted, the admin server is disabled",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCJWTSecretPath,
+		jsonRPCJWTSecretFlag,
+		"",
+		"the path to the hex encoded 32 bytes JWT secret of the admin JSON-RPC server, "+
+			"generated if it doesn't exist (default <data-dir>/jwt.hex)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.GraphQL,
+		graphQLFlag,
+		false,
+		"enable the EIP-1767 GraphQL endpoint at /graphql of the JSON-RPC server",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GraphQLMaxDepth,
+		graphQLMaxDepthFlag,
+		defaultConfig.GraphQLMaxDepth,
+		"max nesting of the selection sets of a graphql query, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GraphQLMaxComplexity,
+		graphQLMaxComplexityFlag,
+		defaultConfig.GraphQLMaxComplexity,
+		"max number of the fields resolved by a graphql query, counting the fields of every list item, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WSSendQueueSize,
+		wsSendQueueSizeFlag,
+		defaultConfig.WSSendQueueSize,
+		"max number of the outgoing messages buffered per websocket connection of the JSON-RPC server",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.WSDisconnectSlow,
+		wsDisconnectSlowFlag,
+		false,
+		"close the websocket connections whose send queue is full, instead of dropping their subscription events",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WSPingInterval,
+		wsPingIntervalFlag,
+		defaultConfig.WSPingInterval,
+		"interval of the keepalive pings of the websocket connections in seconds, the connections "+
+			"not responding within twice the interval are closed, value of 0 disables it",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
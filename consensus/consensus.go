@@ -6,6 +6,7 @@ import (
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
 	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
 	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
 	"github.com/ExzoNetwork/ExzoCoin/network"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
@@ -62,6 +63,7 @@ type Config struct {
 type Params struct {
 	Context        context.Context
 	Seal           bool
+	Light          bool
 	Config         *Config
 	TxPool         *txpool.TxPool
 	Network        *network.Server
@@ -71,6 +73,7 @@ type Params struct {
 	Logger         hclog.Logger
 	Metrics        *Metrics
 	SecretsManager secrets.SecretsManager
+	RemoteSigner   *signer.RemoteSignerConfig
 	BlockTime      uint64
 }
 
@@ -148,10 +148,6 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 
 	header.GasLimit = gasLimit
 
-	if err := i.currentHooks.ModifyHeader(header, i.currentSigner.Address()); err != nil {
-		return nil, err
-	}
-
 	// set the timestamp
 	header.Timestamp = uint64(time.Now().Unix())
 
@@ -162,6 +158,11 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 
 	i.currentSigner.InitIBFTExtra(header, i.currentValidators, parentCommittedSeals)
 
+	// the hooks may put the fields in IBFT Extra
+	if err := i.currentHooks.ModifyHeader(header, i.currentSigner.Address()); err != nil {
+		return nil, err
+	}
+
 	transition, err := i.executor.BeginTxn(parent.StateRoot, header, i.currentSigner.Address())
 	if err != nil {
 		return nil, err
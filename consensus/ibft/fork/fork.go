@@ -143,6 +143,18 @@ func (fs *IBFTForks) getFork(height uint64) *IBFTFork {
 	return nil
 }
 
+// IsPoAOnly returns true if all the forks are PoA. Unlike PoS, their validators
+// are known from the headers and the config, without the state
+func (fs *IBFTForks) IsPoAOnly() bool {
+	for _, fork := range *fs {
+		if fork.Type != PoA {
+			return false
+		}
+	}
+
+	return true
+}
+
 // filterByType returns new list of IBFTFork whose type matches with the given type
 func (fs *IBFTForks) filterByType(ibftType IBFTType) IBFTForks {
 	filteredForks := make(IBFTForks, 0)
@@ -331,3 +331,28 @@ func TestIBFTForks_filterByType(t *testing.T) {
 		forks.filterByType(PoS),
 	)
 }
+
+func TestIBFTForks_IsPoAOnly(t *testing.T) {
+	t.Parallel()
+
+	forks := IBFTForks{
+		{
+			Type: PoA,
+			From: common.JSONNumber{Value: 0},
+			To:   &common.JSONNumber{Value: 10},
+		},
+		{
+			Type: PoA,
+			From: common.JSONNumber{Value: 11},
+		},
+	}
+
+	assert.True(t, forks.IsPoAOnly())
+
+	forks = append(forks, &IBFTFork{
+		Type: PoS,
+		From: common.JSONNumber{Value: 21},
+	})
+
+	assert.False(t, forks.IsPoAOnly())
+}
@@ -0,0 +1,537 @@
+package fork
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/hook"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/staking"
+	"github.com/ExzoNetwork/ExzoCoin/helper/common"
+	stakingHelper "github.com/ExzoNetwork/ExzoCoin/helper/staking"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+	"github.com/ExzoNetwork/ExzoCoin/validators/store/contract"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// Key of the governance configuration in IBFT Configuration
+	KeyGovernance = "governance"
+)
+
+// GovernanceConfig represents params.engine.ibft.governance of genesis.json
+type GovernanceConfig struct {
+	// From is the height governance is enabled from
+	// the governance contract is deployed at this height if it doesn't exist yet
+	From common.JSONNumber `json:"from"`
+}
+
+// GetGovernanceConfig returns the governance configuration from chain config
+// returns nil if governance is not enabled
+func GetGovernanceConfig(ibftConfig map[string]interface{}) (*GovernanceConfig, error) {
+	rawConfig, ok := ibftConfig[KeyGovernance]
+	if !ok || rawConfig == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &GovernanceConfig{}
+	if err := json.Unmarshal(bytes, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+var (
+	errProposalNotApplicable = errors.New("proposal is not applicable")
+)
+
+// GovernanceHookRegister registers the hooks
+// that maintain the governance contract and apply approved proposals
+type GovernanceHookRegister struct {
+	logger        hclog.Logger
+	config        *GovernanceConfig
+	chainParams   *chain.Params
+	executor      contract.Executor
+	getValidators func(uint64) (validators.Validators, error)
+
+	// parameters in genesis, on top of which the approved proposals are applied
+	genesisParams *governedParams
+
+	// epoch sizes approved in the state of the latest loaded header
+	epochSizesLock sync.RWMutex
+	epochSizes     epochSchedule
+}
+
+// governedParams are the chain parameters which can be changed by the proposals
+type governedParams struct {
+	forks          *chain.Forks
+	blockGasTarget uint64
+	epochSizes     epochSchedule
+}
+
+// NewGovernanceHookRegister is a constructor of GovernanceHookRegister
+func NewGovernanceHookRegister(
+	logger hclog.Logger,
+	config *GovernanceConfig,
+	epochSize uint64,
+	chainParams *chain.Params,
+	executor contract.Executor,
+	getValidators func(uint64) (validators.Validators, error),
+) *GovernanceHookRegister {
+	genesisForks := chainParams.GetForks()
+	if genesisForks != nil {
+		genesisForks = genesisForks.Copy()
+	} else {
+		genesisForks = &chain.Forks{}
+	}
+
+	genesisParams := &governedParams{
+		forks:          genesisForks,
+		blockGasTarget: chainParams.GetBlockGasTarget(),
+		epochSizes:     newEpochSchedule(epochSize),
+	}
+
+	return &GovernanceHookRegister{
+		logger:        logger,
+		config:        config,
+		chainParams:   chainParams,
+		executor:      executor,
+		getValidators: getValidators,
+		genesisParams: genesisParams,
+		epochSizes:    genesisParams.epochSizes,
+	}
+}
+
+// RegisterHooks registers hooks of governance on top of the hooks registered by other registers
+func (r *GovernanceHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
+	if height < r.config.From.Value {
+		return
+	}
+
+	r.registerPreCommitStateHook(hooks, height)
+
+	if r.IsLastOfEpoch(height) {
+		r.registerPostInsertBlockHook(hooks)
+	}
+}
+
+// Load sets the chain parameters of the genesis with the approved proposals in the state
+// of the given header applied. The parameters are derived from scratch every time,
+// so that it also reverts the proposals in the blocks removed by a reorg or a rewind
+func (r *GovernanceHookRegister) Load(header *types.Header) error {
+	params := r.genesisParams
+
+	if header.Number >= r.config.From.Value {
+		transition, err := r.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+		if err != nil {
+			return err
+		}
+
+		params = r.deriveParams(transition.Txn())
+	}
+
+	r.chainParams.SetGovernedParams(params.forks, params.blockGasTarget)
+
+	r.epochSizesLock.Lock()
+	r.epochSizes = params.epochSizes
+	r.epochSizesLock.Unlock()
+
+	return nil
+}
+
+// EpochSize returns the epoch size at the given height
+func (r *GovernanceHookRegister) EpochSize(height uint64) uint64 {
+	r.epochSizesLock.RLock()
+	defer r.epochSizesLock.RUnlock()
+
+	return r.epochSizes.sizeAt(height)
+}
+
+// Epoch returns the number of the epoch the given height is in
+func (r *GovernanceHookRegister) Epoch(height uint64) uint64 {
+	r.epochSizesLock.RLock()
+	defer r.epochSizesLock.RUnlock()
+
+	return r.epochSizes.epochAt(height)
+}
+
+// IsLastOfEpoch returns whether the given height is the last of the epoch
+func (r *GovernanceHookRegister) IsLastOfEpoch(height uint64) bool {
+	return height > 0 && height%r.EpochSize(height) == 0
+}
+
+// registerPreCommitStateHook registers the hook
+// to record proposals and votes in the block, and to close voting at the end of epoch
+func (r *GovernanceHookRegister) registerPreCommitStateHook(hooks *hook.Hooks, height uint64) {
+	prevFunc := hooks.PreCommitStateFunc
+
+	hooks.PreCommitStateFunc = func(header *types.Header, txn *state.Transition) error {
+		if prevFunc != nil {
+			if err := prevFunc(header, txn); err != nil {
+				return err
+			}
+		}
+
+		if height == r.config.From.Value && !txn.AccountExists(governance.AddrGovernanceContract) {
+			if err := txn.SetAccountDirectly(
+				governance.AddrGovernanceContract,
+				governance.PredeployGovernanceSC(),
+			); err != nil {
+				return err
+			}
+		}
+
+		vals, err := r.getValidators(header.Number)
+		if err != nil {
+			return err
+		}
+
+		r.processCalls(txn.Txn(), txn.Receipts(), vals, header.Number)
+
+		if r.IsLastOfEpoch(header.Number) {
+			r.closeVoting(txn.Txn(), vals, header.Number)
+		}
+
+		return nil
+	}
+}
+
+// registerPostInsertBlockHook registers the hook
+// to apply the chain parameters approved in the inserted block
+func (r *GovernanceHookRegister) registerPostInsertBlockHook(hooks *hook.Hooks) {
+	prevFunc := hooks.PostInsertBlockFunc
+
+	hooks.PostInsertBlockFunc = func(block *types.Block) error {
+		if prevFunc != nil {
+			if err := prevFunc(block); err != nil {
+				return err
+			}
+		}
+
+		return r.Load(block.Header)
+	}
+}
+
+// processCalls records the proposals and votes by validators in the given receipts
+func (r *GovernanceHookRegister) processCalls(
+	st governance.State,
+	receipts []*types.Receipt,
+	vals validators.Validators,
+	height uint64,
+) {
+	// failed transactions don't have logs
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			if log.Address != governance.AddrGovernanceContract {
+				continue
+			}
+
+			call, err := governance.DecodeCallLog(log)
+			if err != nil {
+				r.logger.Debug("ignored invalid governance call", "height", height, "err", err)
+
+				continue
+			}
+
+			if !vals.Includes(call.Caller) {
+				r.logger.Debug("ignored governance call by non-validator", "height", height, "caller", call.Caller)
+
+				continue
+			}
+
+			if call.IsVote {
+				recordVote(st, call.ProposalID, call.Caller)
+			} else {
+				recordProposal(st, call, height)
+			}
+		}
+	}
+}
+
+// recordProposal adds a new proposal with the vote by the proposer
+func recordProposal(st governance.State, call *governance.Call, height uint64) {
+	id := governance.AddProposal(st, &governance.Proposal{
+		Kind:       call.Kind,
+		Key:        call.Key,
+		Value:      call.Value,
+		Proposer:   call.Caller,
+		Status:     governance.StatusPending,
+		ProposedAt: height,
+	})
+
+	recordVote(st, id, call.Caller)
+}
+
+// recordVote adds the vote to the pending proposal unless the voter has voted already
+func recordVote(st governance.State, id uint64, voter types.Address) {
+	if id >= governance.GetProposalCount(st) || governance.HasVoted(st, id, voter) {
+		return
+	}
+
+	proposal := governance.GetProposal(st, id)
+	if proposal.Status != governance.StatusPending {
+		return
+	}
+
+	proposal.Votes++
+
+	governance.SetVoted(st, id, voter)
+	governance.SetProposal(st, proposal)
+}
+
+// closeVoting settles the pending proposals at the end of epoch
+// Proposals that reached quorum are applied, and proposals that have been open
+// for a whole epoch without reaching quorum expire
+func (r *GovernanceHookRegister) closeVoting(
+	st governance.State,
+	vals validators.Validators,
+	height uint64,
+) {
+	var (
+		quorum    = governanceQuorum(vals)
+		epochSize = r.EpochSize(height)
+	)
+
+	for _, proposal := range governance.GetProposals(st) {
+		if proposal.Status != governance.StatusPending {
+			continue
+		}
+
+		switch {
+		case proposal.Votes >= quorum:
+			if err := r.applyProposal(st, proposal, height); err != nil {
+				r.logger.Info("rejected governance proposal", "id", proposal.ID, "height", height, "err", err)
+
+				proposal.Status = governance.StatusRejected
+			} else {
+				r.logger.Info("approved governance proposal", "id", proposal.ID, "height", height, "kind", proposal.Kind)
+
+				proposal.Status = governance.StatusApproved
+			}
+		case proposal.ProposedAt+epochSize <= height:
+			proposal.Status = governance.StatusExpired
+		default:
+			continue
+		}
+
+		proposal.ClosedAt = height
+
+		governance.SetProposal(st, proposal)
+	}
+}
+
+// applyProposal validates the approved proposal and applies the changes in state
+// Changes of chain parameters are applied by Load after the block is inserted
+func (r *GovernanceHookRegister) applyProposal(
+	st governance.State,
+	proposal *governance.Proposal,
+	height uint64,
+) error {
+	switch proposal.Kind {
+	case governance.KindBlockGasTarget:
+		if proposal.Value == 0 {
+			return errProposalNotApplicable
+		}
+
+		return nil
+	case governance.KindEpochSize:
+		if proposal.Value == 0 || proposal.Value > common.MaxSafeJSInt {
+			return errProposalNotApplicable
+		}
+
+		return nil
+	case governance.KindMinValidatorCount, governance.KindMaxValidatorCount:
+		return applyValidatorCount(st, proposal)
+	case governance.KindFork:
+		name := proposal.ForkName()
+
+		// forks can be only scheduled in the future and never be rescheduled once activated
+		if !governance.IsKnownFork(name) ||
+			proposal.Value <= height ||
+			governance.IsForkActive(r.deriveParams(st).forks, name, height) {
+			return errProposalNotApplicable
+		}
+
+		return nil
+	default:
+		return governance.ErrUnknownProposalKind
+	}
+}
+
+// applyValidatorCount updates the validator count limit in the staking contract
+func applyValidatorCount(st governance.State, proposal *governance.Proposal) error {
+	var (
+		minIndex = stakingHelper.MinValidatorCountIndex()
+		maxIndex = stakingHelper.MaxValidatorCountIndex()
+		getCount = func(index types.Hash) uint64 {
+			return new(big.Int).SetBytes(st.GetState(staking.AddrStakingContract, index).Bytes()).Uint64()
+		}
+		minCount = getCount(minIndex)
+		maxCount = getCount(maxIndex)
+	)
+
+	// staking contract doesn't exist
+	if minCount == 0 && maxCount == 0 {
+		return errProposalNotApplicable
+	}
+
+	index := minIndex
+	if proposal.Kind == governance.KindMinValidatorCount {
+		minCount = proposal.Value
+	} else {
+		index = maxIndex
+		maxCount = proposal.Value
+	}
+
+	if minCount == 0 || minCount > maxCount || maxCount > common.MaxSafeJSInt {
+		return errProposalNotApplicable
+	}
+
+	st.SetState(
+		staking.AddrStakingContract,
+		index,
+		types.BytesToHash(new(big.Int).SetUint64(proposal.Value).Bytes()),
+	)
+
+	return nil
+}
+
+// deriveParams returns the chain parameters of the genesis
+// with the approved proposals in the given state applied in order of approval
+func (r *GovernanceHookRegister) deriveParams(st governance.StateReader) *governedParams {
+	params := &governedParams{
+		forks:          r.genesisParams.forks.Copy(),
+		blockGasTarget: r.genesisParams.blockGasTarget,
+		epochSizes:     r.genesisParams.epochSizes,
+	}
+
+	approved := make([]*governance.Proposal, 0)
+
+	for _, proposal := range governance.GetProposals(st) {
+		if proposal.Status == governance.StatusApproved {
+			approved = append(approved, proposal)
+		}
+	}
+
+	sort.SliceStable(approved, func(i, j int) bool {
+		return approved[i].ClosedAt < approved[j].ClosedAt
+	})
+
+	for _, proposal := range approved {
+		switch proposal.Kind {
+		case governance.KindBlockGasTarget:
+			params.blockGasTarget = proposal.Value
+		case governance.KindEpochSize:
+			params.epochSizes = params.epochSizes.add(proposal.ClosedAt, proposal.Value)
+		case governance.KindFork:
+			if err := governance.ScheduleFork(params.forks, proposal.ForkName(), proposal.Value); err != nil {
+				r.logger.Error("failed to schedule fork", "fork", proposal.ForkName(), "err", err)
+			}
+		}
+	}
+
+	return params
+}
+
+// governanceQuorum returns the number of votes required to approve a proposal
+// Q = ceil(2/3 * N)
+func governanceQuorum(vals validators.Validators) uint64 {
+	return uint64((2*vals.Len() + 2) / 3)
+}
+
+// epochSizeChange is the epoch size in use from the height
+type epochSizeChange struct {
+	from uint64
+	size uint64
+}
+
+// epochSchedule is the list of the epoch sizes in order of approval
+// The first one is the epoch size in genesis
+type epochSchedule []epochSizeChange
+
+func newEpochSchedule(size uint64) epochSchedule {
+	return epochSchedule{{from: 0, size: size}}
+}
+
+// add returns the schedule with the epoch size approved at the given height.
+// The new size is used from the first multiple of it after the approval,
+// where the epoch in progress ends
+func (s epochSchedule) add(approvedAt, size uint64) epochSchedule {
+	added := make(epochSchedule, len(s), len(s)+1)
+	copy(added, s)
+
+	return append(added, epochSizeChange{
+		from: (approvedAt/size + 1) * size,
+		size: size,
+	})
+}
+
+// sizeAt returns the epoch size at the given height
+// The size approved later overrides the ones approved earlier
+func (s epochSchedule) sizeAt(height uint64) uint64 {
+	size := s[0].size
+
+	for _, change := range s[1:] {
+		if change.from <= height {
+			size = change.size
+		}
+	}
+
+	return size
+}
+
+// epochAt returns the number of the epoch the given height is in,
+// which is the number of the last heights of epochs below the height plus one
+func (s epochSchedule) epochAt(height uint64) uint64 {
+	if height == 0 {
+		return 0
+	}
+
+	// the heights at which the epoch size may change
+	bounds := []uint64{0}
+
+	for _, change := range s[1:] {
+		if change.from < height {
+			bounds = append(bounds, change.from)
+		}
+	}
+
+	sort.Slice(bounds, func(i, j int) bool {
+		return bounds[i] < bounds[j]
+	})
+
+	epoch := uint64(1)
+
+	for idx, begin := range bounds {
+		end := height
+		if idx+1 < len(bounds) {
+			end = bounds[idx+1]
+		}
+
+		if begin == 0 {
+			begin = 1
+		}
+
+		if begin >= end {
+			continue
+		}
+
+		// the multiples of the size in [begin, end)
+		size := s.sizeAt(begin)
+		epoch += (end-1)/size - (begin-1)/size
+	}
+
+	return epoch
+}
@@ -0,0 +1,393 @@
+package fork
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/hook"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/governance"
+	"github.com/ExzoNetwork/ExzoCoin/contracts/staking"
+	"github.com/ExzoNetwork/ExzoCoin/helper/common"
+	stakingHelper "github.com/ExzoNetwork/ExzoCoin/helper/staking"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/state/runtime/evm"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGovernanceTransition(t *testing.T, height uint64) *state.Transition {
+	t.Helper()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	ex := state.NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+	}, st, hclog.NewNullLogger())
+
+	rootHash := ex.WriteGenesis(nil)
+
+	ex.SetRuntime(evm.NewEVM())
+	ex.GetHash = func(h *types.Header) state.GetHashByNumber {
+		return func(i uint64) types.Hash {
+			return rootHash
+		}
+	}
+
+	transition, err := ex.BeginTxn(
+		rootHash,
+		&types.Header{
+			Number:   height,
+			GasLimit: 10000000,
+		},
+		types.ZeroAddress,
+	)
+	assert.NoError(t, err)
+
+	return transition
+}
+
+func writeGovernanceTx(t *testing.T, txn *state.Transition, from types.Address, input []byte) {
+	t.Helper()
+
+	to := governance.AddrGovernanceContract
+
+	assert.NoError(t, txn.Write(&types.Transaction{
+		Nonce:    txn.GetNonce(from),
+		From:     from,
+		To:       &to,
+		Gas:      100000,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+		Input:    input,
+	}))
+}
+
+func TestGetGovernanceConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := GetGovernanceConfig(map[string]interface{}{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = GetGovernanceConfig(map[string]interface{}{
+		KeyGovernance: map[string]interface{}{
+			"from": "0xa",
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &GovernanceConfig{From: common.JSONNumber{Value: 10}}, config)
+}
+
+func TestGovernanceHookRegister(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 10
+
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+		addr4 = types.StringToAddress("4")
+
+		vals = validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+			validators.NewECDSAValidator(addr3),
+		)
+	)
+
+	newRegister := func(params *chain.Params) *GovernanceHookRegister {
+		return NewGovernanceHookRegister(
+			hclog.NewNullLogger(),
+			&GovernanceConfig{From: common.JSONNumber{Value: 0}},
+			epochSize,
+			params,
+			nil,
+			func(u uint64) (validators.Validators, error) {
+				return vals, nil
+			},
+		)
+	}
+
+	preCommitState := func(r *GovernanceHookRegister, txn *state.Transition, height uint64) {
+		hooks := &hook.Hooks{}
+		r.RegisterHooks(hooks, height)
+
+		assert.NoError(t, hooks.PreCommitState(&types.Header{Number: height}, txn))
+	}
+
+	t.Run("should record proposals and votes by validators", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRegister(&chain.Params{Forks: &chain.Forks{}})
+		txn := newTestGovernanceTransition(t, 1)
+
+		assert.NoError(t, txn.SetAccountDirectly(
+			governance.AddrGovernanceContract,
+			governance.PredeployGovernanceSC(),
+		))
+
+		proposeInput, err := governance.EncodeProposeCall(governance.KindBlockGasTarget, types.ZeroHash, 20000000)
+		assert.NoError(t, err)
+
+		voteInput, err := governance.EncodeVoteCall(0)
+		assert.NoError(t, err)
+
+		writeGovernanceTx(t, txn, addr1, proposeInput)
+		// duplicated vote by proposer
+		writeGovernanceTx(t, txn, addr1, voteInput)
+		writeGovernanceTx(t, txn, addr2, voteInput)
+		// non-validator
+		writeGovernanceTx(t, txn, addr4, voteInput)
+		writeGovernanceTx(t, txn, addr4, proposeInput)
+
+		preCommitState(r, txn, 1)
+
+		assert.Equal(
+			t,
+			[]*governance.Proposal{
+				{
+					ID:         0,
+					Kind:       governance.KindBlockGasTarget,
+					Value:      20000000,
+					Proposer:   addr1,
+					Votes:      2,
+					Status:     governance.StatusPending,
+					ProposedAt: 1,
+				},
+			},
+			governance.GetProposals(txn.Txn()),
+		)
+	})
+
+	t.Run("should deploy governance contract at the beginning height", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRegister(&chain.Params{Forks: &chain.Forks{}})
+		txn := newTestGovernanceTransition(t, 0)
+
+		preCommitState(r, txn, 0)
+
+		assert.Equal(t, governance.PredeployGovernanceSC().Code, txn.GetCode(governance.AddrGovernanceContract))
+	})
+
+	t.Run("should settle proposals at the end of epoch", func(t *testing.T) {
+		t.Parallel()
+
+		params := &chain.Params{Forks: &chain.Forks{}}
+		r := newRegister(params)
+		txn := newTestGovernanceTransition(t, epochSize)
+
+		predeployed, err := stakingHelper.PredeployStakingSC(vals, stakingHelper.PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 10,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, txn.SetAccountDirectly(staking.AddrStakingContract, predeployed))
+
+		st := txn.Txn()
+		proposals := []*governance.Proposal{
+			// approved
+			{Kind: governance.KindBlockGasTarget, Value: 20000000, Votes: 2, ProposedAt: 5},
+			// approved
+			{Kind: governance.KindFork, Key: governance.ForkNameToKey("istanbul"), Value: 100, Votes: 2, ProposedAt: 5},
+			// rejected, activation is in the past
+			{Kind: governance.KindFork, Key: governance.ForkNameToKey("byzantium"), Value: 5, Votes: 3, ProposedAt: 5},
+			// approved
+			{Kind: governance.KindMaxValidatorCount, Value: 5, Votes: 2, ProposedAt: 5},
+			// rejected, less than the minimum
+			{Kind: governance.KindMaxValidatorCount, Value: 0, Votes: 2, ProposedAt: 5},
+			// still pending
+			{Kind: governance.KindMinValidatorCount, Value: 2, Votes: 1, ProposedAt: 5},
+			// expired
+			{Kind: governance.KindMinValidatorCount, Value: 2, Votes: 1, ProposedAt: 0},
+		}
+
+		for _, p := range proposals {
+			governance.AddProposal(st, p)
+		}
+
+		preCommitState(r, txn, epochSize)
+
+		expectedStatuses := []governance.ProposalStatus{
+			governance.StatusApproved,
+			governance.StatusApproved,
+			governance.StatusRejected,
+			governance.StatusApproved,
+			governance.StatusRejected,
+			governance.StatusPending,
+			governance.StatusExpired,
+		}
+
+		for idx, p := range governance.GetProposals(st) {
+			assert.Equal(t, expectedStatuses[idx], p.Status, "proposal %d", idx)
+		}
+
+		assert.Equal(
+			t,
+			types.BytesToHash(big.NewInt(5).Bytes()),
+			st.GetState(staking.AddrStakingContract, stakingHelper.MaxValidatorCountIndex()),
+		)
+
+		derived := r.deriveParams(st)
+
+		assert.Equal(t, uint64(20000000), derived.blockGasTarget)
+		assert.Equal(t, chain.NewFork(100), derived.forks.Istanbul)
+		assert.Nil(t, derived.forks.Byzantium)
+
+		// the shared parameters are left as they are until the block is inserted
+		assert.Nil(t, params.Forks.Istanbul)
+	})
+
+	t.Run("should change epoch size from the next multiple of the new size", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRegister(&chain.Params{Forks: &chain.Forks{}})
+		txn := newTestGovernanceTransition(t, epochSize)
+
+		st := txn.Txn()
+
+		governance.AddProposal(st, &governance.Proposal{
+			Kind: governance.KindEpochSize, Value: 15, Votes: 2, ProposedAt: 5,
+		})
+		governance.AddProposal(st, &governance.Proposal{
+			Kind: governance.KindEpochSize, Value: 0, Votes: 2, ProposedAt: 5,
+		})
+
+		preCommitState(r, txn, epochSize)
+
+		proposals := governance.GetProposals(st)
+
+		assert.Equal(t, governance.StatusApproved, proposals[0].Status)
+		assert.Equal(t, epochSize, proposals[0].ClosedAt)
+		assert.Equal(t, governance.StatusRejected, proposals[1].Status)
+
+		epochSizes := r.deriveParams(st).epochSizes
+
+		assert.Equal(t, epochSize, epochSizes.sizeAt(14))
+		assert.Equal(t, uint64(15), epochSizes.sizeAt(15))
+	})
+}
+
+func TestGovernanceHookRegister_Load(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 10
+
+		genesisForks = &chain.Forks{Homestead: chain.NewFork(0)}
+		params       = &chain.Params{Forks: genesisForks, BlockGasTarget: 8000000}
+
+		approvedRoot = types.StringToHash("1")
+		genesisRoot  = types.StringToHash("2")
+	)
+
+	approved := newTestGovernanceTransition(t, epochSize)
+	governance.AddProposal(approved.Txn(), &governance.Proposal{
+		Kind: governance.KindBlockGasTarget, Value: 20000000, Status: governance.StatusApproved, ClosedAt: 10,
+	})
+	governance.AddProposal(approved.Txn(), &governance.Proposal{
+		Kind:     governance.KindFork,
+		Key:      governance.ForkNameToKey("istanbul"),
+		Value:    100,
+		Status:   governance.StatusApproved,
+		ClosedAt: 10,
+	})
+	governance.AddProposal(approved.Txn(), &governance.Proposal{
+		Kind: governance.KindEpochSize, Value: 20, Status: governance.StatusApproved, ClosedAt: 10,
+	})
+
+	r := NewGovernanceHookRegister(
+		hclog.NewNullLogger(),
+		&GovernanceConfig{From: common.JSONNumber{Value: 0}},
+		epochSize,
+		params,
+		&MockExecutor{
+			BeginTxnFunc: func(root types.Hash, _ *types.Header, _ types.Address) (*state.Transition, error) {
+				if root == approvedRoot {
+					return approved, nil
+				}
+
+				return newTestGovernanceTransition(t, 0), nil
+			},
+		},
+		nil,
+	)
+
+	assert.NoError(t, r.Load(&types.Header{Number: 10, StateRoot: approvedRoot}))
+
+	assert.Equal(t, uint64(20000000), params.GetBlockGasTarget())
+	assert.Equal(t, chain.NewFork(100), params.GetForks().Istanbul)
+	assert.Equal(t, chain.NewFork(0), params.GetForks().Homestead)
+	assert.Equal(t, uint64(20), r.EpochSize(20))
+	assert.True(t, r.IsLastOfEpoch(20))
+
+	// the genesis forks aren't modified
+	assert.Nil(t, genesisForks.Istanbul)
+
+	// the blocks approving the proposals have been removed
+	assert.NoError(t, r.Load(&types.Header{Number: 9, StateRoot: genesisRoot}))
+
+	assert.Equal(t, uint64(8000000), params.GetBlockGasTarget())
+	assert.Nil(t, params.GetForks().Istanbul)
+	assert.Equal(t, epochSize, r.EpochSize(20))
+}
+
+func Test_epochSchedule(t *testing.T) {
+	t.Parallel()
+
+	schedule := newEpochSchedule(10)
+
+	assert.Equal(t, uint64(0), schedule.epochAt(0))
+	assert.Equal(t, uint64(1), schedule.epochAt(10))
+	assert.Equal(t, uint64(2), schedule.epochAt(11))
+
+	// approved at 20, the new size is used from 30
+	schedule = schedule.add(20, 15)
+
+	assert.Equal(t, uint64(10), schedule.sizeAt(29))
+	assert.Equal(t, uint64(15), schedule.sizeAt(30))
+	assert.Equal(t, uint64(3), schedule.epochAt(30))
+	assert.Equal(t, uint64(4), schedule.epochAt(31))
+	assert.Equal(t, uint64(4), schedule.epochAt(45))
+	assert.Equal(t, uint64(5), schedule.epochAt(46))
+
+	// approved at 45, the later approval overrides the former one
+	schedule = schedule.add(45, 4)
+
+	assert.Equal(t, uint64(15), schedule.sizeAt(47))
+	assert.Equal(t, uint64(4), schedule.sizeAt(48))
+	assert.Equal(t, uint64(5), schedule.epochAt(48))
+	assert.Equal(t, uint64(6), schedule.epochAt(49))
+}
+
+func Test_governanceQuorum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		validators int
+		quorum     uint64
+	}{
+		{1, 1},
+		{3, 2},
+		{4, 3},
+		{6, 4},
+		{7, 5},
+	}
+
+	for _, test := range tests {
+		vals := validators.NewECDSAValidatorSet()
+
+		for i := 0; i < test.validators; i++ {
+			assert.NoError(t, vals.Add(validators.NewECDSAValidator(types.BytesToAddress([]byte{byte(i + 1)}))))
+		}
+
+		assert.Equal(t, test.quorum, governanceQuorum(vals))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/ExzoNetwork/ExzoCoin/validators/store/snapshot"
 )
@@ -28,6 +29,44 @@ func loadSnapshots(path string) ([]*snapshot.Snapshot, error) {
 	return snaps, nil
 }
 
+// RewindSnapshotFiles drops the snapshots of the blocks above the height from the snapshot files
+// in the directory, after the chain of the stopped node is rewound to the height.
+// The snapshot of the height is rebuilt from the chain on the next start if it's dropped
+func RewindSnapshotFiles(dirPath string, height uint64) error {
+	metadataPath := filepath.Join(dirPath, snapshotMetadataFilename)
+	snapshotsPath := filepath.Join(dirPath, snapshotSnapshotsFilename)
+
+	metadata, err := loadSnapshotMetadata(metadataPath)
+	if err != nil {
+		return err
+	}
+
+	if metadata == nil || metadata.LastBlock <= height {
+		return nil
+	}
+
+	snapshots, err := loadSnapshots(snapshotsPath)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]*snapshot.Snapshot, 0, len(snapshots))
+
+	for _, snap := range snapshots {
+		if snap.Number <= height {
+			remaining = append(remaining, snap)
+		}
+	}
+
+	metadata.LastBlock = height
+
+	if err := writeDataStore(snapshotsPath, remaining); err != nil {
+		return err
+	}
+
+	return writeDataStore(metadataPath, metadata)
+}
+
 // readDataStore attempts to read the specific file from file storage
 // return nil if the file doesn't exist
 func readDataStore(path string, obj interface{}) error {
@@ -141,6 +141,95 @@ func Test_loadSnapshots(t *testing.T) {
 	})
 }
 
+func TestRewindSnapshotFiles(t *testing.T) {
+	t.Parallel()
+
+	newSnapshot := func(number uint64) *snapshot.Snapshot {
+		return &snapshot.Snapshot{
+			Number: number,
+			Hash:   types.BytesToHash(crypto.Keccak256([]byte{byte(number)})).String(),
+			Set: validators.NewECDSAValidatorSet(
+				validators.NewECDSAValidator(types.StringToAddress("1")),
+			),
+			Votes: []*store.Vote{},
+		}
+	}
+
+	writeFiles := func(t *testing.T, lastBlock uint64, snapshots []*snapshot.Snapshot) string {
+		t.Helper()
+
+		dirPath := createTestTempDirectory(t)
+
+		assert.NoError(
+			t,
+			writeDataStore(
+				path.Join(dirPath, snapshotMetadataFilename),
+				&snapshot.SnapshotMetadata{LastBlock: lastBlock},
+			),
+		)
+		assert.NoError(t, writeDataStore(path.Join(dirPath, snapshotSnapshotsFilename), snapshots))
+
+		return dirPath
+	}
+
+	readFiles := func(t *testing.T, dirPath string) (*snapshot.SnapshotMetadata, []*snapshot.Snapshot) {
+		t.Helper()
+
+		metadata, err := loadSnapshotMetadata(path.Join(dirPath, snapshotMetadataFilename))
+		assert.NoError(t, err)
+
+		snapshots, err := loadSnapshots(path.Join(dirPath, snapshotSnapshotsFilename))
+		assert.NoError(t, err)
+
+		return metadata, snapshots
+	}
+
+	t.Run("should drop the snapshots above the height", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := writeFiles(t, 20, []*snapshot.Snapshot{
+			newSnapshot(0),
+			newSnapshot(5),
+			newSnapshot(10),
+			newSnapshot(15),
+		})
+
+		assert.NoError(t, RewindSnapshotFiles(dirPath, 10))
+
+		metadata, snapshots := readFiles(t, dirPath)
+
+		assert.Equal(t, &snapshot.SnapshotMetadata{LastBlock: 10}, metadata)
+		assert.Equal(t, []*snapshot.Snapshot{newSnapshot(0), newSnapshot(5), newSnapshot(10)}, snapshots)
+	})
+
+	t.Run("should keep the files if the height isn't below the last block", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := writeFiles(t, 5, []*snapshot.Snapshot{
+			newSnapshot(0),
+			newSnapshot(5),
+		})
+
+		assert.NoError(t, RewindSnapshotFiles(dirPath, 5))
+
+		metadata, snapshots := readFiles(t, dirPath)
+
+		assert.Equal(t, &snapshot.SnapshotMetadata{LastBlock: 5}, metadata)
+		assert.Equal(t, []*snapshot.Snapshot{newSnapshot(0), newSnapshot(5)}, snapshots)
+	})
+
+	t.Run("should do nothing if the files don't exist", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := createTestTempDirectory(t)
+
+		assert.NoError(t, RewindSnapshotFiles(dirPath, 5))
+
+		_, err := os.Stat(path.Join(dirPath, snapshotMetadataFilename))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
 func Test_readDataStore(t *testing.T) {
 	t.Parallel()
 
@@ -60,14 +60,14 @@ func (r *PoAHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
 // PoAHookRegisterer that registers hooks for PoS mode
 type PoSHookRegister struct {
 	posForks            IBFTForks
-	epochSize           uint64
+	isLastOfEpoch       func(uint64) bool
 	deployContractForks map[uint64]*IBFTFork
 }
 
 // NewPoSHookRegister is a constructor of PoSHookRegister
 func NewPoSHookRegister(
 	forks IBFTForks,
-	epochSize uint64,
+	isLastOfEpoch func(uint64) bool,
 ) *PoSHookRegister {
 	posForks := forks.filterByType(PoS)
 
@@ -83,7 +83,7 @@ func NewPoSHookRegister(
 
 	return &PoSHookRegister{
 		posForks:            posForks,
-		epochSize:           epochSize,
+		isLastOfEpoch:       isLastOfEpoch,
 		deployContractForks: deployContractForks,
 	}
 }
@@ -92,7 +92,7 @@ func NewPoSHookRegister(
 func (r *PoSHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
 	if currentFork := r.posForks.getFork(height); currentFork != nil {
 		// in PoS mode currently
-		registerTxInclusionGuardHooks(hooks, r.epochSize)
+		registerTxInclusionGuardHooks(hooks, r.isLastOfEpoch)
 	}
 
 	if deploymentFork, ok := r.deployContractForks[height]; ok {
@@ -217,7 +217,9 @@ func Test_registerTxInclusionGuardHooks(t *testing.T) {
 	epochSize := uint64(10)
 	hooks := &hook.Hooks{}
 
-	registerTxInclusionGuardHooks(hooks, epochSize)
+	registerTxInclusionGuardHooks(hooks, func(height uint64) bool {
+		return height > 0 && height%epochSize == 0
+	})
 
 	assert.Nil(t, hooks.ModifyHeaderFunc)
 	assert.Nil(t, hooks.VerifyHeaderFunc)
@@ -0,0 +1,39 @@
+package fork
+
+import (
+	"encoding/json"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/common"
+)
+
+const (
+	// Key of the key rotation configuration in IBFT Configuration
+	KeyKeyRotation = "keyRotation"
+)
+
+// KeyRotationConfig represents params.engine.ibft.keyRotation of genesis.json
+type KeyRotationConfig struct {
+	// From is the height from which the headers can register the successor keys of the validators
+	From common.JSONNumber `json:"from"`
+}
+
+// GetKeyRotationConfig returns the key rotation configuration from chain config
+// returns nil if key rotation is not enabled
+func GetKeyRotationConfig(ibftConfig map[string]interface{}) (*KeyRotationConfig, error) {
+	rawConfig, ok := ibftConfig[KeyKeyRotation]
+	if !ok || rawConfig == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &KeyRotationConfig{}
+	if err := json.Unmarshal(bytes, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
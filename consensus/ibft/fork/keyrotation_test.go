@@ -0,0 +1,26 @@
+package fork
+
+import (
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeyRotationConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := GetKeyRotationConfig(map[string]interface{}{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = GetKeyRotationConfig(map[string]interface{}{
+		KeyKeyRotation: map[string]interface{}{
+			"from": "0xa",
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &KeyRotationConfig{From: common.JSONNumber{Value: 10}}, config)
+}
@@ -2,7 +2,12 @@ package fork
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 
+	"github.com/ExzoNetwork/ExzoCoin/chain"
 	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/hook"
 	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
@@ -18,13 +23,18 @@ const (
 	loggerName                = "fork_manager"
 	snapshotMetadataFilename  = "metadata"
 	snapshotSnapshotsFilename = "snapshots"
+	keyRotationFilename       = "key-rotation"
 )
 
 var (
-	ErrForkNotFound           = errors.New("fork not found")
-	ErrSignerNotFound         = errors.New("signer not found")
-	ErrValidatorStoreNotFound = errors.New("validator set not found")
-	ErrKeyManagerNotFound     = errors.New("key manager not found")
+	ErrForkNotFound             = errors.New("fork not found")
+	ErrSignerNotFound           = errors.New("signer not found")
+	ErrValidatorStoreNotFound   = errors.New("validator set not found")
+	ErrKeyManagerNotFound       = errors.New("key manager not found")
+	ErrKeyRotationNotSupported  = errors.New("key rotation is not supported with remote signer")
+	ErrKeyRotationScheduled     = errors.New("key rotation has been scheduled already")
+	ErrInvalidKeyRotationHeight = errors.New("key rotation height must be at least 3 blocks ahead of the latest block")
+	ErrKeyRotationByContract    = errors.New("key rotation is not supported with contract validators")
 )
 
 // ValidatorStore is an interface that ForkManager calls for Validator Store
@@ -36,6 +46,19 @@ type ValidatorStore interface {
 	GetValidators(height, epochSize, forkFrom uint64) (validators.Validators, error)
 }
 
+// KeyRotatable is an interface for the validator store that registers key rotations in headers
+type KeyRotatable interface {
+	// ProposeKeyRotation registers the successor of the validator taking effect at the given height
+	// with the proof of possession of the successor key
+	ProposeKeyRotation(types.Address, validators.Validator, []byte, uint64) error
+}
+
+// Rewindable is an interface for the validator store that keeps the data derived from the headers
+type Rewindable interface {
+	// Rewind drops the data of the blocks above the given height
+	Rewind(uint64) error
+}
+
 // HookRegister is an interface that ForkManager calls for hook registrations
 type HooksRegister interface {
 	// RegisterHooks register hooks for the given block height
@@ -60,17 +83,34 @@ type ForkManager struct {
 	logger         hclog.Logger
 	blockchain     store.HeaderGetter
 	executor       contract.Executor
+	chainParams    *chain.Params
 	secretsManager secrets.SecretsManager
+	remoteSigner   *signer.RemoteSignerConfig
+	protectionDB   *signer.SlashingProtectionDB
 
 	// configuration
-	forks     IBFTForks
-	filePath  string
-	epochSize uint64
+	forks             IBFTForks
+	governanceConfig  *GovernanceConfig
+	keyRotationConfig *KeyRotationConfig
+	filePath          string
+	epochSize         uint64
 
 	// submodule lookup
 	keyManagers     map[validators.ValidatorType]signer.KeyManager
 	validatorStores map[store.SourceType]ValidatorStore
 	hooksRegisters  map[IBFTType]HooksRegister
+	governance      *GovernanceHookRegister
+
+	// key rotation of the local validator
+	keyRotationLock      sync.RWMutex
+	keyRotation          *keyRotation
+	successorKeyManagers map[validators.ValidatorType]signer.KeyManager
+}
+
+// keyRotation is the key rotation of the local validator saved in local storage
+type keyRotation struct {
+	// Height is the height from which the successor key is used
+	Height uint64
 }
 
 // NewForkManager is a constructor of ForkManager
@@ -78,7 +118,9 @@ func NewForkManager(
 	logger hclog.Logger,
 	blockchain store.HeaderGetter,
 	executor contract.Executor,
+	chainParams *chain.Params,
 	secretManager secrets.SecretsManager,
+	remoteSigner *signer.RemoteSignerConfig,
 	filePath string,
 	epochSize uint64,
 	ibftConfig map[string]interface{},
@@ -88,17 +130,41 @@ func NewForkManager(
 		return nil, err
 	}
 
+	governanceConfig, err := GetGovernanceConfig(ibftConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	keyRotationConfig, err := GetKeyRotationConfig(ibftConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	fm := &ForkManager{
-		logger:          logger.Named(loggerName),
-		blockchain:      blockchain,
-		executor:        executor,
-		secretsManager:  secretManager,
-		filePath:        filePath,
-		epochSize:       epochSize,
-		forks:           forks,
-		keyManagers:     make(map[validators.ValidatorType]signer.KeyManager),
-		validatorStores: make(map[store.SourceType]ValidatorStore),
-		hooksRegisters:  make(map[IBFTType]HooksRegister),
+		logger:            logger.Named(loggerName),
+		blockchain:        blockchain,
+		executor:          executor,
+		chainParams:       chainParams,
+		secretsManager:    secretManager,
+		remoteSigner:      remoteSigner,
+		filePath:          filePath,
+		epochSize:         epochSize,
+		forks:             forks,
+		governanceConfig:  governanceConfig,
+		keyRotationConfig: keyRotationConfig,
+		keyManagers:       make(map[validators.ValidatorType]signer.KeyManager),
+		validatorStores:   make(map[store.SourceType]ValidatorStore),
+		hooksRegisters:    make(map[IBFTType]HooksRegister),
+
+		successorKeyManagers: make(map[validators.ValidatorType]signer.KeyManager),
+	}
+
+	if filePath != "" {
+		if fm.protectionDB, err = signer.NewSlashingProtectionDB(
+			filepath.Join(filePath, signer.SlashingProtectionFileName),
+		); err != nil {
+			return nil, fmt.Errorf("failed to load slashing protection database: %w", err)
+		}
 	}
 
 	// Need initialization of signers in the constructor
@@ -107,6 +173,10 @@ func NewForkManager(
 		return nil, err
 	}
 
+	if err := fm.loadKeyRotation(); err != nil {
+		return nil, err
+	}
+
 	return fm, nil
 }
 
@@ -118,6 +188,22 @@ func (m *ForkManager) Initialize() error {
 
 	m.initializeHooksRegisters()
 
+	if err := m.initializeGovernance(); err != nil {
+		return err
+	}
+
+	// the key rotation may have taken effect before restart
+	if m.keyRotation != nil {
+		if err := m.promoteKeyRotation(m.blockchain.Header().Number); err != nil {
+			return err
+		}
+	}
+
+	// the key rotation may not have been put in a header before restart
+	if err := m.proposeKeyRotation(); err != nil {
+		m.logger.Warn("failed to propose key rotation", "err", err)
+	}
+
 	return nil
 }
 
@@ -132,6 +218,23 @@ func (m *ForkManager) Close() error {
 	return nil
 }
 
+// RewindValidatorStores drops the data of the blocks above the height from the validator stores
+// It needs to be called after the head of the chain is set back to the height
+func (m *ForkManager) RewindValidatorStores(height uint64) error {
+	for _, store := range m.validatorStores {
+		rewindable, ok := store.(Rewindable)
+		if !ok {
+			continue
+		}
+
+		if err := rewindable.Rewind(height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetSigner returns a proper signer at specified height
 func (m *ForkManager) GetSigner(height uint64) (signer.Signer, error) {
 	keyManager, err := m.getKeyManager(height)
@@ -182,11 +285,45 @@ func (m *ForkManager) GetValidators(height uint64) (validators.Validators, error
 
 	return set.GetValidators(
 		height,
-		m.epochSize,
+		m.GetEpochSize(height),
 		fork.From.Value,
 	)
 }
 
+// GetEpochSize returns the epoch size at specified height,
+// which may have been changed by governance
+func (m *ForkManager) GetEpochSize(height uint64) uint64 {
+	if m.governance != nil {
+		return m.governance.EpochSize(height)
+	}
+
+	return m.epochSize
+}
+
+// GetEpoch returns the number of the epoch specified height is in
+func (m *ForkManager) GetEpoch(height uint64) uint64 {
+	if m.governance != nil {
+		return m.governance.Epoch(height)
+	}
+
+	return newEpochSchedule(m.epochSize).epochAt(height)
+}
+
+// IsLastOfEpoch returns whether specified height is the last of the epoch
+func (m *ForkManager) IsLastOfEpoch(height uint64) bool {
+	return height > 0 && height%m.GetEpochSize(height) == 0
+}
+
+// ReloadGovernance derives the chain parameters approved by governance from the state of the given header
+// It needs to be called after a reorg or a rewind which may have removed the blocks approving proposals
+func (m *ForkManager) ReloadGovernance(header *types.Header) error {
+	if m.governance == nil {
+		return nil
+	}
+
+	return m.governance.Load(header)
+}
+
 // GetHooks returns a hooks at specified height
 func (m *ForkManager) GetHooks(height uint64) HooksInterface {
 	hooks := &hook.Hooks{}
@@ -195,9 +332,40 @@ func (m *ForkManager) GetHooks(height uint64) HooksInterface {
 		r.RegisterHooks(hooks, height)
 	}
 
+	// governance hooks wrap the hooks registered above
+	if m.governance != nil {
+		m.governance.RegisterHooks(hooks, height)
+	}
+
+	m.registerKeyRotationHook(hooks, height)
+
 	return hooks
 }
 
+// registerKeyRotationHook registers the hook to promote the successor keys
+// once the block at the rotation height is inserted
+func (m *ForkManager) registerKeyRotationHook(hooks *hook.Hooks, height uint64) {
+	m.keyRotationLock.RLock()
+	rotation := m.keyRotation
+	m.keyRotationLock.RUnlock()
+
+	if rotation == nil || height < rotation.Height {
+		return
+	}
+
+	prevFunc := hooks.PostInsertBlockFunc
+
+	hooks.PostInsertBlockFunc = func(block *types.Block) error {
+		if prevFunc != nil {
+			if err := prevFunc(block); err != nil {
+				return err
+			}
+		}
+
+		return m.promoteKeyRotation(block.Number())
+	}
+}
+
 func (m *ForkManager) getValidatorStoreByIBFTFork(fork *IBFTFork) ValidatorStore {
 	set, ok := m.validatorStores[ibftTypesToSourceType[fork.Type]]
 	if !ok {
@@ -207,13 +375,43 @@ func (m *ForkManager) getValidatorStoreByIBFTFork(fork *IBFTFork) ValidatorStore
 	return set
 }
 
+// getKeyManager returns the key manager signing at the given height.
+// The successor keys sign from the rotation height only if the validator set at the height has the successor,
+// otherwise the validator would drop out of its own set
 func (m *ForkManager) getKeyManager(height uint64) (signer.KeyManager, error) {
+	m.keyRotationLock.RLock()
+	rotation := m.keyRotation
+	keyManagers := m.keyManagers
+	successorKeyManagers := m.successorKeyManagers
+	m.keyRotationLock.RUnlock()
+
+	if rotation != nil && height >= rotation.Height && m.isSuccessorRegistered(successorKeyManagers, height) {
+		keyManagers = successorKeyManagers
+	}
+
+	return m.getKeyManagerFrom(keyManagers, height)
+}
+
+// getSuccessorKeyManager returns the key manager of the successor keys at the given height
+func (m *ForkManager) getSuccessorKeyManager(height uint64) (signer.KeyManager, error) {
+	m.keyRotationLock.RLock()
+	successorKeyManagers := m.successorKeyManagers
+	m.keyRotationLock.RUnlock()
+
+	return m.getKeyManagerFrom(successorKeyManagers, height)
+}
+
+// getKeyManagerFrom returns the key manager for the validator type at the given height
+func (m *ForkManager) getKeyManagerFrom(
+	keyManagers map[validators.ValidatorType]signer.KeyManager,
+	height uint64,
+) (signer.KeyManager, error) {
 	fork := m.forks.getFork(height)
 	if fork == nil {
 		return nil, ErrForkNotFound
 	}
 
-	keyManager, ok := m.keyManagers[fork.ValidatorType]
+	keyManager, ok := keyManagers[fork.ValidatorType]
 	if !ok {
 		return nil, ErrKeyManagerNotFound
 	}
@@ -221,6 +419,285 @@ func (m *ForkManager) getKeyManager(height uint64) (signer.KeyManager, error) {
 	return keyManager, nil
 }
 
+// isSuccessorRegistered returns whether the validator set at the given height has the successor
+func (m *ForkManager) isSuccessorRegistered(
+	successorKeyManagers map[validators.ValidatorType]signer.KeyManager,
+	height uint64,
+) bool {
+	// the contract validators don't register key rotations,
+	// and fetching them would need the signer at the height
+	valStore, err := m.GetValidatorStore(height)
+	if err != nil || valStore.SourceType() == store.Contract {
+		return false
+	}
+
+	successorKeyManager, err := m.getKeyManagerFrom(successorKeyManagers, height)
+	if err != nil {
+		return false
+	}
+
+	successor, err := signer.KeyManagerToValidator(successorKeyManager)
+	if err != nil {
+		return false
+	}
+
+	vals, err := m.GetValidators(height)
+	if err != nil {
+		return false
+	}
+
+	index := vals.Index(successor.Addr())
+
+	return index != -1 && vals.At(uint64(index)).Equal(successor)
+}
+
+// RotateKey schedules the validator key to be switched to the successor key from the given height
+// and proposes the successor to the validator store.
+// It returns the address of the validator and the successor
+func (m *ForkManager) RotateKey(height uint64) (types.Address, validators.Validator, error) {
+	if m.remoteSigner != nil {
+		return types.ZeroAddress, nil, ErrKeyRotationNotSupported
+	}
+
+	latestHeight := m.blockchain.Header().Number
+	if height <= latestHeight+2 {
+		return types.ZeroAddress, nil, ErrInvalidKeyRotationHeight
+	}
+
+	// the previous rotation needs to be completed before scheduling another one
+	if err := m.promoteKeyRotation(latestHeight); err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	valStore, err := m.GetValidatorStore(height)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	// the staking contract has no registration of the successor keys
+	if valStore.SourceType() == store.Contract {
+		return types.ZeroAddress, nil, ErrKeyRotationByContract
+	}
+
+	m.keyRotationLock.Lock()
+
+	if m.keyRotation != nil && m.keyRotation.Height != height {
+		m.keyRotationLock.Unlock()
+
+		return types.ZeroAddress, nil, ErrKeyRotationScheduled
+	}
+
+	if err := m.initializeSuccessorKeyManagers(); err != nil {
+		m.keyRotationLock.Unlock()
+
+		return types.ZeroAddress, nil, err
+	}
+
+	previous := m.keyRotation
+	m.keyRotation = &keyRotation{Height: height}
+
+	if err := m.saveKeyRotation(); err != nil {
+		m.keyRotation = previous
+		m.keyRotationLock.Unlock()
+
+		return types.ZeroAddress, nil, err
+	}
+
+	m.keyRotationLock.Unlock()
+
+	validator, successor, err := m.getKeyRotationValidators(latestHeight+1, height)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	if err := m.proposeKeyRotation(); err != nil {
+		m.keyRotationLock.Lock()
+		defer m.keyRotationLock.Unlock()
+
+		// cancel the rotation which is not registered
+		m.keyRotation = previous
+
+		if rmErr := m.saveKeyRotation(); rmErr != nil {
+			m.logger.Error("failed to cancel key rotation", "err", rmErr)
+		}
+
+		return types.ZeroAddress, nil, err
+	}
+
+	return validator, successor, nil
+}
+
+// proposeKeyRotation proposes the scheduled key rotation to the validator store
+// if the rotation hasn't taken effect yet and the store registers key rotations in headers
+func (m *ForkManager) proposeKeyRotation() error {
+	m.keyRotationLock.RLock()
+	rotation := m.keyRotation
+	m.keyRotationLock.RUnlock()
+
+	if rotation == nil {
+		return nil
+	}
+
+	latestHeight := m.blockchain.Header().Number
+	if rotation.Height <= latestHeight+1 {
+		// the rotation has taken effect
+		return nil
+	}
+
+	valStore, err := m.GetValidatorStore(latestHeight + 1)
+	if err != nil {
+		return err
+	}
+
+	rotatable, ok := valStore.(KeyRotatable)
+	if !ok {
+		return ErrKeyRotationByContract
+	}
+
+	validator, successor, err := m.getKeyRotationValidators(latestHeight+1, rotation.Height)
+	if err != nil {
+		return err
+	}
+
+	successorKeyManager, err := m.getSuccessorKeyManager(rotation.Height)
+	if err != nil {
+		return err
+	}
+
+	proof, err := signer.CreateKeyRotationProof(successorKeyManager)
+	if err != nil {
+		return err
+	}
+
+	return rotatable.ProposeKeyRotation(validator, successor, proof, rotation.Height)
+}
+
+// keyRotationFrom returns the height from which the headers can register key rotations,
+// or nil if key rotation is not enabled
+func (m *ForkManager) keyRotationFrom() *uint64 {
+	if m.keyRotationConfig == nil {
+		return nil
+	}
+
+	from := m.keyRotationConfig.From.Value
+
+	return &from
+}
+
+// getKeyRotationValidators returns the address of the validator at the current height
+// and the successor at the rotation height
+func (m *ForkManager) getKeyRotationValidators(
+	currentHeight, rotationHeight uint64,
+) (types.Address, validators.Validator, error) {
+	currentKeyManager, err := m.getKeyManager(currentHeight)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	successorKeyManager, err := m.getSuccessorKeyManager(rotationHeight)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	successor, err := signer.KeyManagerToValidator(successorKeyManager)
+	if err != nil {
+		return types.ZeroAddress, nil, err
+	}
+
+	return currentKeyManager.Address(), successor, nil
+}
+
+// loadKeyRotation loads the scheduled key rotation from local storage
+func (m *ForkManager) loadKeyRotation() error {
+	if m.filePath == "" {
+		return nil
+	}
+
+	var rotation *keyRotation
+	if err := readDataStore(filepath.Join(m.filePath, keyRotationFilename), &rotation); err != nil {
+		return fmt.Errorf("failed to load key rotation: %w", err)
+	}
+
+	if rotation == nil {
+		return nil
+	}
+
+	if m.remoteSigner != nil {
+		return ErrKeyRotationNotSupported
+	}
+
+	// the successor keys are removed after they've been promoted to the validator keys,
+	// the rotation had been completed except for the removal of the file
+	if !m.secretsManager.HasSecret(secrets.ValidatorSuccessorKey) {
+		return m.saveKeyRotation()
+	}
+
+	m.keyRotation = rotation
+
+	return m.initializeSuccessorKeyManagers()
+}
+
+// promoteKeyRotation completes the key rotation which has taken effect by the given height.
+// The successor keys replace the validator keys and are removed with the key rotation,
+// so that new successor keys are created for the next rotation.
+// The rotation is cancelled if the successor hasn't been registered by the height
+func (m *ForkManager) promoteKeyRotation(latestHeight uint64) error {
+	m.keyRotationLock.Lock()
+	defer m.keyRotationLock.Unlock()
+
+	if m.keyRotation == nil || latestHeight < m.keyRotation.Height {
+		return nil
+	}
+
+	if !m.isSuccessorRegistered(m.successorKeyManagers, latestHeight+1) {
+		m.logger.Warn(
+			"cancel key rotation because the successor hasn't been registered",
+			"height", m.keyRotation.Height,
+		)
+
+		m.keyRotation = nil
+		m.successorKeyManagers = make(map[validators.ValidatorType]signer.KeyManager)
+
+		return m.saveKeyRotation()
+	}
+
+	if err := signer.PromoteSuccessorKeys(m.secretsManager); err != nil {
+		return fmt.Errorf("failed to promote successor keys: %w", err)
+	}
+
+	m.keyManagers = m.successorKeyManagers
+	m.successorKeyManagers = make(map[validators.ValidatorType]signer.KeyManager)
+	m.keyRotation = nil
+
+	if err := m.saveKeyRotation(); err != nil {
+		return err
+	}
+
+	m.logger.Info("completed key rotation", "height", latestHeight)
+
+	return nil
+}
+
+// saveKeyRotation saves the scheduled key rotation into local storage
+// unsafe against concurrent access
+func (m *ForkManager) saveKeyRotation() error {
+	if m.filePath == "" {
+		return nil
+	}
+
+	path := filepath.Join(m.filePath, keyRotationFilename)
+
+	if m.keyRotation == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	return writeDataStore(path, m.keyRotation)
+}
+
 // initializeKeyManagers initialize all key managers based on Fork configuration
 func (m *ForkManager) initializeKeyManagers() error {
 	for _, fork := range m.forks {
@@ -238,16 +715,53 @@ func (m *ForkManager) initializeKeyManager(valType validators.ValidatorType) err
 		return nil
 	}
 
-	keyManager, err := signer.NewKeyManagerFromType(m.secretsManager, valType)
+	var (
+		keyManager signer.KeyManager
+		err        error
+	)
+
+	if m.remoteSigner != nil {
+		keyManager, err = signer.NewRemoteKeyManager(m.remoteSigner, valType)
+	} else {
+		keyManager, err = signer.NewKeyManagerFromType(m.secretsManager, valType)
+	}
+
 	if err != nil {
 		return err
 	}
 
+	if m.protectionDB != nil {
+		keyManager = signer.NewProtectedKeyManager(keyManager, m.protectionDB)
+	}
+
 	m.keyManagers[valType] = keyManager
 
 	return nil
 }
 
+// initializeSuccessorKeyManagers initializes the key managers of the successor keys
+// for all validator types in Fork configuration
+func (m *ForkManager) initializeSuccessorKeyManagers() error {
+	for _, fork := range m.forks {
+		if _, ok := m.successorKeyManagers[fork.ValidatorType]; ok {
+			continue
+		}
+
+		keyManager, err := signer.NewSuccessorKeyManagerFromType(m.secretsManager, fork.ValidatorType)
+		if err != nil {
+			return err
+		}
+
+		if m.protectionDB != nil {
+			keyManager = signer.NewProtectedKeyManager(keyManager, m.protectionDB)
+		}
+
+		m.successorKeyManagers[fork.ValidatorType] = keyManager
+	}
+
+	return nil
+}
+
 // initializeValidatorStores initializes all validator sets based on Fork configuration
 func (m *ForkManager) initializeValidatorStores() error {
 	for _, fork := range m.forks {
@@ -273,12 +787,15 @@ func (m *ForkManager) initializeValidatorStore(setType store.SourceType) error {
 
 	switch setType {
 	case store.Snapshot:
+		// the votes in snapshots are reset by the epoch size of genesis,
+		// the epoch size changed by governance applies to the contract validators
 		valStore, err = NewSnapshotValidatorStoreWrapper(
 			m.logger,
 			m.blockchain,
 			m.GetSigner,
 			m.filePath,
 			m.epochSize,
+			m.keyRotationFrom(),
 		)
 	case store.Contract:
 		valStore, err = NewContractValidatorStoreWrapper(
@@ -320,7 +837,26 @@ func (m *ForkManager) initializeHooksRegister(ibftType IBFTType) {
 	case PoS:
 		m.hooksRegisters[PoS] = NewPoSHookRegister(
 			m.forks,
-			m.epochSize,
+			m.IsLastOfEpoch,
 		)
 	}
 }
+
+// initializeGovernance initializes GovernanceHookRegister if governance is enabled
+// and restores the chain parameters approved in the past blocks
+func (m *ForkManager) initializeGovernance() error {
+	if m.governanceConfig == nil {
+		return nil
+	}
+
+	m.governance = NewGovernanceHookRegister(
+		m.logger,
+		m.governanceConfig,
+		m.epochSize,
+		m.chainParams,
+		m.executor,
+		m.GetValidators,
+	)
+
+	return m.governance.Load(m.blockchain.Header())
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/helper/common"
 	testHelper "github.com/ExzoNetwork/ExzoCoin/helper/tests"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
+	"github.com/ExzoNetwork/ExzoCoin/secrets/local"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/ExzoNetwork/ExzoCoin/validators"
 	"github.com/ExzoNetwork/ExzoCoin/validators/store"
@@ -24,16 +25,50 @@ type mockValidatorStore struct {
 
 	CloseFunc         func() error
 	GetValidatorsFunc func(uint64, uint64, uint64) (validators.Validators, error)
+	SourceTypeFunc    func() store.SourceType
 }
 
 func (m *mockValidatorStore) Close() error {
 	return m.CloseFunc()
 }
 
+func (m *mockValidatorStore) SourceType() store.SourceType {
+	return m.SourceTypeFunc()
+}
+
 func (m *mockValidatorStore) GetValidators(height, epoch, from uint64) (validators.Validators, error) {
 	return m.GetValidatorsFunc(height, epoch, from)
 }
 
+type mockKeyRotatableStore struct {
+	mockValidatorStore
+
+	ProposeKeyRotationFunc func(types.Address, validators.Validator, []byte, uint64) error
+}
+
+func (m *mockKeyRotatableStore) SourceType() store.SourceType {
+	return store.Snapshot
+}
+
+func (m *mockKeyRotatableStore) ProposeKeyRotation(
+	validator types.Address,
+	successor validators.Validator,
+	proof []byte,
+	height uint64,
+) error {
+	return m.ProposeKeyRotationFunc(validator, successor, proof, height)
+}
+
+type mockRewindableStore struct {
+	mockValidatorStore
+
+	RewindFunc func(uint64) error
+}
+
+func (m *mockRewindableStore) Rewind(height uint64) error {
+	return m.RewindFunc(height)
+}
+
 type mockHooksRegister struct {
 	RegisterHooksFunc func(hooks *hook.Hooks, height uint64)
 }
@@ -76,6 +111,8 @@ func TestNewForkManager(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 			"",
 			0,
 			map[string]interface{}{},
@@ -104,7 +141,9 @@ func TestNewForkManager(t *testing.T) {
 			logger,
 			nil,
 			nil,
+			nil,
 			secretManager,
+			nil,
 			"",
 			epochSize,
 			map[string]interface{}{
@@ -152,7 +191,9 @@ func TestNewForkManager(t *testing.T) {
 			logger,
 			blockchain,
 			nil,
+			nil,
 			secretManager,
+			nil,
 			dirPath,
 			epochSize,
 			map[string]interface{}{
@@ -233,7 +274,9 @@ func TestNewForkManager(t *testing.T) {
 			logger,
 			blockchain,
 			nil,
+			nil,
 			secretManager,
+			nil,
 			dirPath,
 			epochSize,
 			map[string]interface{}{
@@ -278,7 +321,9 @@ func TestNewForkManager(t *testing.T) {
 			logger,
 			nil,
 			nil,
+			nil,
 			secretManager,
+			nil,
 			"",
 			epochSize,
 			map[string]interface{}{
@@ -362,6 +407,48 @@ type MockKeyManager struct {
 	ValType validators.ValidatorType
 }
 
+func TestForkManagerRewindValidatorStores(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should rewind the rewindable stores", func(t *testing.T) {
+		t.Parallel()
+
+		var rewoundHeight uint64
+
+		fm := &ForkManager{
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Contract: &mockValidatorStore{},
+				store.Snapshot: &mockRewindableStore{
+					RewindFunc: func(height uint64) error {
+						rewoundHeight = height
+
+						return nil
+					},
+				},
+			},
+		}
+
+		assert.NoError(t, fm.RewindValidatorStores(10))
+		assert.Equal(t, uint64(10), rewoundHeight)
+	})
+
+	t.Run("should return error if Rewind fails", func(t *testing.T) {
+		t.Parallel()
+
+		fm := &ForkManager{
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Snapshot: &mockRewindableStore{
+					RewindFunc: func(uint64) error {
+						return errTest
+					},
+				},
+			},
+		}
+
+		assert.Equal(t, errTest, fm.RewindValidatorStores(10))
+	})
+}
+
 func TestForkManagerGetSigner(t *testing.T) {
 	t.Parallel()
 
@@ -924,3 +1011,406 @@ func TestForkManager_initializeHooksRegisters(t *testing.T) {
 		fm.hooksRegisters[PoS],
 	)
 }
+
+func TestForkManagerKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	key, _, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	assert.NoError(t, err)
+
+	successorKey, successorKeyBytes, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	assert.NoError(t, err)
+
+	var (
+		keyManager          = signer.NewECDSAKeyManagerFromKey(key)
+		successorKeyManager = signer.NewECDSAKeyManagerFromKey(successorKey)
+		forks               = IBFTForks{
+			{
+				Type:          PoA,
+				ValidatorType: validators.ECDSAValidatorType,
+				From:          common.JSONNumber{Value: 0},
+			},
+		}
+	)
+
+	newKeyRotatableStore := func(registeredFrom uint64) *mockKeyRotatableStore {
+		return &mockKeyRotatableStore{
+			mockValidatorStore: mockValidatorStore{
+				GetValidatorsFunc: func(height, _, _ uint64) (validators.Validators, error) {
+					if height >= registeredFrom {
+						return validators.NewECDSAValidatorSet(
+							validators.NewECDSAValidator(successorKeyManager.Address()),
+						), nil
+					}
+
+					return validators.NewECDSAValidatorSet(
+						validators.NewECDSAValidator(keyManager.Address()),
+					), nil
+				},
+			},
+		}
+	}
+
+	t.Run("should switch to the successor key from the rotation height", func(t *testing.T) {
+		t.Parallel()
+
+		fm := &ForkManager{
+			forks: forks,
+			keyManagers: map[validators.ValidatorType]signer.KeyManager{
+				validators.ECDSAValidatorType: keyManager,
+			},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Snapshot: newKeyRotatableStore(10),
+			},
+			successorKeyManagers: map[validators.ValidatorType]signer.KeyManager{
+				validators.ECDSAValidatorType: successorKeyManager,
+			},
+			keyRotation: &keyRotation{Height: 10},
+		}
+
+		tests := []struct {
+			height         uint64
+			expectedSigner signer.Signer
+		}{
+			{9, signer.NewSigner(keyManager, keyManager)},
+			{10, signer.NewSigner(successorKeyManager, keyManager)},
+			{11, signer.NewSigner(successorKeyManager, successorKeyManager)},
+		}
+
+		for _, test := range tests {
+			signer, err := fm.GetSigner(test.height)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSigner, signer)
+		}
+	})
+
+	t.Run("should keep the validator key if the successor hasn't been registered", func(t *testing.T) {
+		t.Parallel()
+
+		fm := &ForkManager{
+			forks: forks,
+			keyManagers: map[validators.ValidatorType]signer.KeyManager{
+				validators.ECDSAValidatorType: keyManager,
+			},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				// the successor is registered late
+				store.Snapshot: newKeyRotatableStore(12),
+			},
+			successorKeyManagers: map[validators.ValidatorType]signer.KeyManager{
+				validators.ECDSAValidatorType: successorKeyManager,
+			},
+			keyRotation: &keyRotation{Height: 10},
+		}
+
+		tests := []struct {
+			height         uint64
+			expectedSigner signer.Signer
+		}{
+			{10, signer.NewSigner(keyManager, keyManager)},
+			{11, signer.NewSigner(keyManager, keyManager)},
+			{12, signer.NewSigner(successorKeyManager, keyManager)},
+		}
+
+		for _, test := range tests {
+			signer, err := fm.GetSigner(test.height)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSigner, signer)
+		}
+	})
+
+	t.Run("should load the saved key rotation with successor keys", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := t.TempDir()
+
+		fm := &ForkManager{
+			forks:       forks,
+			filePath:    dirPath,
+			keyRotation: &keyRotation{Height: 10},
+		}
+
+		assert.NoError(t, fm.saveKeyRotation())
+
+		loaded := &ForkManager{
+			forks:    forks,
+			filePath: dirPath,
+			secretsManager: &mockSecretManager{
+				HasSecretFunc: func(name string) bool {
+					assert.Equal(t, secrets.ValidatorSuccessorKey, name)
+
+					return true
+				},
+				GetSecretFunc: func(name string) ([]byte, error) {
+					assert.Equal(t, secrets.ValidatorSuccessorKey, name)
+
+					return successorKeyBytes, nil
+				},
+			},
+			successorKeyManagers: map[validators.ValidatorType]signer.KeyManager{},
+		}
+
+		assert.NoError(t, loaded.loadKeyRotation())
+		assert.Equal(t, &keyRotation{Height: 10}, loaded.keyRotation)
+		assert.Equal(
+			t,
+			map[validators.ValidatorType]signer.KeyManager{
+				validators.ECDSAValidatorType: successorKeyManager,
+			},
+			loaded.successorKeyManagers,
+		)
+
+		// cancel the rotation
+		fm.keyRotation = nil
+		assert.NoError(t, fm.saveKeyRotation())
+
+		loaded.keyRotation = nil
+		assert.NoError(t, loaded.loadKeyRotation())
+		assert.Nil(t, loaded.keyRotation)
+	})
+
+	t.Run("should rotate the key again once the rotation has taken effect", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := t.TempDir()
+
+		secretsManager, err := local.SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path: dirPath,
+			},
+		})
+		assert.NoError(t, err)
+
+		var (
+			latestHeight uint64
+			proposals    = make(map[uint64]validators.Validator)
+			// the successors are registered as soon as they're proposed
+			registered = validators.NewECDSAValidatorSet()
+		)
+
+		fm := &ForkManager{
+			logger: hclog.NewNullLogger(),
+			blockchain: &store.MockBlockchain{
+				HeaderFn: func() *types.Header {
+					return &types.Header{Number: latestHeight}
+				},
+			},
+			secretsManager: secretsManager,
+			forks:          forks,
+			filePath:       dirPath,
+			keyManagers:    map[validators.ValidatorType]signer.KeyManager{},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Snapshot: &mockKeyRotatableStore{
+					mockValidatorStore: mockValidatorStore{
+						GetValidatorsFunc: func(_, _, _ uint64) (validators.Validators, error) {
+							return registered, nil
+						},
+					},
+					ProposeKeyRotationFunc: func(
+						_ types.Address,
+						successor validators.Validator,
+						proof []byte,
+						height uint64,
+					) error {
+						// ECDSA key needs no proof
+						assert.Nil(t, proof)
+
+						proposals[height] = successor
+
+						return registered.Add(successor)
+					},
+				},
+			},
+			successorKeyManagers: map[validators.ValidatorType]signer.KeyManager{},
+		}
+
+		assert.NoError(t, fm.initializeKeyManagers())
+
+		original := fm.keyManagers[validators.ECDSAValidatorType].Address()
+
+		validator, first, err := fm.RotateKey(10)
+		assert.NoError(t, err)
+		assert.Equal(t, original, validator)
+		assert.Equal(t, first, proposals[10])
+
+		// the first rotation hasn't taken effect yet
+		_, _, err = fm.RotateKey(20)
+		assert.ErrorIs(t, err, ErrKeyRotationScheduled)
+
+		// the block at the rotation height is inserted
+		latestHeight = 10
+
+		assert.NoError(t, fm.GetHooks(10).PostInsertBlock(&types.Block{
+			Header: &types.Header{Number: 10},
+		}))
+
+		assert.Nil(t, fm.keyRotation)
+		assert.NoFileExists(t, path.Join(dirPath, keyRotationFilename))
+		assert.False(t, secretsManager.HasSecret(secrets.ValidatorSuccessorKey))
+
+		// the successor key has been promoted to the validator key
+		promoted, err := signer.NewKeyManagerFromType(secretsManager, validators.ECDSAValidatorType)
+		assert.NoError(t, err)
+		assert.Equal(t, first.Addr(), promoted.Address())
+
+		currentSigner, err := fm.GetSigner(11)
+		assert.NoError(t, err)
+		assert.Equal(t, first.Addr(), currentSigner.Address())
+
+		validator, second, err := fm.RotateKey(20)
+		assert.NoError(t, err)
+		assert.Equal(t, first.Addr(), validator)
+		assert.Equal(t, second, proposals[20])
+		assert.NotEqual(t, first.Addr(), second.Addr())
+		assert.NotEqual(t, original, second.Addr())
+	})
+
+	t.Run("should cancel the key rotation whose successor hasn't been registered", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := t.TempDir()
+
+		secretsManager, err := local.SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path: dirPath,
+			},
+		})
+		assert.NoError(t, err)
+
+		var latestHeight uint64
+
+		fm := &ForkManager{
+			logger: hclog.NewNullLogger(),
+			blockchain: &store.MockBlockchain{
+				HeaderFn: func() *types.Header {
+					return &types.Header{Number: latestHeight}
+				},
+			},
+			secretsManager: secretsManager,
+			forks:          forks,
+			filePath:       dirPath,
+			keyManagers:    map[validators.ValidatorType]signer.KeyManager{},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Snapshot: &mockKeyRotatableStore{
+					mockValidatorStore: mockValidatorStore{
+						// the header registering the successor has never been mined
+						GetValidatorsFunc: func(_, _, _ uint64) (validators.Validators, error) {
+							return validators.NewECDSAValidatorSet(), nil
+						},
+					},
+					ProposeKeyRotationFunc: func(types.Address, validators.Validator, []byte, uint64) error {
+						return nil
+					},
+				},
+			},
+			successorKeyManagers: map[validators.ValidatorType]signer.KeyManager{},
+		}
+
+		assert.NoError(t, fm.initializeKeyManagers())
+
+		original := fm.keyManagers[validators.ECDSAValidatorType].Address()
+
+		_, _, err = fm.RotateKey(10)
+		assert.NoError(t, err)
+
+		currentSigner, err := fm.GetSigner(10)
+		assert.NoError(t, err)
+		assert.Equal(t, original, currentSigner.Address())
+
+		// the block at the rotation height is inserted
+		latestHeight = 10
+
+		assert.NoError(t, fm.GetHooks(10).PostInsertBlock(&types.Block{
+			Header: &types.Header{Number: 10},
+		}))
+
+		assert.Nil(t, fm.keyRotation)
+		assert.NoFileExists(t, path.Join(dirPath, keyRotationFilename))
+
+		// the validator key is kept
+		kept, err := signer.NewKeyManagerFromType(secretsManager, validators.ECDSAValidatorType)
+		assert.NoError(t, err)
+		assert.Equal(t, original, kept.Address())
+
+		currentSigner, err = fm.GetSigner(11)
+		assert.NoError(t, err)
+		assert.Equal(t, original, currentSigner.Address())
+	})
+
+	t.Run("should return error for contract validators", func(t *testing.T) {
+		t.Parallel()
+
+		fm := &ForkManager{
+			logger: hclog.NewNullLogger(),
+			blockchain: &store.MockBlockchain{
+				HeaderFn: func() *types.Header {
+					return &types.Header{Number: 0}
+				},
+			},
+			forks: IBFTForks{
+				{
+					Type:          PoS,
+					ValidatorType: validators.ECDSAValidatorType,
+					From:          common.JSONNumber{Value: 0},
+				},
+			},
+			validatorStores: map[store.SourceType]ValidatorStore{
+				store.Contract: &mockValidatorStore{
+					SourceTypeFunc: func() store.SourceType {
+						return store.Contract
+					},
+				},
+			},
+		}
+
+		_, _, err := fm.RotateKey(10)
+
+		assert.ErrorIs(t, err, ErrKeyRotationByContract)
+		assert.Nil(t, fm.keyRotation)
+	})
+
+	t.Run("should drop the saved key rotation whose successor keys have been promoted", func(t *testing.T) {
+		t.Parallel()
+
+		dirPath := t.TempDir()
+
+		fm := &ForkManager{
+			forks:       forks,
+			filePath:    dirPath,
+			keyRotation: &keyRotation{Height: 10},
+		}
+
+		assert.NoError(t, fm.saveKeyRotation())
+
+		loaded := &ForkManager{
+			forks:    forks,
+			filePath: dirPath,
+			secretsManager: &mockSecretManager{
+				HasSecretFunc: func(name string) bool {
+					return false
+				},
+			},
+			successorKeyManagers: map[validators.ValidatorType]signer.KeyManager{},
+		}
+
+		assert.NoError(t, loaded.loadKeyRotation())
+		assert.Nil(t, loaded.keyRotation)
+		assert.NoFileExists(t, path.Join(dirPath, keyRotationFilename))
+	})
+
+	t.Run("should return error for remote signer", func(t *testing.T) {
+		t.Parallel()
+
+		fm := &ForkManager{
+			forks:        forks,
+			remoteSigner: &signer.RemoteSignerConfig{},
+		}
+
+		_, _, err := fm.RotateKey(10)
+
+		assert.ErrorIs(t, err, ErrKeyRotationNotSupported)
+	})
+}
@@ -50,6 +50,7 @@ func NewSnapshotValidatorStoreWrapper(
 	getSigner func(uint64) (signer.Signer, error),
 	dirPath string,
 	epochSize uint64,
+	keyRotationFrom *uint64,
 ) (*SnapshotValidatorStoreWrapper, error) {
 	snapshotMeta, err := loadSnapshotMetadata(filepath.Join(dirPath, snapshotMetadataFilename))
 	if err != nil {
@@ -73,6 +74,7 @@ func NewSnapshotValidatorStoreWrapper(
 			return snapshot.SignerInterface(rawSigner), nil
 		},
 		epochSize,
+		keyRotationFrom,
 		snapshotMeta,
 		snapshots,
 	)
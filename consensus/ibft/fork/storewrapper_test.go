@@ -128,6 +128,7 @@ func TestSnapshotValidatorStoreWrapper(t *testing.T) {
 				},
 				dirPath,
 				test.epochSize,
+				nil,
 			)
 
 			testHelper.AssertErrorMessageContains(
@@ -178,6 +179,7 @@ func TestSnapshotValidatorStoreWrapperGetValidators(t *testing.T) {
 			return nil, nil
 		},
 		epochSize,
+		nil,
 		metadata,
 		snapshots,
 	)
@@ -226,6 +228,7 @@ func TestSnapshotValidatorStoreWrapperClose(t *testing.T) {
 			return nil, nil
 		},
 		epochSize,
+		nil,
 		metadata,
 		snapshots,
 	)
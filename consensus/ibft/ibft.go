@@ -1,6 +1,7 @@
 package ibft
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -26,6 +27,10 @@ const (
 	IbftKeyName      = "validator.key"
 	KeyEpochSize     = "epochSize"
 
+	// KeySealBitmapBlockNum is the block number from which the bitmaps of the aggregated seals
+	// must not refer to the validators out of the set
+	KeySealBitmapBlockNum = "sealBitmapBlockNum"
+
 	ibftProto = "/ibft/0.2"
 )
 
@@ -36,6 +41,8 @@ var (
 	ErrInvalidSha3Uncles            = errors.New("invalid sha3 uncles")
 	ErrWrongDifficulty              = errors.New("wrong difficulty")
 	ErrParentCommittedSealsNotFound = errors.New("parent committed seals not found")
+	ErrBlockNotFound                = errors.New("block not found")
+	ErrLightModeNotSupported        = errors.New("light mode supports only PoA")
 )
 
 type txPoolInterface interface {
@@ -55,6 +62,11 @@ type forkManagerInterface interface {
 	GetValidatorStore(uint64) (fork.ValidatorStore, error)
 	GetValidators(uint64) (validators.Validators, error)
 	GetHooks(uint64) fork.HooksInterface
+	RotateKey(uint64) (types.Address, validators.Validator, error)
+	GetEpoch(uint64) uint64
+	IsLastOfEpoch(uint64) bool
+	ReloadGovernance(*types.Header) error
+	RewindValidatorStores(uint64) error
 }
 
 // backendIBFT represents the IBFT consensus mechanism object
@@ -82,10 +94,11 @@ type backendIBFT struct {
 
 	// Configurations
 	config             *consensus.Config // Consensus configuration
-	epochSize          uint64
 	quorumSizeBlockNum uint64
+	sealBitmapBlockNum *uint64       // Block number from which the seal bitmaps are checked, if set
 	blockTime          time.Duration // Minimum block generation time in seconds
 	sealing            bool          // Flag indicating if the node is a sealer
+	light              bool          // Flag indicating if the node keeps only headers
 
 	// Channels
 	closeCh chan struct{} // Channel for closing
@@ -119,13 +132,40 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		quorumSizeBlockNum = uint64(readBlockNum)
 	}
 
+	var sealBitmapBlockNum *uint64
+
+	if rawBlockNum, ok := params.Config.Config[KeySealBitmapBlockNum]; ok {
+		// Block number specified for the seal bitmap check
+		readBlockNum, ok := rawBlockNum.(float64)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+
+		blockNum := uint64(readBlockNum)
+		sealBitmapBlockNum = &blockNum
+	}
+
 	logger := params.Logger.Named("ibft")
 
+	// the validators of PoS are in the state, which the light node doesn't have
+	if params.Light {
+		forks, err := fork.GetIBFTForks(params.Config.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		if !forks.IsPoAOnly() {
+			return nil, ErrLightModeNotSupported
+		}
+	}
+
 	forkManager, err := fork.NewForkManager(
 		logger,
 		params.Blockchain,
 		params.Executor,
+		params.Config.Params,
 		params.SecretsManager,
+		params.RemoteSigner,
 		params.Config.Path,
 		epochSize,
 		params.Config.Config,
@@ -135,19 +175,28 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		return nil, err
 	}
 
+	var (
+		blockTimeout = time.Duration(params.BlockTime) * 3 * time.Second
+		blockSyncer  syncer.Syncer
+	)
+
+	if params.Light {
+		blockSyncer = syncer.NewLightSyncer(params.Logger, params.Network, params.Blockchain, blockTimeout)
+	} else {
+		// the state proves its values to the light peers
+		prover, _ := params.Executor.State().(syncer.StateProver)
+
+		blockSyncer = syncer.NewSyncer(params.Logger, params.Network, params.Blockchain, prover, blockTimeout)
+	}
+
 	p := &backendIBFT{
 		// References
-		logger:     logger,
-		blockchain: params.Blockchain,
-		network:    params.Network,
-		executor:   params.Executor,
-		txpool:     params.TxPool,
-		syncer: syncer.NewSyncer(
-			params.Logger,
-			params.Network,
-			params.Blockchain,
-			time.Duration(params.BlockTime)*3*time.Second,
-		),
+		logger:         logger,
+		blockchain:     params.Blockchain,
+		network:        params.Network,
+		executor:       params.Executor,
+		txpool:         params.TxPool,
+		syncer:         blockSyncer,
 		secretsManager: params.SecretsManager,
 		Grpc:           params.Grpc,
 		metrics:        params.Metrics,
@@ -155,10 +204,11 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 
 		// Configurations
 		config:             params.Config,
-		epochSize:          epochSize,
 		quorumSizeBlockNum: quorumSizeBlockNum,
+		sealBitmapBlockNum: sealBitmapBlockNum,
 		blockTime:          time.Duration(params.BlockTime) * time.Second,
 		sealing:            params.Seal,
+		light:              params.Light,
 
 		// Channels
 		closeCh: make(chan struct{}),
@@ -177,9 +227,11 @@ func (i *backendIBFT) Initialize() error {
 		proto.RegisterIbftOperatorServer(i.Grpc, i.operator)
 	}
 
-	// start the transport protocol
-	if err := i.setupTransport(); err != nil {
-		return err
+	// start the transport protocol, the light node doesn't take part in the consensus
+	if !i.light {
+		if err := i.setupTransport(); err != nil {
+			return err
+		}
 	}
 
 	// initialize fork manager
@@ -228,6 +280,23 @@ func (i *backendIBFT) startSyncing() {
 	}
 }
 
+// startSyncingHeaders runs the syncer in the background to receive headers from advanced peers
+func (i *backendIBFT) startSyncingHeaders() {
+	callUpdateModules := func(header *types.Header) bool {
+		if err := i.updateCurrentModules(header.Number + 1); err != nil {
+			i.logger.Error("failed to update sub modules", "height", header.Number+1, "err", err)
+		}
+
+		return false
+	}
+
+	if err := i.syncer.SyncHeaders(
+		callUpdateModules,
+	); err != nil {
+		i.logger.Error("watch header sync failed", "err", err)
+	}
+}
+
 // Start starts the IBFT consensus
 func (i *backendIBFT) Start() error {
 	// Start the syncer
@@ -235,20 +304,66 @@ func (i *backendIBFT) Start() error {
 		return err
 	}
 
+	// The light node only follows the headers
+	if i.light {
+		go i.startSyncingHeaders()
+
+		return nil
+	}
+
 	// Start syncing blocks from other peers
 	go i.startSyncing()
 
+	// Revert the governance changes in the blocks removed from the chain
+	go i.watchReorgs()
+
 	// Start the actual consensus protocol
 	go i.startConsensus()
 
 	return nil
 }
 
+// GetStateValue returns the value of the key in the trie with the root,
+// proven by the full peers. It's meant for the light node which has no state
+func (i *backendIBFT) GetStateValue(root types.Hash, key []byte) ([]byte, error) {
+	return i.syncer.GetStateValue(root, key)
+}
+
 // GetSyncProgression gets the latest sync progression, if any
 func (i *backendIBFT) GetSyncProgression() *progress.Progression {
 	return i.syncer.GetSyncProgression()
 }
 
+// watchReorgs reloads the chain parameters approved by governance
+// when blocks are removed from the chain by a reorg or a rewind,
+// and drops the validator snapshots of the blocks removed by SetHead
+func (i *backendIBFT) watchReorgs() {
+	sub := i.blockchain.SubscribeEvents()
+	defer sub.Close()
+
+	for {
+		select {
+		case ev := <-sub.GetEventCh():
+			if ev.Type != blockchain.EventReorg {
+				continue
+			}
+
+			// the removed blocks are above the new head
+			if ev.Source == blockchain.SetHeadSource && len(ev.OldChain) > 0 {
+				if err := i.forkManager.RewindValidatorStores(ev.OldChain[0].Number - 1); err != nil {
+					i.logger.Error("failed to rewind validator stores after sethead", "err", err)
+				}
+			}
+
+			if err := i.forkManager.ReloadGovernance(i.blockchain.Header()); err != nil {
+				i.logger.Error("failed to reload governance after reorg", "err", err)
+			}
+		case <-i.closeCh:
+			return
+		}
+	}
+}
+
 func (i *backendIBFT) startConsensus() {
 	var (
 		newBlockSub   = i.blockchain.SubscribeEvents()
@@ -261,7 +376,7 @@ func (i *backendIBFT) startConsensus() {
 	go func() {
 		for {
 			if ev := <-newBlockSub.GetEventCh(); ev.Source == "syncer" {
-				if ev.NewChain[0].Number < i.blockchain.Header().Number {
+				if ev.Header().Number < i.blockchain.Header().Number {
 					// The blockchain notification system can eventually deliver
 					// stale block notifications. These should be ignored
 					continue
@@ -440,7 +555,23 @@ func (i *backendIBFT) VerifyHeader(header *types.Header) error {
 		return err
 	}
 
-	return nil
+	if !i.isSealBitmapChecked(header.Number) {
+		return nil
+	}
+
+	extra, err := headerSigner.GetIBFTExtra(header)
+	if err != nil {
+		return err
+	}
+
+	return signer.VerifySealBitmap(extra.CommittedSeals, validators)
+}
+
+// isSealBitmapChecked returns true if the bitmaps of the aggregated seals in the header
+// are checked, which is enabled from the configured block number only,
+// so that the nodes agree on the validity of the headers sealed before
+func (i *backendIBFT) isSealBitmapChecked(blockNumber uint64) bool {
+	return i.sealBitmapBlockNum != nil && blockNumber >= *i.sealBitmapBlockNum
 }
 
 // quorumSize returns a callback that when executed on a Validators computes
@@ -486,16 +617,12 @@ func (i *backendIBFT) PreCommitState(header *types.Header, txn *state.Transition
 
 // GetEpoch returns the current epoch
 func (i *backendIBFT) GetEpoch(number uint64) uint64 {
-	if number%i.epochSize == 0 {
-		return number / i.epochSize
-	}
-
-	return number/i.epochSize + 1
+	return i.forkManager.GetEpoch(number)
 }
 
 // IsLastOfEpoch checks if the block number is the last of the epoch
 func (i *backendIBFT) IsLastOfEpoch(number uint64) bool {
-	return number > 0 && number%i.epochSize == 0
+	return i.forkManager.IsLastOfEpoch(number)
 }
 
 // Close closes the IBFT consensus mechanism, and does write back to disk
@@ -582,15 +709,71 @@ func (i *backendIBFT) verifyParentCommittedSeals(
 
 	// if shouldVerifyParentCommittedSeals is false, skip the verification
 	// when header doesn't have Parent Committed Seals (Backward Compatibility)
-	return parentSigner.VerifyParentCommittedSeals(
+	if err := parentSigner.VerifyParentCommittedSeals(
 		parent,
 		header,
 		parentValidators,
 		i.quorumSize(parent.Number)(parentValidators),
 		shouldVerifyParentCommittedSeals,
+	); err != nil {
+		return err
+	}
+
+	if !i.isSealBitmapChecked(header.Number) {
+		return nil
+	}
+
+	parentCommittedSeals, err := parentSigner.GetParentCommittedSeals(header)
+	if err != nil {
+		return err
+	}
+
+	return signer.VerifySealBitmap(parentCommittedSeals, parentValidators)
+}
+
+// GetFinalityProof returns the proof of finality of the block at the given height
+// built from the aggregated committed seals in the header
+func (i *backendIBFT) GetFinalityProof(height uint64) (*signer.FinalityProof, error) {
+	header, ok := i.blockchain.GetHeaderByNumber(height)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	if header.IsGenesis() {
+		// genesis header isn't committed by validators
+		return nil, signer.ErrEmptyCommittedSeals
+	}
+
+	headerSigner, validators, _, err := getModulesFromForkManager(
+		i.forkManager,
+		height,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.NewFinalityProof(
+		headerSigner,
+		header,
+		validators,
+		i.quorumSize(height)(validators),
 	)
 }
 
+// GetSnapshot returns the validators and the votes at the given height,
+// same as the snapshot served by the operator service
+func (i *backendIBFT) GetSnapshot(height uint64) (*proto.Snapshot, error) {
+	return (&operator{ibft: i}).GetSnapshot(context.Background(), &proto.SnapshotReq{Number: height})
+}
+
+// Propose proposes the candidate to be added to / removed from the validator set,
+// same as the proposal through the operator service
+func (i *backendIBFT) Propose(candidate *proto.Candidate) error {
+	_, err := (&operator{ibft: i}).Propose(context.Background(), candidate)
+
+	return err
+}
+
 // getModulesFromForkManager is a helper function to get all modules from ForkManager
 func getModulesFromForkManager(forkManager forkManagerInterface, height uint64) (
 	signer.Signer,
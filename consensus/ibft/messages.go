@@ -4,6 +4,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 )
 
@@ -13,13 +14,39 @@ func (i *backendIBFT) signMessage(msg *protoIBFT.Message) *protoIBFT.Message {
 		return nil
 	}
 
-	if msg.Signature, err = i.currentSigner.SignIBFTMessage(raw); err != nil {
+	if msg.Signature, err = i.currentSigner.SignIBFTMessage(raw, signingContextOf(msg)); err != nil {
+		i.logger.Error("Unable to sign IBFT message", "type", msg.Type, "err", err)
+
 		return nil
 	}
 
 	return msg
 }
 
+// signingContextOf returns the signing context describing the given IBFT message
+func signingContextOf(msg *protoIBFT.Message) *signer.SigningContext {
+	ctx := &signer.SigningContext{
+		Height: msg.GetView().GetHeight(),
+		Round:  msg.GetView().GetRound(),
+	}
+
+	switch msg.Type {
+	case protoIBFT.MessageType_PREPREPARE:
+		ctx.Kind = signer.SigningKindPrePrepare
+		ctx.Digest = msg.GetPreprepareData().GetProposalHash()
+	case protoIBFT.MessageType_PREPARE:
+		ctx.Kind = signer.SigningKindPrepare
+		ctx.Digest = msg.GetPrepareData().GetProposalHash()
+	case protoIBFT.MessageType_COMMIT:
+		ctx.Kind = signer.SigningKindCommit
+		ctx.Digest = msg.GetCommitData().GetProposalHash()
+	case protoIBFT.MessageType_ROUND_CHANGE:
+		ctx.Kind = signer.SigningKindRoundChange
+	}
+
+	return ctx
+}
+
 func (i *backendIBFT) BuildPrePrepareMessage(
 	proposal []byte,
 	certificate *protoIBFT.RoundChangeCertificate,
@@ -64,7 +91,15 @@ func (i *backendIBFT) BuildPrepareMessage(proposalHash []byte, view *protoIBFT.V
 }
 
 func (i *backendIBFT) BuildCommitMessage(proposalHash []byte, view *protoIBFT.View) *protoIBFT.Message {
-	committedSeal, err := i.currentSigner.CreateCommittedSeal(proposalHash)
+	committedSeal, err := i.currentSigner.CreateCommittedSeal(
+		proposalHash,
+		&signer.SigningContext{
+			Kind:   signer.SigningKindCommittedSeal,
+			Height: view.GetHeight(),
+			Round:  view.GetRound(),
+			Digest: proposalHash,
+		},
+	)
 	if err != nil {
 		i.logger.Error("Unable to build commit message, %v", err)
 
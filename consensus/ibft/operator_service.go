@@ -120,6 +120,26 @@ func (o *operator) Candidates(ctx context.Context, req *empty.Empty) (*proto.Can
 	}, nil
 }
 
+// RotateKey schedules the validator key to be switched to the successor key from the given height
+func (o *operator) RotateKey(ctx context.Context, req *proto.KeyRotationReq) (*proto.KeyRotation, error) {
+	validator, successor, err := o.ibft.forkManager.RotateKey(req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.KeyRotation{
+		Validator: validator.String(),
+		Successor: successor.Addr().String(),
+		Height:    req.Height,
+	}
+
+	if blsValidator, ok := successor.(*validators.BLSValidator); ok {
+		resp.BlsPubkey = blsValidator.BLSPublicKey
+	}
+
+	return resp, nil
+}
+
 // parseCandidate parses proto.Candidate and maps to validator
 func (o *operator) parseCandidate(req *proto.Candidate) (validators.Validator, error) {
 	signer, err := o.getLatestSigner()
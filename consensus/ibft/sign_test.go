@@ -88,7 +88,7 @@ func TestSign_CommittedSeals(t *testing.T) {
 				),
 			)
 
-			seal, err := signer.CreateCommittedSeal(h.Hash.Bytes())
+			seal, err := signer.CreateCommittedSeal(h.Hash.Bytes(), nil)
 
 			assert.NoError(t, err)
 
@@ -111,3 +111,46 @@ func TestSign_CommittedSeals(t *testing.T) {
 	// Failed - Not enough signatures
 	assert.Error(t, buildCommittedSeal([]string{"A"}))
 }
+
+func TestIsSealBitmapChecked(t *testing.T) {
+	t.Parallel()
+
+	blockNum := uint64(10)
+
+	tests := []struct {
+		name               string
+		sealBitmapBlockNum *uint64
+		number             uint64
+		expected           bool
+	}{
+		{
+			name:     "should not check without configuration",
+			number:   100,
+			expected: false,
+		},
+		{
+			name:               "should not check before the configured block",
+			sealBitmapBlockNum: &blockNum,
+			number:             9,
+			expected:           false,
+		},
+		{
+			name:               "should check from the configured block",
+			sealBitmapBlockNum: &blockNum,
+			number:             10,
+			expected:           true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			i := &backendIBFT{sealBitmapBlockNum: test.sealBitmapBlockNum}
+
+			assert.Equal(t, test.expected, i.isSealBitmapChecked(test.number))
+		})
+	}
+}
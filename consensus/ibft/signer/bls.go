@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"math/bits"
 
 	"github.com/ExzoNetwork/ExzoCoin/crypto"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
@@ -163,8 +164,52 @@ type AggregatedSeal struct {
 	Signature []byte
 }
 
+// Num returns the number of validators that signed the aggregated signature
 func (s *AggregatedSeal) Num() int {
-	return s.Bitmap.BitLen()
+	if s.Bitmap == nil {
+		return 0
+	}
+
+	num := 0
+	for _, word := range s.Bitmap.Bits() {
+		num += bits.OnesCount(uint(word))
+	}
+
+	return num
+}
+
+// Signers returns the addresses of the validators marked in the bitmap
+func (s *AggregatedSeal) Signers(vals validators.Validators) ([]types.Address, error) {
+	if s.Bitmap == nil || s.Bitmap.BitLen() > vals.Len() {
+		return nil, ErrNonValidatorCommittedSeal
+	}
+
+	signers := make([]types.Address, 0, s.Num())
+
+	for idx := 0; idx < vals.Len(); idx++ {
+		if s.Bitmap.Bit(idx) == 0 {
+			continue
+		}
+
+		signers = append(signers, vals.At(uint64(idx)).Addr())
+	}
+
+	return signers, nil
+}
+
+// VerifySealBitmap returns ErrNonValidatorCommittedSeal if the bitmap of the aggregated seals refers to
+// the validators out of the set. The seals without bitmap are always valid
+func VerifySealBitmap(seals Seals, vals validators.Validators) error {
+	aggregated, ok := seals.(*AggregatedSeal)
+	if !ok || aggregated == nil || aggregated.Bitmap == nil {
+		return nil
+	}
+
+	if aggregated.Bitmap.BitLen() > vals.Len() {
+		return ErrNonValidatorCommittedSeal
+	}
+
+	return nil
 }
 
 func (s *AggregatedSeal) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
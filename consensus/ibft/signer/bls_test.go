@@ -1052,6 +1052,21 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 			expectedRes: 2,
 			expectedErr: nil,
 		},
+		{
+			// the bits out of the set are checked by VerifySealBitmap from the configured block only
+			name: "should ignore the bits out of the validator set",
+			committedSeal: &AggregatedSeal{
+				Signature: correctAggregatedSig,
+				Bitmap:    new(big.Int).SetBytes([]byte{0x7}), // validator1 & validator 2 & out of the set
+			},
+			validators: validators.NewBLSValidatorSet(
+				testBLSKeyManagerToBLSValidator(t, validatorKeyManager1),
+				testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
+			),
+			msg:         msg,
+			expectedRes: 2,
+			expectedErr: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -1071,3 +1086,56 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySealBitmap(t *testing.T) {
+	t.Parallel()
+
+	validatorKeyManager1, _, _ := newTestBLSKeyManager(t)
+	validatorKeyManager2, _, _ := newTestBLSKeyManager(t)
+
+	vals := validators.NewBLSValidatorSet(
+		testBLSKeyManagerToBLSValidator(t, validatorKeyManager1),
+		testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
+	)
+
+	tests := []struct {
+		name        string
+		seals       Seals
+		expectedErr error
+	}{
+		{
+			name:        "should succeed for the seals without bitmap",
+			seals:       &SerializedSeal{},
+			expectedErr: nil,
+		},
+		{
+			name:        "should succeed for the nil aggregated seal",
+			seals:       (*AggregatedSeal)(nil),
+			expectedErr: nil,
+		},
+		{
+			name: "should succeed for the bitmap in the validator set",
+			seals: &AggregatedSeal{
+				Bitmap: new(big.Int).SetBytes([]byte{0x3}),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "should return ErrNonValidatorCommittedSeal for the bitmap out of the validator set",
+			seals: &AggregatedSeal{
+				Bitmap: new(big.Int).SetBytes([]byte{0x5}),
+			},
+			expectedErr: ErrNonValidatorCommittedSeal,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, VerifySealBitmap(test.seals, vals), test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package signer
+
+// SigningKind is the kind of consensus data a signature is created for
+type SigningKind string
+
+const (
+	SigningKindProposerSeal  SigningKind = "proposer-seal"
+	SigningKindCommittedSeal SigningKind = "committed-seal"
+	SigningKindPrePrepare    SigningKind = "preprepare"
+	SigningKindPrepare       SigningKind = "prepare"
+	SigningKindCommit        SigningKind = "commit"
+	SigningKindRoundChange   SigningKind = "round-change"
+)
+
+// SigningContext describes the consensus data being signed
+type SigningContext struct {
+	Kind   SigningKind
+	Height uint64
+	Round  uint64
+	// Digest is the hash of the proposal the signature commits to
+	Digest []byte
+}
+
+// IsSlashable returns whether signing two different digests
+// for the same kind, height and round is a double signing
+func (c *SigningContext) IsSlashable() bool {
+	switch c.Kind {
+	case SigningKindPrePrepare, SigningKindPrepare, SigningKindCommit, SigningKindCommittedSeal:
+		return true
+	default:
+		// proposer seals don't carry the round and round changes don't commit to a proposal
+		return false
+	}
+}
+
+// ContextualKeyManager is a KeyManager that needs to know
+// which consensus data it signs, e.g. in order to apply slashing protection
+type ContextualKeyManager interface {
+	KeyManager
+	// SignInContext signs the hash of the consensus data described by the context
+	SignInContext(ctx *SigningContext, hash []byte) ([]byte, error)
+}
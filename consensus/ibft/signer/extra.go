@@ -28,6 +28,7 @@ type IstanbulExtra struct {
 	ProposerSeal         []byte
 	CommittedSeals       Seals
 	ParentCommittedSeals Seals
+	KeyRotationProof     []byte
 }
 
 type Seals interface {
@@ -62,6 +63,14 @@ func (i *IstanbulExtra) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
 	// ParentCommittedSeal
 	if i.ParentCommittedSeals != nil {
 		vv.Set(i.ParentCommittedSeals.MarshalRLPWith(ar))
+	} else if len(i.KeyRotationProof) > 0 {
+		// the header of the first block has no parent committed seals
+		vv.Set(ar.NewNullArray())
+	}
+
+	// KeyRotationProof
+	if len(i.KeyRotationProof) > 0 {
+		vv.Set(ar.NewCopyBytes(i.KeyRotationProof))
 	}
 
 	return vv
@@ -105,6 +114,13 @@ func (i *IstanbulExtra) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) er
 		}
 	}
 
+	// KeyRotationProof
+	if len(elems) >= 5 {
+		if i.KeyRotationProof, err = elems[4].GetBytes(i.KeyRotationProof); err != nil {
+			return fmt.Errorf("failed to decode KeyRotationProof: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -213,6 +229,11 @@ func packProposerSealIntoExtra(
 				newArrayValue.Set(oldValues[3])
 			}
 
+			// KeyRotationProof
+			if len(oldValues) >= 5 {
+				newArrayValue.Set(oldValues[4])
+			}
+
 			return nil
 		},
 	)
@@ -244,6 +265,48 @@ func packCommittedSealsIntoExtra(
 				newArrayValue.Set(oldValues[3])
 			}
 
+			// KeyRotationProof
+			if len(oldValues) >= 5 {
+				newArrayValue.Set(oldValues[4])
+			}
+
+			return nil
+		},
+	)
+}
+
+// packKeyRotationProofIntoExtra updates only KeyRotationProof field in Extra
+func packKeyRotationProofIntoExtra(
+	extraBytes []byte,
+	proof []byte,
+) []byte {
+	return packFieldsIntoExtra(
+		extraBytes,
+		func(
+			ar *fastrlp.Arena,
+			oldValues []*fastrlp.Value,
+			newArrayValue *fastrlp.Value,
+		) error {
+			// Validators
+			newArrayValue.Set(oldValues[0])
+
+			// Seal
+			newArrayValue.Set(oldValues[1])
+
+			// CommittedSeal
+			newArrayValue.Set(oldValues[2])
+
+			// ParentCommittedSeal
+			if len(oldValues) >= 4 {
+				newArrayValue.Set(oldValues[3])
+			} else {
+				// the header of the first block has no parent committed seals
+				newArrayValue.Set(ar.NewNullArray())
+			}
+
+			// KeyRotationProof
+			newArrayValue.Set(ar.NewCopyBytes(proof))
+
 			return nil
 		},
 	)
@@ -93,6 +93,38 @@ func TestIstanbulExtraMarshalAndUnmarshal(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "BLSExtra with KeyRotationProof",
+			extra: &IstanbulExtra{
+				Validators: validators.NewBLSValidatorSet(
+					blsValidator1,
+				),
+				ProposerSeal: testProposerSeal,
+				CommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x8}),
+					Signature: []byte{0x1},
+				},
+				ParentCommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x9}),
+					Signature: []byte{0x2},
+				},
+				KeyRotationProof: []byte{0x3},
+			},
+		},
+		{
+			name: "BLSExtra with KeyRotationProof without ParentCommittedSeals",
+			extra: &IstanbulExtra{
+				Validators: validators.NewBLSValidatorSet(
+					blsValidator1,
+				),
+				ProposerSeal: testProposerSeal,
+				CommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x8}),
+					Signature: []byte{0x1},
+				},
+				KeyRotationProof: []byte{0x3},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -363,6 +395,90 @@ func Test_packCommittedSealsIntoExtra(t *testing.T) {
 	}
 }
 
+func Test_packKeyRotationProofIntoExtra(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		extra *IstanbulExtra
+	}{
+		{
+			name: "BLSExtra",
+			extra: &IstanbulExtra{
+				Validators: validators.NewBLSValidatorSet(
+					blsValidator1,
+				),
+				ProposerSeal: testProposerSeal,
+				CommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x8}),
+					Signature: []byte{0x1},
+				},
+				ParentCommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x9}),
+					Signature: []byte{0x2},
+				},
+			},
+		},
+		{
+			name: "BLSExtra without ParentCommittedSeals",
+			extra: &IstanbulExtra{
+				Validators: validators.NewBLSValidatorSet(
+					blsValidator1,
+				),
+				ProposerSeal: testProposerSeal,
+				CommittedSeals: &AggregatedSeal{
+					Bitmap:    new(big.Int).SetBytes([]byte{0x8}),
+					Signature: []byte{0x1},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			proof := []byte{0x3}
+
+			// create expected data
+			test.extra.KeyRotationProof = proof
+			expectedJSON := JSONMarshalHelper(t, test.extra)
+			test.extra.KeyRotationProof = nil
+
+			// put proof
+			newExtraBytes := packKeyRotationProofIntoExtra(
+				// prepend IstanbulExtraHeader
+				append(
+					make([]byte, IstanbulExtraVanity),
+					test.extra.MarshalRLPTo(nil)...,
+				),
+				proof,
+			)
+
+			// the proof is kept by the update of the committed seals
+			newExtraBytes = packCommittedSealsIntoExtra(
+				newExtraBytes,
+				test.extra.CommittedSeals,
+			)
+
+			// decode RLP data
+			assert.NoError(
+				t,
+				test.extra.UnmarshalRLP(newExtraBytes[IstanbulExtraVanity:]),
+			)
+
+			// check json of decoded data matches with the original data
+			assert.Equal(
+				t,
+				expectedJSON,
+				JSONMarshalHelper(t, test.extra),
+			)
+		})
+	}
+}
+
 func Test_unmarshalRLPForParentCS(t *testing.T) {
 	t.Parallel()
 
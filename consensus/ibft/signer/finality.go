@@ -0,0 +1,96 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+)
+
+var (
+	ErrFinalityProofNotSupported = errors.New("finality proof is supported only by BLS validators")
+)
+
+// FinalityProof is a proof that the block has been committed by a quorum of validators.
+// It carries everything a light client needs to check the finality of the block
+// with a single pairing check, given it trusts the validator set
+type FinalityProof struct {
+	Number     uint64
+	Hash       types.Hash
+	Message    []byte
+	Validators validators.Validators
+	Bitmap     *big.Int
+	Signers    []types.Address
+	Signature  []byte
+	Quorum     int
+}
+
+// NewFinalityProof builds the finality proof from the aggregated committed seals of the header
+func NewFinalityProof(
+	signer Signer,
+	header *types.Header,
+	vals validators.Validators,
+	quorum int,
+) (*FinalityProof, error) {
+	if signer.Type() != validators.BLSValidatorType || vals.Type() != validators.BLSValidatorType {
+		return nil, ErrFinalityProofNotSupported
+	}
+
+	extra, err := signer.GetIBFTExtra(header)
+	if err != nil {
+		return nil, err
+	}
+
+	committedSeal, ok := extra.CommittedSeals.(*AggregatedSeal)
+	if !ok {
+		return nil, ErrInvalidCommittedSealType
+	}
+
+	if committedSeal.Num() == 0 {
+		return nil, ErrEmptyCommittedSeals
+	}
+
+	hash, err := signer.CalculateHeaderHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	signers, err := committedSeal.Signers(vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FinalityProof{
+		Number:     header.Number,
+		Hash:       hash,
+		Message:    crypto.Keccak256(wrapCommitHash(hash.Bytes())),
+		Validators: vals,
+		Bitmap:     committedSeal.Bitmap,
+		Signers:    signers,
+		Signature:  committedSeal.Signature,
+		Quorum:     quorum,
+	}, nil
+}
+
+// Verify checks the aggregated signature in the proof against the validator set in the proof
+func (p *FinalityProof) Verify() error {
+	numSeals, err := verifyBLSCommittedSealsImpl(
+		&AggregatedSeal{
+			Bitmap:    p.Bitmap,
+			Signature: p.Signature,
+		},
+		crypto.Keccak256(wrapCommitHash(p.Hash.Bytes())),
+		p.Validators,
+	)
+	if err != nil {
+		return err
+	}
+
+	if numSeals < p.Quorum {
+		return ErrNotEnoughCommittedSeals
+	}
+
+	return nil
+}
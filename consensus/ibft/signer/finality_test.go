@@ -0,0 +1,120 @@
+package signer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregatedSealNum(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, (&AggregatedSeal{}).Num())
+	assert.Equal(t, 0, newTestAggregatedSeals([]int{}, nil).Num())
+	assert.Equal(t, 2, newTestAggregatedSeals([]int{0, 5}, nil).Num())
+	assert.Equal(t, 3, newTestAggregatedSeals([]int{1, 64, 130}, nil).Num())
+}
+
+func TestAggregatedSealSigners(t *testing.T) {
+	t.Parallel()
+
+	vals := validators.NewBLSValidatorSet(
+		validators.NewBLSValidator(types.StringToAddress("1"), testBLSPubKey1),
+		validators.NewBLSValidator(types.StringToAddress("2"), testBLSPubKey2),
+	)
+
+	signers, err := newTestAggregatedSeals([]int{1}, nil).Signers(vals)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Address{types.StringToAddress("2")}, signers)
+
+	_, err = newTestAggregatedSeals([]int{0, 2}, nil).Signers(vals)
+
+	assert.ErrorIs(t, err, ErrNonValidatorCommittedSeal)
+}
+
+func TestFinalityProof(t *testing.T) {
+	t.Parallel()
+
+	keyManagers := make([]KeyManager, 4)
+	vals := validators.NewBLSValidatorSet()
+
+	for idx := range keyManagers {
+		keyManagers[idx], _, _ = newTestBLSKeyManager(t)
+
+		assert.NoError(t, vals.Add(testBLSKeyManagerToBLSValidator(t, keyManagers[idx])))
+	}
+
+	signer := newTestSingleKeyManagerSigner(keyManagers[0])
+
+	header := &types.Header{Number: 10}
+	signer.InitIBFTExtra(header, vals, nil)
+
+	hash, err := signer.CalculateHeaderHash(header)
+	assert.NoError(t, err)
+
+	// validator 0, 1 and 3 commit the block
+	sealMap := map[types.Address][]byte{}
+
+	for _, idx := range []int{0, 1, 3} {
+		seal, err := newTestSingleKeyManagerSigner(keyManagers[idx]).CreateCommittedSeal(hash.Bytes(), nil)
+		assert.NoError(t, err)
+
+		sealMap[keyManagers[idx].Address()] = seal
+	}
+
+	header, err = signer.WriteCommittedSeals(header, sealMap)
+	assert.NoError(t, err)
+
+	t.Run("should build and verify proof", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := NewFinalityProof(signer, header, vals, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), proof.Number)
+		assert.Equal(t, hash, proof.Hash)
+		assert.Equal(t, big.NewInt(0xb), proof.Bitmap)
+		assert.Equal(
+			t,
+			[]types.Address{
+				keyManagers[0].Address(),
+				keyManagers[1].Address(),
+				keyManagers[3].Address(),
+			},
+			proof.Signers,
+		)
+		assert.NoError(t, proof.Verify())
+	})
+
+	t.Run("should fail to verify proof without quorum", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := NewFinalityProof(signer, header, vals, 4)
+
+		assert.NoError(t, err)
+		assert.ErrorIs(t, proof.Verify(), ErrNotEnoughCommittedSeals)
+	})
+
+	t.Run("should fail to verify proof for another block", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := NewFinalityProof(signer, header, vals, 3)
+		assert.NoError(t, err)
+
+		proof.Hash = types.StringToHash("1")
+
+		assert.ErrorIs(t, proof.Verify(), ErrInvalidSignature)
+	})
+
+	t.Run("should return error for ECDSA validators", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewFinalityProof(signer, header, ecdsaValidators, 3)
+
+		assert.ErrorIs(t, err, ErrFinalityProofNotSupported)
+	})
+}
@@ -29,13 +29,92 @@ func wrapCommitHash(data []byte) []byte {
 
 // getOrCreateECDSAKey loads ECDSA key or creates a new key
 func getOrCreateECDSAKey(manager secrets.SecretsManager) (*ecdsa.PrivateKey, error) {
-	if !manager.HasSecret(secrets.ValidatorKey) {
-		if _, err := helper.InitECDSAValidatorKey(manager); err != nil {
+	return loadOrCreateECDSAKey(manager, secrets.ValidatorKey, helper.InitECDSAValidatorKey)
+}
+
+// getOrCreateBLSKey loads BLS key or creates a new key
+func getOrCreateBLSKey(manager secrets.SecretsManager) (*bls_sig.SecretKey, error) {
+	return loadOrCreateBLSKey(manager, secrets.ValidatorBLSKey, helper.InitBLSValidatorKey)
+}
+
+// getOrCreateSuccessorECDSAKey loads the ECDSA key the validator key is rotated to or creates a new key
+func getOrCreateSuccessorECDSAKey(manager secrets.SecretsManager) (*ecdsa.PrivateKey, error) {
+	return loadOrCreateECDSAKey(manager, secrets.ValidatorSuccessorKey, helper.InitECDSASuccessorKey)
+}
+
+// getOrCreateSuccessorBLSKey loads the BLS key the validator key is rotated to or creates a new key
+func getOrCreateSuccessorBLSKey(manager secrets.SecretsManager) (*bls_sig.SecretKey, error) {
+	return loadOrCreateBLSKey(manager, secrets.ValidatorSuccessorBLSKey, helper.InitBLSSuccessorKey)
+}
+
+// PromoteSuccessorKeys replaces the validator keys with the successor keys
+// and removes the successor keys afterwards
+func PromoteSuccessorKeys(manager secrets.SecretsManager) error {
+	promotions := []struct {
+		successor string
+		validator string
+	}{
+		{secrets.ValidatorSuccessorKey, secrets.ValidatorKey},
+		{secrets.ValidatorSuccessorBLSKey, secrets.ValidatorBLSKey},
+	}
+
+	promoted := make([]string, 0, len(promotions))
+
+	for _, p := range promotions {
+		if !manager.HasSecret(p.successor) {
+			continue
+		}
+
+		key, err := manager.GetSecret(p.successor)
+		if err != nil {
+			return err
+		}
+
+		if err := replaceSecret(manager, p.validator, key); err != nil {
+			return err
+		}
+
+		promoted = append(promoted, p.successor)
+	}
+
+	// the ECDSA successor key is removed last as its existence tells the rotation is in progress
+	for idx := len(promoted) - 1; idx >= 0; idx-- {
+		if err := manager.RemoveSecret(promoted[idx]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceSecret sets the secret, removing the existing one first
+// if the secrets manager doesn't overwrite secrets
+func replaceSecret(manager secrets.SecretsManager, name string, value []byte) error {
+	if err := manager.SetSecret(name, value); err == nil {
+		return nil
+	}
+
+	if manager.HasSecret(name) {
+		if err := manager.RemoveSecret(name); err != nil {
+			return err
+		}
+	}
+
+	return manager.SetSecret(name, value)
+}
+
+func loadOrCreateECDSAKey(
+	manager secrets.SecretsManager,
+	name string,
+	initKey func(secrets.SecretsManager) (types.Address, error),
+) (*ecdsa.PrivateKey, error) {
+	if !manager.HasSecret(name) {
+		if _, err := initKey(manager); err != nil {
 			return nil, err
 		}
 	}
 
-	keyBytes, err := manager.GetSecret(secrets.ValidatorKey)
+	keyBytes, err := manager.GetSecret(name)
 	if err != nil {
 		return nil, err
 	}
@@ -43,15 +122,18 @@ func getOrCreateECDSAKey(manager secrets.SecretsManager) (*ecdsa.PrivateKey, err
 	return crypto.BytesToECDSAPrivateKey(keyBytes)
 }
 
-// getOrCreateECDSAKey loads BLS key or creates a new key
-func getOrCreateBLSKey(manager secrets.SecretsManager) (*bls_sig.SecretKey, error) {
-	if !manager.HasSecret(secrets.ValidatorBLSKey) {
-		if _, err := helper.InitBLSValidatorKey(manager); err != nil {
+func loadOrCreateBLSKey(
+	manager secrets.SecretsManager,
+	name string,
+	initKey func(secrets.SecretsManager) ([]byte, error),
+) (*bls_sig.SecretKey, error) {
+	if !manager.HasSecret(name) {
+		if _, err := initKey(manager); err != nil {
 			return nil, err
 		}
 	}
 
-	keyBytes, err := manager.GetSecret(secrets.ValidatorBLSKey)
+	keyBytes, err := manager.GetSecret(name)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +191,72 @@ func NewKeyManagerFromType(
 	}
 }
 
+// NewSuccessorKeyManagerFromType creates KeyManager based on the given type
+// by the keys the validator key is rotated to. The keys are created if they don't exist
+func NewSuccessorKeyManagerFromType(
+	secretManager secrets.SecretsManager,
+	validatorType validators.ValidatorType,
+) (KeyManager, error) {
+	ecdsaKey, err := getOrCreateSuccessorECDSAKey(secretManager)
+	if err != nil {
+		return nil, err
+	}
+
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		return NewECDSAKeyManagerFromKey(ecdsaKey), nil
+	case validators.BLSValidatorType:
+		blsKey, err := getOrCreateSuccessorBLSKey(secretManager)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewBLSKeyManagerFromKeys(ecdsaKey, blsKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+}
+
+// KeyManagerToValidator returns the validator of the keys the KeyManager holds
+func KeyManagerToValidator(keyManager KeyManager) (validators.Validator, error) {
+	switch km := keyManager.(type) {
+	case *ProtectedKeyManager:
+		return KeyManagerToValidator(km.KeyManager)
+	case *ECDSAKeyManager:
+		return validators.NewECDSAValidator(km.Address()), nil
+	case *BLSKeyManager:
+		pubKey, err := crypto.BLSSecretKeyToPubkeyBytes(km.blsKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return validators.NewBLSValidator(km.Address(), pubKey), nil
+	case *RemoteKeyManager:
+		if km.Type() == validators.BLSValidatorType {
+			return validators.NewBLSValidator(km.Address(), km.blsPublicKey), nil
+		}
+
+		return validators.NewECDSAValidator(km.Address()), nil
+	default:
+		return nil, fmt.Errorf("unsupported key manager: %T", keyManager)
+	}
+}
+
+// CreateKeyRotationProof returns the proof that the holder of the keys in the KeyManager
+// possesses its BLS key, to be put in the header registering the key. ECDSA keys need no proof
+func CreateKeyRotationProof(keyManager KeyManager) ([]byte, error) {
+	switch km := keyManager.(type) {
+	case *ProtectedKeyManager:
+		return CreateKeyRotationProof(km.KeyManager)
+	case *ECDSAKeyManager:
+		return nil, nil
+	case *BLSKeyManager:
+		return crypto.CreateBLSProofOfPossession(km.blsKey)
+	default:
+		return nil, fmt.Errorf("unsupported key manager: %T", keyManager)
+	}
+}
+
 // verifyIBFTExtraSize checks whether header.ExtraData has enough size for IBFT Extra
 func verifyIBFTExtraSize(header *types.Header) error {
 	if len(header.ExtraData) < IstanbulExtraVanity {
@@ -404,6 +404,39 @@ func TestNewKeyManagerFromType(t *testing.T) {
 	}
 }
 
+func TestCreateKeyRotationProof(t *testing.T) {
+	t.Parallel()
+
+	testECDSAKey, _ := newTestECDSAKey(t)
+	testBLSKey, _ := newTestBLSKey(t)
+
+	t.Run("ECDSA key needs no proof", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := CreateKeyRotationProof(NewECDSAKeyManagerFromKey(testECDSAKey))
+
+		assert.NoError(t, err)
+		assert.Nil(t, proof)
+	})
+
+	t.Run("BLS key is proven", func(t *testing.T) {
+		t.Parallel()
+
+		keyManager := NewBLSKeyManagerFromKeys(testECDSAKey, testBLSKey)
+
+		proof, err := CreateKeyRotationProof(keyManager)
+		assert.NoError(t, err)
+
+		validator, err := KeyManagerToValidator(keyManager)
+		assert.NoError(t, err)
+
+		blsValidator, ok := validator.(*validators.BLSValidator)
+		assert.True(t, ok)
+
+		assert.NoError(t, crypto.VerifyBLSProofOfPossession(blsValidator.BLSPublicKey, proof))
+	})
+}
+
 func Test_verifyIBFTExtraSize(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,239 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	// SlashingProtectionFileName is the file name of the slashing protection database
+	// in the consensus directory
+	SlashingProtectionFileName = "slashing-protection.json"
+)
+
+var (
+	ErrSigningLowerView = errors.New("refused to sign at lower height or round than signed before")
+)
+
+// SignedRecord is the latest view and digest the validator signed for a kind of data
+type SignedRecord struct {
+	Height uint64     `json:"height"`
+	Round  uint64     `json:"round"`
+	Digest types.Hash `json:"digest"`
+}
+
+// isLowerView returns whether the given height and round is lower than the record
+func (r *SignedRecord) isLowerView(height, round uint64) bool {
+	return height < r.Height || (height == r.Height && round < r.Round)
+}
+
+// SlashingProtectionData is the content of the slashing protection database,
+// also used as the interchange format for import and export
+type SlashingProtectionData struct {
+	Validators map[types.Address]map[SigningKind]*SignedRecord `json:"validators"`
+}
+
+// Merge merges the given data by keeping the highest record for every validator and kind.
+// The existing record is kept if both records are at the same view
+func (d *SlashingProtectionData) Merge(other *SlashingProtectionData) {
+	if d.Validators == nil {
+		d.Validators = make(map[types.Address]map[SigningKind]*SignedRecord)
+	}
+
+	for addr, records := range other.Validators {
+		if d.Validators[addr] == nil {
+			d.Validators[addr] = make(map[SigningKind]*SignedRecord)
+		}
+
+		for kind, record := range records {
+			current, ok := d.Validators[addr][kind]
+			if !ok || record.isLowerView(current.Height, current.Round) {
+				copied := *record
+				d.Validators[addr][kind] = &copied
+			}
+		}
+	}
+}
+
+// SlashingProtectionDB is the local database recording the latest data the validators signed,
+// which is consulted before signing in order not to sign conflicting consensus messages
+// even after the node is restored from a backup or started twice
+type SlashingProtectionDB struct {
+	path string
+
+	lock sync.Mutex
+	data *SlashingProtectionData
+}
+
+// NewSlashingProtectionDB loads the slashing protection database from the given path
+func NewSlashingProtectionDB(path string) (*SlashingProtectionDB, error) {
+	data, err := ReadSlashingProtectionData(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlashingProtectionDB{
+		path: path,
+		data: data,
+	}, nil
+}
+
+// ReadSlashingProtectionData reads the slashing protection data from the file
+// returns empty data if the file doesn't exist
+func ReadSlashingProtectionData(path string) (*SlashingProtectionData, error) {
+	data := &SlashingProtectionData{
+		Validators: make(map[types.Address]map[SigningKind]*SignedRecord),
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return data, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, err
+	}
+
+	if data.Validators == nil {
+		data.Validators = make(map[types.Address]map[SigningKind]*SignedRecord)
+	}
+
+	return data, nil
+}
+
+// WriteSlashingProtectionData writes the slashing protection data into the file
+// The file is replaced atomically so that the records are never lost by a crash
+func WriteSlashingProtectionData(path string, data *SlashingProtectionData) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(raw); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// CheckAndRecord checks the validator has never signed conflicting data for the context
+// and records the context before the signature is created
+func (db *SlashingProtectionDB) CheckAndRecord(address types.Address, ctx *SigningContext) error {
+	if !ctx.IsSlashable() {
+		return nil
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	records, ok := db.data.Validators[address]
+	if !ok {
+		records = make(map[SigningKind]*SignedRecord)
+		db.data.Validators[address] = records
+	}
+
+	digest := types.BytesToHash(ctx.Digest)
+
+	if last, ok := records[ctx.Kind]; ok {
+		if last.isLowerView(ctx.Height, ctx.Round) {
+			return ErrSigningLowerView
+		}
+
+		if last.Height == ctx.Height && last.Round == ctx.Round {
+			if last.Digest != digest {
+				return ErrDoubleSign
+			}
+
+			// signing the same data again is safe
+			return nil
+		}
+	}
+
+	previous := records[ctx.Kind]
+	records[ctx.Kind] = &SignedRecord{
+		Height: ctx.Height,
+		Round:  ctx.Round,
+		Digest: digest,
+	}
+
+	if err := WriteSlashingProtectionData(db.path, db.data); err != nil {
+		// don't keep the record which is not persisted
+		if previous != nil {
+			records[ctx.Kind] = previous
+		} else {
+			delete(records, ctx.Kind)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ProtectedKeyManager is a KeyManager that consults the slashing protection database
+// before signing consensus messages
+type ProtectedKeyManager struct {
+	KeyManager
+
+	db *SlashingProtectionDB
+}
+
+// NewProtectedKeyManager wraps the KeyManager with the slashing protection
+func NewProtectedKeyManager(keyManager KeyManager, db *SlashingProtectionDB) KeyManager {
+	return &ProtectedKeyManager{
+		KeyManager: keyManager,
+		db:         db,
+	}
+}
+
+// SignInContext signs the hash by the underlying KeyManager
+// if the data doesn't conflict with the data signed before
+func (m *ProtectedKeyManager) SignInContext(ctx *SigningContext, hash []byte) ([]byte, error) {
+	if err := m.db.CheckAndRecord(m.Address(), ctx); err != nil {
+		return nil, err
+	}
+
+	if keyManager, ok := m.KeyManager.(ContextualKeyManager); ok {
+		return keyManager.SignInContext(ctx, hash)
+	}
+
+	switch ctx.Kind {
+	case SigningKindProposerSeal:
+		return m.KeyManager.SignProposerSeal(hash)
+	case SigningKindCommittedSeal:
+		return m.KeyManager.SignCommittedSeal(hash)
+	default:
+		return m.KeyManager.SignIBFTMessage(hash)
+	}
+}
@@ -0,0 +1,139 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashingProtectionDBCheckAndRecord(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr      = types.StringToAddress("1")
+		hash      = crypto.Keccak256([]byte{0x1})
+		otherHash = crypto.Keccak256([]byte{0x2})
+		path      = filepath.Join(t.TempDir(), SlashingProtectionFileName)
+	)
+
+	db, err := NewSlashingProtectionDB(path)
+	assert.NoError(t, err)
+
+	// round changes are not recorded
+	assert.NoError(t, db.CheckAndRecord(addr, &SigningContext{Kind: SigningKindRoundChange, Height: 10}))
+
+	assert.NoError(t, db.CheckAndRecord(addr, &SigningContext{Kind: SigningKindCommit, Height: 10, Round: 1, Digest: hash}))
+
+	// same data can be signed again
+	assert.NoError(t, db.CheckAndRecord(addr, &SigningContext{Kind: SigningKindCommit, Height: 10, Round: 1, Digest: hash}))
+
+	assert.ErrorIs(
+		t,
+		db.CheckAndRecord(addr, &SigningContext{Kind: SigningKindCommit, Height: 10, Round: 1, Digest: otherHash}),
+		ErrDoubleSign,
+	)
+	assert.ErrorIs(
+		t,
+		db.CheckAndRecord(addr, &SigningContext{Kind: SigningKindCommit, Height: 10, Round: 0, Digest: hash}),
+		ErrSigningLowerView,
+	)
+
+	// other kinds and validators are recorded separately
+	assert.NoError(t, db.CheckAndRecord(addr, &SigningContext{Kind: SigningKindPrepare, Height: 10, Round: 1, Digest: otherHash}))
+	assert.NoError(
+		t,
+		db.CheckAndRecord(
+			types.StringToAddress("2"),
+			&SigningContext{Kind: SigningKindCommit, Height: 10, Round: 1, Digest: otherHash},
+		),
+	)
+
+	// the records survive restarts
+	reloaded, err := NewSlashingProtectionDB(path)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(
+		t,
+		reloaded.CheckAndRecord(addr, &SigningContext{Kind: SigningKindCommit, Height: 10, Round: 1, Digest: otherHash}),
+		ErrDoubleSign,
+	)
+	assert.NoError(
+		t,
+		reloaded.CheckAndRecord(addr, &SigningContext{Kind: SigningKindCommit, Height: 11, Digest: otherHash}),
+	)
+}
+
+func TestSlashingProtectionDataMerge(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		hash1 = types.StringToHash("1")
+		hash2 = types.StringToHash("2")
+	)
+
+	data := &SlashingProtectionData{
+		Validators: map[types.Address]map[SigningKind]*SignedRecord{
+			addr1: {
+				SigningKindCommit:  {Height: 10, Round: 0, Digest: hash1},
+				SigningKindPrepare: {Height: 10, Round: 2, Digest: hash1},
+			},
+		},
+	}
+
+	data.Merge(&SlashingProtectionData{
+		Validators: map[types.Address]map[SigningKind]*SignedRecord{
+			addr1: {
+				SigningKindCommit:  {Height: 10, Round: 1, Digest: hash2},
+				SigningKindPrepare: {Height: 10, Round: 2, Digest: hash2},
+			},
+			addr2: {
+				SigningKindCommit: {Height: 5, Digest: hash2},
+			},
+		},
+	})
+
+	assert.Equal(
+		t,
+		map[types.Address]map[SigningKind]*SignedRecord{
+			addr1: {
+				SigningKindCommit:  {Height: 10, Round: 1, Digest: hash2},
+				SigningKindPrepare: {Height: 10, Round: 2, Digest: hash1},
+			},
+			addr2: {
+				SigningKindCommit: {Height: 5, Digest: hash2},
+			},
+		},
+		data.Validators,
+	)
+}
+
+func TestProtectedKeyManager(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewSlashingProtectionDB(filepath.Join(t.TempDir(), SlashingProtectionFileName))
+	assert.NoError(t, err)
+
+	km, _ := newTestECDSAKeyManager(t)
+	signer := newTestSingleKeyManagerSigner(NewProtectedKeyManager(km, db))
+
+	var (
+		msg       = []byte("message")
+		hash      = crypto.Keccak256([]byte{0x1})
+		otherHash = crypto.Keccak256([]byte{0x2})
+	)
+
+	sig, err := signer.SignIBFTMessage(msg, &SigningContext{Kind: SigningKindPrepare, Height: 1, Digest: hash})
+	assert.NoError(t, err)
+
+	from, err := signer.EcrecoverFromIBFTMessage(sig, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, km.Address(), from)
+
+	_, err = signer.SignIBFTMessage(msg, &SigningContext{Kind: SigningKindPrepare, Height: 1, Digest: otherHash})
+	assert.ErrorIs(t, err, ErrDoubleSign)
+}
@@ -0,0 +1,313 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+)
+
+const (
+	// DefaultRemoteSignerTimeout is the default timeout of a request to the remote signer
+	DefaultRemoteSignerTimeout = 5 * time.Second
+
+	remoteSignerUpcheckPath = "/upcheck"
+	remoteSignerKeysPath    = "/api/v1/ibft/keys"
+	remoteSignerSignPath    = "/api/v1/ibft/sign/"
+
+	remoteKeyECDSA = "ecdsa"
+	remoteKeyBLS   = "bls"
+)
+
+var (
+	ErrRemoteSignerKeyNotFound  = errors.New("key not found in remote signer")
+	ErrRemoteSignerAmbiguousKey = errors.New("remote signer holds multiple keys, validator address must be specified")
+	ErrRemoteSignerInvalidSig   = errors.New("remote signer returned invalid signature")
+	ErrDoubleSign               = errors.New("refused to sign conflicting data at the same height and round")
+)
+
+// RemoteSignerConfig is the configuration of the remote signer holding the validator keys
+type RemoteSignerConfig struct {
+	// URL is the base URL of the remote signer
+	URL string
+	// Address is the validator address, optional if the remote signer holds only one key
+	Address types.Address
+	// Timeout is the timeout of a request to the remote signer
+	Timeout time.Duration
+}
+
+// remoteKey is an entry of the keys the remote signer holds
+type remoteKey struct {
+	Address      types.Address `json:"address"`
+	BLSPublicKey string        `json:"blsPublicKey,omitempty"`
+}
+
+// remoteSignRequest is the body of the sign request to the remote signer.
+// The remote signer signs the digest as is, and it may apply its own slashing protection
+// based on the kind, height and round
+type remoteSignRequest struct {
+	Key    string `json:"key"`
+	Digest string `json:"digest"`
+	Kind   string `json:"kind,omitempty"`
+	Height uint64 `json:"height"`
+	Round  uint64 `json:"round"`
+	Data   string `json:"data,omitempty"`
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// RemoteKeyManager is a KeyManager that delegates signing to a remote signer
+// over HTTP, so that the validator keys never touch the node host.
+// It refuses to sign two different proposals or seals at the same height and round
+type RemoteKeyManager struct {
+	// KeyManager of the validator type without keys, used for verification
+	KeyManager
+
+	url          string
+	client       *http.Client
+	address      types.Address
+	blsPublicKey []byte
+
+	signedLock sync.Mutex
+	signed     map[remoteSigningKey][]byte
+}
+
+// remoteSigningKey identifies the consensus data which must be signed only once
+type remoteSigningKey struct {
+	kind   SigningKind
+	height uint64
+	round  uint64
+}
+
+// NewRemoteKeyManager initializes RemoteKeyManager for the given validator type
+// by fetching the keys from the remote signer
+func NewRemoteKeyManager(
+	config *RemoteSignerConfig,
+	validatorType validators.ValidatorType,
+) (KeyManager, error) {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultRemoteSignerTimeout
+	}
+
+	m := &RemoteKeyManager{
+		url:    strings.TrimRight(config.URL, "/"),
+		client: &http.Client{Timeout: timeout},
+		signed: make(map[remoteSigningKey][]byte),
+	}
+
+	if err := m.get(remoteSignerUpcheckPath, nil); err != nil {
+		return nil, fmt.Errorf("remote signer is not available: %w", err)
+	}
+
+	key, err := m.selectKey(config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	m.address = key.Address
+
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		m.KeyManager = &ECDSAKeyManager{address: key.Address}
+	case validators.BLSValidatorType:
+		if m.blsPublicKey, err = hex.DecodeHex(key.BLSPublicKey); err != nil || len(m.blsPublicKey) == 0 {
+			return nil, fmt.Errorf("remote signer doesn't hold BLS key for %s", key.Address)
+		}
+
+		m.KeyManager = &BLSKeyManager{address: key.Address}
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+
+	return m, nil
+}
+
+// selectKey returns the key for the given address from the keys the remote signer holds
+func (m *RemoteKeyManager) selectKey(address types.Address) (*remoteKey, error) {
+	keys := []*remoteKey{}
+	if err := m.get(remoteSignerKeysPath, &keys); err != nil {
+		return nil, fmt.Errorf("failed to fetch keys from remote signer: %w", err)
+	}
+
+	if address == types.ZeroAddress {
+		switch len(keys) {
+		case 0:
+			return nil, ErrRemoteSignerKeyNotFound
+		case 1:
+			return keys[0], nil
+		default:
+			return nil, ErrRemoteSignerAmbiguousKey
+		}
+	}
+
+	for _, key := range keys {
+		if key.Address == address {
+			return key, nil
+		}
+	}
+
+	return nil, ErrRemoteSignerKeyNotFound
+}
+
+// Address returns the address of the validator key in the remote signer
+func (m *RemoteKeyManager) Address() types.Address {
+	return m.address
+}
+
+// SignProposerSeal requests the remote signer to sign ProposerSeal by ECDSA key
+func (m *RemoteKeyManager) SignProposerSeal(hash []byte) ([]byte, error) {
+	return m.SignInContext(&SigningContext{Kind: SigningKindProposerSeal}, hash)
+}
+
+// SignCommittedSeal requests the remote signer to sign committed seal
+func (m *RemoteKeyManager) SignCommittedSeal(hash []byte) ([]byte, error) {
+	return m.SignInContext(&SigningContext{Kind: SigningKindCommittedSeal}, hash)
+}
+
+// SignIBFTMessage requests the remote signer to sign IBFT message by ECDSA key
+func (m *RemoteKeyManager) SignIBFTMessage(hash []byte) ([]byte, error) {
+	return m.SignInContext(&SigningContext{}, hash)
+}
+
+// SignInContext requests the remote signer to sign the hash of the consensus data
+// described by the context, unless the validator has signed different data in the same context
+func (m *RemoteKeyManager) SignInContext(ctx *SigningContext, hash []byte) ([]byte, error) {
+	m.signedLock.Lock()
+	defer m.signedLock.Unlock()
+
+	var key remoteSigningKey
+
+	if ctx.IsSlashable() {
+		key = remoteSigningKey{kind: ctx.Kind, height: ctx.Height, round: ctx.Round}
+
+		if digest, ok := m.signed[key]; ok && !bytes.Equal(digest, ctx.Digest) {
+			return nil, ErrDoubleSign
+		}
+	}
+
+	keyType := remoteKeyECDSA
+	if ctx.Kind == SigningKindCommittedSeal && m.blsPublicKey != nil {
+		keyType = remoteKeyBLS
+	}
+
+	signature, err := m.sign(keyType, ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.IsSlashable() {
+		m.signed[key] = ctx.Digest
+		m.pruneSigned(ctx.Height)
+	}
+
+	return signature, nil
+}
+
+// pruneSigned drops the records of the past heights
+// the consensus never goes back to lower heights than the current one
+func (m *RemoteKeyManager) pruneSigned(height uint64) {
+	for key := range m.signed {
+		if key.height < height {
+			delete(m.signed, key)
+		}
+	}
+}
+
+// sign requests the signature to the remote signer and verifies it
+func (m *RemoteKeyManager) sign(keyType string, ctx *SigningContext, hash []byte) ([]byte, error) {
+	req := &remoteSignRequest{
+		Key:    keyType,
+		Digest: hex.EncodeToHex(hash),
+		Kind:   string(ctx.Kind),
+		Height: ctx.Height,
+		Round:  ctx.Round,
+	}
+
+	if len(ctx.Digest) > 0 {
+		req.Data = hex.EncodeToHex(ctx.Digest)
+	}
+
+	res := &remoteSignResponse{}
+	if err := m.post(remoteSignerSignPath+m.address.String(), req, res); err != nil {
+		return nil, fmt.Errorf("failed to sign by remote signer: %w", err)
+	}
+
+	signature, err := hex.DecodeHex(res.Signature)
+	if err != nil {
+		return nil, ErrRemoteSignerInvalidSig
+	}
+
+	if keyType == remoteKeyBLS {
+		if err := crypto.VerifyBLSSignatureFromBytes(m.blsPublicKey, signature, hash); err != nil {
+			return nil, ErrRemoteSignerInvalidSig
+		}
+
+		return signature, nil
+	}
+
+	// signers may return V in Ethereum style (27 or 28)
+	if len(signature) == IstanbulExtraSeal && signature[IstanbulExtraSeal-1] >= 27 {
+		signature[IstanbulExtraSeal-1] -= 27
+	}
+
+	if signer, err := ecrecover(signature, hash); err != nil || signer != m.address {
+		return nil, ErrRemoteSignerInvalidSig
+	}
+
+	return signature, nil
+}
+
+func (m *RemoteKeyManager) get(path string, res interface{}) error {
+	resp, err := m.client.Get(m.url + path)
+	if err != nil {
+		return err
+	}
+
+	return readRemoteSignerResponse(resp, res)
+}
+
+func (m *RemoteKeyManager) post(path string, req, res interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.client.Post(m.url+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	return readRemoteSignerResponse(resp, res)
+}
+
+func readRemoteSignerResponse(resp *http.Response, res interface{}) error {
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if res == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, res)
+}
@@ -0,0 +1,235 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRemoteSigner is a minimal remote signer holding the keys in memory
+type testRemoteSigner struct {
+	ecdsaKeys map[types.Address]*ecdsa.PrivateKey
+	blsKeys   map[types.Address]*bls_sig.SecretKey
+
+	// ethereumStyleV makes the signer return V as 27 or 28
+	ethereumStyleV bool
+
+	lock     sync.Mutex
+	requests []*remoteSignRequest
+}
+
+func newTestRemoteSigner(t *testing.T, num int) *testRemoteSigner {
+	t.Helper()
+
+	s := &testRemoteSigner{
+		ecdsaKeys: map[types.Address]*ecdsa.PrivateKey{},
+		blsKeys:   map[types.Address]*bls_sig.SecretKey{},
+	}
+
+	for i := 0; i < num; i++ {
+		ecdsaKey, _ := newTestECDSAKey(t)
+		blsKey, _ := newTestBLSKey(t)
+		addr := crypto.PubKeyToAddress(&ecdsaKey.PublicKey)
+
+		s.ecdsaKeys[addr] = ecdsaKey
+		s.blsKeys[addr] = blsKey
+	}
+
+	return s
+}
+
+func (s *testRemoteSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == remoteSignerUpcheckPath:
+		_, _ = w.Write([]byte("OK"))
+	case r.URL.Path == remoteSignerKeysPath:
+		keys := []*remoteKey{}
+
+		for addr, blsKey := range s.blsKeys {
+			pubKey, _ := crypto.BLSSecretKeyToPubkeyBytes(blsKey)
+
+			keys = append(keys, &remoteKey{Address: addr, BLSPublicKey: hex.EncodeToHex(pubKey)})
+		}
+
+		_ = json.NewEncoder(w).Encode(keys)
+	case strings.HasPrefix(r.URL.Path, remoteSignerSignPath):
+		req := &remoteSignRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		s.lock.Lock()
+		s.requests = append(s.requests, req)
+		s.lock.Unlock()
+
+		addr := types.StringToAddress(strings.TrimPrefix(r.URL.Path, remoteSignerSignPath))
+		digest, _ := hex.DecodeHex(req.Digest)
+
+		var (
+			signature []byte
+			err       error
+		)
+
+		if req.Key == remoteKeyBLS {
+			signature, err = crypto.SignByBLS(s.blsKeys[addr], digest)
+		} else {
+			signature, err = crypto.Sign(s.ecdsaKeys[addr], digest)
+			if err == nil && s.ethereumStyleV {
+				signature[len(signature)-1] += 27
+			}
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(&remoteSignResponse{Signature: hex.EncodeToHex(signature)})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestNewRemoteKeyManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should select the only key", func(t *testing.T) {
+		t.Parallel()
+
+		remote := newTestRemoteSigner(t, 1)
+		server := httptest.NewServer(remote)
+		t.Cleanup(server.Close)
+
+		km, err := NewRemoteKeyManager(&RemoteSignerConfig{URL: server.URL}, validators.BLSValidatorType)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validators.BLSValidatorType, km.Type())
+
+		for addr := range remote.ecdsaKeys {
+			assert.Equal(t, addr, km.Address())
+		}
+	})
+
+	t.Run("should require address if the signer holds multiple keys", func(t *testing.T) {
+		t.Parallel()
+
+		remote := newTestRemoteSigner(t, 2)
+		server := httptest.NewServer(remote)
+		t.Cleanup(server.Close)
+
+		_, err := NewRemoteKeyManager(&RemoteSignerConfig{URL: server.URL}, validators.ECDSAValidatorType)
+		assert.ErrorIs(t, err, ErrRemoteSignerAmbiguousKey)
+
+		_, err = NewRemoteKeyManager(
+			&RemoteSignerConfig{URL: server.URL, Address: types.StringToAddress("1")},
+			validators.ECDSAValidatorType,
+		)
+		assert.ErrorIs(t, err, ErrRemoteSignerKeyNotFound)
+
+		for addr := range remote.ecdsaKeys {
+			km, err := NewRemoteKeyManager(
+				&RemoteSignerConfig{URL: server.URL, Address: addr},
+				validators.ECDSAValidatorType,
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, addr, km.Address())
+		}
+	})
+
+	t.Run("should return error if the signer is not available", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(server.Close)
+
+		_, err := NewRemoteKeyManager(&RemoteSignerConfig{URL: server.URL}, validators.ECDSAValidatorType)
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoteKeyManagerSign(t *testing.T) {
+	t.Parallel()
+
+	remote := newTestRemoteSigner(t, 1)
+	remote.ethereumStyleV = true
+
+	server := httptest.NewServer(remote)
+	t.Cleanup(server.Close)
+
+	km, err := NewRemoteKeyManager(&RemoteSignerConfig{URL: server.URL}, validators.BLSValidatorType)
+	assert.NoError(t, err)
+
+	var (
+		signer    = newTestSingleKeyManagerSigner(km)
+		vals      = validators.NewBLSValidatorSet()
+		hash      = crypto.Keccak256([]byte{0x1})
+		otherHash = crypto.Keccak256([]byte{0x2})
+	)
+
+	for addr, blsKey := range remote.blsKeys {
+		pubKey, err := crypto.BLSSecretKeyToPubkeyBytes(blsKey)
+		assert.NoError(t, err)
+		assert.NoError(t, vals.Add(validators.NewBLSValidator(addr, pubKey)))
+	}
+
+	t.Run("should sign IBFT message and committed seal", func(t *testing.T) {
+		t.Parallel()
+
+		msg := []byte("message")
+
+		sig, err := signer.SignIBFTMessage(msg, &SigningContext{Kind: SigningKindPrepare, Height: 1, Digest: hash})
+		assert.NoError(t, err)
+
+		from, err := signer.EcrecoverFromIBFTMessage(sig, msg)
+		assert.NoError(t, err)
+		assert.Equal(t, km.Address(), from)
+
+		seal, err := signer.CreateCommittedSeal(
+			hash,
+			&SigningContext{Kind: SigningKindCommittedSeal, Height: 1, Digest: hash},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, signer.VerifyCommittedSeal(vals, km.Address(), seal, hash))
+	})
+
+	t.Run("should refuse to sign conflicting data", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &SigningContext{Kind: SigningKindCommit, Height: 2, Round: 1, Digest: hash}
+
+		_, err := signer.SignIBFTMessage([]byte("commit"), ctx)
+		assert.NoError(t, err)
+
+		// same data can be signed again
+		_, err = signer.SignIBFTMessage([]byte("commit"), ctx)
+		assert.NoError(t, err)
+
+		// different proposal in the next round
+		_, err = signer.SignIBFTMessage(
+			[]byte("commit2"),
+			&SigningContext{Kind: SigningKindCommit, Height: 2, Round: 2, Digest: otherHash},
+		)
+		assert.NoError(t, err)
+
+		_, err = signer.SignIBFTMessage(
+			[]byte("commit3"),
+			&SigningContext{Kind: SigningKindCommit, Height: 2, Round: 1, Digest: otherHash},
+		)
+		assert.ErrorIs(t, err, ErrDoubleSign)
+	})
+}
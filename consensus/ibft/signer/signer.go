@@ -38,7 +38,7 @@ type Signer interface {
 	EcrecoverFromHeader(*types.Header) (types.Address, error)
 
 	// CommittedSeal
-	CreateCommittedSeal([]byte) ([]byte, error)
+	CreateCommittedSeal([]byte, *SigningContext) ([]byte, error)
 	VerifyCommittedSeal(validators.Validators, types.Address, []byte, []byte) error
 
 	// CommittedSeals
@@ -50,6 +50,7 @@ type Signer interface {
 	) error
 
 	// ParentCommittedSeals
+	GetParentCommittedSeals(*types.Header) (Seals, error)
 	VerifyParentCommittedSeals(
 		parent, header *types.Header,
 		parentValidators validators.Validators,
@@ -57,8 +58,12 @@ type Signer interface {
 		mustExist bool,
 	) error
 
+	// KeyRotationProof
+	GetKeyRotationProof(*types.Header) ([]byte, error)
+	WriteKeyRotationProof(*types.Header, []byte) error
+
 	// IBFTMessage
-	SignIBFTMessage([]byte) ([]byte, error)
+	SignIBFTMessage([]byte, *SigningContext) ([]byte, error)
 	EcrecoverFromIBFTMessage([]byte, []byte) (types.Address, error)
 
 	// Hash of Header
@@ -137,8 +142,14 @@ func (s *SignerImpl) WriteProposerSeal(header *types.Header) (*types.Header, err
 		return nil, err
 	}
 
-	seal, err := s.keyManager.SignProposerSeal(
+	seal, err := s.signInContext(
+		&SigningContext{
+			Kind:   SigningKindProposerSeal,
+			Height: header.Number,
+			Digest: hash.Bytes(),
+		},
 		crypto.Keccak256(hash.Bytes()),
+		s.keyManager.SignProposerSeal,
 	)
 	if err != nil {
 		return nil, err
@@ -163,8 +174,9 @@ func (s *SignerImpl) EcrecoverFromHeader(header *types.Header) (types.Address, e
 }
 
 // CreateCommittedSeal returns CommittedSeal from given hash
-func (s *SignerImpl) CreateCommittedSeal(hash []byte) ([]byte, error) {
-	return s.keyManager.SignCommittedSeal(
+func (s *SignerImpl) CreateCommittedSeal(hash []byte, ctx *SigningContext) ([]byte, error) {
+	return s.signInContext(
+		ctx,
 		// Of course, this keccaking of an extended array is not according to the IBFT 2.0 spec,
 		// but almost nothing in this legacy signing package is. This is kept
 		// in order to preserve the running chains that used these
@@ -172,6 +184,7 @@ func (s *SignerImpl) CreateCommittedSeal(hash []byte) ([]byte, error) {
 		crypto.Keccak256(
 			wrapCommitHash(hash[:]),
 		),
+		s.keyManager.SignCommittedSeal,
 	)
 }
 
@@ -297,9 +310,44 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 	return nil
 }
 
+// GetKeyRotationProof extracts the proof of possession of the successor key from IBFT Extra in Header
+func (s *SignerImpl) GetKeyRotationProof(header *types.Header) ([]byte, error) {
+	extra, err := s.GetIBFTExtra(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return extra.KeyRotationProof, nil
+}
+
+// WriteKeyRotationProof sets the proof of possession of the successor key into IBFT Extra of the header
+func (s *SignerImpl) WriteKeyRotationProof(header *types.Header, proof []byte) error {
+	if err := verifyIBFTExtraSize(header); err != nil {
+		return err
+	}
+
+	header.ExtraData = packKeyRotationProofIntoExtra(header.ExtraData, proof)
+
+	return nil
+}
+
 // SignIBFTMessage signs arbitrary message
-func (s *SignerImpl) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return s.keyManager.SignIBFTMessage(crypto.Keccak256(msg))
+func (s *SignerImpl) SignIBFTMessage(msg []byte, ctx *SigningContext) ([]byte, error) {
+	return s.signInContext(ctx, crypto.Keccak256(msg), s.keyManager.SignIBFTMessage)
+}
+
+// signInContext signs the hash by the KeyManager, passing the context along
+// if the KeyManager needs to know what it signs
+func (s *SignerImpl) signInContext(
+	ctx *SigningContext,
+	hash []byte,
+	sign func([]byte) ([]byte, error),
+) ([]byte, error) {
+	if keyManager, ok := s.keyManager.(ContextualKeyManager); ok && ctx != nil {
+		return keyManager.SignInContext(ctx, hash)
+	}
+
+	return sign(hash)
 }
 
 // EcrecoverFromIBFTMessage recovers signer address from given signature and digest
@@ -376,8 +424,14 @@ func (s *SignerImpl) filterHeaderForHash(header *types.Header) (*types.Header, e
 	}
 
 	// This will effectively remove the Seal and CommittedSeals from the IBFT Extra of header,
-	// while keeping proposer vanity, validator set, and ParentCommittedSeals
-	s.initIbftExtra(clone, extra.Validators, parentCommittedSeals)
+	// while keeping proposer vanity, validator set, ParentCommittedSeals, and KeyRotationProof
+	putIbftExtra(clone, &IstanbulExtra{
+		Validators:           extra.Validators,
+		ProposerSeal:         []byte{},
+		CommittedSeals:       s.keyManager.NewEmptyCommittedSeals(),
+		ParentCommittedSeals: parentCommittedSeals,
+		KeyRotationProof:     extra.KeyRotationProof,
+	})
 
 	return clone, nil
 }
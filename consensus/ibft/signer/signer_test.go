@@ -684,7 +684,7 @@ func TestSignerCreateCommittedSeal(t *testing.T) {
 		},
 	)
 
-	res, err := signer.CreateCommittedSeal(hash)
+	res, err := signer.CreateCommittedSeal(hash, nil)
 
 	assert.Equal(t, sig, res)
 	assert.NoError(t, err)
@@ -1139,6 +1139,54 @@ func TestSignerVerifyParentCommittedSeals(t *testing.T) {
 	}
 }
 
+func TestSignerKeyRotationProof(t *testing.T) {
+	t.Parallel()
+
+	proof := []byte{0x1, 0x2}
+
+	signer := newTestSingleKeyManagerSigner(
+		&MockKeyManager{
+			NewEmptyValidatorsFunc: func() validators.Validators {
+				return validators.NewBLSValidatorSet()
+			},
+			NewEmptyCommittedSealsFunc: func() Seals {
+				return &AggregatedSeal{}
+			},
+		},
+	)
+
+	header := &types.Header{
+		Number: 2,
+	}
+
+	signer.InitIBFTExtra(header, blsValidators, testAggregatedSeals1)
+
+	hash, err := signer.CalculateHeaderHash(header)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signer.WriteKeyRotationProof(header, proof))
+
+	res, err := signer.GetKeyRotationProof(header)
+	assert.NoError(t, err)
+	assert.Equal(t, proof, res)
+
+	// the proof is covered by the header hash
+	hashWithProof, err := signer.CalculateHeaderHash(header)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, hashWithProof)
+
+	// the proof is kept when the committed seals are written
+	header.ExtraData = packCommittedSealsIntoExtra(header.ExtraData, testAggregatedSeals2)
+
+	res, err = signer.GetKeyRotationProof(header)
+	assert.NoError(t, err)
+	assert.Equal(t, proof, res)
+
+	hashWithSeals, err := signer.CalculateHeaderHash(header)
+	assert.NoError(t, err)
+	assert.Equal(t, hashWithProof, hashWithSeals)
+}
+
 func TestSignerSignIBFTMessage(t *testing.T) {
 	t.Parallel()
 
@@ -1155,7 +1203,7 @@ func TestSignerSignIBFTMessage(t *testing.T) {
 		},
 	}
 
-	res, err := signer.SignIBFTMessage(msg)
+	res, err := signer.SignIBFTMessage(msg, nil)
 
 	assert.Equal(
 		t,
@@ -1232,7 +1280,7 @@ func TestSignerSignIBFTMessageAndEcrecoverFromIBFTMessage(t *testing.T) {
 
 			signer := newTestSingleKeyManagerSigner(test.keyManager)
 
-			sig, err := signer.SignIBFTMessage(msg)
+			sig, err := signer.SignIBFTMessage(msg, nil)
 			assert.NoError(t, err)
 
 			recovered, err := signer.EcrecoverFromIBFTMessage(sig, msg)
@@ -10,4 +10,7 @@ var (
 
 	// ABI for Contract used in e2e stress test
 	StressTestABI = abi.MustNewABI(StressTestJSONABI)
+
+	// ABI for Governance Contract
+	GovernanceABI = abi.MustNewABI(GovernanceJSONABI)
 )
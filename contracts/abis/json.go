@@ -384,3 +384,42 @@ const StressTestJSONABI = `[
       "type": "function"
     }
   ]`
+
+const GovernanceJSONABI = `[
+	{
+		"inputs": [
+			{
+				"internalType": "uint8",
+				"name": "kind",
+				"type": "uint8"
+			},
+			{
+				"internalType": "bytes32",
+				"name": "key",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "uint256",
+				"name": "value",
+				"type": "uint256"
+			}
+		],
+		"name": "propose",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "proposalId",
+				"type": "uint256"
+			}
+		],
+		"name": "vote",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
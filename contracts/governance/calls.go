@@ -0,0 +1,159 @@
+package governance
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/ExzoNetwork/ExzoCoin/contracts/abis"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/ethgo/abi"
+)
+
+const (
+	methodPropose = "propose"
+	methodVote    = "vote"
+)
+
+var (
+	ErrMethodNotFoundInABI = errors.New("method not found in ABI")
+	ErrFailedTypeAssertion = errors.New("failed type assertion")
+	ErrInvalidCallLog      = errors.New("invalid governance call log")
+	ErrUnknownMethod       = errors.New("unknown governance method")
+)
+
+// Call is a call to the governance contract recovered from the log the contract emits
+type Call struct {
+	Caller types.Address
+
+	// IsVote is true for vote calls and false for propose calls
+	IsVote bool
+
+	// propose arguments
+	Kind  ProposalKind
+	Key   types.Hash
+	Value uint64
+
+	// vote arguments
+	ProposalID uint64
+}
+
+func getMethod(name string) (*abi.Method, error) {
+	method, ok := abis.GovernanceABI.Methods[name]
+	if !ok {
+		return nil, ErrMethodNotFoundInABI
+	}
+
+	return method, nil
+}
+
+// EncodeProposeCall returns the input of the transaction to submit a new proposal
+func EncodeProposeCall(kind ProposalKind, key types.Hash, value uint64) ([]byte, error) {
+	method, err := getMethod(methodPropose)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Encode(map[string]interface{}{
+		"kind":  uint8(kind),
+		"key":   [32]byte(key),
+		"value": new(big.Int).SetUint64(value),
+	})
+}
+
+// EncodeVoteCall returns the input of the transaction to vote for the proposal
+func EncodeVoteCall(proposalID uint64) ([]byte, error) {
+	method, err := getMethod(methodVote)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Encode(map[string]interface{}{
+		"proposalId": new(big.Int).SetUint64(proposalID),
+	})
+}
+
+// DecodeCallLog parses the log emitted by the governance contract
+func DecodeCallLog(log *types.Log) (*Call, error) {
+	if log.Address != AddrGovernanceContract || len(log.Topics) != 1 || len(log.Data) < 4 {
+		return nil, ErrInvalidCallLog
+	}
+
+	call := &Call{
+		Caller: types.BytesToAddress(log.Topics[0].Bytes()),
+	}
+
+	proposeMethod, err := getMethod(methodPropose)
+	if err != nil {
+		return nil, err
+	}
+
+	voteMethod, err := getMethod(methodVote)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		methodID = log.Data[:4]
+		input    = log.Data[4:]
+	)
+
+	switch {
+	case bytes.Equal(methodID, proposeMethod.ID()):
+		args, err := decodeInputs(proposeMethod, input)
+		if err != nil {
+			return nil, err
+		}
+
+		kind, ok := args["kind"].(uint8)
+		if !ok {
+			return nil, ErrFailedTypeAssertion
+		}
+
+		key, ok := args["key"].([32]byte)
+		if !ok {
+			return nil, ErrFailedTypeAssertion
+		}
+
+		value, ok := args["value"].(*big.Int)
+		if !ok || !value.IsUint64() {
+			return nil, ErrFailedTypeAssertion
+		}
+
+		call.Kind = ProposalKind(kind)
+		call.Key = types.Hash(key)
+		call.Value = value.Uint64()
+	case bytes.Equal(methodID, voteMethod.ID()):
+		args, err := decodeInputs(voteMethod, input)
+		if err != nil {
+			return nil, err
+		}
+
+		id, ok := args["proposalId"].(*big.Int)
+		if !ok || !id.IsUint64() {
+			return nil, ErrFailedTypeAssertion
+		}
+
+		call.IsVote = true
+		call.ProposalID = id.Uint64()
+	default:
+		return nil, ErrUnknownMethod
+	}
+
+	return call, nil
+}
+
+// decodeInputs decodes the arguments of the method call
+func decodeInputs(method *abi.Method, input []byte) (map[string]interface{}, error) {
+	decoded, err := method.Inputs.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+
+	args, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return args, nil
+}
@@ -0,0 +1,344 @@
+package governance
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/helper/common"
+	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+var (
+	// governance contract address
+	AddrGovernanceContract = types.StringToAddress("1002")
+
+	ErrUnknownProposalKind = errors.New("unknown proposal kind")
+	ErrUnknownFork         = errors.New("unknown fork")
+)
+
+// GovernanceSCBytecode is the runtime code of the governance contract.
+//
+// The contract keeps no state by itself. It rejects calls carrying value and
+// emits every call as LOG1(topic = caller, data = calldata), so that the IBFT
+// governance hook can pick up proposals and votes from the block receipts
+// and keep the governance storage of this account up to date:
+//
+//	CALLVALUE PUSH1 0x10 JUMPI
+//	CALLDATASIZE PUSH1 0x00 PUSH1 0x00 CALLDATACOPY
+//	CALLER CALLDATASIZE PUSH1 0x00 LOG1 STOP
+//	JUMPDEST PUSH1 0x00 PUSH1 0x00 REVERT
+const GovernanceSCBytecode = "0x3460105736600060003733366000a1005b60006000fd"
+
+// ProposalKind is the type of parameter a proposal changes
+type ProposalKind uint8
+
+const (
+	// KindBlockGasTarget changes the block gas target of the chain
+	KindBlockGasTarget ProposalKind = iota + 1
+	// KindMinValidatorCount changes the minimum validator count of the staking contract
+	KindMinValidatorCount
+	// KindMaxValidatorCount changes the maximum validator count of the staking contract
+	KindMaxValidatorCount
+	// KindFork schedules the EVM fork named by the proposal key at the block given by the value
+	KindFork
+	// KindEpochSize changes the IBFT epoch size from the first multiple of the new size
+	// after the approval, so that the epoch in progress isn't cut in the middle
+	KindEpochSize
+)
+
+var proposalKindNames = map[ProposalKind]string{
+	KindBlockGasTarget:    "block-gas-target",
+	KindMinValidatorCount: "min-validator-count",
+	KindMaxValidatorCount: "max-validator-count",
+	KindFork:              "fork",
+	KindEpochSize:         "epoch-size",
+}
+
+func (k ProposalKind) String() string {
+	if name, ok := proposalKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(k))
+}
+
+// ParseProposalKind converts the given name into ProposalKind
+func ParseProposalKind(name string) (ProposalKind, error) {
+	for kind, kindName := range proposalKindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+
+	return 0, ErrUnknownProposalKind
+}
+
+// ProposalStatus is the state of a proposal
+type ProposalStatus uint8
+
+const (
+	// StatusPending means the proposal is still collecting votes
+	StatusPending ProposalStatus = iota
+	// StatusApproved means the proposal reached quorum and has been applied
+	StatusApproved
+	// StatusRejected means the proposal reached quorum but can't be applied
+	StatusRejected
+	// StatusExpired means the proposal didn't reach quorum in the voting period
+	StatusExpired
+)
+
+var proposalStatusNames = map[ProposalStatus]string{
+	StatusPending:  "pending",
+	StatusApproved: "approved",
+	StatusRejected: "rejected",
+	StatusExpired:  "expired",
+}
+
+func (s ProposalStatus) String() string {
+	if name, ok := proposalStatusNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(s))
+}
+
+// Proposal is a parameter change request recorded in the governance contract
+type Proposal struct {
+	ID         uint64
+	Kind       ProposalKind
+	Key        types.Hash
+	Value      uint64
+	Proposer   types.Address
+	Votes      uint64
+	Status     ProposalStatus
+	ProposedAt uint64
+	// ClosedAt is the height at which the proposal has been approved, rejected or expired
+	ClosedAt uint64
+}
+
+// ForkName returns the fork name stored in the key of fork proposals
+func (p *Proposal) ForkName() string {
+	return strings.TrimRight(string(p.Key.Bytes()), "\x00")
+}
+
+// ForkNameToKey encodes a fork name into the key of a fork proposal
+func ForkNameToKey(name string) types.Hash {
+	key := types.Hash{}
+	copy(key[:], name)
+
+	return key
+}
+
+// forkSetters maps the fork names that can be scheduled to the fields of chain.Forks
+var forkSetters = map[string]func(*chain.Forks) **chain.Fork{
+	"homestead":      func(f *chain.Forks) **chain.Fork { return &f.Homestead },
+	"byzantium":      func(f *chain.Forks) **chain.Fork { return &f.Byzantium },
+	"constantinople": func(f *chain.Forks) **chain.Fork { return &f.Constantinople },
+	"petersburg":     func(f *chain.Forks) **chain.Fork { return &f.Petersburg },
+	"istanbul":       func(f *chain.Forks) **chain.Fork { return &f.Istanbul },
+	"EIP150":         func(f *chain.Forks) **chain.Fork { return &f.EIP150 },
+	"EIP158":         func(f *chain.Forks) **chain.Fork { return &f.EIP158 },
+	"EIP155":         func(f *chain.Forks) **chain.Fork { return &f.EIP155 },
+}
+
+// IsKnownFork returns whether the fork with the given name can be scheduled
+func IsKnownFork(name string) bool {
+	_, ok := forkSetters[name]
+
+	return ok
+}
+
+// IsForkActive returns whether the fork with the given name is active at the given height
+func IsForkActive(forks *chain.Forks, name string, height uint64) bool {
+	setter, ok := forkSetters[name]
+	if !ok {
+		return false
+	}
+
+	fork := *setter(forks)
+
+	return fork != nil && fork.Active(height)
+}
+
+// ScheduleFork sets the activation block of the fork with the given name
+func ScheduleFork(forks *chain.Forks, name string, block uint64) error {
+	setter, ok := forkSetters[name]
+	if !ok {
+		return ErrUnknownFork
+	}
+
+	*setter(forks) = chain.NewFork(block)
+
+	return nil
+}
+
+// PredeployGovernanceSC returns the genesis account of the governance contract
+func PredeployGovernanceSC() *chain.GenesisAccount {
+	code, _ := hex.DecodeHex(GovernanceSCBytecode)
+
+	return &chain.GenesisAccount{
+		Code:    code,
+		Balance: big.NewInt(0),
+	}
+}
+
+// Storage layout of the governance contract account
+//
+//	slot 0: number of proposals
+//	slot 1: mapping(uint256 => Proposal)
+//	slot 2: mapping(uint256 => mapping(address => bool)) votes
+var (
+	proposalCountSlot = int64(0) // Slot 0
+	proposalsSlot     = int64(1) // Slot 1
+	votesSlot         = int64(2) // Slot 2
+)
+
+// Field offsets of Proposal in the proposals mapping
+const (
+	kindOffset uint64 = iota
+	keyOffset
+	valueOffset
+	proposerOffset
+	votesOffset
+	statusOffset
+	proposedAtOffset
+	closedAtOffset
+)
+
+// StateReader is an interface to read the storage of the governance contract
+type StateReader interface {
+	GetState(types.Address, types.Hash) types.Hash
+}
+
+// State is an interface to read and write the storage of the governance contract
+type State interface {
+	StateReader
+	SetState(types.Address, types.Hash, types.Hash)
+}
+
+// mappingIndex returns the storage index of the mapping element
+// keccak(key . slot)
+func mappingIndex(key []byte, slot []byte) []byte {
+	return keccak.Keccak256(
+		nil,
+		append(
+			common.PadLeftOrTrim(key, 32),
+			common.PadLeftOrTrim(slot, 32)...,
+		),
+	)
+}
+
+// proposalFieldIndex returns the storage index of the field of the given proposal
+func proposalFieldIndex(id uint64, offset uint64) types.Hash {
+	base := new(big.Int).SetBytes(
+		mappingIndex(
+			new(big.Int).SetUint64(id).Bytes(),
+			big.NewInt(proposalsSlot).Bytes(),
+		),
+	)
+
+	return types.BytesToHash(base.Add(base, new(big.Int).SetUint64(offset)).Bytes())
+}
+
+// voteIndex returns the storage index of the vote of the given voter for the given proposal
+func voteIndex(id uint64, voter types.Address) types.Hash {
+	return types.BytesToHash(
+		mappingIndex(
+			voter.Bytes(),
+			mappingIndex(
+				new(big.Int).SetUint64(id).Bytes(),
+				big.NewInt(votesSlot).Bytes(),
+			),
+		),
+	)
+}
+
+func slotIndex(slot int64) types.Hash {
+	return types.BytesToHash(big.NewInt(slot).Bytes())
+}
+
+func uint64ToHash(value uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(value).Bytes())
+}
+
+func hashToUint64(hash types.Hash) uint64 {
+	return new(big.Int).SetBytes(hash.Bytes()).Uint64()
+}
+
+// GetProposalCount returns the number of proposals recorded in the contract
+func GetProposalCount(st StateReader) uint64 {
+	return hashToUint64(st.GetState(AddrGovernanceContract, slotIndex(proposalCountSlot)))
+}
+
+// GetProposal returns the proposal with the given ID
+func GetProposal(st StateReader, id uint64) *Proposal {
+	get := func(offset uint64) types.Hash {
+		return st.GetState(AddrGovernanceContract, proposalFieldIndex(id, offset))
+	}
+
+	return &Proposal{
+		ID:         id,
+		Kind:       ProposalKind(hashToUint64(get(kindOffset))),
+		Key:        get(keyOffset),
+		Value:      hashToUint64(get(valueOffset)),
+		Proposer:   types.BytesToAddress(get(proposerOffset).Bytes()),
+		Votes:      hashToUint64(get(votesOffset)),
+		Status:     ProposalStatus(hashToUint64(get(statusOffset))),
+		ProposedAt: hashToUint64(get(proposedAtOffset)),
+		ClosedAt:   hashToUint64(get(closedAtOffset)),
+	}
+}
+
+// GetProposals returns all proposals recorded in the contract in order of ID
+func GetProposals(st StateReader) []*Proposal {
+	count := GetProposalCount(st)
+	proposals := make([]*Proposal, 0, count)
+
+	for id := uint64(0); id < count; id++ {
+		proposals = append(proposals, GetProposal(st, id))
+	}
+
+	return proposals
+}
+
+// AddProposal records a new proposal and returns its ID
+func AddProposal(st State, proposal *Proposal) uint64 {
+	id := GetProposalCount(st)
+	proposal.ID = id
+
+	SetProposal(st, proposal)
+	st.SetState(AddrGovernanceContract, slotIndex(proposalCountSlot), uint64ToHash(id+1))
+
+	return id
+}
+
+// SetProposal writes the given proposal into the storage
+func SetProposal(st State, proposal *Proposal) {
+	set := func(offset uint64, value types.Hash) {
+		st.SetState(AddrGovernanceContract, proposalFieldIndex(proposal.ID, offset), value)
+	}
+
+	set(kindOffset, uint64ToHash(uint64(proposal.Kind)))
+	set(keyOffset, proposal.Key)
+	set(valueOffset, uint64ToHash(proposal.Value))
+	set(proposerOffset, types.BytesToHash(proposal.Proposer.Bytes()))
+	set(votesOffset, uint64ToHash(proposal.Votes))
+	set(statusOffset, uint64ToHash(uint64(proposal.Status)))
+	set(proposedAtOffset, uint64ToHash(proposal.ProposedAt))
+	set(closedAtOffset, uint64ToHash(proposal.ClosedAt))
+}
+
+// HasVoted returns whether the voter has already voted for the given proposal
+func HasVoted(st StateReader, id uint64, voter types.Address) bool {
+	return st.GetState(AddrGovernanceContract, voteIndex(id, voter)) != types.ZeroHash
+}
+
+// SetVoted records the vote of the voter for the given proposal
+func SetVoted(st State, id uint64, voter types.Address) {
+	st.SetState(AddrGovernanceContract, voteIndex(id, voter), uint64ToHash(1))
+}
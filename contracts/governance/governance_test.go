@@ -0,0 +1,177 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockState map[types.Address]map[types.Hash]types.Hash
+
+func (m mockState) GetState(addr types.Address, key types.Hash) types.Hash {
+	return m[addr][key]
+}
+
+func (m mockState) SetState(addr types.Address, key types.Hash, value types.Hash) {
+	if _, ok := m[addr]; !ok {
+		m[addr] = make(map[types.Hash]types.Hash)
+	}
+
+	m[addr][key] = value
+}
+
+func TestParseProposalKind(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range []ProposalKind{
+		KindBlockGasTarget,
+		KindMinValidatorCount,
+		KindMaxValidatorCount,
+		KindFork,
+		KindEpochSize,
+	} {
+		parsed, err := ParseProposalKind(kind.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, kind, parsed)
+	}
+
+	_, err := ParseProposalKind("block-time")
+	assert.ErrorIs(t, err, ErrUnknownProposalKind)
+}
+
+func TestProposalStorage(t *testing.T) {
+	t.Parallel()
+
+	st := mockState{}
+
+	assert.Equal(t, uint64(0), GetProposalCount(st))
+	assert.Empty(t, GetProposals(st))
+
+	proposals := []*Proposal{
+		{
+			Kind:       KindBlockGasTarget,
+			Value:      20000000,
+			Proposer:   types.StringToAddress("1"),
+			Votes:      1,
+			Status:     StatusPending,
+			ProposedAt: 10,
+		},
+		{
+			Kind:       KindFork,
+			Key:        ForkNameToKey("istanbul"),
+			Value:      500,
+			Proposer:   types.StringToAddress("2"),
+			Votes:      3,
+			Status:     StatusApproved,
+			ProposedAt: 20,
+			ClosedAt:   30,
+		},
+	}
+
+	for idx, proposal := range proposals {
+		assert.Equal(t, uint64(idx), AddProposal(st, proposal))
+	}
+
+	assert.Equal(t, uint64(2), GetProposalCount(st))
+	assert.Equal(t, proposals, GetProposals(st))
+	assert.Equal(t, "istanbul", GetProposal(st, 1).ForkName())
+
+	voter := types.StringToAddress("3")
+
+	assert.False(t, HasVoted(st, 0, voter))
+
+	SetVoted(st, 0, voter)
+
+	assert.True(t, HasVoted(st, 0, voter))
+	assert.False(t, HasVoted(st, 1, voter))
+}
+
+func TestScheduleFork(t *testing.T) {
+	t.Parallel()
+
+	forks := &chain.Forks{
+		Homestead: chain.NewFork(0),
+	}
+
+	assert.True(t, IsForkActive(forks, "homestead", 0))
+	assert.False(t, IsForkActive(forks, "istanbul", 1000))
+
+	assert.NoError(t, ScheduleFork(forks, "istanbul", 100))
+
+	assert.False(t, IsForkActive(forks, "istanbul", 99))
+	assert.True(t, IsForkActive(forks, "istanbul", 100))
+
+	assert.ErrorIs(t, ScheduleFork(forks, "london", 100), ErrUnknownFork)
+}
+
+func TestDecodeCallLog(t *testing.T) {
+	t.Parallel()
+
+	caller := types.StringToAddress("1")
+
+	newLog := func(data []byte) *types.Log {
+		return &types.Log{
+			Address: AddrGovernanceContract,
+			Topics:  []types.Hash{types.BytesToHash(caller.Bytes())},
+			Data:    data,
+		}
+	}
+
+	t.Run("should decode propose call", func(t *testing.T) {
+		t.Parallel()
+
+		input, err := EncodeProposeCall(KindFork, ForkNameToKey("istanbul"), 100)
+		assert.NoError(t, err)
+
+		call, err := DecodeCallLog(newLog(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Call{
+			Caller: caller,
+			Kind:   KindFork,
+			Key:    ForkNameToKey("istanbul"),
+			Value:  100,
+		}, call)
+	})
+
+	t.Run("should decode vote call", func(t *testing.T) {
+		t.Parallel()
+
+		input, err := EncodeVoteCall(5)
+		assert.NoError(t, err)
+
+		call, err := DecodeCallLog(newLog(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Call{
+			Caller:     caller,
+			IsVote:     true,
+			ProposalID: 5,
+		}, call)
+	})
+
+	t.Run("should return error for unknown method", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeCallLog(newLog([]byte{0x1, 0x2, 0x3, 0x4}))
+
+		assert.ErrorIs(t, err, ErrUnknownMethod)
+	})
+
+	t.Run("should return error for log by other contract", func(t *testing.T) {
+		t.Parallel()
+
+		input, err := EncodeVoteCall(5)
+		assert.NoError(t, err)
+
+		log := newLog(input)
+		log.Address = types.StringToAddress("1001")
+
+		_, err = DecodeCallLog(log)
+
+		assert.ErrorIs(t, err, ErrInvalidCallLog)
+	})
+}
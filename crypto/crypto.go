@@ -31,7 +31,8 @@ var (
 	secp256k1N = hex.MustDecodeHex("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
 	one        = []byte{0x01}
 
-	ErrInvalidBLSSignature = errors.New("invalid BLS Signature")
+	ErrInvalidBLSSignature         = errors.New("invalid BLS Signature")
+	ErrInvalidBLSProofOfPossession = errors.New("invalid BLS Proof of Possession")
 )
 
 type KeyType string
@@ -228,6 +229,41 @@ func VerifyBLSSignatureFromBytes(rawPubkey, rawSig, message []byte) error {
 	return VerifyBLSSignature(pubkey, signature, message)
 }
 
+// CreateBLSProofOfPossession creates the proof that the holder of the given secret key
+// owns the BLS Public Key
+func CreateBLSProofOfPossession(prv *bls_sig.SecretKey) ([]byte, error) {
+	pop, err := bls_sig.NewSigPop().PopProve(prv)
+	if err != nil {
+		return nil, err
+	}
+
+	return pop.MarshalBinary()
+}
+
+// VerifyBLSProofOfPossession verifies the proof of possession of BLS Public Key in bytes
+func VerifyBLSProofOfPossession(rawPubkey, rawPop []byte) error {
+	pubkey, err := UnmarshalBLSPublicKey(rawPubkey)
+	if err != nil {
+		return err
+	}
+
+	pop := &bls_sig.ProofOfPossession{}
+	if err := pop.UnmarshalBinary(rawPop); err != nil {
+		return err
+	}
+
+	ok, err := bls_sig.NewSigPop().PopVerify(pubkey, pop)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrInvalidBLSProofOfPossession
+	}
+
+	return nil
+}
+
 // SigToPub returns the public key that created the given signature.
 func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	s, err := Ecrecover(hash, sig)
@@ -255,3 +255,33 @@ func TestPrivateKeyGeneration(t *testing.T) {
 	assert.True(t, writtenKey.Equal(readKey))
 	assert.Equal(t, writtenAddress.String(), readAddress.String())
 }
+
+func TestBLSProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBLSKey()
+	assert.NoError(t, err)
+
+	pubkey, err := BLSSecretKeyToPubkeyBytes(key)
+	assert.NoError(t, err)
+
+	otherKey, err := GenerateBLSKey()
+	assert.NoError(t, err)
+
+	otherPubkey, err := BLSSecretKeyToPubkeyBytes(otherKey)
+	assert.NoError(t, err)
+
+	pop, err := CreateBLSProofOfPossession(key)
+	assert.NoError(t, err)
+
+	assert.NoError(t, VerifyBLSProofOfPossession(pubkey, pop))
+
+	// the proof doesn't prove the possession of another key
+	assert.ErrorIs(t, VerifyBLSProofOfPossession(otherPubkey, pop), ErrInvalidBLSProofOfPossession)
+
+	// the signature of the key isn't a proof of possession
+	signature, err := SignByBLS(key, pubkey)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, VerifyBLSProofOfPossession(pubkey, signature), ErrInvalidBLSProofOfPossession)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	errInvalidEntry = errors.New("invalid export entry")
+)
+
+// Entry is a canonical block with the receipts of its transactions
+type Entry struct {
+	Block    *types.Block
+	Receipts []*types.Receipt
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (e *Entry) MarshalRLP() []byte {
+	return e.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (e *Entry) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(e.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode.
+// The hash is included since the block hash depends on the consensus
+func (e *Entry) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	receipts := types.Receipts(e.Receipts)
+
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(e.Block.Hash().Bytes()))
+	vv.Set(e.Block.MarshalRLPWith(arena))
+	vv.Set(receipts.MarshalStoreRLPWith(arena))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (e *Entry) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(e.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (e *Entry) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 3 {
+		return fmt.Errorf("%w: expected 3 elements but found %d", errInvalidEntry, len(elems))
+	}
+
+	hash := types.Hash{}
+	if err := elems[0].GetHash(hash[:]); err != nil {
+		return err
+	}
+
+	e.Block = &types.Block{}
+	if err := e.Block.UnmarshalRLPFrom(p, elems[1]); err != nil {
+		return err
+	}
+
+	// the decoded header has the hash of the default hashing, not the one of the consensus
+	e.Block.Header.Hash = hash
+
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalStoreRLPFrom(p, elems[2]); err != nil {
+		return err
+	}
+
+	e.Receipts = receipts
+
+	return nil
+}
+
+// DecodeEntries decodes the concatenated RLP encoded entries
+// in the data of the events of the export stream
+func DecodeEntries(data []byte) ([]*Entry, error) {
+	entries := []*Entry{}
+
+	// wrap the entries into a list so that they can be parsed at once
+	err := types.UnmarshalRlp(func(p *fastrlp.Parser, v *fastrlp.Value) error {
+		elems, err := v.GetElems()
+		if err != nil {
+			return err
+		}
+
+		for _, elem := range elems {
+			entry := &Entry{}
+			if err := entry.UnmarshalRLPFrom(p, elem); err != nil {
+				return err
+			}
+
+			entries = append(entries, entry)
+		}
+
+		return nil
+	}, append(listPrefix(len(data)), data...))
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// listPrefix returns the RLP prefix of a list with the payload of the given size
+func listPrefix(size int) []byte {
+	if size < 56 {
+		return []byte{0xC0 + byte(size)}
+	}
+
+	sizeBytes := []byte{}
+	for n := size; n > 0; n >>= 8 {
+		sizeBytes = append([]byte{byte(n)}, sizeBytes...)
+	}
+
+	return append([]byte{0xF7 + byte(len(sizeBytes))}, sizeBytes...)
+}
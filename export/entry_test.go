@@ -0,0 +1,145 @@
+package export
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChainID = 100
+
+var (
+	testTokenAddr = types.StringToAddress("0x1000")
+	testRecipient = types.StringToAddress("0x2000")
+)
+
+// newTestEntries returns the entries of a chain of the given length, every block has
+// a signed transaction without the sender and a receipt with a transfer log
+func newTestEntries(t *testing.T, length int) []*Entry {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	signer := crypto.NewEIP155Signer(testChainID)
+	entries := make([]*Entry, length)
+	parentHash := types.ZeroHash
+
+	for i := range entries {
+		tx, err := signer.SignTx(&types.Transaction{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+			To:       &testRecipient,
+			Value:    big.NewInt(int64(i)),
+		}, key)
+		assert.NoError(t, err)
+
+		tx.ComputeHash()
+
+		header := &types.Header{
+			ParentHash: parentHash,
+			Number:     uint64(i),
+			Timestamp:  uint64(1000 + i),
+			Miner:      types.StringToAddress("0x10").Bytes(),
+			ExtraData:  []byte{0x1, 0x2},
+			GasLimit:   1000000,
+			GasUsed:    21000,
+		}
+
+		// the hash of the consensus differs from the default hashing
+		header.Hash = types.BytesToHash(big.NewInt(int64(i + 1)).Bytes())
+		parentHash = header.Hash
+
+		receipt := &types.Receipt{
+			CumulativeGasUsed: 21000,
+			GasUsed:           21000,
+			TxHash:            tx.Hash,
+			Logs: []*types.Log{
+				{
+					Address: testTokenAddr,
+					Topics: []types.Hash{
+						transferTopic,
+						types.BytesToHash(crypto.PubKeyToAddress(&key.PublicKey).Bytes()),
+						types.BytesToHash(testRecipient.Bytes()),
+					},
+					Data: types.BytesToHash(big.NewInt(int64(i)).Bytes()).Bytes(),
+				},
+			},
+		}
+		receipt.SetStatus(types.ReceiptSuccess)
+
+		entries[i] = &Entry{
+			Block: &types.Block{
+				Header:       header,
+				Transactions: []*types.Transaction{tx},
+			},
+			Receipts: []*types.Receipt{receipt},
+		}
+	}
+
+	return entries
+}
+
+func TestEntry_MarshalRLP(t *testing.T) {
+	t.Parallel()
+
+	entry := newTestEntries(t, 1)[0]
+	entry.Receipts[0].SetContractAddress(types.StringToAddress("0x3000"))
+
+	decoded := &Entry{}
+	assert.NoError(t, decoded.UnmarshalRLP(entry.MarshalRLP()))
+
+	assert.Equal(t, entry.Block.Hash(), decoded.Block.Hash())
+	assert.Equal(t, entry.Block.Number(), decoded.Block.Number())
+	assert.Len(t, decoded.Block.Transactions, 1)
+	assert.Equal(t, entry.Block.Transactions[0].Hash, decoded.Block.Transactions[0].Hash)
+
+	assert.Len(t, decoded.Receipts, 1)
+	assert.Equal(t, entry.Receipts[0].TxHash, decoded.Receipts[0].TxHash)
+	assert.Equal(t, entry.Receipts[0].ContractAddress, decoded.Receipts[0].ContractAddress)
+	assert.Equal(t, entry.Receipts[0].Logs, decoded.Receipts[0].Logs)
+}
+
+func TestDecodeEntries(t *testing.T) {
+	t.Parallel()
+
+	entries := newTestEntries(t, 3)
+
+	// a block without transactions and receipts
+	entries = append(entries, &Entry{
+		Block: &types.Block{
+			Header: &types.Header{Number: 3, Hash: types.StringToHash("0x4")},
+		},
+	})
+
+	data := []byte{}
+	for _, entry := range entries {
+		data = entry.MarshalRLPTo(data)
+	}
+
+	decoded, err := DecodeEntries(data)
+	assert.NoError(t, err)
+	assert.Len(t, decoded, len(entries))
+
+	for i, entry := range decoded {
+		assert.Equal(t, entries[i].Block.Hash(), entry.Block.Hash())
+		assert.Len(t, entry.Receipts, len(entries[i].Receipts))
+	}
+
+	// truncated data
+	_, err = DecodeEntries(data[:len(data)-1])
+	assert.Error(t, err)
+}
+
+func TestListPrefix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte{0xC0}, listPrefix(0))
+	assert.Equal(t, []byte{0xF7}, listPrefix(55))
+	assert.Equal(t, []byte{0xF8, 0x38}, listPrefix(56))
+	assert.Equal(t, []byte{0xF9, 0x01, 0x00}, listPrefix(256))
+}
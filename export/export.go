@@ -0,0 +1,191 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	DefaultPartitionSize uint64 = 100000
+)
+
+var (
+	ErrUnknownTable         = errors.New("unknown table")
+	ErrUnknownFormat        = errors.New("unknown format")
+	ErrInvalidPartitionSize = errors.New("partition size must be greater than zero")
+	ErrNoBlocks             = errors.New("no blocks in the range")
+	errBlockGap             = errors.New("blocks are not consecutive")
+	errReceiptsMismatch     = errors.New("receipts don't match the transactions")
+)
+
+// Config is the configuration of the export
+type Config struct {
+	// Dir is the output directory, the files of a table are in the subdirectory with its name
+	Dir string
+	// Format is the format of the files, parquet or csv
+	Format string
+	// Tables are the names of the exported tables, all the tables if empty
+	Tables []string
+	// PartitionSize is the number of blocks of a file, the partitions are aligned to it
+	PartitionSize uint64
+	// ChainID is used to recover the senders of the transactions
+	ChainID uint64
+}
+
+// Result is the summary of the export
+type Result struct {
+	From   uint64
+	To     uint64
+	Blocks uint64
+	Rows   map[string]uint64
+	Files  map[string][]string
+}
+
+// Export writes the tables of the blocks of the source into the partitioned files
+func Export(source Source, config *Config, logger hclog.Logger) (*Result, error) {
+	if config.PartitionSize == 0 {
+		return nil, ErrInvalidPartitionSize
+	}
+
+	format, err := ParseFormat(config.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := selectTables(config.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := writeSchema(config.Dir, format, tables); err != nil {
+		return nil, err
+	}
+
+	writers := make([]*partitionWriter, len(tables))
+
+	for i, table := range tables {
+		if writers[i], err = newPartitionWriter(config.Dir, format, table, config.PartitionSize); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := exportEntries(source, writers, crypto.NewEIP155Signer(config.ChainID), logger)
+	if err != nil {
+		for _, w := range writers {
+			w.abort()
+		}
+
+		return nil, err
+	}
+
+	for _, w := range writers {
+		if err := w.close(); err != nil {
+			return nil, err
+		}
+
+		result.Rows[w.table.Name] = w.rows
+		result.Files[w.table.Name] = w.files
+	}
+
+	return result, nil
+}
+
+func exportEntries(
+	source Source,
+	writers []*partitionWriter,
+	signer *crypto.EIP155Signer,
+	logger hclog.Logger,
+) (*Result, error) {
+	var (
+		result = &Result{
+			Rows:  map[string]uint64{},
+			Files: map[string][]string{},
+		}
+		prev *types.Header
+	)
+
+	for {
+		entry, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		header := entry.Block.Header
+		if prev != nil && (header.Number != prev.Number+1 || header.ParentHash != prev.Hash) {
+			return nil, fmt.Errorf("%w: block %d follows block %d", errBlockGap, header.Number, prev.Number)
+		}
+
+		if err := prepareEntry(entry, signer); err != nil {
+			return nil, err
+		}
+
+		for _, w := range writers {
+			if err := w.writeBlock(header.Number, tableRows[w.table.Name](entry)); err != nil {
+				return nil, err
+			}
+		}
+
+		if prev == nil {
+			result.From = header.Number
+		}
+
+		result.To = header.Number
+		result.Blocks++
+		prev = header
+
+		if result.Blocks%10000 == 0 {
+			logger.Info("Exported blocks", "number", header.Number, "hash", header.Hash)
+		}
+	}
+
+	if result.Blocks == 0 {
+		return nil, ErrNoBlocks
+	}
+
+	return result, nil
+}
+
+// prepareEntry checks the receipts of the entry and recovers the senders of the transactions
+func prepareEntry(entry *Entry, signer *crypto.EIP155Signer) error {
+	txs := entry.Block.Transactions
+
+	if len(entry.Receipts) != len(txs) {
+		return fmt.Errorf(
+			"%w: block %d has %d transactions and %d receipts",
+			errReceiptsMismatch,
+			entry.Block.Number(),
+			len(txs),
+			len(entry.Receipts),
+		)
+	}
+
+	for _, tx := range txs {
+		// the sender is only stored in the database, not in the consensus encoding
+		if tx.From != types.ZeroAddress {
+			continue
+		}
+
+		from, err := signer.Sender(tx)
+		if err != nil {
+			return fmt.Errorf("unable to recover the sender of transaction %s: %w", tx.Hash, err)
+		}
+
+		tx.From = from
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/memory"
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// sliceSource returns the entries of the slice
+type sliceSource struct {
+	entries []*Entry
+}
+
+func (s *sliceSource) Next() (*Entry, error) {
+	if len(s.entries) == 0 {
+		return nil, io.EOF
+	}
+
+	entry := s.entries[0]
+	s.entries = s.entries[1:]
+
+	return entry, nil
+}
+
+func (s *sliceSource) Close() error {
+	return nil
+}
+
+func newTestSigner() *crypto.EIP155Signer {
+	return crypto.NewEIP155Signer(testChainID)
+}
+
+func newTestConfig(t *testing.T, format string) *Config {
+	t.Helper()
+
+	return &Config{
+		Dir:           t.TempDir(),
+		Format:        format,
+		PartitionSize: 2,
+		ChainID:       testChainID,
+	}
+}
+
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	assert.NoError(t, err)
+
+	return records
+}
+
+func TestExport_CSV(t *testing.T) {
+	t.Parallel()
+
+	entries := newTestEntries(t, 5)
+	config := newTestConfig(t, FormatCSV)
+
+	result, err := Export(&sliceSource{entries: entries[1:]}, config, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1), result.From)
+	assert.Equal(t, uint64(4), result.To)
+	assert.Equal(t, uint64(4), result.Blocks)
+
+	for _, table := range Tables {
+		assert.Equal(t, uint64(4), result.Rows[table.Name], table.Name)
+
+		// the partitions are aligned to the partition size
+		assert.Equal(t, []string{
+			filepath.Join(config.Dir, table.Name, "0000000001-0000000001.csv"),
+			filepath.Join(config.Dir, table.Name, "0000000002-0000000003.csv"),
+			filepath.Join(config.Dir, table.Name, "0000000004-0000000004.csv"),
+		}, result.Files[table.Name])
+	}
+
+	records := readCSV(t, result.Files[TableTransactions][1])
+	assert.Len(t, records, 3)
+	assert.Equal(t, "block_number", records[0][0])
+	assert.Equal(t, "2", records[1][0])
+	assert.Equal(t, entries[2].Block.Hash().String(), records[1][1])
+
+	// the sender is recovered from the signature
+	sender, err := newTestSigner().Sender(entries[2].Block.Transactions[0])
+	assert.NoError(t, err)
+	assert.Equal(t, encodeAddress(sender), records[1][5])
+
+	// null is the empty field
+	records = readCSV(t, result.Files[TableReceipts][0])
+	assert.Equal(t, "1", records[1][4])
+	assert.Equal(t, "", records[1][5])
+
+	// the schema describes the tables
+	data, err := os.ReadFile(filepath.Join(config.Dir, SchemaFile))
+	assert.NoError(t, err)
+
+	schema := struct {
+		Format string   `json:"format"`
+		Tables []*Table `json:"tables"`
+	}{}
+	assert.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, FormatCSV, schema.Format)
+	assert.Equal(t, Tables, schema.Tables)
+
+	// no temporary files are left
+	files, err := os.ReadDir(filepath.Join(config.Dir, TableBlocks))
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+func TestExport_Parquet(t *testing.T) {
+	t.Parallel()
+
+	entries := newTestEntries(t, 3)
+	config := newTestConfig(t, FormatParquet)
+	config.Tables = []string{TableReceipts}
+
+	result, err := Export(&sliceSource{entries: entries}, config, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Files, 1)
+	assert.Len(t, result.Files[TableReceipts], 2)
+
+	file, err := local.NewLocalFileReader(result.Files[TableReceipts][0])
+	assert.NoError(t, err)
+
+	defer file.Close()
+
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	assert.NoError(t, err)
+
+	defer pr.ReadStop()
+
+	assert.Equal(t, int64(2), pr.GetNumRows())
+
+	numbers, _, _, err := pr.ReadColumnByIndex(0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(0), int64(1)}, numbers)
+
+	hashes, _, _, err := pr.ReadColumnByIndex(3, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		entries[0].Block.Transactions[0].Hash.String(),
+		entries[1].Block.Transactions[0].Hash.String(),
+	}, hashes)
+
+	// the root is null for the receipts with the status
+	roots, _, _, err := pr.ReadColumnByIndex(5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, nil}, roots)
+}
+
+func TestExport_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown table", func(t *testing.T) {
+		t.Parallel()
+
+		config := newTestConfig(t, FormatCSV)
+		config.Tables = []string{TableBlocks, "traces"}
+
+		_, err := Export(&sliceSource{}, config, hclog.NewNullLogger())
+		assert.ErrorIs(t, err, ErrUnknownTable)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Export(&sliceSource{}, newTestConfig(t, "json"), hclog.NewNullLogger())
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+	})
+
+	t.Run("no blocks", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Export(&sliceSource{}, newTestConfig(t, FormatCSV), hclog.NewNullLogger())
+		assert.ErrorIs(t, err, ErrNoBlocks)
+	})
+
+	t.Run("gap", func(t *testing.T) {
+		t.Parallel()
+
+		entries := newTestEntries(t, 3)
+		config := newTestConfig(t, FormatCSV)
+
+		_, err := Export(&sliceSource{entries: []*Entry{entries[0], entries[2]}}, config, hclog.NewNullLogger())
+		assert.ErrorIs(t, err, errBlockGap)
+
+		// the incomplete files are removed
+		files, err := os.ReadDir(filepath.Join(config.Dir, TableBlocks))
+		assert.NoError(t, err)
+		assert.Len(t, files, 0)
+	})
+
+	t.Run("missing receipts", func(t *testing.T) {
+		t.Parallel()
+
+		entries := newTestEntries(t, 1)
+		entries[0].Receipts = nil
+
+		_, err := Export(&sliceSource{entries: entries}, newTestConfig(t, FormatCSV), hclog.NewNullLogger())
+		assert.ErrorIs(t, err, errReceiptsMismatch)
+	})
+}
+
+func TestStorageSource(t *testing.T) {
+	t.Parallel()
+
+	db, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	entries := newTestEntries(t, 3)
+
+	// the genesis has neither body nor receipts
+	entries[0].Block.Transactions, entries[0].Receipts = nil, nil
+
+	for _, entry := range entries {
+		header := entry.Block.Header
+
+		assert.NoError(t, db.WriteHeader(header))
+		assert.NoError(t, db.WriteCanonicalHash(header.Number, header.Hash))
+
+		if len(entry.Block.Transactions) > 0 {
+			assert.NoError(t, db.WriteBody(header.Hash, entry.Block.Body()))
+			assert.NoError(t, db.WriteReceipts(header.Hash, entry.Receipts))
+		}
+	}
+
+	assert.NoError(t, db.WriteHeadNumber(2))
+
+	from, to := uint64(0), uint64(5)
+
+	source, err := NewStorageSource(db, from, &to)
+	assert.NoError(t, err)
+
+	for _, expected := range entries {
+		entry, err := source.Next()
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected.Block.Hash(), entry.Block.Hash())
+		assert.Len(t, entry.Block.Transactions, len(expected.Block.Transactions))
+		assert.Len(t, entry.Receipts, len(expected.Receipts))
+	}
+
+	_, err = source.Next()
+	assert.True(t, errors.Is(err, io.EOF))
+}
@@ -0,0 +1,216 @@
+package export
+
+import (
+	"math/big"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	standardERC20  = "erc20"
+	standardERC721 = "erc721"
+)
+
+var (
+	// transferTopic is the signature of Transfer(address,address,uint256),
+	// shared by ERC-20 and ERC-721
+	transferTopic = types.StringToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+)
+
+// row is a row of a table, the values are uint64, string or nil for null
+type row []interface{}
+
+// rowsFunc returns the rows of a table for the entry
+type rowsFunc func(entry *Entry) []row
+
+var tableRows = map[string]rowsFunc{
+	TableBlocks:         blockRows,
+	TableTransactions:   transactionRows,
+	TableReceipts:       receiptRows,
+	TableLogs:           logRows,
+	TableTokenTransfers: tokenTransferRows,
+}
+
+func blockRows(entry *Entry) []row {
+	header := entry.Block.Header
+
+	return []row{{
+		header.Number,
+		header.Hash.String(),
+		header.ParentHash.String(),
+		header.Timestamp,
+		encodeAddress(types.BytesToAddress(header.Miner)),
+		header.StateRoot.String(),
+		header.TxRoot.String(),
+		header.ReceiptsRoot.String(),
+		header.GasLimit,
+		header.GasUsed,
+		header.Difficulty,
+		hex.EncodeToHex(header.ExtraData),
+		uint64(len(entry.Block.Transactions)),
+	}}
+}
+
+func transactionRows(entry *Entry) []row {
+	header := entry.Block.Header
+	rows := make([]row, 0, len(entry.Block.Transactions))
+
+	for i, tx := range entry.Block.Transactions {
+		var to interface{}
+		if tx.To != nil {
+			to = encodeAddress(*tx.To)
+		}
+
+		rows = append(rows, row{
+			header.Number,
+			header.Hash.String(),
+			uint64(i),
+			tx.Hash.String(),
+			tx.Nonce,
+			encodeAddress(tx.From),
+			to,
+			encodeBig(tx.Value),
+			tx.Gas,
+			encodeBig(tx.GasPrice),
+			hex.EncodeToHex(tx.Input),
+		})
+	}
+
+	return rows
+}
+
+func receiptRows(entry *Entry) []row {
+	header := entry.Block.Header
+	rows := make([]row, 0, len(entry.Receipts))
+
+	for i, receipt := range entry.Receipts {
+		var status, root, contractAddress interface{}
+
+		if receipt.Status != nil {
+			status = uint64(*receipt.Status)
+		} else {
+			root = receipt.Root.String()
+		}
+
+		if receipt.ContractAddress != nil {
+			contractAddress = encodeAddress(*receipt.ContractAddress)
+		}
+
+		rows = append(rows, row{
+			header.Number,
+			header.Hash.String(),
+			uint64(i),
+			transactionHash(entry, i).String(),
+			status,
+			root,
+			receipt.CumulativeGasUsed,
+			receipt.GasUsed,
+			contractAddress,
+		})
+	}
+
+	return rows
+}
+
+func logRows(entry *Entry) []row {
+	header := entry.Block.Header
+	rows := []row{}
+
+	forEachLog(entry, func(txIndex int, logIndex uint64, log *types.Log) {
+		topics := make([]interface{}, 4)
+		for i := 0; i < len(log.Topics) && i < len(topics); i++ {
+			topics[i] = log.Topics[i].String()
+		}
+
+		rows = append(rows, row{
+			header.Number,
+			header.Hash.String(),
+			uint64(txIndex),
+			transactionHash(entry, txIndex).String(),
+			logIndex,
+			encodeAddress(log.Address),
+			topics[0],
+			topics[1],
+			topics[2],
+			topics[3],
+			hex.EncodeToHex(log.Data),
+		})
+	})
+
+	return rows
+}
+
+func tokenTransferRows(entry *Entry) []row {
+	header := entry.Block.Header
+	rows := []row{}
+
+	forEachLog(entry, func(txIndex int, logIndex uint64, log *types.Log) {
+		if len(log.Topics) < 3 || log.Topics[0] != transferTopic {
+			return
+		}
+
+		var (
+			value    *big.Int
+			standard string
+		)
+
+		switch {
+		case len(log.Topics) == 3 && len(log.Data) == types.HashLength:
+			// ERC-20 has the amount in the data
+			value, standard = new(big.Int).SetBytes(log.Data), standardERC20
+		case len(log.Topics) == 4 && len(log.Data) == 0:
+			// ERC-721 has the indexed token id
+			value, standard = new(big.Int).SetBytes(log.Topics[3].Bytes()), standardERC721
+		default:
+			return
+		}
+
+		rows = append(rows, row{
+			header.Number,
+			header.Hash.String(),
+			transactionHash(entry, txIndex).String(),
+			logIndex,
+			encodeAddress(log.Address),
+			encodeAddress(types.BytesToAddress(log.Topics[1].Bytes())),
+			encodeAddress(types.BytesToAddress(log.Topics[2].Bytes())),
+			value.String(),
+			standard,
+		})
+	})
+
+	return rows
+}
+
+// forEachLog calls fn for the logs of the receipts with the index of the log in the block
+func forEachLog(entry *Entry, fn func(txIndex int, logIndex uint64, log *types.Log)) {
+	logIndex := uint64(0)
+
+	for i, receipt := range entry.Receipts {
+		for _, log := range receipt.Logs {
+			fn(i, logIndex, log)
+			logIndex++
+		}
+	}
+}
+
+// transactionHash returns the hash of the transaction of the receipt with the index
+func transactionHash(entry *Entry, index int) types.Hash {
+	if index < len(entry.Block.Transactions) {
+		return entry.Block.Transactions[index].Hash
+	}
+
+	return entry.Receipts[index].TxHash
+}
+
+func encodeAddress(addr types.Address) string {
+	return hex.EncodeToHex(addr.Bytes())
+}
+
+func encodeBig(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRows_ColumnCount(t *testing.T) {
+	t.Parallel()
+
+	entry := newTestEntries(t, 1)[0]
+	assert.NoError(t, prepareEntry(entry, newTestSigner()))
+
+	for _, table := range Tables {
+		rows := tableRows[table.Name](entry)
+		assert.NotEmpty(t, rows, table.Name)
+
+		for _, r := range rows {
+			assert.Len(t, r, len(table.Columns), table.Name)
+		}
+	}
+}
+
+func TestReceiptRows_Nullable(t *testing.T) {
+	t.Parallel()
+
+	entry := newTestEntries(t, 1)[0]
+
+	rows := receiptRows(entry)
+	assert.Equal(t, uint64(types.ReceiptSuccess), rows[0][4])
+	assert.Nil(t, rows[0][5])
+	assert.Nil(t, rows[0][8])
+
+	// pre-byzantium receipt with the state root
+	entry.Receipts[0].Status = nil
+	entry.Receipts[0].Root = types.StringToHash("0x1")
+	entry.Receipts[0].SetContractAddress(types.StringToAddress("0x3000"))
+
+	rows = receiptRows(entry)
+	assert.Nil(t, rows[0][4])
+	assert.Equal(t, types.StringToHash("0x1").String(), rows[0][5])
+	assert.Equal(t, "0x0000000000000000000000000000000000003000", rows[0][8])
+}
+
+func TestLogRows_LogIndex(t *testing.T) {
+	t.Parallel()
+
+	entries := newTestEntries(t, 2)
+
+	// merge the transactions into a block
+	entry := entries[0]
+	entry.Block.Transactions = append(entry.Block.Transactions, entries[1].Block.Transactions...)
+	entry.Receipts = append(entry.Receipts, entries[1].Receipts...)
+
+	rows := logRows(entry)
+	assert.Len(t, rows, 2)
+
+	for i, r := range rows {
+		assert.Equal(t, uint64(i), r[2])
+		assert.Equal(t, entry.Block.Transactions[i].Hash.String(), r[3])
+		assert.Equal(t, uint64(i), r[4])
+		assert.Equal(t, transferTopic.String(), r[6])
+		assert.Nil(t, r[9])
+	}
+}
+
+func TestTokenTransferRows(t *testing.T) {
+	t.Parallel()
+
+	from := types.BytesToHash(types.StringToAddress("0x1").Bytes())
+	to := types.BytesToHash(types.StringToAddress("0x2").Bytes())
+	amount := types.BytesToHash(big.NewInt(500).Bytes())
+
+	logs := []*types.Log{
+		// ERC-20
+		{Address: testTokenAddr, Topics: []types.Hash{transferTopic, from, to}, Data: amount.Bytes()},
+		// ERC-721
+		{Address: testTokenAddr, Topics: []types.Hash{transferTopic, from, to, types.StringToHash("0x7")}},
+		// other event
+		{Address: testTokenAddr, Topics: []types.Hash{types.StringToHash("0x1"), from, to}, Data: amount.Bytes()},
+		// transfer with unexpected data
+		{Address: testTokenAddr, Topics: []types.Hash{transferTopic, from, to}},
+	}
+
+	entry := newTestEntries(t, 1)[0]
+	entry.Receipts[0].Logs = logs
+
+	rows := tokenTransferRows(entry)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, uint64(0), rows[0][3])
+	assert.Equal(t, "0x0000000000000000000000000000000000001000", rows[0][4])
+	assert.Equal(t, "0x0000000000000000000000000000000000000001", rows[0][5])
+	assert.Equal(t, "0x0000000000000000000000000000000000000002", rows[0][6])
+	assert.Equal(t, "500", rows[0][7])
+	assert.Equal(t, standardERC20, rows[0][8])
+
+	assert.Equal(t, uint64(1), rows[1][3])
+	assert.Equal(t, "7", rows[1][7])
+	assert.Equal(t, standardERC721, rows[1][8])
+}
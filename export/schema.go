@@ -0,0 +1,184 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Types of the columns. Hashes, addresses and byte data are lowercase 0x-prefixed
+// hex strings, and the values which can exceed 64 bits are decimal strings
+const (
+	TypeUint64 = "uint64"
+	TypeString = "string"
+)
+
+// Names of the tables
+const (
+	TableBlocks         = "blocks"
+	TableTransactions   = "transactions"
+	TableReceipts       = "receipts"
+	TableLogs           = "logs"
+	TableTokenTransfers = "token_transfers"
+)
+
+// SchemaFile is the name of the file describing the tables in the output directory
+const SchemaFile = "schema.json"
+
+// Column is a column of a table
+type Column struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Nullable    bool   `json:"nullable"`
+	Description string `json:"description"`
+}
+
+// Table is an exported table, one row is written for every block, transaction,
+// receipt, log or token transfer in the range
+type Table struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Columns     []*Column `json:"columns"`
+}
+
+// Tables are the exported tables in the order of the export
+var Tables = []*Table{
+	{
+		Name:        TableBlocks,
+		Description: "canonical blocks",
+		Columns: []*Column{
+			{"number", TypeUint64, false, "block number"},
+			{"hash", TypeString, false, "block hash"},
+			{"parent_hash", TypeString, false, "hash of the parent block"},
+			{"timestamp", TypeUint64, false, "block timestamp in unix seconds"},
+			{"miner", TypeString, false, "address of the block proposer"},
+			{"state_root", TypeString, false, "root of the state trie after the block"},
+			{"transactions_root", TypeString, false, "root of the transactions trie"},
+			{"receipts_root", TypeString, false, "root of the receipts trie"},
+			{"gas_limit", TypeUint64, false, "gas limit of the block"},
+			{"gas_used", TypeUint64, false, "gas used by the transactions of the block"},
+			{"difficulty", TypeUint64, false, "block difficulty"},
+			{"extra_data", TypeString, false, "extra data of the header, including the consensus data"},
+			{"transaction_count", TypeUint64, false, "number of transactions in the block"},
+		},
+	},
+	{
+		Name:        TableTransactions,
+		Description: "transactions of the canonical blocks",
+		Columns: []*Column{
+			{"block_number", TypeUint64, false, "number of the block including the transaction"},
+			{"block_hash", TypeString, false, "hash of the block including the transaction"},
+			{"transaction_index", TypeUint64, false, "index of the transaction in the block"},
+			{"hash", TypeString, false, "transaction hash"},
+			{"nonce", TypeUint64, false, "nonce of the sender"},
+			{"from_address", TypeString, false, "address of the sender"},
+			{"to_address", TypeString, true, "address of the recipient, null for contract creations"},
+			{"value", TypeString, false, "transferred value in wei, decimal"},
+			{"gas", TypeUint64, false, "gas limit of the transaction"},
+			{"gas_price", TypeString, false, "gas price in wei, decimal"},
+			{"input", TypeString, false, "input data of the transaction"},
+		},
+	},
+	{
+		Name:        TableReceipts,
+		Description: "receipts of the transactions of the canonical blocks",
+		Columns: []*Column{
+			{"block_number", TypeUint64, false, "number of the block including the transaction"},
+			{"block_hash", TypeString, false, "hash of the block including the transaction"},
+			{"transaction_index", TypeUint64, false, "index of the transaction in the block"},
+			{"transaction_hash", TypeString, false, "transaction hash"},
+			{"status", TypeUint64, true, "1 for success, 0 for failure, null for receipts with the state root"},
+			{"root", TypeString, true, "state root after the transaction, null for receipts with the status"},
+			{"cumulative_gas_used", TypeUint64, false, "gas used in the block up to and including the transaction"},
+			{"gas_used", TypeUint64, false, "gas used by the transaction"},
+			{"contract_address", TypeString, true, "address of the created contract, null for other transactions"},
+		},
+	},
+	{
+		Name:        TableLogs,
+		Description: "logs emitted by the transactions of the canonical blocks",
+		Columns: []*Column{
+			{"block_number", TypeUint64, false, "number of the block including the log"},
+			{"block_hash", TypeString, false, "hash of the block including the log"},
+			{"transaction_index", TypeUint64, false, "index of the transaction in the block"},
+			{"transaction_hash", TypeString, false, "hash of the transaction emitting the log"},
+			{"log_index", TypeUint64, false, "index of the log in the block"},
+			{"address", TypeString, false, "address of the contract emitting the log"},
+			{"topic0", TypeString, true, "first topic, the event signature for non-anonymous events"},
+			{"topic1", TypeString, true, "second topic"},
+			{"topic2", TypeString, true, "third topic"},
+			{"topic3", TypeString, true, "fourth topic"},
+			{"data", TypeString, false, "non-indexed data of the log"},
+		},
+	},
+	{
+		Name:        TableTokenTransfers,
+		Description: "ERC-20 and ERC-721 Transfer events decoded from the logs",
+		Columns: []*Column{
+			{"block_number", TypeUint64, false, "number of the block including the transfer"},
+			{"block_hash", TypeString, false, "hash of the block including the transfer"},
+			{"transaction_hash", TypeString, false, "hash of the transaction emitting the transfer"},
+			{"log_index", TypeUint64, false, "index of the log in the block"},
+			{"token_address", TypeString, false, "address of the token contract"},
+			{"from_address", TypeString, false, "address of the sender, zero address for mints"},
+			{"to_address", TypeString, false, "address of the recipient, zero address for burns"},
+			{"value", TypeString, false, "amount for ERC-20, token id for ERC-721, decimal"},
+			{"standard", TypeString, false, "erc20 or erc721"},
+		},
+	},
+}
+
+// TableNames returns the names of all the tables
+func TableNames() []string {
+	names := make([]string, len(Tables))
+	for i, table := range Tables {
+		names[i] = table.Name
+	}
+
+	return names
+}
+
+// selectTables returns the tables with the given names, all of them if names is empty
+func selectTables(names []string) ([]*Table, error) {
+	if len(names) == 0 {
+		return Tables, nil
+	}
+
+	selected := make([]*Table, 0, len(names))
+
+	for _, table := range Tables {
+		for _, name := range names {
+			if table.Name == name {
+				selected = append(selected, table)
+
+				break
+			}
+		}
+	}
+
+	if len(selected) != len(names) {
+		return nil, fmt.Errorf(
+			"%w: %s, expected any of %s",
+			ErrUnknownTable,
+			strings.Join(names, ","),
+			strings.Join(TableNames(), ","),
+		)
+	}
+
+	return selected, nil
+}
+
+// writeSchema writes the description of the tables into the output directory
+func writeSchema(dir, format string, tables []*Table) error {
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"format": format,
+		"tables": tables,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, SchemaFile), data, 0600)
+}
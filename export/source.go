@@ -0,0 +1,178 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/server/proto"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+var (
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+// Source returns the entries of the consecutive canonical blocks of the range
+type Source interface {
+	// Next returns the entry of the next block, io.EOF after the last one
+	Next() (*Entry, error)
+	// Close releases the resources of the source
+	Close() error
+}
+
+// storageSource reads the entries from the blockchain database of a stopped node
+type storageSource struct {
+	db   storage.Storage
+	next uint64
+	to   uint64
+}
+
+// NewStorageSource returns the source of the blocks from..to in the database,
+// to the head if to is nil
+func NewStorageSource(db storage.Storage, from uint64, to *uint64) (Source, error) {
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, fmt.Errorf("%w: head of the chain", ErrBlockNotFound)
+	}
+
+	last := head
+	if to != nil && *to < head {
+		last = *to
+	}
+
+	return &storageSource{
+		db:   db,
+		next: from,
+		to:   last,
+	}, nil
+}
+
+func (s *storageSource) Next() (*Entry, error) {
+	if s.next > s.to {
+		return nil, io.EOF
+	}
+
+	number := s.next
+
+	hash, ok := s.db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("%w: canonical hash of block %d", ErrBlockNotFound, number)
+	}
+
+	header, err := s.db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header of block %d: %v", ErrBlockNotFound, number, err)
+	}
+
+	// the decoded header has the hash of the default hashing, not the one of the consensus
+	header.Hash = hash
+
+	block := &types.Block{
+		Header: header,
+	}
+
+	body, err := s.db.ReadBody(hash)
+	if err == nil {
+		block.Transactions = body.Transactions
+		block.Uncles = body.Uncles
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	receipts, err := s.db.ReadReceipts(hash)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	s.next++
+
+	return &Entry{
+		Block:    block,
+		Receipts: receipts,
+	}, nil
+}
+
+func (s *storageSource) Close() error {
+	return nil
+}
+
+// grpcSource reads the entries from the export stream of a running node
+type grpcSource struct {
+	stream  proto.System_ExportClient
+	cancel  context.CancelFunc
+	to      *uint64
+	pending []*Entry
+	done    bool
+}
+
+// NewGRPCSource returns the source of the blocks from..to exported by the node,
+// to the latest block if to is nil
+func NewGRPCSource(ctx context.Context, client proto.SystemClient, from uint64, to *uint64) (Source, error) {
+	req := &proto.ExportRequest{
+		From:     from,
+		Receipts: true,
+	}
+
+	if to != nil {
+		req.To = *to
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := client.Export(ctx, req)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return &grpcSource{
+		stream: stream,
+		cancel: cancel,
+		to:     to,
+	}, nil
+}
+
+func (s *grpcSource) Next() (*Entry, error) {
+	for len(s.pending) == 0 {
+		if s.done {
+			return nil, io.EOF
+		}
+
+		event, err := s.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			s.done = true
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if s.pending, err = DecodeEntries(event.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := s.pending[0]
+	s.pending = s.pending[1:]
+
+	// to of zero is the latest block for the stream
+	if s.to != nil && entry.Block.Number() > *s.to {
+		s.pending, s.done = nil, true
+
+		return nil, io.EOF
+	}
+
+	return entry, nil
+}
+
+func (s *grpcSource) Close() error {
+	s.cancel()
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Formats of the exported files
+const (
+	FormatParquet = "parquet"
+	FormatCSV     = "csv"
+)
+
+// tableWriter writes the rows of a table into a file
+type tableWriter interface {
+	write(r row) error
+	close() error
+}
+
+func newTableWriter(format string, table *Table, w io.Writer) (tableWriter, error) {
+	switch format {
+	case FormatParquet:
+		return newParquetWriter(table, w)
+	case FormatCSV:
+		return newCSVWriter(table, w)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// csvWriter writes the rows as CSV with the column names in the first line
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newCSVWriter(table *Table, w io.Writer) (*csvWriter, error) {
+	names := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		names[i] = column.Name
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(names); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{
+		writer: writer,
+		record: make([]string, len(table.Columns)),
+	}, nil
+}
+
+func (w *csvWriter) write(r row) error {
+	for i, value := range r {
+		switch v := value.(type) {
+		case nil:
+			// null is the empty field
+			w.record[i] = ""
+		case uint64:
+			w.record[i] = strconv.FormatUint(v, 10)
+		case string:
+			w.record[i] = v
+		default:
+			return fmt.Errorf("unexpected value of type %T", value)
+		}
+	}
+
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// parquetWriter writes the rows as Parquet with the schema of the table
+type parquetWriter struct {
+	writer *writer.CSVWriter
+}
+
+func newParquetWriter(table *Table, w io.Writer) (*parquetWriter, error) {
+	metadata := make([]string, len(table.Columns))
+
+	for i, column := range table.Columns {
+		typ := "type=BYTE_ARRAY, convertedtype=UTF8"
+		if column.Type == TypeUint64 {
+			typ = "type=INT64, convertedtype=UINT_64"
+		}
+
+		repetition := "REQUIRED"
+		if column.Nullable {
+			repetition = "OPTIONAL"
+		}
+
+		metadata[i] = fmt.Sprintf("name=%s, %s, repetitiontype=%s", column.Name, typ, repetition)
+	}
+
+	writer, err := writer.NewCSVWriterFromWriter(metadata, w, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parquetWriter{
+		writer: writer,
+	}, nil
+}
+
+func (w *parquetWriter) write(r row) error {
+	record := make([]interface{}, len(r))
+
+	for i, value := range r {
+		switch v := value.(type) {
+		case nil, string:
+			record[i] = v
+		case uint64:
+			// UINT_64 is stored in the INT64 physical type
+			record[i] = int64(v)
+		default:
+			return fmt.Errorf("unexpected value of type %T", value)
+		}
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *parquetWriter) close() error {
+	return w.writer.WriteStop()
+}
+
+// partitionWriter writes a table into the files of the partitions,
+// named <table>/<first block>-<last block>.<format> in the output directory
+type partitionWriter struct {
+	dir    string
+	format string
+	table  *Table
+	size   uint64
+
+	file      *os.File
+	buf       *bufio.Writer
+	writer    tableWriter
+	partition uint64
+	first     uint64
+	last      uint64
+	rows      uint64
+
+	files []string
+}
+
+func newPartitionWriter(dir, format string, table *Table, size uint64) (*partitionWriter, error) {
+	dir = filepath.Join(dir, table.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &partitionWriter{
+		dir:    dir,
+		format: format,
+		table:  table,
+		size:   size,
+	}, nil
+}
+
+// writeBlock writes the rows of the block, the partition file is switched
+// when the block belongs to the next partition
+func (w *partitionWriter) writeBlock(number uint64, rows []row) error {
+	if w.writer != nil && number/w.size != w.partition {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+
+	if w.writer == nil {
+		if err := w.openFile(number); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range rows {
+		if err := w.writer.write(r); err != nil {
+			return err
+		}
+	}
+
+	w.last = number
+	w.rows += uint64(len(rows))
+
+	return nil
+}
+
+func (w *partitionWriter) openFile(number uint64) error {
+	// the file is renamed once it's complete, the name has the range of the written blocks
+	file, err := os.CreateTemp(w.dir, "."+w.table.Name+"-*")
+	if err != nil {
+		return err
+	}
+
+	buf := bufio.NewWriter(file)
+
+	writer, err := newTableWriter(w.format, w.table, buf)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	w.file, w.buf, w.writer = file, buf, writer
+	w.partition, w.first = number/w.size, number
+
+	return nil
+}
+
+func (w *partitionWriter) closeFile() error {
+	if err := w.writer.close(); err != nil {
+		return err
+	}
+
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.dir, fmt.Sprintf("%010d-%010d.%s", w.first, w.last, w.format))
+	if err := os.Rename(w.file.Name(), path); err != nil {
+		return err
+	}
+
+	w.files = append(w.files, path)
+	w.file, w.buf, w.writer = nil, nil, nil
+
+	return nil
+}
+
+// close completes the file of the current partition
+func (w *partitionWriter) close() error {
+	if w.writer == nil {
+		return nil
+	}
+
+	return w.closeFile()
+}
+
+// abort removes the incomplete file of the current partition
+func (w *partitionWriter) abort() {
+	if w.file == nil {
+		return
+	}
+
+	w.file.Close()
+	os.Remove(w.file.Name())
+
+	w.file, w.buf, w.writer = nil, nil, nil
+}
+
+// ParseFormat returns the format with the name, case insensitive
+func ParseFormat(name string) (string, error) {
+	switch format := strings.ToLower(name); format {
+	case FormatParquet, FormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+	}
+}
@@ -0,0 +1,815 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrDepthLimitExceeded      = errors.New("query depth exceeds limit")
+	ErrComplexityLimitExceeded = errors.New("query complexity exceeds limit")
+)
+
+// Limits are the limits of the request execution
+type Limits struct {
+	// MaxDepth is the max nesting of the selection sets, 0 disables the limit
+	MaxDepth int
+	// MaxComplexity is the max number of the fields resolved by the request,
+	// counting the fields of every list item, 0 disables the limit
+	MaxComplexity int
+}
+
+// Request is the GraphQL request sent by the client.
+// Numbers in Variables are expected to be json.Number, see json.Decoder.UseNumber
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the result of the request
+type Response struct {
+	Data   interface{} `json:"data"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error of the request or of a field
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func errorResponse(err error) *Response {
+	return &Response{
+		Errors: []*Error{{Message: err.Error()}},
+	}
+}
+
+// Execute validates the request against the schema and the limits, and executes it.
+// Errors of the fields are returned together with the data of the other fields
+func (s *Schema) Execute(ctx context.Context, req *Request, limits Limits) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	op, err := doc.getOperation(req.OperationName)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	var root *Object
+
+	switch op.Type {
+	case "query":
+		root = s.Query
+	case "mutation":
+		root = s.Mutation
+	}
+
+	if root == nil {
+		return errorResponse(fmt.Errorf("%s operations are not supported", op.Type))
+	}
+
+	e := &executor{
+		ctx:    ctx,
+		schema: s,
+		doc:    doc,
+		limits: limits,
+	}
+
+	if e.variables, err = s.coerceVariables(op.Variables, req.Variables); err != nil {
+		return errorResponse(err)
+	}
+
+	if err := doc.checkFragmentCycles(); err != nil {
+		return errorResponse(err)
+	}
+
+	// every field is resolved once at least, which is checked before the execution
+	complexity, err := e.validate(root, op.SelectionSet, 1)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return errorResponse(ErrComplexityLimitExceeded)
+	}
+
+	data, err := e.executeSelections(root, nil, op.SelectionSet, nil)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	return &Response{
+		Data:   data,
+		Errors: e.errors,
+	}
+}
+
+func (d *Document) getOperation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) != 1 {
+			return nil, errors.New("operation name is required for the document with multiple operations")
+		}
+
+		return d.Operations[0], nil
+	}
+
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("operation %s not found", name)
+}
+
+// checkFragmentCycles returns error if a fragment spreads itself directly or indirectly
+func (d *Document) checkFragmentCycles() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	state := make(map[string]int, len(d.Fragments))
+
+	var visit func(name string) error
+
+	visitSelections := func(selections []Selection) error {
+		return walkSpreads(selections, visit)
+	}
+
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("fragment %s spreads itself", name)
+		case visited:
+			return nil
+		}
+
+		fragment, ok := d.Fragments[name]
+		if !ok {
+			return fmt.Errorf("unknown fragment %s", name)
+		}
+
+		state[name] = visiting
+
+		if err := visitSelections(fragment.SelectionSet); err != nil {
+			return err
+		}
+
+		state[name] = visited
+
+		return nil
+	}
+
+	for name := range d.Fragments {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkSpreads calls fn with the names of the fragments spread in the selections at any depth
+func walkSpreads(selections []Selection, fn func(string) error) error {
+	for _, selection := range selections {
+		var err error
+
+		switch s := selection.(type) {
+		case *Field:
+			err = walkSpreads(s.SelectionSet, fn)
+		case *FragmentSpread:
+			err = fn(s.Name)
+		case *InlineFragment:
+			err = walkSpreads(s.SelectionSet, fn)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// coerceVariables checks the values of the variables against their types and parses them
+func (s *Schema) coerceVariables(
+	defs []*VariableDefinition,
+	values map[string]interface{},
+) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(defs))
+
+	for _, def := range defs {
+		typ, err := s.parseType(def.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isInputType(typ) {
+			return nil, fmt.Errorf("variable $%s of type %s must be of input type", def.Name, def.Type)
+		}
+
+		value, ok := values[def.Name]
+		if !ok && def.Default != nil {
+			if value, err = valueToGo(def.Default, nil); err != nil {
+				return nil, err
+			}
+
+			ok = true
+		}
+
+		if !ok {
+			if _, required := typ.(*NonNull); required {
+				return nil, fmt.Errorf("variable $%s of type %s is required", def.Name, def.Type)
+			}
+
+			res[def.Name] = nil
+
+			continue
+		}
+
+		if res[def.Name], err = coerceValue(typ, value); err != nil {
+			return nil, fmt.Errorf("variable $%s: %w", def.Name, err)
+		}
+	}
+
+	return res, nil
+}
+
+// coerceValue checks the input value against the type and parses the values of the scalars
+func coerceValue(typ Type, value interface{}) (interface{}, error) {
+	if t, ok := typ.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected non-null value of type %s", typeName(t))
+		}
+
+		return coerceValue(t.OfType, value)
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t := typ.(type) {
+	case *List:
+		items, ok := value.([]interface{})
+		if !ok {
+			// a single value is accepted as the list of one item
+			items = []interface{}{value}
+		}
+
+		res := make([]interface{}, len(items))
+
+		for idx, item := range items {
+			var err error
+
+			if res[idx], err = coerceValue(t.OfType, item); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	case *InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid %s value %v", t.Name, value)
+		}
+
+		for name := range fields {
+			if _, ok := t.Fields[name]; !ok {
+				return nil, fmt.Errorf("unknown field %s of input type %s", name, t.Name)
+			}
+		}
+
+		res := make(map[string]interface{}, len(fields))
+
+		for _, name := range argumentNames(t.Fields) {
+			def := t.Fields[name]
+
+			fieldValue, ok := fields[name]
+			if !ok {
+				if _, required := def.Type.(*NonNull); required {
+					return nil, fmt.Errorf("field %s of input type %s is required", name, t.Name)
+				}
+
+				continue
+			}
+
+			var err error
+
+			if res[name], err = coerceValue(def.Type, fieldValue); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	case *Enum:
+		if name, ok := value.(string); ok && containsString(t.Values, name) {
+			return name, nil
+		}
+
+		return nil, fmt.Errorf("invalid %s value %v", t.Name, value)
+	case *Scalar:
+		if t.ParseValue == nil {
+			return value, nil
+		}
+
+		switch value.(type) {
+		case []interface{}, map[string]interface{}:
+			return nil, fmt.Errorf("invalid %s value %v", t.Name, value)
+		}
+
+		return t.ParseValue(value)
+	}
+
+	return nil, fmt.Errorf("type %s is not an input type", typeName(typ))
+}
+
+func valueToGo(value Value, variables map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *Variable:
+		res, ok := variables[v.Name]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined", v.Name)
+		}
+
+		return res, nil
+	case *ScalarValue:
+		return v.Value, nil
+	case *ListValue:
+		res := make([]interface{}, len(v.Values))
+
+		for idx, item := range v.Values {
+			var err error
+
+			if res[idx], err = valueToGo(item, variables); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	case *ObjectValue:
+		res := make(map[string]interface{}, len(v.Fields))
+
+		for _, field := range v.Fields {
+			var err error
+
+			if res[field.Name], err = valueToGo(field.Value, variables); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("unexpected value %T", value)
+}
+
+type executor struct {
+	ctx       context.Context
+	schema    *Schema
+	doc       *Document
+	variables map[string]interface{}
+	limits    Limits
+
+	// resolved is the number of the resolved fields
+	resolved int
+	errors   []*Error
+}
+
+// validate checks the selections against the object type and the depth limit,
+// and returns the number of the fields in the selections
+func (e *executor) validate(obj *Object, selections []Selection, depth int) (int, error) {
+	if e.limits.MaxDepth > 0 && depth > e.limits.MaxDepth {
+		return 0, ErrDepthLimitExceeded
+	}
+
+	keys, groups, err := e.collectFields(obj, selections)
+	if err != nil {
+		return 0, err
+	}
+
+	complexity := 0
+
+	for _, key := range keys {
+		for _, field := range groups[key] {
+			complexity++
+
+			n, err := e.validateField(obj, field, depth)
+			if err != nil {
+				return 0, err
+			}
+
+			complexity += n
+		}
+	}
+
+	return complexity, nil
+}
+
+func (e *executor) validateField(obj *Object, field *Field, depth int) (int, error) {
+	if field.Name == "__typename" {
+		if len(field.SelectionSet) > 0 {
+			return 0, errors.New("field __typename must not have a selection")
+		}
+
+		return 0, nil
+	}
+
+	def, ok := e.schema.getField(obj, field.Name)
+	if !ok {
+		return 0, fmt.Errorf("cannot query field %s on type %s", field.Name, obj.Name)
+	}
+
+	if _, err := e.coerceArguments(obj, def, field); err != nil {
+		return 0, err
+	}
+
+	switch t := namedType(def.Type).(type) {
+	case *Object:
+		if len(field.SelectionSet) == 0 {
+			return 0, fmt.Errorf("field %s of type %s must have a selection", field.Name, t.Name)
+		}
+
+		if field.Name == "__schema" || field.Name == "__type" {
+			// the introspection queries are exempt from the depth limit as their depth is fixed by the clients,
+			// they're bounded by the nesting limit of the parser and by the complexity limit
+			maxDepth := e.limits.MaxDepth
+			defer func() {
+				e.limits.MaxDepth = maxDepth
+			}()
+
+			e.limits.MaxDepth = 0
+		}
+
+		return e.validate(t, field.SelectionSet, depth+1)
+	default:
+		if len(field.SelectionSet) > 0 {
+			return 0, fmt.Errorf("field %s of type %s must not have a selection", field.Name, typeName(t))
+		}
+	}
+
+	return 0, nil
+}
+
+// coerceArguments returns the values of the arguments of the field parsed by their types
+func (e *executor) coerceArguments(obj *Object, def *FieldDefinition, field *Field) (map[string]interface{}, error) {
+	args := make(map[string]interface{}, len(field.Arguments))
+
+	for _, arg := range field.Arguments {
+		argDef, ok := def.Args[arg.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %s on field %s.%s", arg.Name, obj.Name, field.Name)
+		}
+
+		value, err := valueToGo(arg.Value, e.variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if args[arg.Name], err = coerceValue(argDef.Type, value); err != nil {
+			return nil, fmt.Errorf("argument %s on field %s.%s: %w", arg.Name, obj.Name, field.Name, err)
+		}
+	}
+
+	for _, name := range argumentNames(def.Args) {
+		if _, required := def.Args[name].Type.(*NonNull); required && args[name] == nil {
+			return nil, fmt.Errorf("argument %s on field %s.%s is required", name, obj.Name, field.Name)
+		}
+	}
+
+	return args, nil
+}
+
+// collectFields returns the fields of the selections grouped by the response keys in order,
+// including the fields of the fragments which apply to the object type
+func (e *executor) collectFields(obj *Object, selections []Selection) ([]string, map[string][]*Field, error) {
+	var (
+		keys    []string
+		groups  = make(map[string][]*Field)
+		visited = make(map[string]bool)
+		collect func([]Selection) error
+	)
+
+	collect = func(selections []Selection) error {
+		for _, selection := range selections {
+			var (
+				directives []*Directive
+				children   []Selection
+				condition  string
+			)
+
+			switch s := selection.(type) {
+			case *Field:
+				directives = s.Directives
+			case *FragmentSpread:
+				if visited[s.Name] {
+					continue
+				}
+
+				fragment, ok := e.doc.Fragments[s.Name]
+				if !ok {
+					return fmt.Errorf("unknown fragment %s", s.Name)
+				}
+
+				visited[s.Name] = true
+				directives, children, condition = s.Directives, fragment.SelectionSet, fragment.TypeCondition
+			case *InlineFragment:
+				directives, children, condition = s.Directives, s.SelectionSet, s.TypeCondition
+			}
+
+			include, err := e.shouldInclude(directives)
+			if err != nil {
+				return err
+			}
+
+			if !include {
+				continue
+			}
+
+			field, ok := selection.(*Field)
+			if !ok {
+				if typeConditionApplies(condition, obj) {
+					if err := collect(children); err != nil {
+						return err
+					}
+				}
+
+				continue
+			}
+
+			key := field.ResponseKey()
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+
+			groups[key] = append(groups[key], field)
+		}
+
+		return nil
+	}
+
+	if err := collect(selections); err != nil {
+		return nil, nil, err
+	}
+
+	return keys, groups, nil
+}
+
+// shouldInclude evaluates @skip and @include directives
+func (e *executor) shouldInclude(directives []*Directive) (bool, error) {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			return false, fmt.Errorf("unknown directive @%s", directive.Name)
+		}
+
+		if len(directive.Arguments) != 1 || directive.Arguments[0].Name != "if" {
+			return false, fmt.Errorf("directive @%s requires argument if", directive.Name)
+		}
+
+		value, err := valueToGo(directive.Arguments[0].Value, e.variables)
+		if err != nil {
+			return false, err
+		}
+
+		condition, ok := value.(bool)
+		if !ok {
+			return false, fmt.Errorf("argument if of directive @%s must be boolean", directive.Name)
+		}
+
+		if (directive.Name == "skip") == condition {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (e *executor) executeSelections(
+	obj *Object,
+	source interface{},
+	selections []Selection,
+	path []interface{},
+) (*orderedMap, error) {
+	keys, groups, err := e.collectFields(obj, selections)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &orderedMap{
+		keys:   keys,
+		values: make(map[string]interface{}, len(keys)),
+	}
+
+	for _, key := range keys {
+		fields := groups[key]
+
+		value, err := e.executeField(obj, source, fields, appendPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+
+		res.values[key] = value
+	}
+
+	return res, nil
+}
+
+// executeField resolves the field, the error of the resolver is collected and the value is set to null.
+// It returns error only if the execution must be aborted
+func (e *executor) executeField(
+	obj *Object,
+	source interface{},
+	fields []*Field,
+	path []interface{},
+) (interface{}, error) {
+	if err := e.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	field := fields[0]
+	if field.Name == "__typename" {
+		return obj.Name, nil
+	}
+
+	e.resolved++
+	if e.limits.MaxComplexity > 0 && e.resolved > e.limits.MaxComplexity {
+		return nil, ErrComplexityLimitExceeded
+	}
+
+	def, _ := e.schema.getField(obj, field.Name)
+
+	args, err := e.coerceArguments(obj, def, field)
+	if err != nil {
+		e.addError(err, path)
+
+		return nil, nil
+	}
+
+	value, err := def.Resolve(ResolveParams{
+		Context: e.ctx,
+		Source:  source,
+		Args:    args,
+	})
+	if err != nil {
+		e.addError(err, path)
+
+		return nil, nil
+	}
+
+	// the selections of the fields with the same response key are merged
+	var selections []Selection
+	for _, f := range fields {
+		selections = append(selections, f.SelectionSet...)
+	}
+
+	return e.completeValue(def.Type, value, selections, path)
+}
+
+func (e *executor) completeValue(
+	typ Type,
+	value interface{},
+	selections []Selection,
+	path []interface{},
+) (interface{}, error) {
+	if t, ok := typ.(*NonNull); ok {
+		if isNil(value) {
+			e.addError(errors.New("cannot return null for non-nullable field"), path)
+
+			return nil, nil
+		}
+
+		typ = t.OfType
+	}
+
+	if isNil(value) {
+		return nil, nil
+	}
+
+	switch t := typ.(type) {
+	case *Object:
+		return e.executeSelections(t, value, selections, path)
+	case *List:
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice {
+			e.addError(fmt.Errorf("expected list, got %T", value), path)
+
+			return nil, nil
+		}
+
+		res := make([]interface{}, items.Len())
+
+		for idx := 0; idx < items.Len(); idx++ {
+			var err error
+
+			item := items.Index(idx).Interface()
+
+			if res[idx], err = e.completeValue(t.OfType, item, selections, appendPath(path, idx)); err != nil {
+				return nil, err
+			}
+		}
+
+		return res, nil
+	}
+
+	return value, nil
+}
+
+func (e *executor) addError(err error, path []interface{}) {
+	e.errors = append(e.errors, &Error{
+		Message: err.Error(),
+		Path:    path,
+	})
+}
+
+// orderedMap is the result of the selection set, which keeps the order of the fields in JSON
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for idx, key := range m.keys {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+
+		rawKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		rawValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(rawKey)
+		buf.WriteByte(':')
+		buf.Write(rawValue)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// namedType returns the type wrapped by the lists and the non-null types
+func namedType(typ Type) Type {
+	for {
+		switch t := typ.(type) {
+		case *List:
+			typ = t.OfType
+		case *NonNull:
+			typ = t.OfType
+		default:
+			return typ
+		}
+	}
+}
+
+func typeConditionApplies(condition string, obj *Object) bool {
+	return condition == "" || condition == obj.Name
+}
+
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	res := make([]interface{}, len(path), len(path)+1)
+	copy(res, path)
+
+	return append(res, elem)
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
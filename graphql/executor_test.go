@@ -0,0 +1,253 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testNode struct {
+	ID       string
+	Children []*testNode
+}
+
+func newTestSchema() *Schema {
+	node := &Object{Name: "Node"}
+	node.Fields = map[string]*FieldDefinition{
+		"id": {
+			Type: ID,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*testNode).ID, nil //nolint:forcetypeassert
+			},
+		},
+		"children": {
+			Type: &List{OfType: node},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*testNode).Children, nil //nolint:forcetypeassert
+			},
+		},
+		"fail": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, errors.New("failed")
+			},
+		},
+		"required": {
+			Type: &NonNull{OfType: String},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	}
+
+	// any accepts the input values as is
+	anyScalar := &Scalar{Name: "Any"}
+
+	tree := &testNode{
+		ID: "root",
+		Children: []*testNode{
+			{ID: "a", Children: []*testNode{{ID: "a1"}}},
+			{ID: "b", Children: []*testNode{}},
+		},
+	}
+
+	return &Schema{
+		Query: &Object{
+			Name: "Query",
+			Fields: map[string]*FieldDefinition{
+				"node": {
+					Type: node,
+					Args: map[string]*ArgumentDefinition{
+						"id": {Type: ID},
+					},
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if p.Args["id"] == "missing" {
+							return (*testNode)(nil), nil
+						}
+
+						return tree, nil
+					},
+				},
+				"echo": {
+					Type: String,
+					Args: map[string]*ArgumentDefinition{
+						"value": {Type: &NonNull{OfType: anyScalar}},
+					},
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						raw, err := json.Marshal(p.Args["value"])
+
+						return string(raw), err
+					},
+				},
+			},
+		},
+	}
+}
+
+func execute(t *testing.T, req *Request, limits Limits) string {
+	t.Helper()
+
+	res, err := json.Marshal(newTestSchema().Execute(context.Background(), req, limits))
+	assert.NoError(t, err)
+
+	return string(res)
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		req      *Request
+		limits   Limits
+		expected string
+	}{
+		{
+			name: "nested lists keep the order of the fields",
+			req:  &Request{Query: `{ node { id children { id children { id } } } }`},
+			expected: `{"data":{"node":{"id":"root","children":[` +
+				`{"id":"a","children":[{"id":"a1"}]},{"id":"b","children":[]}]}}}`,
+		},
+		{
+			name:     "aliases, typename and null objects",
+			req:      &Request{Query: `query Q { n: node { __typename } missing: node(id: "missing") { id } }`},
+			expected: `{"data":{"n":{"__typename":"Node"},"missing":null}}`,
+		},
+		{
+			name: "fragments and directives",
+			req: &Request{
+				Query: `query Q($skip: Boolean = true) {
+					node { ...F ... on Node { children @skip(if: $skip) { id } } }
+				}
+				fragment F on Node { id }`,
+			},
+			expected: `{"data":{"node":{"id":"root"}}}`,
+		},
+		{
+			name: "variables and literals",
+			req: &Request{
+				Query:     `query Q($v: String!) { a: echo(value: [1, "x", {k: $v}, null, ENUM]) }`,
+				Variables: map[string]interface{}{"v": "var"},
+			},
+			expected: `{"data":{"a":"[1,\"x\",{\"k\":\"var\"},null,\"ENUM\"]"}}`,
+		},
+		{
+			name:     "field errors",
+			req:      &Request{Query: `{ node { id fail } }`},
+			expected: `{"data":{"node":{"id":"root","fail":null}},"errors":[{"message":"failed","path":["node","fail"]}]}`,
+		},
+		{
+			name:     "unknown field",
+			req:      &Request{Query: `{ node { name } }`},
+			expected: `{"data":null,"errors":[{"message":"cannot query field name on type Node"}]}`,
+		},
+		{
+			name:     "missing required variable",
+			req:      &Request{Query: `query Q($v: String!) { echo(value: $v) }`},
+			expected: `{"data":null,"errors":[{"message":"variable $v of type String! is required"}]}`,
+		},
+		{
+			name:     "invalid argument",
+			req:      &Request{Query: `{ node(id: true) { id } }`},
+			expected: `{"data":null,"errors":[{"message":"argument id on field Query.node: invalid ID value true"}]}`,
+		},
+		{
+			name:     "unknown argument",
+			req:      &Request{Query: `{ node(name: "a") { id } }`},
+			expected: `{"data":null,"errors":[{"message":"unknown argument name on field Query.node"}]}`,
+		},
+		{
+			name:     "missing required argument",
+			req:      &Request{Query: `{ echo }`},
+			expected: `{"data":null,"errors":[{"message":"argument value on field Query.echo is required"}]}`,
+		},
+		{
+			name: "invalid variable",
+			req: &Request{
+				Query:     `query Q($v: Boolean) { node(id: $v) { id } }`,
+				Variables: map[string]interface{}{"v": "x"},
+			},
+			expected: `{"data":null,"errors":[{"message":"variable $v: invalid Boolean value x"}]}`,
+		},
+		{
+			name:     "variable of unknown type",
+			req:      &Request{Query: `query Q($v: Node) { node(id: $v) { id } }`},
+			expected: `{"data":null,"errors":[{"message":"variable $v of type Node must be of input type"}]}`,
+		},
+		{
+			name: "null value of non-null field",
+			req:  &Request{Query: `{ node { required } }`},
+			expected: `{"data":{"node":{"required":null}},` +
+				`"errors":[{"message":"cannot return null for non-nullable field","path":["node","required"]}]}`,
+		},
+		{
+			name: "type introspection is exempt from the depth limit",
+			req: &Request{
+				Query: `{ __type(name: "Node") { kind name fields { name type { kind name ofType { kind name } } } } }`,
+			},
+			limits: Limits{MaxDepth: 2},
+			expected: `{"data":{"__type":{"kind":"OBJECT","name":"Node","fields":[` +
+				`{"name":"children","type":{"kind":"LIST","name":null,"ofType":{"kind":"OBJECT","name":"Node"}}},` +
+				`{"name":"fail","type":{"kind":"SCALAR","name":"String","ofType":null}},` +
+				`{"name":"id","type":{"kind":"SCALAR","name":"ID","ofType":null}},` +
+				`{"name":"required","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String"}}}]}}}`,
+		},
+		{
+			name: "schema introspection",
+			req: &Request{
+				Query: `{ __schema { queryType { name } mutationType { name } types { name } } ` +
+					`__type(name: "Missing") { name } }`,
+			},
+			expected: `{"data":{"__schema":{"queryType":{"name":"Query"},"mutationType":null,"types":[` +
+				`{"name":"Any"},{"name":"Boolean"},{"name":"ID"},{"name":"Node"},{"name":"Query"},{"name":"String"},` +
+				`{"name":"__Directive"},{"name":"__DirectiveLocation"},{"name":"__EnumValue"},{"name":"__Field"},` +
+				`{"name":"__InputValue"},{"name":"__Schema"},{"name":"__Type"},{"name":"__TypeKind"}]},` +
+				`"__type":null}}`,
+		},
+		{
+			name:     "introspection fields are defined on the query type only",
+			req:      &Request{Query: `{ node { __schema { types { name } } } }`},
+			expected: `{"data":null,"errors":[{"message":"cannot query field __schema on type Node"}]}`,
+		},
+		{
+			name:     "fragment cycle",
+			req:      &Request{Query: `{ node { ...F } } fragment F on Node { children { ...F } }`},
+			expected: `{"data":null,"errors":[{"message":"fragment F spreads itself"}]}`,
+		},
+		{
+			name:     "depth limit",
+			req:      &Request{Query: `{ node { children { children { id } } } }`},
+			limits:   Limits{MaxDepth: 3},
+			expected: `{"data":null,"errors":[{"message":"query depth exceeds limit"}]}`,
+		},
+		{
+			name:     "complexity limit counts the list items",
+			req:      &Request{Query: `{ node { children { id } } }`},
+			limits:   Limits{MaxComplexity: 3},
+			expected: `{"data":null,"errors":[{"message":"query complexity exceeds limit"}]}`,
+		},
+		{
+			name:     "mutation not supported",
+			req:      &Request{Query: `mutation { echo }`},
+			expected: `{"data":null,"errors":[{"message":"mutation operations are not supported"}]}`,
+		},
+		{
+			name:     "syntax error",
+			req:      &Request{Query: `{ node { id }`},
+			expected: `{"data":null,"errors":[{"message":"unexpected end of document"}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.expected, execute(t, c.req, c.limits))
+		})
+	}
+}
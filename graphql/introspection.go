@@ -0,0 +1,464 @@
+package graphql
+
+import (
+	"sort"
+)
+
+// The kinds of the types in the introspection
+const (
+	kindScalar      = "SCALAR"
+	kindObject      = "OBJECT"
+	kindEnum        = "ENUM"
+	kindInputObject = "INPUT_OBJECT"
+	kindList        = "LIST"
+	kindNonNull     = "NON_NULL"
+)
+
+// introspectionField is the source of the __Field type
+type introspectionField struct {
+	name string
+	def  *FieldDefinition
+}
+
+// introspectionInputValue is the source of the __InputValue type
+type introspectionInputValue struct {
+	name string
+	def  *ArgumentDefinition
+}
+
+// introspectionDirective is the source of the __Directive type
+type introspectionDirective struct {
+	name        string
+	description string
+	locations   []string
+	args        map[string]*ArgumentDefinition
+}
+
+// directives are the directives supported by the executor
+var directives = []*introspectionDirective{
+	{
+		name:        "include",
+		description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args: map[string]*ArgumentDefinition{
+			"if": {Type: &NonNull{OfType: Boolean}, Description: "Included when true."},
+		},
+	},
+	{
+		name:        "skip",
+		description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args: map[string]*ArgumentDefinition{
+			"if": {Type: &NonNull{OfType: Boolean}, Description: "Skipped when true."},
+		},
+	},
+}
+
+// introspectionSchema is the __Schema type, which references the other introspection types,
+// introspectionType is the __Type type
+var introspectionSchema, introspectionType *Object
+
+func init() {
+	// the types are built in init as their resolvers refer to the schema types, which include them
+	introspectionSchema, introspectionType = newIntrospectionTypes()
+}
+
+// newMetaFields returns the __schema and __type fields of the query root type
+func (s *Schema) newMetaFields() map[string]*FieldDefinition {
+	return map[string]*FieldDefinition{
+		"__schema": {
+			Type:        &NonNull{OfType: introspectionSchema},
+			Description: "Access the current type schema of this server.",
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return s, nil
+			},
+		},
+		"__type": {
+			Type:        introspectionType,
+			Description: "Request the type information of a single type.",
+			Args: map[string]*ArgumentDefinition{
+				"name": {Type: &NonNull{OfType: String}},
+			},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				name, _ := p.Args["name"].(string)
+
+				typ, ok := s.getTypes()[name]
+				if !ok {
+					return nil, nil
+				}
+
+				return typ, nil
+			},
+		},
+	}
+}
+
+func newIntrospectionTypes() (*Object, *Object) {
+	var (
+		schemaType     = &Object{Name: "__Schema"}
+		typeType       = &Object{Name: "__Type"}
+		fieldType      = &Object{Name: "__Field"}
+		inputValueType = &Object{Name: "__InputValue"}
+		enumValueType  = &Object{Name: "__EnumValue"}
+		directiveType  = &Object{Name: "__Directive"}
+
+		typeKindEnum = &Enum{
+			Name: "__TypeKind",
+			Values: []string{
+				kindScalar, "INTERFACE", "UNION", kindObject, kindEnum, kindInputObject, kindList, kindNonNull,
+			},
+		}
+		directiveLocationEnum = &Enum{
+			Name: "__DirectiveLocation",
+			Values: []string{
+				"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD",
+				"INLINE_FRAGMENT", "VARIABLE_DEFINITION", "SCHEMA", "SCALAR", "OBJECT", "FIELD_DEFINITION",
+				"ARGUMENT_DEFINITION", "INTERFACE", "UNION", "ENUM", "ENUM_VALUE", "INPUT_OBJECT",
+				"INPUT_FIELD_DEFINITION",
+			},
+		}
+
+		nonNull = func(typ Type) Type {
+			return &NonNull{OfType: typ}
+		}
+		listOf = func(typ Type) Type {
+			return &List{OfType: &NonNull{OfType: typ}}
+		}
+
+		// includeDeprecated is accepted for compatibility, nothing in the schema is deprecated
+		includeDeprecated = map[string]*ArgumentDefinition{
+			"includeDeprecated": {Type: Boolean},
+		}
+
+		// notDeprecated are the deprecation fields of __Field, __InputValue and __EnumValue
+		notDeprecated = func(fields map[string]*FieldDefinition) map[string]*FieldDefinition {
+			fields["isDeprecated"] = &FieldDefinition{
+				Type: nonNull(Boolean),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return false, nil
+				},
+			}
+			fields["deprecationReason"] = &FieldDefinition{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return nil, nil
+				},
+			}
+
+			return fields
+		}
+	)
+
+	schemaType.Fields = map[string]*FieldDefinition{
+		"description": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+		"types": {
+			Type: nonNull(listOf(typeType)),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				types := p.Source.(*Schema).getTypes() //nolint:forcetypeassert
+
+				names := make([]string, 0, len(types))
+				for name := range types {
+					names = append(names, name)
+				}
+
+				sort.Strings(names)
+
+				res := make([]Type, len(names))
+				for idx, name := range names {
+					res[idx] = types[name]
+				}
+
+				return res, nil
+			},
+		},
+		"queryType": {
+			Type: nonNull(typeType),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*Schema).Query, nil //nolint:forcetypeassert
+			},
+		},
+		"mutationType": {
+			Type: typeType,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*Schema).Mutation, nil //nolint:forcetypeassert
+			},
+		},
+		"subscriptionType": {
+			Type: typeType,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+		"directives": {
+			Type: nonNull(listOf(directiveType)),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return directives, nil
+			},
+		},
+	}
+
+	typeType.Fields = map[string]*FieldDefinition{
+		"kind": {
+			Type: nonNull(typeKindEnum),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return typeKind(p.Source.(Type)), nil //nolint:forcetypeassert
+			},
+		},
+		"name": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				switch p.Source.(type) {
+				case *List, *NonNull:
+					return nil, nil
+				}
+
+				return typeName(p.Source.(Type)), nil //nolint:forcetypeassert
+			},
+		},
+		"description": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				var description string
+
+				switch t := p.Source.(type) {
+				case *Scalar:
+					description = t.Description
+				case *Enum:
+					description = t.Description
+				case *Object:
+					description = t.Description
+				case *InputObject:
+					description = t.Description
+				}
+
+				return optionalString(description), nil
+			},
+		},
+		"specifiedByURL": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+		"fields": {
+			Type: listOf(fieldType),
+			Args: includeDeprecated,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				obj, ok := p.Source.(*Object)
+				if !ok {
+					return nil, nil
+				}
+
+				res := make([]*introspectionField, 0, len(obj.Fields))
+				for _, name := range fieldNames(obj.Fields) {
+					res = append(res, &introspectionField{name: name, def: obj.Fields[name]})
+				}
+
+				return res, nil
+			},
+		},
+		"interfaces": {
+			Type: listOf(typeType),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				if _, ok := p.Source.(*Object); !ok {
+					return nil, nil
+				}
+
+				return []Type{}, nil
+			},
+		},
+		"possibleTypes": {
+			Type: listOf(typeType),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+		"enumValues": {
+			Type: listOf(enumValueType),
+			Args: includeDeprecated,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				enum, ok := p.Source.(*Enum)
+				if !ok {
+					return nil, nil
+				}
+
+				return enum.Values, nil
+			},
+		},
+		"inputFields": {
+			Type: listOf(inputValueType),
+			Args: includeDeprecated,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				obj, ok := p.Source.(*InputObject)
+				if !ok {
+					return nil, nil
+				}
+
+				return toInputValues(obj.Fields), nil
+			},
+		},
+		"ofType": {
+			Type: typeType,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				switch t := p.Source.(type) {
+				case *List:
+					return t.OfType, nil
+				case *NonNull:
+					return t.OfType, nil
+				}
+
+				return nil, nil
+			},
+		},
+	}
+
+	fieldType.Fields = notDeprecated(map[string]*FieldDefinition{
+		"name": {
+			Type: nonNull(String),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*introspectionField).name, nil //nolint:forcetypeassert
+			},
+		},
+		"description": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return optionalString(p.Source.(*introspectionField).def.Description), nil //nolint:forcetypeassert
+			},
+		},
+		"args": {
+			Type: nonNull(listOf(inputValueType)),
+			Args: includeDeprecated,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return toInputValues(p.Source.(*introspectionField).def.Args), nil //nolint:forcetypeassert
+			},
+		},
+		"type": {
+			Type: nonNull(typeType),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*introspectionField).def.Type, nil //nolint:forcetypeassert
+			},
+		},
+	})
+
+	inputValueType.Fields = notDeprecated(map[string]*FieldDefinition{
+		"name": {
+			Type: nonNull(String),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*introspectionInputValue).name, nil //nolint:forcetypeassert
+			},
+		},
+		"description": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return optionalString(p.Source.(*introspectionInputValue).def.Description), nil //nolint:forcetypeassert
+			},
+		},
+		"type": {
+			Type: nonNull(typeType),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*introspectionInputValue).def.Type, nil //nolint:forcetypeassert
+			},
+		},
+		"defaultValue": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	})
+
+	enumValueType.Fields = notDeprecated(map[string]*FieldDefinition{
+		"name": {
+			Type: nonNull(String),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source, nil
+			},
+		},
+		"description": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	})
+
+	directiveType.Fields = map[string]*FieldDefinition{
+		"name": {
+			Type: nonNull(String),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*introspectionDirective).name, nil //nolint:forcetypeassert
+			},
+		},
+		"description": {
+			Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return optionalString(p.Source.(*introspectionDirective).description), nil //nolint:forcetypeassert
+			},
+		},
+		"locations": {
+			Type: nonNull(listOf(directiveLocationEnum)),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*introspectionDirective).locations, nil //nolint:forcetypeassert
+			},
+		},
+		"args": {
+			Type: nonNull(listOf(inputValueType)),
+			Args: includeDeprecated,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return toInputValues(p.Source.(*introspectionDirective).args), nil //nolint:forcetypeassert
+			},
+		},
+		"isRepeatable": {
+			Type: nonNull(Boolean),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return false, nil
+			},
+		},
+	}
+
+	return schemaType, typeType
+}
+
+// typeKind returns the introspection kind of the type
+func typeKind(typ Type) string {
+	switch typ.(type) {
+	case *Scalar:
+		return kindScalar
+	case *Object:
+		return kindObject
+	case *Enum:
+		return kindEnum
+	case *InputObject:
+		return kindInputObject
+	case *List:
+		return kindList
+	case *NonNull:
+		return kindNonNull
+	}
+
+	return ""
+}
+
+// toInputValues returns the arguments or the input fields in order
+func toInputValues(args map[string]*ArgumentDefinition) []*introspectionInputValue {
+	res := make([]*introspectionInputValue, 0, len(args))
+	for _, name := range argumentNames(args) {
+		res = append(res, &introspectionInputValue{name: name, def: args[name]})
+	}
+
+	return res
+}
+
+// optionalString returns the string, or nil if it's empty
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
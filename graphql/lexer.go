@@ -0,0 +1,214 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// unicodeBOM is the byte order mark which is ignored like a whitespace
+const unicodeBOM = "\uFEFF"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lexer splits the GraphQL document into tokens,
+// ignoring the whitespaces, the commas and the comments
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+
+	switch {
+	case strings.HasPrefix(l.input[l.pos:], "..."):
+		l.pos += 3
+
+		return token{kind: tokenPunct, value: "...", pos: start}, nil
+	case strings.IndexByte("!$()[]{}:=@|&", c) >= 0:
+		l.pos++
+
+		return token{kind: tokenPunct, value: string(c), pos: start}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.input) && (l.input[l.pos] == '_' || isLetter(l.input[l.pos]) || isDigit(l.input[l.pos])) {
+			l.pos++
+		}
+
+		return token{kind: tokenName, value: l.input[start:l.pos], pos: start}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber()
+	case c == '"':
+		return l.readString()
+	}
+
+	return token{}, fmt.Errorf("unexpected character %q at %d", c, start)
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.input) {
+		switch c := l.input[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.input) && l.input[l.pos] != '\n' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.input[l.pos:], unicodeBOM):
+			l.pos += len(unicodeBOM)
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) readNumber() (token, error) {
+	start := l.pos
+	kind := tokenInt
+
+	if l.input[l.pos] == '-' {
+		l.pos++
+	}
+
+	if !l.readDigits() {
+		return token{}, fmt.Errorf("invalid number at %d", start)
+	}
+
+	if l.pos < len(l.input) && l.input[l.pos] == '.' {
+		l.pos++
+		kind = tokenFloat
+
+		if !l.readDigits() {
+			return token{}, fmt.Errorf("invalid number at %d", start)
+		}
+	}
+
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		l.pos++
+		kind = tokenFloat
+
+		if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+			l.pos++
+		}
+
+		if !l.readDigits() {
+			return token{}, fmt.Errorf("invalid number at %d", start)
+		}
+	}
+
+	return token{kind: kind, value: l.input[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) readDigits() bool {
+	start := l.pos
+
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+
+	return l.pos > start
+}
+
+func (l *lexer) readString() (token, error) {
+	start := l.pos
+
+	if strings.HasPrefix(l.input[l.pos:], `"""`) {
+		end := strings.Index(l.input[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, fmt.Errorf("unterminated string at %d", start)
+		}
+
+		value := l.input[l.pos+3 : l.pos+3+end]
+		l.pos += end + 6
+
+		return token{kind: tokenString, value: value, pos: start}, nil
+	}
+
+	l.pos++
+
+	var sb strings.Builder
+
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+
+		switch c {
+		case '"':
+			l.pos++
+
+			return token{kind: tokenString, value: sb.String(), pos: start}, nil
+		case '\n', '\r':
+			return token{}, fmt.Errorf("unterminated string at %d", start)
+		case '\\':
+			if err := l.readEscape(&sb); err != nil {
+				return token{}, err
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+			sb.WriteRune(r)
+			l.pos += size
+		}
+	}
+
+	return token{}, fmt.Errorf("unterminated string at %d", start)
+}
+
+func (l *lexer) readEscape(sb *strings.Builder) error {
+	if l.pos+1 >= len(l.input) {
+		return fmt.Errorf("invalid escape at %d", l.pos)
+	}
+
+	escapes := map[byte]byte{'"': '"', '\\': '\\', '/': '/', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t'}
+
+	c := l.input[l.pos+1]
+	if e, ok := escapes[c]; ok {
+		sb.WriteByte(e)
+		l.pos += 2
+
+		return nil
+	}
+
+	if c != 'u' || l.pos+6 > len(l.input) {
+		return fmt.Errorf("invalid escape at %d", l.pos)
+	}
+
+	r, err := strconv.ParseUint(l.input[l.pos+2:l.pos+6], 16, 32)
+	if err != nil {
+		return fmt.Errorf("invalid escape at %d", l.pos)
+	}
+
+	sb.WriteRune(rune(r))
+	l.pos += 6
+
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lexAll returns the tokens of the input up to the end
+func lexAll(input string) ([]token, error) {
+	l := &lexer{input: input}
+	tokens := []token{}
+
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+
+		tokens = append(tokens, tok)
+	}
+}
+
+func TestLexer(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    string
+		expected []token
+	}{
+		{
+			name:  "punctuators and names",
+			input: "{ a_1: _b(x: $v) ...F @skip }",
+			expected: []token{
+				{kind: tokenPunct, value: "{", pos: 0},
+				{kind: tokenName, value: "a_1", pos: 2},
+				{kind: tokenPunct, value: ":", pos: 5},
+				{kind: tokenName, value: "_b", pos: 7},
+				{kind: tokenPunct, value: "(", pos: 9},
+				{kind: tokenName, value: "x", pos: 10},
+				{kind: tokenPunct, value: ":", pos: 11},
+				{kind: tokenPunct, value: "$", pos: 13},
+				{kind: tokenName, value: "v", pos: 14},
+				{kind: tokenPunct, value: ")", pos: 15},
+				{kind: tokenPunct, value: "...", pos: 17},
+				{kind: tokenName, value: "F", pos: 20},
+				{kind: tokenPunct, value: "@", pos: 22},
+				{kind: tokenName, value: "skip", pos: 23},
+				{kind: tokenPunct, value: "}", pos: 28},
+			},
+		},
+		{
+			name:  "ignored commas, comments and byte order mark",
+			input: unicodeBOM + "a,,b # comment\n\tc",
+			expected: []token{
+				{kind: tokenName, value: "a", pos: 3},
+				{kind: tokenName, value: "b", pos: 6},
+				{kind: tokenName, value: "c", pos: 19},
+			},
+		},
+		{
+			name:  "numbers",
+			input: "0 -12 1.5 2e10 -3.1E-2",
+			expected: []token{
+				{kind: tokenInt, value: "0", pos: 0},
+				{kind: tokenInt, value: "-12", pos: 2},
+				{kind: tokenFloat, value: "1.5", pos: 6},
+				{kind: tokenFloat, value: "2e10", pos: 10},
+				{kind: tokenFloat, value: "-3.1E-2", pos: 15},
+			},
+		},
+		{
+			name:  "strings",
+			input: `"a\"b\\\né" """block "quoted" """`,
+			expected: []token{
+				{kind: tokenString, value: "a\"b\\\né", pos: 0},
+				{kind: tokenString, value: `block "quoted" `, pos: 13},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokens, err := lexAll(c.input)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, tokens)
+		})
+	}
+}
+
+func TestLexer_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "a ; b", expected: `unexpected character ';' at 2`},
+		{input: "-", expected: "invalid number at 0"},
+		{input: "1.", expected: "invalid number at 0"},
+		{input: "1e+", expected: "invalid number at 0"},
+		{input: `"abc`, expected: "unterminated string at 0"},
+		{input: "\"a\nb\"", expected: "unterminated string at 0"},
+		{input: `"""abc`, expected: "unterminated string at 0"},
+		{input: `"\x"`, expected: "invalid escape at 1"},
+		{input: `"\u12"`, expected: "invalid escape at 1"},
+		{input: `"\uZZZZ"`, expected: "invalid escape at 1"},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := lexAll(c.input)
+			assert.EqualError(t, err, c.expected)
+		})
+	}
+}
@@ -0,0 +1,698 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// maxNesting is the max nesting of the selection sets, the lists, the objects and the types
+	// in the document. It bounds the recursion of the parser regardless of the depth limit of the execution
+	maxNesting = 128
+
+	// maxTokens is the max number of the tokens in the document
+	maxTokens = 100000
+)
+
+var (
+	ErrNestingLimitExceeded = errors.New("document nesting exceeds limit")
+	ErrTokenLimitExceeded   = errors.New("document size exceeds limit")
+)
+
+// Document is the parsed GraphQL request document
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query or a mutation in the document
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// VariableDefinition is a variable declared by the operation
+type VariableDefinition struct {
+	Name    string
+	Type    string
+	Default Value
+}
+
+// Fragment is a named fragment in the document
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+// Selection is a field, a fragment spread or an inline fragment in the selection set
+type Selection interface {
+	isSelection()
+}
+
+// Field is a field selection
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// ResponseKey returns the key of the field in the response
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
+// FragmentSpread is a selection of the named fragment
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+// InlineFragment is a selection set with optional type condition
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) isSelection()          {}
+func (*FragmentSpread) isSelection() {}
+func (*InlineFragment) isSelection() {}
+
+// Argument is an argument of a field or a directive
+type Argument struct {
+	Name  string
+	Value Value
+}
+
+// Directive is a directive such as @skip or @include
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// Value is an input value in the document
+type Value interface {
+	isValue()
+}
+
+// Variable is a reference to the variable of the operation
+type Variable struct {
+	Name string
+}
+
+// ScalarValue is an int, float, string, boolean, null or enum literal
+type ScalarValue struct {
+	Value interface{}
+}
+
+// ListValue is a list literal
+type ListValue struct {
+	Values []Value
+}
+
+// ObjectValue is an input object literal
+type ObjectValue struct {
+	Fields []*Argument
+}
+
+func (*Variable) isValue()    {}
+func (*ScalarValue) isValue() {}
+func (*ListValue) isValue()   {}
+func (*ObjectValue) isValue() {}
+
+// Parse parses the GraphQL request document
+func Parse(query string) (*Document, error) {
+	p := &parser{lexer: &lexer{input: query}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		Fragments: make(map[string]*Fragment),
+	}
+
+	for p.token.kind != tokenEOF {
+		if err := p.parseDefinition(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("no operation in document")
+	}
+
+	return doc, nil
+}
+
+type parser struct {
+	lexer *lexer
+	token token
+
+	// tokens is the number of the read tokens
+	tokens int
+	// depth is the current nesting in the document
+	depth int
+}
+
+func (p *parser) advance() (err error) {
+	if p.tokens++; p.tokens > maxTokens {
+		return ErrTokenLimitExceeded
+	}
+
+	p.token, err = p.lexer.next()
+
+	return err
+}
+
+// enter increases the nesting, it returns error if the nesting exceeds the limit
+func (p *parser) enter() error {
+	if p.depth++; p.depth > maxNesting {
+		return ErrNestingLimitExceeded
+	}
+
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) peekPunct(value string) bool {
+	return p.token.kind == tokenPunct && p.token.value == value
+}
+
+func (p *parser) peekName(value string) bool {
+	return p.token.kind == tokenName && p.token.value == value
+}
+
+func (p *parser) unexpected() error {
+	if p.token.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of document")
+	}
+
+	return fmt.Errorf("unexpected %q at %d", p.token.value, p.token.pos)
+}
+
+func (p *parser) expectPunct(value string) error {
+	if !p.peekPunct(value) {
+		return p.unexpected()
+	}
+
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.token.value
+
+	return name, p.advance()
+}
+
+func (p *parser) parseDefinition(doc *Document) error {
+	if p.peekPunct("{") {
+		// query shorthand
+		selections, err := p.parseSelectionSet()
+		if err != nil {
+			return err
+		}
+
+		doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selections})
+
+		return nil
+	}
+
+	if p.peekName("fragment") {
+		fragment, err := p.parseFragment()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := doc.Fragments[fragment.Name]; ok {
+			return fmt.Errorf("duplicate fragment %s", fragment.Name)
+		}
+
+		doc.Fragments[fragment.Name] = fragment
+
+		return nil
+	}
+
+	if p.peekName("query") || p.peekName("mutation") || p.peekName("subscription") {
+		operation, err := p.parseOperation()
+		if err != nil {
+			return err
+		}
+
+		doc.Operations = append(doc.Operations, operation)
+
+		return nil
+	}
+
+	return p.unexpected()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.token.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	if p.token.kind == tokenName {
+		if op.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peekPunct("(") {
+		if op.Variables, err = p.parseVariableDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+
+	if op.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if op.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	defs := []*VariableDefinition{}
+
+	for !p.peekPunct(")") {
+		if err := p.expectPunct("$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+
+		def := &VariableDefinition{Name: name}
+
+		if def.Type, err = p.parseType(); err != nil {
+			return nil, err
+		}
+
+		if p.peekPunct("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+
+			if def.Default, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+
+		defs = append(defs, def)
+	}
+
+	return defs, p.advance()
+}
+
+func (p *parser) parseType() (string, error) {
+	if err := p.enter(); err != nil {
+		return "", err
+	}
+
+	defer p.leave()
+
+	var typ string
+
+	if p.peekPunct("[") {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+
+		inner, err := p.parseType()
+		if err != nil {
+			return "", err
+		}
+
+		if err := p.expectPunct("]"); err != nil {
+			return "", err
+		}
+
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.expectName()
+		if err != nil {
+			return "", err
+		}
+
+		typ = name
+	}
+
+	if p.peekPunct("!") {
+		typ += "!"
+
+		return typ, p.advance()
+	}
+
+	return typ, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "on" {
+		return nil, fmt.Errorf("invalid fragment name %s", name)
+	}
+
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	fragment := &Fragment{Name: name}
+
+	if fragment.TypeCondition, err = p.expectName(); err != nil {
+		return nil, err
+	}
+
+	if fragment.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if fragment.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+
+	defer p.leave()
+
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	selections := []Selection{}
+
+	for !p.peekPunct("}") {
+		selection, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set at %d", p.token.pos)
+	}
+
+	return selections, p.advance()
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	if p.peekPunct("...") {
+		return p.parseFragmentSelection()
+	}
+
+	return p.parseField()
+}
+
+func (p *parser) parseFragmentSelection() (Selection, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenName && !p.peekName("on") {
+		spread := &FragmentSpread{Name: p.token.value}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		var err error
+
+		spread.Directives, err = p.parseDirectives()
+
+		return spread, err
+	}
+
+	inline := &InlineFragment{}
+
+	var err error
+
+	if p.peekName("on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if inline.TypeCondition, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if inline.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if inline.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return inline, nil
+}
+
+func (p *parser) parseField() (*Field, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	field := &Field{Name: name}
+
+	if p.peekPunct(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		field.Alias = name
+
+		if field.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peekPunct("(") {
+		if field.Arguments, err = p.parseArguments(false); err != nil {
+			return nil, err
+		}
+	}
+
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if p.peekPunct("{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseArguments(isConst bool) ([]*Argument, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	args := []*Argument{}
+
+	for !p.peekPunct(")") {
+		arg, err := p.parseArgument(isConst)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, p.advance()
+}
+
+func (p *parser) parseArgument(isConst bool) (*Argument, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseValue(isConst)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Argument{Name: name, Value: value}, nil
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+
+	for p.peekPunct("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		directive := &Directive{Name: name}
+
+		if p.peekPunct("(") {
+			if directive.Arguments, err = p.parseArguments(false); err != nil {
+				return nil, err
+			}
+		}
+
+		directives = append(directives, directive)
+	}
+
+	return directives, nil
+}
+
+func (p *parser) parseValue(isConst bool) (Value, error) {
+	tok := p.token
+
+	switch {
+	case p.peekPunct("$") && !isConst:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Variable{Name: name}, nil
+	case p.peekPunct("["):
+		return p.parseListValue(isConst)
+	case p.peekPunct("{"):
+		return p.parseObjectValue(isConst)
+	case tok.kind == tokenInt || tok.kind == tokenFloat:
+		return &ScalarValue{Value: json.Number(tok.value)}, p.advance()
+	case tok.kind == tokenString:
+		return &ScalarValue{Value: tok.value}, p.advance()
+	case tok.kind == tokenName:
+		var value interface{}
+
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			// enum values are passed to the resolvers as strings
+			value = tok.value
+		}
+
+		return &ScalarValue{Value: value}, p.advance()
+	}
+
+	return nil, p.unexpected()
+}
+
+func (p *parser) parseListValue(isConst bool) (Value, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+
+	defer p.leave()
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	list := &ListValue{Values: []Value{}}
+
+	for !p.peekPunct("]") {
+		value, err := p.parseValue(isConst)
+		if err != nil {
+			return nil, err
+		}
+
+		list.Values = append(list.Values, value)
+	}
+
+	return list, p.advance()
+}
+
+func (p *parser) parseObjectValue(isConst bool) (Value, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+
+	defer p.leave()
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	object := &ObjectValue{Fields: []*Argument{}}
+
+	for !p.peekPunct("}") {
+		field, err := p.parseArgument(isConst)
+		if err != nil {
+			return nil, err
+		}
+
+		object.Fields = append(object.Fields, field)
+	}
+
+	return object, p.advance()
+}
@@ -0,0 +1,175 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Parse(`
+		query Q($id: ID! = "a", $list: [[Int!]]) @dir {
+			n: node(id: $id, filter: {ids: [1, 2.5], exact: true, none: null, order: ASC}) {
+				... on Node @include(if: true) { id }
+				...F
+			}
+		}
+		fragment F on Node { children { id } }
+		{ node { id } }
+	`)
+	assert.NoError(t, err)
+
+	assert.Len(t, doc.Operations, 2)
+	assert.Equal(t, &Operation{Type: "query", SelectionSet: []Selection{
+		&Field{Name: "node", SelectionSet: []Selection{&Field{Name: "id"}}},
+	}}, doc.Operations[1])
+
+	op := doc.Operations[0]
+	assert.Equal(t, "query", op.Type)
+	assert.Equal(t, "Q", op.Name)
+	assert.Equal(t, []*Directive{{Name: "dir"}}, op.Directives)
+	assert.Equal(t, []*VariableDefinition{
+		{Name: "id", Type: "ID!", Default: &ScalarValue{Value: "a"}},
+		{Name: "list", Type: "[[Int!]]"},
+	}, op.Variables)
+
+	assert.Equal(t, []Selection{
+		&Field{
+			Alias: "n",
+			Name:  "node",
+			Arguments: []*Argument{
+				{Name: "id", Value: &Variable{Name: "id"}},
+				{Name: "filter", Value: &ObjectValue{Fields: []*Argument{
+					{Name: "ids", Value: &ListValue{Values: []Value{
+						&ScalarValue{Value: json.Number("1")},
+						&ScalarValue{Value: json.Number("2.5")},
+					}}},
+					{Name: "exact", Value: &ScalarValue{Value: true}},
+					{Name: "none", Value: &ScalarValue{Value: nil}},
+					{Name: "order", Value: &ScalarValue{Value: "ASC"}},
+				}}},
+			},
+			SelectionSet: []Selection{
+				&InlineFragment{
+					TypeCondition: "Node",
+					Directives: []*Directive{{
+						Name:      "include",
+						Arguments: []*Argument{{Name: "if", Value: &ScalarValue{Value: true}}},
+					}},
+					SelectionSet: []Selection{&Field{Name: "id"}},
+				},
+				&FragmentSpread{Name: "F"},
+			},
+		},
+	}, op.SelectionSet)
+
+	assert.Equal(t, &Fragment{
+		Name:          "F",
+		TypeCondition: "Node",
+		SelectionSet: []Selection{
+			&Field{Name: "children", SelectionSet: []Selection{&Field{Name: "id"}}},
+		},
+	}, doc.Fragments["F"])
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	nested := func(open, close string, n int) string {
+		return strings.Repeat(open, n) + strings.Repeat(close, n)
+	}
+
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "empty document",
+			query:    " # comment",
+			expected: "no operation in document",
+		},
+		{
+			name:     "empty selection set",
+			query:    "{ node { } }",
+			expected: "empty selection set at 9",
+		},
+		{
+			name:     "unterminated selection set",
+			query:    "{ node { id }",
+			expected: "unexpected end of document",
+		},
+		{
+			name:     "duplicate fragment",
+			query:    "{ a } fragment F on A { a } fragment F on A { b }",
+			expected: "duplicate fragment F",
+		},
+		{
+			name:     "fragment named on",
+			query:    "{ a } fragment on on A { a }",
+			expected: "invalid fragment name on",
+		},
+		{
+			name:     "variable in the default value",
+			query:    "query Q($a: Int = $b) { a }",
+			expected: `unexpected "$" at 18`,
+		},
+		{
+			name:     "lexer error",
+			query:    "{ a ; }",
+			expected: `unexpected character ';' at 4`,
+		},
+		{
+			name:     "nested selection sets",
+			query:    "{ a " + strings.Repeat("{ a ", maxNesting) + strings.Repeat("}", maxNesting+1),
+			expected: ErrNestingLimitExceeded.Error(),
+		},
+		{
+			name:     "nested lists",
+			query:    "{ a(x: " + nested("[", "]", maxNesting) + ") }",
+			expected: ErrNestingLimitExceeded.Error(),
+		},
+		{
+			name:     "nested objects",
+			query:    "{ a(x: " + strings.Repeat("{x: ", maxNesting) + strings.Repeat("}", maxNesting) + ") }",
+			expected: ErrNestingLimitExceeded.Error(),
+		},
+		{
+			name:     "nested types",
+			query:    "query Q($a: " + nested("[", "]", maxNesting+1) + ") { a }",
+			expected: ErrNestingLimitExceeded.Error(),
+		},
+		{
+			name:     "too many tokens",
+			query:    "{ " + strings.Repeat("a ", maxTokens) + "}",
+			expected: ErrTokenLimitExceeded.Error(),
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(c.query)
+			assert.EqualError(t, err, c.expected)
+		})
+	}
+}
+
+func TestParse_NestingLimit(t *testing.T) {
+	t.Parallel()
+
+	// the document nested up to the limit is parsed
+	_, err := Parse("{ a(x: " + strings.Repeat("[", maxNesting-1) + strings.Repeat("]", maxNesting-1) + ") }")
+	assert.NoError(t, err)
+
+	// the nesting of the siblings doesn't add up
+	_, err = Parse("{ " + strings.Repeat("a { b } ", maxNesting+1) + "}")
+	assert.NoError(t, err)
+}
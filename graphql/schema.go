@@ -0,0 +1,330 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Type is a type of the schema
+type Type interface {
+	isType()
+}
+
+// ParseFunc parses the input value of the scalar, which can be string, bool or json.Number,
+// and returns the value passed to the resolvers
+type ParseFunc func(value interface{}) (interface{}, error)
+
+// Scalar is a leaf type, its values are serialized as JSON
+type Scalar struct {
+	Name        string
+	Description string
+	// ParseValue parses the input values of the scalar, the values are passed as is if not set
+	ParseValue ParseFunc
+}
+
+// Enum is a leaf type of the named values, which are serialized as strings
+type Enum struct {
+	Name        string
+	Description string
+	Values      []string
+}
+
+// Object is an object type, its fields are resolved by the selection set
+type Object struct {
+	Name        string
+	Description string
+	Fields      map[string]*FieldDefinition
+}
+
+// InputObject is an input type of the named fields
+type InputObject struct {
+	Name        string
+	Description string
+	Fields      map[string]*ArgumentDefinition
+}
+
+// List is a list of the values of the type
+type List struct {
+	OfType Type
+}
+
+// NonNull is a type which doesn't accept or return null
+type NonNull struct {
+	OfType Type
+}
+
+func (*Scalar) isType()      {}
+func (*Enum) isType()        {}
+func (*Object) isType()      {}
+func (*InputObject) isType() {}
+func (*List) isType()        {}
+func (*NonNull) isType()     {}
+
+// The built-in scalars
+var (
+	Int = &Scalar{
+		Name:       "Int",
+		ParseValue: parseInt,
+	}
+	Float = &Scalar{
+		Name:       "Float",
+		ParseValue: parseFloat,
+	}
+	String = &Scalar{
+		Name:       "String",
+		ParseValue: parseString,
+	}
+	Boolean = &Scalar{
+		Name:       "Boolean",
+		ParseValue: parseBoolean,
+	}
+	ID = &Scalar{
+		Name:       "ID",
+		ParseValue: parseID,
+	}
+)
+
+func parseInt(value interface{}) (interface{}, error) {
+	if number, ok := value.(json.Number); ok {
+		if n, err := strconv.ParseInt(number.String(), 10, 32); err == nil {
+			return int(n), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid Int value %v", value)
+}
+
+func parseFloat(value interface{}) (interface{}, error) {
+	if number, ok := value.(json.Number); ok {
+		if f, err := number.Float64(); err == nil && !math.IsInf(f, 0) {
+			return f, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid Float value %v", value)
+}
+
+func parseString(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("invalid String value %v", value)
+}
+
+func parseBoolean(value interface{}) (interface{}, error) {
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("invalid Boolean value %v", value)
+}
+
+func parseID(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return v.String(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid ID value %v", value)
+}
+
+// ResolveParams are the parameters passed to the resolver of the field
+type ResolveParams struct {
+	Context context.Context
+	// Source is the value of the object the field belongs to
+	Source interface{}
+	// Args are the argument values of the field parsed by their types.
+	// The values of the input objects are map[string]interface{}, the values of the lists are []interface{}.
+	// The arguments which aren't set are missing, the arguments set to null are nil
+	Args map[string]interface{}
+}
+
+// ResolveFunc resolves the value of the field
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// FieldDefinition is a field of the object type
+type FieldDefinition struct {
+	Type        Type
+	Description string
+	// Args are the arguments the field accepts by their names
+	Args map[string]*ArgumentDefinition
+	// Resolve returns the value of the field, nil value is serialized as null
+	Resolve ResolveFunc
+}
+
+// ArgumentDefinition is an argument of the field or a field of the input object
+type ArgumentDefinition struct {
+	Type        Type
+	Description string
+}
+
+// Schema is the root types of the GraphQL API
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+
+	once       sync.Once
+	types      map[string]Type
+	metaFields map[string]*FieldDefinition
+}
+
+// build collects the types of the schema and creates the introspection fields of the query root type
+func (s *Schema) build() {
+	s.once.Do(func() {
+		s.types = make(map[string]Type)
+		s.metaFields = s.newMetaFields()
+
+		for _, typ := range []Type{String, Boolean, s.Query, introspectionSchema} {
+			collectTypes(s.types, typ)
+		}
+
+		if s.Mutation != nil {
+			collectTypes(s.types, s.Mutation)
+		}
+	})
+}
+
+// getTypes returns the named types of the schema by their names,
+// including the built-in scalars and the introspection types
+func (s *Schema) getTypes() map[string]Type {
+	s.build()
+
+	return s.types
+}
+
+// getField returns the definition of the field of the object type,
+// the introspection fields are defined on the query root type only
+func (s *Schema) getField(obj *Object, name string) (*FieldDefinition, bool) {
+	if def, ok := obj.Fields[name]; ok {
+		return def, true
+	}
+
+	if obj != s.Query {
+		return nil, false
+	}
+
+	s.build()
+
+	def, ok := s.metaFields[name]
+
+	return def, ok
+}
+
+// collectTypes adds the named types referenced by the type to the types
+func collectTypes(types map[string]Type, typ Type) {
+	typ = namedType(typ)
+
+	name := typeName(typ)
+	if _, ok := types[name]; ok {
+		return
+	}
+
+	types[name] = typ
+
+	switch t := typ.(type) {
+	case *Object:
+		for _, field := range t.Fields {
+			collectTypes(types, field.Type)
+
+			for _, arg := range field.Args {
+				collectTypes(types, arg.Type)
+			}
+		}
+	case *InputObject:
+		for _, field := range t.Fields {
+			collectTypes(types, field.Type)
+		}
+	}
+}
+
+// parseType returns the type of the variable definition such as [Long!]!
+func (s *Schema) parseType(name string) (Type, error) {
+	if n := len(name); n > 1 && name[n-1] == '!' {
+		inner, err := s.parseType(name[:n-1])
+		if err != nil {
+			return nil, err
+		}
+
+		return &NonNull{OfType: inner}, nil
+	}
+
+	if n := len(name); n > 1 && name[0] == '[' && name[n-1] == ']' {
+		inner, err := s.parseType(name[1 : n-1])
+		if err != nil {
+			return nil, err
+		}
+
+		return &List{OfType: inner}, nil
+	}
+
+	typ, ok := s.getTypes()[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+
+	return typ, nil
+}
+
+// typeName returns the name of the named type, or the type reference such as [Long!]!
+func typeName(typ Type) string {
+	switch t := typ.(type) {
+	case *Scalar:
+		return t.Name
+	case *Enum:
+		return t.Name
+	case *Object:
+		return t.Name
+	case *InputObject:
+		return t.Name
+	case *List:
+		return "[" + typeName(t.OfType) + "]"
+	case *NonNull:
+		return typeName(t.OfType) + "!"
+	}
+
+	return ""
+}
+
+// isInputType returns true if the values of the type can be passed as the arguments
+func isInputType(typ Type) bool {
+	switch namedType(typ).(type) {
+	case *Scalar, *Enum, *InputObject:
+		return true
+	}
+
+	return false
+}
+
+// fieldNames returns the names of the fields in order
+func fieldNames(fields map[string]*FieldDefinition) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// argumentNames returns the names of the arguments in order
+func argumentNames(args map[string]*ArgumentDefinition) []string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -255,3 +255,13 @@ func PredeployStakingSC(
 
 	return stakingAccount, nil
 }
+
+// MinValidatorCountIndex returns the storage index of the minimum number of validators
+func MinValidatorCountIndex() types.Hash {
+	return types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes())
+}
+
+// MaxValidatorCountIndex returns the storage index of the maximum number of validators
+func MaxValidatorCountIndex() types.Hash {
+	return types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes())
+}
@@ -0,0 +1,245 @@
+package jsonrpc
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// APIKeyHeader is the HTTP header carrying the API key
+	APIKeyHeader = "X-Api-Key"
+
+	// APIKeyQueryParam is the query parameter carrying the API key,
+	// for the clients that can't set headers such as browsers opening WebSocket
+	APIKeyQueryParam = "api_key"
+
+	// allMethods matches all methods in the namespace
+	allMethods = "*"
+
+	// limiterIdleTimeout is the duration after which the limiter of an idle caller is dropped
+	limiterIdleTimeout = 10 * time.Minute
+)
+
+// RateLimit is the token bucket limit of the requests
+type RateLimit struct {
+	// Rate is the number of tokens refilled per second
+	Rate float64
+	// Burst is the size of the bucket
+	Burst int
+}
+
+// NamespaceAccess is the allow and deny lists of the methods in a namespace.
+// The lists take the full method names like eth_call, or "*" which matches all methods
+type NamespaceAccess struct {
+	// Allow is the list of the allowed methods, all methods are allowed if empty
+	Allow []string
+	// Deny is the list of the denied methods, which takes priority over Allow
+	Deny []string
+}
+
+// AccessConfig is the configuration of the rate limits and the method access of JSON-RPC
+type AccessConfig struct {
+	// IPLimit is the rate limit per IP address for the requests without API key, nil means no limit
+	IPLimit *RateLimit
+	// APIKeys are the valid API keys and their rate limits, nil limit means no limit
+	APIKeys map[string]*RateLimit
+	// RequireAPIKey rejects the requests without API key
+	RequireAPIKey bool
+	// MethodCosts are the tokens the methods consume, the methods not listed consume 1 token
+	MethodCosts map[string]int
+	// Namespaces are the allow and deny lists of the methods per namespace
+	Namespaces map[string]*NamespaceAccess
+	// TrustForwardedFor takes the IP address of the caller from X-Forwarded-For header
+	// which must be set only by a trusted reverse proxy
+	TrustForwardedFor bool
+}
+
+// Caller identifies the client sending the requests
+type Caller struct {
+	IP     string
+	APIKey string
+}
+
+// newCaller returns the caller of the HTTP request
+func newCaller(r *http.Request, trustForwardedFor bool) *Caller {
+	caller := &Caller{
+		APIKey: r.Header.Get(APIKeyHeader),
+	}
+
+	if caller.APIKey == "" {
+		caller.APIKey = r.URL.Query().Get(APIKeyQueryParam)
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); trustForwardedFor && forwarded != "" {
+		// the first address is the original client
+		caller.IP = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+
+		return caller
+	}
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		caller.IP = host
+	} else {
+		caller.IP = r.RemoteAddr
+	}
+
+	return caller
+}
+
+// callerLimiter is the token bucket of a caller
+type callerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// accessControl enforces AccessConfig on the requests
+type accessControl struct {
+	config *AccessConfig
+
+	lock sync.Mutex
+	// limiters are the token buckets of the callers by API key or IP address
+	limiters  map[string]*callerLimiter
+	lastPrune time.Time
+}
+
+// newAccessControl returns the access control for the config, nil if the config is nil
+func newAccessControl(config *AccessConfig) *accessControl {
+	if config == nil {
+		return nil
+	}
+
+	return &accessControl{
+		config:    config,
+		limiters:  make(map[string]*callerLimiter),
+		lastPrune: time.Now(),
+	}
+}
+
+// check checks the caller is allowed to call the method
+// and consumes the tokens of the method from the bucket of the caller
+func (a *accessControl) check(caller *Caller, method string) Error {
+	if a == nil {
+		return nil
+	}
+
+	if caller == nil {
+		// in-process requests are not limited
+		return nil
+	}
+
+	limit, limitKey, err := a.getRateLimit(caller)
+	if err != nil {
+		return err
+	}
+
+	if !a.isMethodAllowed(method) {
+		return NewMethodNotAllowedError(method)
+	}
+
+	if limit == nil {
+		return nil
+	}
+
+	if !a.allow(limitKey, limit, a.methodCost(method)) {
+		return NewLimitExceededError(method)
+	}
+
+	return nil
+}
+
+// getRateLimit returns the rate limit of the caller and the key of its bucket
+func (a *accessControl) getRateLimit(caller *Caller) (*RateLimit, string, Error) {
+	if caller.APIKey != "" {
+		limit, ok := a.config.APIKeys[caller.APIKey]
+		if !ok {
+			return nil, "", NewUnauthorizedError("invalid API key")
+		}
+
+		return limit, "key:" + caller.APIKey, nil
+	}
+
+	if a.config.RequireAPIKey {
+		return nil, "", NewUnauthorizedError("API key required")
+	}
+
+	return a.config.IPLimit, "ip:" + caller.IP, nil
+}
+
+// isMethodAllowed returns whether the method is allowed by the lists of its namespace
+func (a *accessControl) isMethodAllowed(method string) bool {
+	namespace := strings.SplitN(method, "_", 2)[0]
+
+	access, ok := a.config.Namespaces[namespace]
+	if !ok || access == nil {
+		return true
+	}
+
+	if matchMethod(access.Deny, method) {
+		return false
+	}
+
+	return len(access.Allow) == 0 || matchMethod(access.Allow, method)
+}
+
+// methodCost returns the tokens the method consumes
+func (a *accessControl) methodCost(method string) int {
+	if cost, ok := a.config.MethodCosts[method]; ok {
+		return cost
+	}
+
+	return 1
+}
+
+// allow consumes the tokens from the bucket of the caller
+// and returns false if the bucket doesn't have enough tokens
+func (a *accessControl) allow(key string, limit *RateLimit, cost int) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+
+	l, ok := a.limiters[key]
+	if !ok {
+		l = &callerLimiter{
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst),
+		}
+		a.limiters[key] = l
+	}
+
+	l.lastSeen = now
+
+	a.pruneLimiters(now)
+
+	return l.limiter.AllowN(now, cost)
+}
+
+// pruneLimiters drops the limiters of the idle callers
+// unsafe against concurrent access
+func (a *accessControl) pruneLimiters(now time.Time) {
+	if now.Sub(a.lastPrune) < limiterIdleTimeout {
+		return
+	}
+
+	for key, l := range a.limiters {
+		if now.Sub(l.lastSeen) >= limiterIdleTimeout {
+			delete(a.limiters, key)
+		}
+	}
+
+	a.lastPrune = now
+}
+
+func matchMethod(list []string, method string) bool {
+	for _, m := range list {
+		if m == allMethods || m == method {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,177 @@
+package jsonrpc
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCaller(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest("POST", "/?api_key=query-key", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "192.168.0.1, 10.0.0.2")
+
+	assert.Equal(t, &Caller{IP: "10.0.0.1", APIKey: "query-key"}, newCaller(req, false))
+	assert.Equal(t, &Caller{IP: "192.168.0.1", APIKey: "query-key"}, newCaller(req, true))
+
+	req.Header.Set(APIKeyHeader, "header-key")
+
+	assert.Equal(t, &Caller{IP: "10.0.0.1", APIKey: "header-key"}, newCaller(req, false))
+}
+
+func TestAccessControlCheck(t *testing.T) {
+	t.Parallel()
+
+	newTestAccessControl := func() *accessControl {
+		return newAccessControl(&AccessConfig{
+			IPLimit: &RateLimit{Rate: 0.001, Burst: 3},
+			APIKeys: map[string]*RateLimit{
+				"limited":   {Rate: 0.001, Burst: 5},
+				"unlimited": nil,
+			},
+			MethodCosts: map[string]int{
+				"eth_call": 2,
+			},
+			Namespaces: map[string]*NamespaceAccess{
+				"eth":    {Deny: []string{"eth_sendRawTransaction"}},
+				"txpool": {Allow: []string{"txpool_status"}},
+				"web3":   {Deny: []string{"*"}},
+			},
+		})
+	}
+
+	t.Run("should not limit without config or caller", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, newAccessControl(nil).check(&Caller{IP: "1"}, "eth_call"))
+		assert.Nil(t, newTestAccessControl().check(nil, "web3_sha3"))
+	})
+
+	t.Run("should apply allow and deny lists", func(t *testing.T) {
+		t.Parallel()
+
+		ac := newTestAccessControl()
+		caller := &Caller{APIKey: "unlimited"}
+
+		assert.Nil(t, ac.check(caller, "eth_blockNumber"))
+		assert.IsType(t, &methodNotAllowedError{}, ac.check(caller, "eth_sendRawTransaction"))
+		assert.Nil(t, ac.check(caller, "txpool_status"))
+		assert.IsType(t, &methodNotAllowedError{}, ac.check(caller, "txpool_content"))
+		assert.IsType(t, &methodNotAllowedError{}, ac.check(caller, "web3_sha3"))
+		assert.Nil(t, ac.check(caller, "net_version"))
+	})
+
+	t.Run("should limit by IP with method costs", func(t *testing.T) {
+		t.Parallel()
+
+		ac := newTestAccessControl()
+
+		assert.Nil(t, ac.check(&Caller{IP: "1"}, "eth_call"))
+		assert.Nil(t, ac.check(&Caller{IP: "1"}, "eth_blockNumber"))
+
+		err := ac.check(&Caller{IP: "1"}, "eth_blockNumber")
+		assert.IsType(t, &limitExceededError{}, err)
+		assert.Equal(t, -32005, err.ErrorCode())
+
+		// another IP has its own bucket
+		assert.Nil(t, ac.check(&Caller{IP: "2"}, "eth_call"))
+	})
+
+	t.Run("should limit by API key", func(t *testing.T) {
+		t.Parallel()
+
+		ac := newTestAccessControl()
+
+		for i := 0; i < 5; i++ {
+			// the same key from different IP addresses shares the bucket
+			assert.Nil(t, ac.check(&Caller{IP: string(rune('a' + i)), APIKey: "limited"}, "eth_blockNumber"))
+		}
+
+		assert.IsType(t, &limitExceededError{}, ac.check(&Caller{APIKey: "limited"}, "eth_blockNumber"))
+
+		for i := 0; i < 10; i++ {
+			assert.Nil(t, ac.check(&Caller{APIKey: "unlimited"}, "eth_call"))
+		}
+	})
+
+	t.Run("should reject invalid or missing API key", func(t *testing.T) {
+		t.Parallel()
+
+		ac := newTestAccessControl()
+
+		assert.IsType(t, &unauthorizedError{}, ac.check(&Caller{APIKey: "unknown"}, "eth_call"))
+
+		ac.config.RequireAPIKey = true
+
+		assert.IsType(t, &unauthorizedError{}, ac.check(&Caller{IP: "1"}, "eth_call"))
+		assert.Nil(t, ac.check(&Caller{IP: "1", APIKey: "unlimited"}, "eth_call"))
+	})
+}
+
+func TestDispatcherAccessControl(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		0,
+		0,
+		20,
+		1000,
+		&AccessConfig{
+			IPLimit: &RateLimit{Rate: 0.001, Burst: 2},
+			Namespaces: map[string]*NamespaceAccess{
+				"txpool": {Deny: []string{"*"}},
+			},
+		},
+	)
+
+	caller := &Caller{IP: "1"}
+
+	t.Run("should return error for denied method", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"txpool_status"}`), caller)
+		assert.NoError(t, err)
+
+		var res string
+
+		err = expectJSONResult(resp, &res)
+
+		assert.Equal(t, &ObjectError{Code: -32004, Message: "the method txpool_status is not allowed"}, err)
+	})
+
+	t.Run("should throttle requests in batch", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`[
+			{"id":1,"jsonrpc":"2.0","method":"web3_clientVersion"},
+			{"id":2,"jsonrpc":"2.0","method":"web3_clientVersion"},
+			{"id":3,"jsonrpc":"2.0","method":"web3_clientVersion"}]`), caller)
+		assert.NoError(t, err)
+
+		var res []SuccessResponse
+
+		assert.NoError(t, expectBatchJSONResult(resp, &res))
+		assert.Len(t, res, 3)
+		// the denied request doesn't consume the tokens
+		assert.Nil(t, res[0].Error)
+		assert.Nil(t, res[1].Error)
+		assert.Equal(t, -32005, res[2].Error.Code)
+	})
+
+	t.Run("should throttle websocket requests", func(t *testing.T) {
+		resp, err := dispatcher.HandleWs(
+			[]byte(`{"id":1,"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"]}`),
+			&mockWsConn{msgCh: make(chan []byte, 1)},
+			caller,
+		)
+		assert.NoError(t, err)
+
+		var res string
+
+		err = expectJSONResult(resp, &res)
+
+		assert.Equal(t, &ObjectError{Code: -32005, Message: "request rate limit exceeded for eth_subscribe"}, err)
+	})
+}
@@ -0,0 +1,91 @@
+package jsonrpc
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/versioning"
+)
+
+// adminStore provides access to the methods needed by admin endpoint
+type adminStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetPeersInfo returns the info of the connected peers
+	GetPeersInfo() ([]*PeerInfo, error)
+
+	// AddPeer marks the peer at the given libp2p address ready for dialing
+	AddPeer(rawPeerMultiaddr string) error
+
+	// RemovePeer disconnects from the peer with the given ID
+	RemovePeer(peerID string) error
+
+	// GetNodeAddrs returns the libp2p ID of the node and its listening addresses
+	GetNodeAddrs() (string, []string)
+}
+
+// PeerInfo is the info of a connected peer
+type PeerInfo struct {
+	ID        string   `json:"id"`
+	Addrs     []string `json:"addrs"`
+	Protocols []string `json:"protocols"`
+}
+
+type nodeInfo struct {
+	ID          string    `json:"id"`
+	ListenAddrs []string  `json:"listenAddrs"`
+	ChainID     argUint64 `json:"chainId"`
+	Head        *nodeHead `json:"head"`
+	Version     string    `json:"version"`
+}
+
+type nodeHead struct {
+	Number argUint64  `json:"number"`
+	Hash   types.Hash `json:"hash"`
+}
+
+// Admin is the admin jsonrpc endpoint, only served by the JWT-authenticated admin listener
+type Admin struct {
+	store   adminStore
+	chainID uint64
+}
+
+// Peers returns the info of the connected peers
+func (a *Admin) Peers() (interface{}, error) {
+	return a.store.GetPeersInfo()
+}
+
+// AddPeer dials the peer at the libp2p address,
+// the address must include the peer ID (e.g. /ip4/127.0.0.1/tcp/1478/p2p/16Uiu2...)
+func (a *Admin) AddPeer(rawPeerMultiaddr string) (interface{}, error) {
+	if err := a.store.AddPeer(rawPeerMultiaddr); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer with the given ID
+func (a *Admin) RemovePeer(peerID string) (interface{}, error) {
+	if err := a.store.RemovePeer(peerID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// NodeInfo returns the network identity of the node and its current head
+func (a *Admin) NodeInfo() (interface{}, error) {
+	id, addrs := a.store.GetNodeAddrs()
+	header := a.store.Header()
+
+	return &nodeInfo{
+		ID:          id,
+		ListenAddrs: addrs,
+		ChainID:     argUint64(a.chainID),
+		Head: &nodeHead{
+			Number: argUint64(header.Number),
+			Hash:   header.Hash,
+		},
+		Version: versioning.Version,
+	}, nil
+}
@@ -0,0 +1,281 @@
+package jsonrpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ibftProto "github.com/ExzoNetwork/ExzoCoin/consensus/ibft/proto"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAdminStore struct {
+	debugStore
+	header     *types.Header
+	peers      []*PeerInfo
+	added      []string
+	snapshots  map[uint64]*ibftProto.Snapshot
+	candidates []*ibftProto.Candidate
+	txs        map[types.Address][]*types.Transaction
+}
+
+func (m *mockAdminStore) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockAdminStore) GetPeersInfo() ([]*PeerInfo, error) {
+	return m.peers, nil
+}
+
+func (m *mockAdminStore) AddPeer(rawPeerMultiaddr string) error {
+	m.added = append(m.added, rawPeerMultiaddr)
+
+	return nil
+}
+
+func (m *mockAdminStore) RemovePeer(peerID string) error {
+	for idx, p := range m.peers {
+		if p.ID == peerID {
+			m.peers = append(m.peers[:idx], m.peers[idx+1:]...)
+
+			return nil
+		}
+	}
+
+	return errors.New("peer not found")
+}
+
+func (m *mockAdminStore) GetNodeAddrs() (string, []string) {
+	return "node", []string{"/ip4/127.0.0.1/tcp/1478/p2p/node"}
+}
+
+func (m *mockAdminStore) GetTxs(bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction) {
+	return nil, nil
+}
+
+func (m *mockAdminStore) GetCapacity() (uint64, uint64) {
+	return 0, 0
+}
+
+func (m *mockAdminStore) DropTx(hash types.Hash) []types.Hash {
+	for addr, txs := range m.txs {
+		for idx, tx := range txs {
+			if tx.Hash == hash {
+				m.txs[addr] = txs[:idx]
+
+				return toHashes(txs[idx:])
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *mockAdminStore) DropAccount(addr types.Address) []types.Hash {
+	dropped := toHashes(m.txs[addr])
+	delete(m.txs, addr)
+
+	return dropped
+}
+
+func toHashes(txs []*types.Transaction) []types.Hash {
+	hashes := make([]types.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash)
+	}
+
+	return hashes
+}
+
+func (m *mockAdminStore) GetFinalityProof(uint64) (*signer.FinalityProof, error) {
+	return nil, errors.New("not found")
+}
+
+func (m *mockAdminStore) GetIBFTSnapshot(height uint64) (*ibftProto.Snapshot, error) {
+	snapshot, ok := m.snapshots[height]
+	if !ok {
+		return nil, errors.New("header not found")
+	}
+
+	return snapshot, nil
+}
+
+func (m *mockAdminStore) ProposeIBFTCandidate(candidate *ibftProto.Candidate) error {
+	m.candidates = append(m.candidates, candidate)
+
+	return nil
+}
+
+func TestAdminDispatcher(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+
+		store = &mockAdminStore{
+			header: &types.Header{Number: 3, Hash: types.StringToHash("3")},
+			peers: []*PeerInfo{
+				{ID: "peer1", Addrs: []string{"/ip4/127.0.0.1/tcp/10001"}, Protocols: []string{"/ibft/0.2"}},
+			},
+			snapshots: map[uint64]*ibftProto.Snapshot{
+				3: {
+					Number: 3,
+					Hash:   types.StringToHash("3").String(),
+					Validators: []*ibftProto.Snapshot_Validator{
+						{Type: "ecdsa", Address: addr1.String(), Data: addr1.Bytes()},
+					},
+					Votes: []*ibftProto.Snapshot_Vote{
+						{Validator: addr1.String(), Proposed: addr2.String(), Auth: true},
+					},
+				},
+			},
+			txs: map[types.Address][]*types.Transaction{
+				addr1: {
+					{Nonce: 0, Hash: types.StringToHash("0x1")},
+					{Nonce: 1, Hash: types.StringToHash("0x2")},
+					{Nonce: 2, Hash: types.StringToHash("0x3")},
+				},
+				addr2: {
+					{Nonce: 0, Hash: types.StringToHash("0x4")},
+				},
+			},
+		}
+
+		dispatcher = newAdminDispatcher(hclog.NewNullLogger(), store, 100, 20)
+	)
+
+	t.Run("admin_peers", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"method": "admin_peers", "params": []}`), nil)
+		assert.NoError(t, err)
+
+		var res []*PeerInfo
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.Equal(t, store.peers, res)
+	})
+
+	t.Run("admin_nodeInfo", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"method": "admin_nodeInfo", "params": []}`), nil)
+		assert.NoError(t, err)
+
+		var res nodeInfo
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.Equal(t, "node", res.ID)
+		assert.Equal(t, argUint64(100), res.ChainID)
+		assert.Equal(t, argUint64(3), res.Head.Number)
+	})
+
+	t.Run("admin_addPeer", func(t *testing.T) {
+		resp, err := dispatcher.Handle(
+			[]byte(`{"method": "admin_addPeer", "params": ["/ip4/127.0.0.1/tcp/10002/p2p/peer2"]}`),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		var res bool
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.True(t, res)
+		assert.Equal(t, []string{"/ip4/127.0.0.1/tcp/10002/p2p/peer2"}, store.added)
+	})
+
+	t.Run("ibft_getSnapshot", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"method": "ibft_getSnapshot", "params": ["latest"]}`), nil)
+		assert.NoError(t, err)
+
+		var res snapshot
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.Equal(t, snapshot{
+			Number: argUint64(3),
+			Hash:   types.StringToHash("3"),
+			Validators: []*snapshotValidator{
+				{Type: "ecdsa", Address: addr1, Data: addr1.Bytes()},
+			},
+			Votes: []*snapshotVote{
+				{Validator: addr1, Proposed: addr2, Auth: true},
+			},
+		}, res)
+	})
+
+	t.Run("ibft_propose", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"method": "ibft_propose", "params": ["`+addr2.String()+`", true]}`), nil)
+		assert.NoError(t, err)
+
+		var res bool
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.True(t, res)
+		assert.Len(t, store.candidates, 1)
+		assert.Equal(t, addr2.String(), store.candidates[0].Address)
+		assert.True(t, store.candidates[0].Auth)
+	})
+
+	t.Run("txpool_dropTransaction", func(t *testing.T) {
+		resp, err := dispatcher.Handle(
+			[]byte(`{"method": "txpool_dropTransaction", "params": ["`+types.StringToHash("0x2").String()+`"]}`),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		var res []types.Hash
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.Equal(t, []types.Hash{types.StringToHash("0x2"), types.StringToHash("0x3")}, res)
+		assert.Len(t, store.txs[addr1], 1)
+
+		// unknown transaction
+		resp, err = dispatcher.Handle(
+			[]byte(`{"method": "txpool_dropTransaction", "params": ["`+types.StringToHash("0x5").String()+`"]}`),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.Empty(t, res)
+	})
+
+	t.Run("txpool_dropAccount", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"method": "txpool_dropAccount", "params": ["`+addr2.String()+`"]}`), nil)
+		assert.NoError(t, err)
+
+		var res []types.Hash
+
+		assert.NoError(t, expectJSONResult(resp, &res))
+		assert.Equal(t, []types.Hash{types.StringToHash("0x4")}, res)
+		assert.NotContains(t, store.txs, addr2)
+	})
+
+	t.Run("eth namespace is not served", func(t *testing.T) {
+		resp, err := dispatcher.Handle([]byte(`{"method": "eth_blockNumber", "params": []}`), nil)
+		assert.NoError(t, err)
+
+		var res interface{}
+
+		assert.Error(t, expectJSONResult(resp, &res))
+	})
+}
+
+func TestAdminRequestSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	j := &JSONRPC{
+		logger:          hclog.NewNullLogger(),
+		adminDispatcher: newAdminDispatcher(hclog.NewNullLogger(), &mockAdminStore{}, 100, 20),
+	}
+
+	// the body over the size limit isn't read
+	body := `{"method": "admin_peers", "params": []` + strings.Repeat(" ", adminMaxRequestSize) + `}`
+	rec := httptest.NewRecorder()
+
+	j.handleAdmin(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "request body too large")
+}
@@ -0,0 +1,440 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// maxDebugRangeResults is the maximum number of the entries returned by a range dump
+const maxDebugRangeResults = 256
+
+var (
+	ErrInvalidTxIndex = errors.New("transaction index out of range")
+)
+
+// StateDumper reads the entries of the tries of a state
+type StateDumper interface {
+	// Iterate walks over the entries of the trie with the given root in the order of their keys,
+	// starting from the given key. The walk stops when fn returns false
+	Iterate(root types.Hash, start []byte, fn func(key, value []byte) bool) error
+
+	// GetPreimage returns the key whose hash is the given key of the tries, if it is known
+	GetPreimage(hash types.Hash) ([]byte, bool)
+
+	// GetCode returns the code by its hash
+	GetCode(hash types.Hash) ([]byte, bool)
+}
+
+// debugStore provides access to the methods needed by debug endpoint
+type debugStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetHeaderByNumber returns the header by number
+	GetHeaderByNumber(block uint64) (*types.Header, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetStateDumper returns the reader of the entries of the state tries
+	GetStateDumper() (StateDumper, error)
+
+	// ReplayBlock executes the first txIndex transactions of the block on top of its parent state,
+	// the changes are kept in memory only. It returns the reader of the state and the state root
+	ReplayBlock(block *types.Block, txIndex int) (StateDumper, types.Hash, error)
+
+	// SetHead rolls the canonical chain back to the block with the given number
+	SetHead(number uint64) error
+}
+
+// Debug is the debug jsonrpc endpoint, which dumps the accounts and the storage of the state
+type Debug struct {
+	store debugStore
+}
+
+// dumpAccount is an account in the state dump
+type dumpAccount struct {
+	Balance  string                    `json:"balance"`
+	Nonce    uint64                    `json:"nonce"`
+	Root     types.Hash                `json:"root"`
+	CodeHash types.Hash                `json:"codeHash"`
+	Code     argBytes                  `json:"code,omitempty"`
+	Storage  map[types.Hash]types.Hash `json:"storage,omitempty"`
+	Address  *types.Address            `json:"address,omitempty"`
+	Key      types.Hash                `json:"key"`
+}
+
+// stateDump is the dump of the accounts of the state, keyed by the address
+// or by pre(hash) if the address of the account is not known
+type stateDump struct {
+	Root     types.Hash              `json:"root"`
+	Accounts map[string]*dumpAccount `json:"accounts"`
+	// Next is the key to continue the dump with, it is empty once the dump is complete
+	Next argBytes `json:"next,omitempty"`
+	// MissingPreimages is the number of the visited accounts and storage slots whose addresses
+	// or slots are not known. The preimages are recorded only by the nodes with record-preimages,
+	// and only for the state written since then, so the dumps of the older state are incomplete
+	MissingPreimages int `json:"missingPreimages,omitempty"`
+}
+
+// dumpOpts are the options of the state dump
+type dumpOpts struct {
+	limit       int
+	noCode      bool
+	noStorage   bool
+	incompletes bool
+}
+
+// storageEntry is a storage slot in the storage range dump,
+// its key is nil if the slot of the hashed key is not known
+type storageEntry struct {
+	Key   *types.Hash `json:"key"`
+	Value types.Hash  `json:"value"`
+}
+
+// storageRange is the storage range dump keyed by the hashed keys of the slots
+type storageRange struct {
+	Storage map[types.Hash]storageEntry `json:"storage"`
+	NextKey *types.Hash                 `json:"nextKey"`
+}
+
+// AccountRange dumps the accounts of the state at the block in the order of their hashed addresses,
+// starting from the given hashed address. The accounts whose addresses are not known
+// are skipped unless incompletes is set, either way they are counted in missingPreimages
+func (d *Debug) AccountRange(
+	filter BlockNumberOrHash,
+	start argBytes,
+	maxResults int,
+	noCode bool,
+	noStorage bool,
+	incompletes bool,
+) (interface{}, error) {
+	header, err := d.getHeader(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	dumper, err := d.store.GetStateDumper()
+	if err != nil {
+		return nil, err
+	}
+
+	if maxResults <= 0 || maxResults > maxDebugRangeResults {
+		maxResults = maxDebugRangeResults
+	}
+
+	return dumpState(dumper, header.StateRoot, start, dumpOpts{
+		limit:       maxResults,
+		noCode:      noCode,
+		noStorage:   noStorage,
+		incompletes: incompletes,
+	})
+}
+
+// DumpBlock dumps all the accounts of the state at the block with their code and storage
+func (d *Debug) DumpBlock(number BlockNumber) (interface{}, error) {
+	header, err := d.getHeader(BlockNumberOrHash{BlockNumber: &number})
+	if err != nil {
+		return nil, err
+	}
+
+	dumper, err := d.store.GetStateDumper()
+	if err != nil {
+		return nil, err
+	}
+
+	return dumpState(dumper, header.StateRoot, nil, dumpOpts{incompletes: true})
+}
+
+// SetHead rolls the canonical chain back to the block with the given number, whose state has to be
+// available. The transactions of the removed blocks are returned to the txpool
+func (d *Debug) SetHead(number argUint64) (interface{}, error) {
+	if err := d.store.SetHead(uint64(number)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// StorageRangeAt dumps the storage of the account in the state after the first txIndex transactions
+// of the block, in the order of the hashed keys of the slots starting from the given hashed key
+func (d *Debug) StorageRangeAt(
+	blockHash types.Hash,
+	txIndex int,
+	address types.Address,
+	keyStart argBytes,
+	maxResult int,
+) (interface{}, error) {
+	block, ok := d.store.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, fmt.Errorf("block %s not found", blockHash)
+	}
+
+	if txIndex < 0 || txIndex > len(block.Transactions) {
+		return nil, ErrInvalidTxIndex
+	}
+
+	var (
+		dumper StateDumper
+		root   types.Hash
+		err    error
+	)
+
+	// the state after the whole block is committed already
+	if txIndex == len(block.Transactions) {
+		dumper, err = d.store.GetStateDumper()
+		root = block.Header.StateRoot
+	} else {
+		dumper, root, err = d.store.ReplayBlock(block, txIndex)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if maxResult <= 0 || maxResult > maxDebugRangeResults {
+		maxResult = maxDebugRangeResults
+	}
+
+	res := &storageRange{Storage: map[types.Hash]storageEntry{}}
+
+	account, err := readAccount(dumper, root, address)
+	if err != nil || account == nil {
+		return res, err
+	}
+
+	var decodeErr error
+
+	err = dumper.Iterate(account.Root, keyStart, func(key, value []byte) bool {
+		hash := types.BytesToHash(key)
+
+		if len(res.Storage) == maxResult {
+			res.NextKey = &hash
+
+			return false
+		}
+
+		entry := storageEntry{}
+		if entry.Value, decodeErr = decodeStorageValue(value); decodeErr != nil {
+			return false
+		}
+
+		if slot, ok := dumper.GetPreimage(hash); ok {
+			slotHash := types.BytesToHash(slot)
+			entry.Key = &slotHash
+		}
+
+		res.Storage[hash] = entry
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	return res, nil
+}
+
+// getHeader returns the header of the block by its number or hash, the latest by default
+func (d *Debug) getHeader(filter BlockNumberOrHash) (*types.Header, error) {
+	if filter.BlockHash != nil {
+		block, ok := d.store.GetBlockByHash(*filter.BlockHash, false)
+		if !ok {
+			return nil, fmt.Errorf("block %s not found", filter.BlockHash)
+		}
+
+		return block.Header, nil
+	}
+
+	number := LatestBlockNumber
+	if filter.BlockNumber != nil {
+		number = *filter.BlockNumber
+	}
+
+	switch number {
+	case LatestBlockNumber:
+		return d.store.Header(), nil
+	case PendingBlockNumber:
+		return nil, ErrPendingBlockNumber
+	case EarliestBlockNumber:
+		number = 0
+	}
+
+	header, ok := d.store.GetHeaderByNumber(uint64(number))
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	return header, nil
+}
+
+// dumpState dumps the accounts of the state with the given root starting from the given hashed address
+func dumpState(dumper StateDumper, root types.Hash, start []byte, opts dumpOpts) (*stateDump, error) {
+	res := &stateDump{
+		Root:     root,
+		Accounts: map[string]*dumpAccount{},
+	}
+
+	var dumpErr error
+
+	err := dumper.Iterate(root, start, func(key, value []byte) bool {
+		if opts.limit > 0 && len(res.Accounts) == opts.limit {
+			res.Next = append(argBytes{}, key...)
+
+			return false
+		}
+
+		hash := types.BytesToHash(key)
+		name := fmt.Sprintf("pre(%s)", hash)
+
+		preimage, ok := dumper.GetPreimage(hash)
+		if !ok {
+			res.MissingPreimages++
+
+			if !opts.incompletes {
+				return true
+			}
+		}
+
+		var (
+			account        *dumpAccount
+			missingStorage int
+		)
+
+		if account, missingStorage, dumpErr = dumpStateAccount(dumper, hash, value, opts); dumpErr != nil {
+			return false
+		}
+
+		res.MissingPreimages += missingStorage
+
+		if ok {
+			address := types.BytesToAddress(preimage)
+			account.Address = &address
+			name = address.String()
+		}
+
+		res.Accounts[name] = account
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dumpErr != nil {
+		return nil, dumpErr
+	}
+
+	return res, nil
+}
+
+// dumpStateAccount dumps the account with its code and storage unless they are skipped.
+// It returns the number of the storage slots whose preimages are not known as well
+func dumpStateAccount(
+	dumper StateDumper,
+	key types.Hash,
+	value []byte,
+	opts dumpOpts,
+) (*dumpAccount, int, error) {
+	var account state.Account
+	if err := account.UnmarshalRlp(value); err != nil {
+		return nil, 0, err
+	}
+
+	res := &dumpAccount{
+		Balance:  account.Balance.String(),
+		Nonce:    account.Nonce,
+		Root:     account.Root,
+		CodeHash: types.BytesToHash(account.CodeHash),
+		Key:      key,
+	}
+
+	if !opts.noCode {
+		if code, ok := dumper.GetCode(res.CodeHash); ok && len(code) != 0 {
+			res.Code = code
+		}
+	}
+
+	if opts.noStorage || account.Root == types.EmptyRootHash {
+		return res, 0, nil
+	}
+
+	var (
+		decodeErr error
+		missing   int
+	)
+
+	res.Storage = map[types.Hash]types.Hash{}
+
+	// the slots whose preimages are not known are keyed by their hashes
+	err := dumper.Iterate(account.Root, nil, func(key, value []byte) bool {
+		slot := types.BytesToHash(key)
+		if preimage, ok := dumper.GetPreimage(slot); ok {
+			slot = types.BytesToHash(preimage)
+		} else {
+			missing++
+		}
+
+		res.Storage[slot], decodeErr = decodeStorageValue(value)
+
+		return decodeErr == nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, missing, decodeErr
+}
+
+// readAccount reads the account from the state, it returns nil if the account doesn't exist
+func readAccount(dumper StateDumper, root types.Hash, address types.Address) (*state.Account, error) {
+	var (
+		key     = types.BytesToHash(keccak.Keccak256(nil, address.Bytes()))
+		account *state.Account
+		err     error
+	)
+
+	iterErr := dumper.Iterate(root, key.Bytes(), func(k, value []byte) bool {
+		if types.BytesToHash(k) == key {
+			account = &state.Account{}
+			err = account.UnmarshalRlp(value)
+		}
+
+		return false
+	})
+
+	if iterErr != nil {
+		return nil, iterErr
+	}
+
+	return account, err
+}
+
+// decodeStorageValue decodes the RLP-encoded value of a storage slot
+func decodeStorageValue(value []byte) (types.Hash, error) {
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(value)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	data, err := v.Bytes()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(data), nil
+}
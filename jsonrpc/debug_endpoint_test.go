@@ -0,0 +1,236 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDebugStore struct {
+	*mockBlockStore
+	state *itrie.State
+	heads []uint64
+}
+
+func (m *mockDebugStore) SetHead(number uint64) error {
+	if number > m.Header().Number {
+		return errors.New("target block is above the head")
+	}
+
+	m.heads = append(m.heads, number)
+
+	return nil
+}
+
+func (m *mockDebugStore) GetStateDumper() (StateDumper, error) {
+	return m.state, nil
+}
+
+func (m *mockDebugStore) ReplayBlock(block *types.Block, txIndex int) (StateDumper, types.Hash, error) {
+	parent, _ := m.GetHeaderByNumber(block.Number() - 1)
+
+	return m.state, parent.StateRoot, nil
+}
+
+func newTestDebugEndpoint(t *testing.T, recordPreimages bool) *Debug {
+	t.Helper()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	st.SetRecordPreimages(recordPreimages)
+
+	code := []byte{0x60, 0x01}
+
+	_, root := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr1,
+			Balance:  big.NewInt(100),
+			Nonce:    1,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: hash1.Bytes(), Val: hash1.Bytes()},
+				{Key: hash2.Bytes(), Val: hash2.Bytes()},
+				{Key: hash3.Bytes(), Val: hash3.Bytes()},
+			},
+		},
+		{
+			Address:   addr2,
+			Balance:   big.NewInt(200),
+			CodeHash:  types.BytesToHash(crypto.Keccak256(code)),
+			Root:      types.EmptyRootHash,
+			DirtyCode: true,
+			Code:      code,
+		},
+	})
+
+	genesis := newTestBlock(0, hash1)
+	genesis.Header.StateRoot = types.EmptyRootHash
+
+	store := newMockBlockStore()
+	store.add(genesis)
+
+	block := newTestBlock(1, hash2)
+	block.Header.StateRoot = types.BytesToHash(root)
+	block.Transactions = []*types.Transaction{{Hash: hash3}}
+	store.add(block)
+
+	return &Debug{&mockDebugStore{mockBlockStore: store, state: st}}
+}
+
+func TestDebug_AccountRange(t *testing.T) {
+	t.Parallel()
+
+	debug := newTestDebugEndpoint(t, true)
+
+	res, err := debug.AccountRange(BlockNumberOrHash{}, nil, 1, false, false, false)
+	assert.NoError(t, err)
+
+	first, ok := res.(*stateDump)
+	assert.True(t, ok)
+	assert.Len(t, first.Accounts, 1)
+	assert.NotEmpty(t, first.Next)
+
+	res, err = debug.AccountRange(BlockNumberOrHash{}, first.Next, 1, true, true, false)
+	assert.NoError(t, err)
+
+	second, ok := res.(*stateDump)
+	assert.True(t, ok)
+	assert.Len(t, second.Accounts, 1)
+	assert.Empty(t, second.Next)
+
+	// the accounts are ordered by the hashes of the addresses
+	keys := []string{}
+
+	for _, dump := range []*stateDump{first, second} {
+		for name, account := range dump.Accounts {
+			assert.Equal(t, name, account.Address.String())
+			assert.Equal(t, types.BytesToHash(crypto.Keccak256(account.Address.Bytes())), account.Key)
+
+			keys = append(keys, account.Key.String())
+		}
+	}
+
+	assert.Len(t, keys, 2)
+	assert.Less(t, keys[0], keys[1])
+	assert.Equal(t, keys[1], types.BytesToHash(first.Next).String())
+}
+
+func TestDebug_DumpBlock(t *testing.T) {
+	t.Parallel()
+
+	debug := newTestDebugEndpoint(t, true)
+
+	res, err := debug.DumpBlock(LatestBlockNumber)
+	assert.NoError(t, err)
+
+	dump, ok := res.(*stateDump)
+	assert.True(t, ok)
+	assert.Empty(t, dump.Next)
+	assert.Len(t, dump.Accounts, 2)
+
+	account := dump.Accounts[addr1.String()]
+	assert.Equal(t, "100", account.Balance)
+	assert.Equal(t, uint64(1), account.Nonce)
+	assert.Empty(t, account.Code)
+	assert.Equal(t, map[types.Hash]types.Hash{hash1: hash1, hash2: hash2, hash3: hash3}, account.Storage)
+
+	account = dump.Accounts[addr2.String()]
+	assert.Equal(t, "200", account.Balance)
+	assert.Equal(t, argBytes{0x60, 0x01}, account.Code)
+	assert.Empty(t, account.Storage)
+
+	assert.Zero(t, dump.MissingPreimages)
+
+	// the genesis block has no state
+	res, err = debug.DumpBlock(EarliestBlockNumber)
+	assert.NoError(t, err)
+	assert.Empty(t, res.(*stateDump).Accounts) //nolint:forcetypeassert
+}
+
+func TestDebug_MissingPreimages(t *testing.T) {
+	t.Parallel()
+
+	debug := newTestDebugEndpoint(t, false)
+
+	// the accounts and the slots are keyed by their hashes
+	res, err := debug.DumpBlock(LatestBlockNumber)
+	assert.NoError(t, err)
+
+	dump, ok := res.(*stateDump)
+	assert.True(t, ok)
+	assert.Len(t, dump.Accounts, 2)
+	assert.Equal(t, 5, dump.MissingPreimages)
+
+	key := types.BytesToHash(crypto.Keccak256(addr1.Bytes()))
+	account := dump.Accounts["pre("+key.String()+")"]
+	assert.Nil(t, account.Address)
+	assert.Contains(t, account.Storage, types.BytesToHash(crypto.Keccak256(hash1.Bytes())))
+
+	// the range skips the accounts without the addresses, but counts them
+	res, err = debug.AccountRange(BlockNumberOrHash{}, nil, 0, true, true, false)
+	assert.NoError(t, err)
+
+	dump, ok = res.(*stateDump)
+	assert.True(t, ok)
+	assert.Empty(t, dump.Accounts)
+	assert.Equal(t, 2, dump.MissingPreimages)
+}
+
+func TestDebug_StorageRangeAt(t *testing.T) {
+	t.Parallel()
+
+	debug := newTestDebugEndpoint(t, true)
+
+	res, err := debug.StorageRangeAt(hash2, 1, addr1, nil, 2)
+	assert.NoError(t, err)
+
+	first, ok := res.(*storageRange)
+	assert.True(t, ok)
+	assert.Len(t, first.Storage, 2)
+	assert.NotNil(t, first.NextKey)
+
+	res, err = debug.StorageRangeAt(hash2, 1, addr1, first.NextKey.Bytes(), 2)
+	assert.NoError(t, err)
+
+	second, ok := res.(*storageRange)
+	assert.True(t, ok)
+	assert.Len(t, second.Storage, 1)
+	assert.Nil(t, second.NextKey)
+
+	for _, storage := range []map[types.Hash]storageEntry{first.Storage, second.Storage} {
+		for key, entry := range storage {
+			assert.Equal(t, types.BytesToHash(crypto.Keccak256(entry.Key.Bytes())), key)
+			assert.Equal(t, *entry.Key, entry.Value)
+		}
+	}
+
+	// the state before the transactions of the block is the parent state
+	res, err = debug.StorageRangeAt(hash2, 0, addr1, nil, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, res.(*storageRange).Storage) //nolint:forcetypeassert
+
+	_, err = debug.StorageRangeAt(hash2, 2, addr1, nil, 0)
+	assert.ErrorIs(t, err, ErrInvalidTxIndex)
+}
+
+func TestDebug_SetHead(t *testing.T) {
+	t.Parallel()
+
+	debug := newTestDebugEndpoint(t, true)
+	store, _ := debug.store.(*mockDebugStore)
+
+	res, err := debug.SetHead(argUint64(0))
+	assert.NoError(t, err)
+	assert.Equal(t, true, res)
+	assert.Equal(t, []uint64{0}, store.heads)
+
+	res, err = debug.SetHead(argUint64(5))
+	assert.Error(t, err)
+	assert.Equal(t, false, res)
+}
@@ -34,6 +34,8 @@ type endpoints struct {
 	Web3   *Web3
 	Net    *Net
 	TxPool *TxPool
+	IBFT   *IBFT
+	Exzo   *Exzo
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -46,6 +48,8 @@ type Dispatcher struct {
 	chainID                 uint64
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
+	blockRangeLimit         uint64
+	accessControl           *accessControl
 }
 
 func newDispatcher(
@@ -55,12 +59,15 @@ func newDispatcher(
 	priceLimit uint64,
 	jsonRPCBatchLengthLimit uint64,
 	blockRangeLimit uint64,
+	accessConfig *AccessConfig,
 ) *Dispatcher {
 	d := &Dispatcher{
 		logger:                  logger.Named("dispatcher"),
 		chainID:                 chainID,
 		priceLimit:              priceLimit,
 		jsonRPCBatchLengthLimit: jsonRPCBatchLengthLimit,
+		blockRangeLimit:         blockRangeLimit,
+		accessControl:           newAccessControl(accessConfig),
 	}
 
 	if store != nil {
@@ -78,11 +85,38 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.endpoints.Net = &Net{store, d.chainID}
 	d.endpoints.Web3 = &Web3{}
 	d.endpoints.TxPool = &TxPool{store}
+	d.endpoints.IBFT = &IBFT{store}
+	d.endpoints.Exzo = &Exzo{store, d.blockRangeLimit}
 
 	d.registerService("eth", d.endpoints.Eth)
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("txpool", d.endpoints.TxPool)
+	d.registerService("ibft", d.endpoints.IBFT)
+	d.registerService("exzo", d.endpoints.Exzo)
+}
+
+// newAdminDispatcher returns the dispatcher of the admin listener,
+// which serves the admin, ibft, txpool and debug endpoints without access control.
+// Its txpool endpoint adds the methods removing transactions from the pool
+func newAdminDispatcher(
+	logger hclog.Logger,
+	store JSONRPCAdminStore,
+	chainID uint64,
+	jsonRPCBatchLengthLimit uint64,
+) *Dispatcher {
+	d := &Dispatcher{
+		logger:                  logger.Named("admin_dispatcher"),
+		chainID:                 chainID,
+		jsonRPCBatchLengthLimit: jsonRPCBatchLengthLimit,
+	}
+
+	d.registerService("admin", &Admin{store, chainID})
+	d.registerService("ibft", &IBFTAdmin{&IBFT{store}, store})
+	d.registerService("txpool", &TxPoolAdmin{&TxPool{store}, store})
+	d.registerService("debug", &Debug{store})
+
+	return d
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -107,10 +141,22 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 	return service, fd, nil
 }
 
+// wsConn is a websocket connection which can hold many subscriptions
 type wsConn interface {
+	// WriteMessage writes the response to the peer
 	WriteMessage(messageType int, data []byte) error
-	GetFilterID() string
-	SetFilterID(string)
+
+	// Notify writes the subscription notification to the peer without blocking
+	Notify(data []byte) error
+
+	// AddSubscription adds the subscription to the connection
+	AddSubscription(id string)
+
+	// RemoveSubscription removes the subscription from the connection
+	RemoveSubscription(id string)
+
+	// GetSubscriptions returns the IDs of the subscriptions of the connection
+	GetSubscriptions() []string
 }
 
 // as per https://www.jsonrpc.org/specification, the `id` in JSON-RPC 2.0
@@ -184,12 +230,16 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn, caller *Caller) ([]byte, error) {
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
+	if err := d.accessControl.check(caller, req.Method); err != nil {
+		return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+	}
+
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "eth_subscribe" {
@@ -235,7 +285,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+func (d *Dispatcher) Handle(reqBody []byte, caller *Caller) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -251,6 +301,10 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
+		if err := d.accessControl.check(caller, req.Method); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
 		resp, err := d.handleReq(req)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
@@ -271,6 +325,12 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
+		if err := d.accessControl.check(caller, req.Method); err != nil {
+			responses = append(responses, NewRPCResponse(req.ID, "2.0", nil, err))
+
+			continue
+		}
+
 		var response, err = d.handleReq(req)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
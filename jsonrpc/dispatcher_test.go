@@ -62,7 +62,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		t.Parallel()
 
 		store := newMockStore()
-		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 0, 20, 1000)
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 0, 20, 1000, nil)
 
 		mockConnection := &mockWsConn{
 			msgCh: make(chan []byte, 1),
@@ -72,7 +72,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		if _, err := dispatcher.HandleWs(req, mockConnection, nil); err != nil {
 			t.Fatal(err)
 		}
 
@@ -96,7 +96,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 	store := newMockStore()
-	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 0, 20, 1000)
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, 0, 20, 1000, nil)
 
 	mockConnection := &mockWsConn{
 		msgCh: make(chan []byte, 1),
@@ -147,7 +147,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(c.msg, mockConnection, nil)
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -165,6 +165,25 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 	}
 }
 
+func TestDispatcher_EmptyBlockFilter(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 20, 1000, nil)
+
+	// the methods taking the block number or hash default to the latest block
+	for _, params := range []string{`[]`, `[null]`} {
+		data, err := dispatcher.Handle(
+			[]byte(`{"id":1,"jsonrpc":"2.0","method":"eth_getBlockReceipts","params":`+params+`}`),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		resp := new(SuccessResponse)
+		assert.NoError(t, json.Unmarshal(data, resp))
+		assert.Nil(t, resp.Error, params)
+	}
+}
+
 type mockService struct {
 	msgCh chan interface{}
 }
@@ -200,7 +219,7 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 func TestDispatcherFuncDecode(t *testing.T) {
 	srv := &mockService{msgCh: make(chan interface{}, 10)}
 
-	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 20, 1000)
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 20, 1000, nil)
 	dispatcher.registerService("mock", srv)
 
 	handleReq := func(typ string, msg string) interface{} {
@@ -267,7 +286,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 
 func TestDispatcherBatchRequest(t *testing.T) {
 	handle := func(dispatcher *Dispatcher, reqBody []byte) []byte {
-		res, _ := dispatcher.Handle(reqBody)
+		res, _ := dispatcher.Handle(reqBody, nil)
 
 		return res
 	}
@@ -283,7 +302,7 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		{
 			"leading-whitespace",
 			"test with leading whitespace (\"  \\t\\n\\n\\r\\)",
-			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 20, 1000),
+			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 20, 1000, nil),
 			append([]byte{0x20, 0x20, 0x09, 0x0A, 0x0A, 0x0D}, []byte(`[
 				{"id":1,"jsonrpc":"2.0","method":"eth_getBalance","params":["0x1", true]},
 				{"id":2,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x2", true]},
@@ -299,7 +318,7 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		{
 			"valid-batch-req",
 			"test with batch req length within batchRequestLengthLimit",
-			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 10, 1000),
+			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 10, 1000, nil),
 			[]byte(`[
 				{"id":1,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", true]},
 				{"id":2,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", true]},
@@ -319,7 +338,7 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		{
 			"invalid-batch-req",
 			"test with batch req length exceeding batchRequestLengthLimit",
-			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 3, 1000),
+			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 3, 1000, nil),
 			[]byte(`[
 				{"id":1,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", true]},
 				{"id":2,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", true]},
@@ -333,7 +352,7 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		{
 			"no-limits",
 			"test when limits are not set",
-			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 0, 0),
+			newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, 0, 0, 0, nil),
 			[]byte(`[
 				{"id":1,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", true]},
 				{"id":2,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["latest", true]},
@@ -76,6 +76,45 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+// methodNotAllowedError is returned when the method is denied by the access lists
+type methodNotAllowedError struct {
+	err string
+}
+
+func (e *methodNotAllowedError) Error() string {
+	return e.err
+}
+
+func (e *methodNotAllowedError) ErrorCode() int {
+	return -32004
+}
+
+// limitExceededError is returned when the caller exceeds the rate limit
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
+// unauthorizedError is returned when the API key is missing or invalid
+type unauthorizedError struct {
+	err string
+}
+
+func (e *unauthorizedError) Error() string {
+	return e.err
+}
+
+func (e *unauthorizedError) ErrorCode() int {
+	return -32006
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -90,6 +129,18 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewMethodNotAllowedError(method string) *methodNotAllowedError {
+	return &methodNotAllowedError{fmt.Sprintf("the method %s is not allowed", method)}
+}
+
+func NewLimitExceededError(method string) *limitExceededError {
+	return &limitExceededError{fmt.Sprintf("request rate limit exceeded for %s", method)}
+}
+
+func NewUnauthorizedError(msg string) *unauthorizedError {
+	return &unauthorizedError{msg}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,6 +10,7 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
 	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
 	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
+	"github.com/ExzoNetwork/ExzoCoin/state"
 	"github.com/ExzoNetwork/ExzoCoin/state/runtime"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/stretchr/testify/assert"
@@ -217,6 +219,70 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+	block := newTestBlock(1, hash4)
+	block.Transactions = []*types.Transaction{
+		newTestTransaction(0, addr0),
+		newTestTransaction(1, addr0),
+	}
+	store.add(block)
+
+	receipts := make([]*types.Receipt, len(block.Transactions))
+	for i := range receipts {
+		receipts[i] = &types.Receipt{
+			GasUsed: uint64(i + 1),
+			Logs:    []*types.Log{{Topics: []types.Hash{hash4}}},
+		}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	store.receipts[hash4] = receipts
+
+	t.Run("returns the receipts of the block by number and by hash", func(t *testing.T) {
+		t.Parallel()
+
+		one := BlockNumber(1)
+
+		for _, filter := range []BlockNumberOrHash{{BlockNumber: &one}, {BlockHash: &hash4}} {
+			res, err := eth.GetBlockReceipts(filter)
+			assert.NoError(t, err)
+
+			//nolint:forcetypeassert
+			response := res.([]*receipt)
+			assert.Len(t, response, 2)
+
+			for i, r := range response {
+				assert.Equal(t, block.Transactions[i].Hash, r.TxHash)
+				assert.Equal(t, argUint64(i), r.TxIndex)
+				assert.Equal(t, argUint64(i+1), r.GasUsed)
+				assert.Equal(t, argUint64(i), r.Logs[0].TxIndex)
+			}
+		}
+	})
+
+	t.Run("returns the receipts of the latest block if the filter is empty", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		assert.Len(t, res.([]*receipt), 2)
+	})
+
+	t.Run("returns nil if the block is not found", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -306,7 +372,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -330,11 +396,54 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
+
+	t.Run("applies the state and block overrides", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+
+		var (
+			stateOverrides stateOverride
+			blockOverrides blockOverride
+		)
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"`+addr1.String()+`": {
+			"nonce": "0x1", "code": "0x6001", "balance": "0x64",
+			"stateDiff": {"`+hash1.String()+`": "`+hash2.String()+`"}}}`), &stateOverrides))
+		assert.NoError(t, json.Unmarshal([]byte(`{"number": "0xa", "time": "0x14", "gasLimit": "0x1e"}`),
+			&blockOverrides))
+
+		_, err := eth.Call(
+			&txnArgs{From: &addr0, To: &addr1, Nonce: argUintPtr(0)},
+			BlockNumberOrHash{},
+			&stateOverrides,
+			&blockOverrides,
+		)
+		assert.NoError(t, err)
+
+		nonce, number, timestamp, gasLimit := uint64(1), uint64(10), uint64(20), uint64(30)
+
+		assert.Equal(t, state.StateOverride{
+			addr1: {
+				Nonce:     &nonce,
+				Code:      []byte{0x60, 0x01},
+				Balance:   big.NewInt(100),
+				StateDiff: map[types.Hash]types.Hash{hash1: hash2},
+			},
+		}, store.stateOverride)
+		assert.Equal(t, &state.BlockOverride{
+			Number:    &number,
+			Timestamp: &timestamp,
+			GasLimit:  &gasLimit,
+		}, store.blockOverride)
+	})
 }
 
 type mockBlockStore struct {
@@ -346,6 +455,8 @@ type mockBlockStore struct {
 	isSyncing       bool
 	averageGasPrice int64
 	ethCallError    error
+	stateOverride   state.StateOverride
+	blockOverride   *state.BlockOverride
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -481,6 +592,16 @@ func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Bloc
 	return nil, false
 }
 
+func (m *mockBlockStore) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
+	for _, b := range m.blocks {
+		if b.Hash() == hash {
+			return b.Body(), true
+		}
+	}
+
+	return nil, false
+}
+
 func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
@@ -528,6 +649,17 @@ func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction)
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
 
+func (m *mockBlockStore) ApplyTxnWithOverrides(
+	header *types.Header,
+	txn *types.Transaction,
+	stateOverride state.StateOverride,
+	blockOverride *state.BlockOverride,
+) (*runtime.ExecutionResult, error) {
+	m.stateOverride, m.blockOverride = stateOverride, blockOverride
+
+	return m.ApplyTxn(header, txn)
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
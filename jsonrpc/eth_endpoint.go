@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
 	"github.com/ExzoNetwork/ExzoCoin/helper/common"
 	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
 	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
@@ -32,6 +33,7 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(hash types.Hash) ([]byte, error)
+	GetStateProof(root types.Hash, slot []byte) ([][]byte, error)
 }
 
 type ethBlockchainStore interface {
@@ -53,12 +55,27 @@ type ethBlockchainStore interface {
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
+	// GetBodyByHash returns the body of the block by its hash
+	GetBodyByHash(hash types.Hash) (*types.Body, bool)
+
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
 
+	// ApplyTxnWithOverrides applies a transaction object to the blockchain
+	// on top of the overridden state and block context
+	ApplyTxnWithOverrides(
+		header *types.Header,
+		txn *types.Transaction,
+		stateOverride state.StateOverride,
+		blockOverride *state.BlockOverride,
+	) (*runtime.ExecutionResult, error)
+
+	// BeginSimulation creates a throwaway transition on top of the state of the header
+	BeginSimulation(header *types.Header) (*state.Transition, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -343,41 +360,46 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		return nil, nil
 	}
 
-	txn := block.Transactions[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
+	return toReceipt(receipts[indx], block.Transactions[indx], indx, block.Header), nil
+}
+
+// GetBlockReceipts returns the receipts of all transactions in the block,
+// read at once instead of looking up every transaction
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	var (
+		header *types.Header
+		ok     bool
+	)
+
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	if filter.BlockHash != nil {
+		block, found := e.store.GetBlockByHash(*filter.BlockHash, false)
+		if found {
+			header, ok = block.Header, true
 		}
+	} else {
+		num, err := GetNumericBlockNumber(*filter.BlockNumber, e)
+		if err != nil {
+			return nil, err
+		}
+
+		header, ok = e.store.GetHeaderByNumber(num)
 	}
 
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(indx),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	if !ok {
+		return nil, nil
 	}
 
-	return res, nil
+	_, receipts, err := readBlockWithReceipts(e.store, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
 }
 
 // GetStorageAt returns the contract storage at the index position
@@ -438,8 +460,14 @@ func (e *Eth) GasPrice() (string, error) {
 	return hex.EncodeUint64(common.Max(e.priceLimit, avgGasPrice)), nil
 }
 
-// Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+// Call executes a smart contract call using the transaction object data,
+// optionally on top of the overridden state and block context
+func (e *Eth) Call(
+	arg *txnArgs,
+	filter BlockNumberOrHash,
+	stateOverride *stateOverride,
+	blockOverride *blockOverride,
+) (interface{}, error) {
 	var (
 		header *types.Header
 		err    error
@@ -462,11 +490,16 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	}
 	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 	if transaction.Gas == 0 {
-		transaction.Gas = header.GasLimit
+		transaction.Gas = blockOverride.gasLimit(header)
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction)
+	result, err := e.store.ApplyTxnWithOverrides(
+		header,
+		transaction,
+		stateOverride.toStateOverride(),
+		blockOverride.toBlockOverride(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -483,13 +516,22 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	return argBytesPtr(result.ReturnValue), nil
 }
 
-// EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+// EstimateGas estimates the gas needed to execute a transaction,
+// optionally on top of the overridden state and block context
+func (e *Eth) EstimateGas(
+	arg *txnArgs,
+	rawNum *BlockNumber,
+	stateOverride *stateOverride,
+	blockOverride *blockOverride,
+) (interface{}, error) {
 	transaction, err := e.decodeTxn(arg)
 	if err != nil {
 		return nil, err
 	}
 
+	stateOverrides := stateOverride.toStateOverride()
+	blockOverrides := blockOverride.toBlockOverride()
+
 	number := LatestBlockNumber
 	if rawNum != nil {
 		number = *rawNum
@@ -520,7 +562,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = transaction.Gas
 	} else {
 		// If not, use the referenced block number
-		highEnd = header.GasLimit
+		highEnd = blockOverride.gasLimit(header)
 	}
 
 	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
@@ -547,6 +589,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			accountBalance = acc.Balance
 		}
 
+		// The overridden balance takes precedence over the one in state
+		if override, ok := stateOverrides[transaction.From]; ok && override.Balance != nil {
+			accountBalance = override.Balance
+		}
+
 		availableBalance = new(big.Int).Set(accountBalance)
 
 		if transaction.Value != nil {
@@ -603,7 +650,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		txn := transaction.Copy()
 		txn.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, txn)
+		result, applyErr := e.store.ApplyTxnWithOverrides(header, txn, stateOverrides, blockOverrides)
 
 		if applyErr != nil {
 			// Check the application error.
@@ -793,6 +840,92 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the Merkle-Patricia proofs of the account and its storage values
+// at given block, as defined in EIP-1186
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	proof, err := e.store.GetStateProof(header.StateRoot, address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// The account which doesn't exist is proven to be empty
+	res := &accountProof{
+		Address:      address,
+		AccountProof: toArgBytesList(proof),
+		Balance:      *argBigPtr(big.NewInt(0)),
+		CodeHash:     types.BytesToHash(crypto.Keccak256(nil)),
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]*storageProof, 0, len(storageKeys)),
+	}
+
+	acc, err := e.store.GetAccount(header.StateRoot, address)
+	if err != nil && !errors.Is(err, ErrStateNotFound) {
+		return nil, err
+	}
+
+	if err == nil {
+		res.Balance = *argBigPtr(acc.Balance)
+		res.Nonce = argUint64(acc.Nonce)
+		res.CodeHash = types.BytesToHash(acc.CodeHash)
+		res.StorageHash = acc.Root
+	}
+
+	for _, key := range storageKeys {
+		proof, err := e.store.GetStateProof(res.StorageHash, key.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		value := big.NewInt(0)
+
+		if acc != nil {
+			result, err := e.store.GetStorage(header.StateRoot, address, key)
+			if err != nil && !errors.Is(err, ErrStateNotFound) {
+				return nil, err
+			}
+
+			if err == nil {
+				// Parse the RLP value
+				p := &fastrlp.Parser{}
+
+				v, err := p.Parse(result)
+				if err != nil {
+					return nil, err
+				}
+
+				data, err := v.Bytes()
+				if err != nil {
+					return nil, err
+				}
+
+				value.SetBytes(data)
+			}
+		}
+
+		res.StorageProof = append(res.StorageProof, &storageProof{
+			Key:   key,
+			Value: *argBigPtr(value),
+			Proof: toArgBytesList(proof),
+		})
+	}
+
+	return res, nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -0,0 +1,251 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/state/runtime"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+const (
+	// maxSimulatedBlocks is the maximum number of the blocks in a simulation
+	maxSimulatedBlocks = 256
+
+	// the error codes of the failed calls, compatible with the ones of geth
+	simulateRevertErrorCode = 3
+	simulateVMErrorCode     = -32015
+)
+
+var (
+	ErrNoSimulatedBlocks      = errors.New("no blocks to simulate")
+	ErrTooManySimulatedBlocks = fmt.Errorf("too many blocks to simulate, the limit is %d", maxSimulatedBlocks)
+	ErrSimulatedBlockNumber   = errors.New("simulated block numbers must increase")
+)
+
+// simulateOpts is the argument of eth_simulateV1
+type simulateOpts struct {
+	BlockStateCalls []*simulateBlock `json:"blockStateCalls"`
+	// Validation only switches the handling of the nonces: the nonces given in the calls
+	// are enforced, otherwise the nonces are taken from the simulated state.
+	// The other checks of the geth validation mode, such as the base fee, aren't performed,
+	// the balance for the gas and the value is checked in both modes as in eth_call
+	Validation bool `json:"validation"`
+}
+
+// simulateBlock is a simulated block with the overrides applied before its calls
+type simulateBlock struct {
+	BlockOverrides *blockOverride `json:"blockOverrides"`
+	StateOverrides *stateOverride `json:"stateOverrides"`
+	Calls          []*txnArgs     `json:"calls"`
+}
+
+// simulatedBlock is the result of a simulated block
+type simulatedBlock struct {
+	Number       argUint64        `json:"number"`
+	Timestamp    argUint64        `json:"timestamp"`
+	GasLimit     argUint64        `json:"gasLimit"`
+	GasUsed      argUint64        `json:"gasUsed"`
+	FeeRecipient types.Address    `json:"feeRecipient"`
+	Calls        []*simulatedCall `json:"calls"`
+}
+
+// simulatedCall is the result of a call in a simulated block
+type simulatedCall struct {
+	ReturnData argBytes            `json:"returnData"`
+	Logs       []*Log              `json:"logs"`
+	GasUsed    argUint64           `json:"gasUsed"`
+	Status     argUint64           `json:"status"`
+	Error      *simulatedCallError `json:"error,omitempty"`
+}
+
+// simulatedCallError is the error of a failed call in a simulated block
+type simulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SimulateV1 executes the calls of the blocks one after another on a single transition
+// on top of the given block, so that every call sees the effects of the previous ones.
+// The validation option only enforces the nonces of the calls, see simulateOpts
+func (e *Eth) SimulateV1(opts *simulateOpts, filter BlockNumberOrHash) (interface{}, error) {
+	if opts == nil || len(opts.BlockStateCalls) == 0 {
+		return nil, ErrNoSimulatedBlocks
+	}
+
+	if len(opts.BlockStateCalls) > maxSimulatedBlocks {
+		return nil, ErrTooManySimulatedBlocks
+	}
+
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := e.store.BeginSimulation(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		number    = header.Number
+		timestamp = header.Timestamp
+		gasLimit  = header.GasLimit
+		coinbase  = transition.GetTxContext().Coinbase
+		res       = make([]*simulatedBlock, 0, len(opts.BlockStateCalls))
+	)
+
+	for _, block := range opts.BlockStateCalls {
+		if block == nil {
+			block = &simulateBlock{}
+		}
+
+		// The simulated block follows the previous one unless it is overridden
+		next := &state.BlockOverride{}
+		if block.BlockOverrides != nil {
+			next = block.BlockOverrides.toBlockOverride()
+		}
+
+		if next.Number != nil && *next.Number <= number {
+			return nil, ErrSimulatedBlockNumber
+		}
+
+		number, timestamp = number+1, timestamp+1
+
+		if next.Number != nil {
+			number = *next.Number
+		}
+
+		if next.Timestamp != nil {
+			timestamp = *next.Timestamp
+		}
+
+		if next.Coinbase != nil {
+			coinbase = *next.Coinbase
+		}
+
+		if next.GasLimit != nil {
+			gasLimit = *next.GasLimit
+		}
+
+		transition.ApplyBlockOverride(&state.BlockOverride{
+			Number:    &number,
+			Timestamp: &timestamp,
+			Coinbase:  &coinbase,
+			GasLimit:  &gasLimit,
+		})
+
+		if err := transition.ApplyStateOverride(block.StateOverrides.toStateOverride()); err != nil {
+			return nil, err
+		}
+
+		simulated, err := e.simulateCalls(transition, block.Calls, opts.Validation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate block %d: %w", number, err)
+		}
+
+		simulated.Number = argUint64(number)
+		simulated.Timestamp = argUint64(timestamp)
+		simulated.GasLimit = argUint64(gasLimit)
+		simulated.FeeRecipient = coinbase
+
+		res = append(res, simulated)
+	}
+
+	return res, nil
+}
+
+// simulateCalls executes the calls of a simulated block on the transition
+func (e *Eth) simulateCalls(
+	transition *state.Transition,
+	calls []*txnArgs,
+	validation bool,
+) (*simulatedBlock, error) {
+	var (
+		ctx      = transition.GetTxContext()
+		gasLimit = uint64(ctx.GasLimit)
+		res      = &simulatedBlock{Calls: make([]*simulatedCall, 0, len(calls))}
+		logIndex uint64
+	)
+
+	for index, arg := range calls {
+		if arg == nil {
+			return nil, fmt.Errorf("call %d is empty", index)
+		}
+
+		if arg.From == nil {
+			arg.From = &types.ZeroAddress
+		}
+
+		// The calls see the nonces of the previous ones unless they are validated
+		if arg.Nonce == nil || !validation {
+			arg.Nonce = argUintPtr(transition.GetNonce(*arg.From))
+		}
+
+		txn, err := e.decodeTxn(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode call %d: %w", index, err)
+		}
+
+		// If the caller didn't supply the gas limit, the call can use the rest of the block
+		if txn.Gas == 0 {
+			txn.Gas = gasLimit - uint64(res.GasUsed)
+		}
+
+		result, logs, err := transition.ApplyWithLogs(txn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply call %d: %w", index, err)
+		}
+
+		call := &simulatedCall{
+			ReturnData: argBytes(result.ReturnValue),
+			Logs:       make([]*Log, 0, len(logs)),
+			GasUsed:    argUint64(result.GasUsed),
+			Status:     argUint64(types.ReceiptSuccess),
+		}
+
+		for _, log := range logs {
+			call.Logs = append(call.Logs, &Log{
+				Address:     log.Address,
+				Topics:      log.Topics,
+				Data:        argBytes(log.Data),
+				BlockNumber: argUint64(ctx.Number),
+				TxHash:      txn.Hash,
+				TxIndex:     argUint64(index),
+				LogIndex:    argUint64(logIndex),
+			})
+
+			logIndex++
+		}
+
+		if result.Failed() {
+			call.Status = argUint64(types.ReceiptFailed)
+			call.Error = newSimulatedCallError(result)
+		}
+
+		res.GasUsed += argUint64(result.GasUsed)
+		res.Calls = append(res.Calls, call)
+	}
+
+	return res, nil
+}
+
+func newSimulatedCallError(result *runtime.ExecutionResult) *simulatedCallError {
+	if result.Reverted() {
+		return &simulatedCallError{
+			Code:    simulateRevertErrorCode,
+			Message: constructErrorFromRevert(result).Error(),
+		}
+	}
+
+	return &simulatedCallError{
+		Code:    simulateVMErrorCode,
+		Message: result.Err.Error(),
+	}
+}
@@ -0,0 +1,172 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/ExzoNetwork/ExzoCoin/state/runtime/evm"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSimulationStore struct {
+	*mockBlockStore
+	executor *state.Executor
+}
+
+func (m *mockSimulationStore) BeginSimulation(header *types.Header) (*state.Transition, error) {
+	return m.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
+}
+
+func newTestSimulationEndpoint(t *testing.T) *Eth {
+	t.Helper()
+
+	executor := state.NewExecutor(&chain.Params{
+		Forks:   chain.AllForksEnabled,
+		ChainID: 100,
+	}, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	executor.SetRuntime(evm.NewEVM())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	root := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		addr0: {Balance: big.NewInt(1000000000000000000)},
+		// returns the balance of addr1 and emits it in a log
+		addr2: {Code: append(append([]byte{0x73}, addr1.Bytes()...),
+			0x31, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xa0, 0x60, 0x20, 0x60, 0x00, 0xf3)},
+	})
+
+	block := newTestBlock(5, hash1)
+	block.Header.StateRoot = root
+	block.Header.Timestamp = 100
+	block.Header.GasLimit = 10000000
+
+	store := newMockBlockStore()
+	store.add(block)
+
+	return newTestEthEndpoint(&mockSimulationStore{mockBlockStore: store, executor: executor})
+}
+
+func TestEth_SimulateV1(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	t.Run("calls see the effects of the previous ones", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestSimulationEndpoint(t)
+
+		var opts simulateOpts
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"blockStateCalls": [
+			{"calls": [
+				{"from": "`+addr0.String()+`", "to": "`+addr1.String()+`", "value": "0x64"},
+				{"from": "`+addr0.String()+`", "to": "`+addr1.String()+`", "value": "0x64"},
+				{"from": "`+addr0.String()+`", "to": "`+addr2.String()+`"}
+			]},
+			{
+				"blockOverrides": {"number": "0xa", "gasLimit": "0x100000"},
+				"stateOverrides": {
+					"`+addr2.String()+`": {"code": "0x4360005260206000f3"},
+					"`+addr3.String()+`": {"code": "0x60006000fd"}
+				},
+				"calls": [
+					{"from": "`+addr0.String()+`", "to": "`+addr2.String()+`"},
+					{"from": "`+addr0.String()+`", "to": "`+addr3.String()+`"}
+				]
+			}
+		]}`), &opts))
+
+		res, err := eth.SimulateV1(&opts, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		blocks, ok := res.([]*simulatedBlock)
+		assert.True(t, ok)
+		assert.Len(t, blocks, 2)
+
+		// the first block follows the parent block
+		first := blocks[0]
+		assert.Equal(t, argUint64(6), first.Number)
+		assert.Equal(t, argUint64(101), first.Timestamp)
+		assert.Equal(t, argUint64(10000000), first.GasLimit)
+		assert.Len(t, first.Calls, 3)
+		assert.Equal(t, argUint64(21000), first.Calls[0].GasUsed)
+		assert.Equal(t, argUint64(21000), first.Calls[1].GasUsed)
+
+		balance := types.BytesToHash(big.NewInt(200).Bytes()).Bytes()
+
+		call := first.Calls[2]
+		assert.Equal(t, argUint64(1), call.Status)
+		assert.Equal(t, argBytes(balance), call.ReturnData)
+		assert.Len(t, call.Logs, 1)
+		assert.Equal(t, addr2, call.Logs[0].Address)
+		assert.Equal(t, argBytes(balance), call.Logs[0].Data)
+		assert.Equal(t, argUint64(6), call.Logs[0].BlockNumber)
+		assert.Equal(t, argUint64(2), call.Logs[0].TxIndex)
+		assert.Equal(t, first.Calls[0].GasUsed+first.Calls[1].GasUsed+call.GasUsed, first.GasUsed)
+
+		// the second block is overridden
+		second := blocks[1]
+		assert.Equal(t, argUint64(10), second.Number)
+		assert.Equal(t, argUint64(102), second.Timestamp)
+		assert.Equal(t, argUint64(0x100000), second.GasLimit)
+		assert.Len(t, second.Calls, 2)
+		assert.Equal(t, argBytes(types.BytesToHash([]byte{10}).Bytes()), second.Calls[0].ReturnData)
+		assert.Equal(t, argUint64(0), second.Calls[1].Status)
+		assert.Equal(t, &simulatedCallError{
+			Code:    simulateRevertErrorCode,
+			Message: "execution was reverted",
+		}, second.Calls[1].Error)
+	})
+
+	t.Run("validation enforces the nonces of the calls", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestSimulationEndpoint(t)
+
+		call := func() *txnArgs {
+			return &txnArgs{From: &addr0, To: &addr1, Nonce: argUintPtr(5)}
+		}
+
+		_, err := eth.SimulateV1(&simulateOpts{
+			BlockStateCalls: []*simulateBlock{{Calls: []*txnArgs{call()}}},
+		}, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		_, err = eth.SimulateV1(&simulateOpts{
+			BlockStateCalls: []*simulateBlock{{Calls: []*txnArgs{call()}}},
+			Validation:      true,
+		}, BlockNumberOrHash{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), state.ErrNonceIncorrect.Error())
+	})
+
+	t.Run("rejects invalid blocks", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestSimulationEndpoint(t)
+
+		_, err := eth.SimulateV1(&simulateOpts{}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrNoSimulatedBlocks)
+
+		_, err = eth.SimulateV1(&simulateOpts{
+			BlockStateCalls: make([]*simulateBlock, maxSimulatedBlocks+1),
+		}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrTooManySimulatedBlocks)
+
+		_, err = eth.SimulateV1(&simulateOpts{
+			BlockStateCalls: []*simulateBlock{{BlockOverrides: &blockOverride{Number: argUintPtr(5)}}},
+		}, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrSimulatedBlockNumber)
+	})
+}
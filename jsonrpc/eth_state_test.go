@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
 	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
 	"github.com/ExzoNetwork/ExzoCoin/state"
 	"github.com/ExzoNetwork/ExzoCoin/state/runtime"
@@ -582,6 +583,87 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	storageRoot := types.StringToHash("1")
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &state.Account{
+				Balance:  big.NewInt(100),
+				Nonce:    100,
+				Root:     storageRoot,
+				CodeHash: types.BytesToHash(addr0.Bytes()).Bytes(),
+			},
+			storage: make(map[types.Hash][]byte),
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: types.EmptyRootHash,
+			},
+		},
+	}
+
+	a := &fastrlp.Arena{}
+	store.account.Storage(hash1, a.NewBytes(big.NewInt(10).Bytes()).MarshalTo(nil))
+
+	eth := newTestEthEndpoint(store)
+
+	t.Run("should return proofs of account and storage", func(t *testing.T) {
+		res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		assert.Equal(
+			t,
+			&accountProof{
+				Address:      addr0,
+				AccountProof: []argBytes{types.EmptyRootHash.Bytes(), addr0.Bytes()},
+				Balance:      *argBigPtr(big.NewInt(100)),
+				CodeHash:     types.BytesToHash(addr0.Bytes()),
+				Nonce:        100,
+				StorageHash:  storageRoot,
+				StorageProof: []*storageProof{
+					{
+						Key:   hash1,
+						Value: *argBigPtr(big.NewInt(10)),
+						Proof: []argBytes{storageRoot.Bytes(), hash1.Bytes()},
+					},
+					{
+						Key:   hash2,
+						Value: *argBigPtr(big.NewInt(0)),
+						Proof: []argBytes{storageRoot.Bytes(), hash2.Bytes()},
+					},
+				},
+			},
+			res,
+		)
+	})
+
+	t.Run("should return proof of empty account for non-existing account", func(t *testing.T) {
+		res, err := eth.GetProof(uninitializedAddress, []types.Hash{hash1}, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProof)
+		assert.True(t, ok)
+
+		assert.Equal(t, *argBigPtr(big.NewInt(0)), proof.Balance)
+		assert.Equal(t, argUint64(0), proof.Nonce)
+		assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+		assert.Equal(t, types.BytesToHash(crypto.Keccak256(nil)), proof.CodeHash)
+		assert.Len(t, proof.StorageProof, 1)
+		assert.Equal(t, *argBigPtr(big.NewInt(0)), proof.StorageProof[0].Value)
+	})
+
+	t.Run("should return error for non-existing block", func(t *testing.T) {
+		blockNumber := BlockNumber(0x1)
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &blockNumber})
+		assert.Error(t, err)
+	})
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -683,7 +765,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -734,6 +816,8 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	estimate, estimateErr := ethEndpoint.EstimateGas(
 		constructMockTx(nil, nil),
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -760,12 +844,24 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	estimate, estimateErr := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
 
 	// Make sure the insufficient funds error message is contained
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
+
+	// The overridden balance covers the value
+	_, estimateErr = ethEndpoint.EstimateGas(
+		mockTx,
+		nil,
+		&stateOverride{*mockTx.From: overrideAccount{Balance: argBigPtr(big.NewInt(1))}},
+		nil,
+	)
+
+	assert.NotErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
 type mockSpecialStore struct {
@@ -823,6 +919,10 @@ func (m *mockSpecialStore) GetStorage(root types.Hash, addr types.Address, slot
 	return val, nil
 }
 
+func (m *mockSpecialStore) GetStateProof(root types.Hash, slot []byte) ([][]byte, error) {
+	return [][]byte{root.Bytes(), slot}, nil
+}
+
 func (m *mockSpecialStore) GetCode(hash types.Hash) ([]byte, error) {
 	if bytes.Equal(m.account.account.CodeHash, hash.Bytes()) {
 		return m.account.code, nil
@@ -835,6 +935,15 @@ func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime
 	return chain.ForksInTime{}
 }
 
+func (m *mockSpecialStore) ApplyTxnWithOverrides(
+	header *types.Header,
+	txn *types.Transaction,
+	stateOverride state.StateOverride,
+	blockOverride *state.BlockOverride,
+) (*runtime.ExecutionResult, error) {
+	return m.ApplyTxn(header, txn)
+}
+
 func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
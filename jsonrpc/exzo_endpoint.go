@@ -0,0 +1,144 @@
+package jsonrpc
+
+import (
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+// blockReceiptsStore provides access to the bodies and the receipts of the blocks
+type blockReceiptsStore interface {
+	// GetBodyByHash returns the body of the block by its hash
+	GetBodyByHash(hash types.Hash) (*types.Body, bool)
+
+	// GetReceiptsByHash returns the receipts for a block hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+}
+
+// exzoStore provides access to the methods needed by exzo endpoint
+type exzoStore interface {
+	blockReceiptsStore
+
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetHeaderByNumber returns the header by number
+	GetHeaderByNumber(block uint64) (*types.Header, bool)
+}
+
+// Exzo is the exzo jsonrpc endpoint, which serves the node specific methods
+type Exzo struct {
+	store           exzoStore
+	blockRangeLimit uint64
+}
+
+// blockWithReceipts is the block with the full transactions and their receipts
+type blockWithReceipts struct {
+	*block
+	Receipts []*receipt `json:"receipts"`
+}
+
+// GetBlockRange returns the blocks in the range with the full transactions and their receipts,
+// the range is capped by the block range limit and stops at the head of the chain
+func (e *Exzo) GetBlockRange(fromNumber, toNumber BlockNumber) (interface{}, error) {
+	from, err := e.resolveBlockNumber(fromNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := e.resolveBlockNumber(toNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if to < from {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if e.blockRangeLimit != 0 && to-from > e.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	if head := e.store.Header().Number; to > head {
+		to = head
+	}
+
+	res := make([]*blockWithReceipts, 0)
+
+	for number := from; number <= to; number++ {
+		header, ok := e.store.GetHeaderByNumber(number)
+		if !ok {
+			break
+		}
+
+		b, receipts, err := readBlockWithReceipts(e.store, header)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &blockWithReceipts{
+			block:    toBlock(b, true),
+			Receipts: receipts,
+		})
+	}
+
+	return res, nil
+}
+
+func (e *Exzo) resolveBlockNumber(number BlockNumber) (uint64, error) {
+	switch number {
+	case PendingBlockNumber:
+		return 0, ErrPendingBlockNumber
+	case EarliestBlockNumber:
+		return 0, nil
+	case LatestBlockNumber:
+		return e.store.Header().Number, nil
+	}
+
+	if number < 0 {
+		return 0, fmt.Errorf("invalid block number %d", number)
+	}
+
+	return uint64(number), nil
+}
+
+// readBlockWithReceipts reads the body and the receipts of the block by the hash of the header,
+// without looking up the transactions one by one
+func readBlockWithReceipts(store blockReceiptsStore, header *types.Header) (*types.Block, []*receipt, error) {
+	block := &types.Block{
+		Header: header,
+	}
+
+	// the genesis block has no body
+	if header.Number != 0 {
+		body, ok := store.GetBodyByHash(header.Hash)
+		if !ok {
+			return nil, nil, fmt.Errorf("body of block %d not found", header.Number)
+		}
+
+		block.Transactions = body.Transactions
+		block.Uncles = body.Uncles
+	}
+
+	receipts := make([]*receipt, 0, len(block.Transactions))
+
+	if len(block.Transactions) == 0 {
+		return block, receipts, nil
+	}
+
+	raws, err := store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(raws) != len(block.Transactions) {
+		return nil, nil, fmt.Errorf("receipts of block %d not found", header.Number)
+	}
+
+	for idx, raw := range raws {
+		receipts = append(receipts, toReceipt(raw, block.Transactions[idx], idx, header))
+	}
+
+	return block, receipts, nil
+}
@@ -0,0 +1,101 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestExzoEndpoint(blockRangeLimit uint64) *Exzo {
+	store := newMockBlockStore()
+	store.add(newTestBlock(0, types.StringToHash("0")))
+
+	for i := uint64(1); i <= 3; i++ {
+		block := newTestBlock(i, types.StringToHash(string(rune('0'+i))))
+		block.Transactions = []*types.Transaction{newTestTransaction(i, addr0)}
+		store.add(block)
+
+		receipt := &types.Receipt{GasUsed: i}
+		receipt.SetStatus(types.ReceiptSuccess)
+		store.receipts[block.Hash()] = []*types.Receipt{receipt}
+	}
+
+	return &Exzo{store, blockRangeLimit}
+}
+
+func TestExzo_GetBlockRange(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		from            BlockNumber
+		to              BlockNumber
+		blockRangeLimit uint64
+		expected        []uint64
+		err             error
+	}{
+		{
+			name:     "returns the blocks with the receipts",
+			from:     EarliestBlockNumber,
+			to:       LatestBlockNumber,
+			expected: []uint64{0, 1, 2, 3},
+		},
+		{
+			name:     "stops at the head of the chain",
+			from:     2,
+			to:       10,
+			expected: []uint64{2, 3},
+		},
+		{
+			name:            "rejects the range over the limit",
+			from:            0,
+			to:              3,
+			blockRangeLimit: 2,
+			err:             ErrBlockRangeTooHigh,
+		},
+		{
+			name: "rejects the reversed range",
+			from: 2,
+			to:   1,
+			err:  ErrIncorrectBlockRange,
+		},
+		{
+			name: "rejects the pending block",
+			from: 1,
+			to:   PendingBlockNumber,
+			err:  ErrPendingBlockNumber,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := newTestExzoEndpoint(c.blockRangeLimit).GetBlockRange(c.from, c.to)
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			//nolint:forcetypeassert
+			blocks := res.([]*blockWithReceipts)
+			assert.Len(t, blocks, len(c.expected))
+
+			for i, b := range blocks {
+				assert.Equal(t, argUint64(c.expected[i]), b.Number)
+				assert.Len(t, b.Receipts, len(b.Transactions))
+
+				for _, r := range b.Receipts {
+					assert.Equal(t, b.Hash, r.BlockHash)
+					assert.Equal(t, b.Number, r.GasUsed)
+				}
+			}
+		})
+	}
+}
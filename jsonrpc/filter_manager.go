@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -98,10 +99,7 @@ func (f *filterBase) writeMessageToWs(msg string) error {
 		return ErrNoWSConnection
 	}
 
-	return f.ws.WriteMessage(
-		websocket.TextMessage,
-		[]byte(fmt.Sprintf(ethSubscriptionTemplate, f.id, msg)),
-	)
+	return f.ws.Notify([]byte(fmt.Sprintf(ethSubscriptionTemplate, f.id, msg)))
 }
 
 // blockFilter is a filter to store the updates of block
@@ -335,7 +333,7 @@ func (f *FilterManager) NewBlockFilter(ws wsConn) string {
 	}
 
 	if filter.hasWSConn() {
-		ws.SetFilterID(filter.id)
+		ws.AddSubscription(filter.id)
 	}
 
 	return f.addFilter(filter)
@@ -349,7 +347,7 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	}
 
 	if filter.hasWSConn() {
-		ws.SetFilterID(filter.id)
+		ws.AddSubscription(filter.id)
 	}
 
 	return f.addFilter(filter)
@@ -559,6 +557,10 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if base := filter.getFilterBase(); base.hasWSConn() {
+		base.ws.RemoveSubscription(id)
+	}
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -566,12 +568,14 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 	return true
 }
 
-// RemoveFilterByWs removes the filter with given WS [Thread safe]
+// RemoveFilterByWs removes all the filters of the given WS [Thread safe]
 func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.Lock()
 	defer f.Unlock()
 
-	f.removeFilterByID(ws.GetFilterID())
+	for _, id := range ws.GetSubscriptions() {
+		f.removeFilterByID(id)
+	}
 }
 
 // refreshFilterTimeout updates the timeout for a filter to the current time
@@ -641,29 +645,67 @@ func (f *FilterManager) dispatchEvent(evnt *blockchain.Event) error {
 	return nil
 }
 
-// processEvent makes each filter append the new data that interests them
+// processEvent makes each filter append the new data that interests them.
+// On a reorg, the logs of the removed blocks are appended first as removed,
+// starting from the latest block
 func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
+	if evnt.Type == blockchain.EventReorg {
+		for i := len(evnt.OldChain) - 1; i >= 0; i-- {
+			header := evnt.OldChain[i]
+
+			var processErr error
+
+			// the receipts deleted along with the removed blocks are delivered with the event
+			if receipts, ok := evnt.OldReceipts[header.Hash]; ok {
+				processErr = f.appendReceiptLogsToFilters(header, receipts, true)
+			} else {
+				processErr = f.appendLogsToFilters(header, true)
+			}
+
+			if errors.Is(processErr, storage.ErrNotFound) {
+				f.logger.Debug("receipts of removed block not found", "hash", header.Hash)
+			} else if processErr != nil {
+				f.logger.Error(fmt.Sprintf("Unable to process removed block, %v", processErr))
+			}
+		}
+	}
+
 	for _, header := range evnt.NewChain {
 		// first include all the new headers in the blockstream for BlockFilter
 		f.blockStream.push(header)
 
 		// process new chain to include new logs for LogFilter
-		if processErr := f.appendLogsToFilters(header); processErr != nil {
+		processErr := f.appendLogsToFilters(header, false)
+
+		// the blocks written without execution and the headers of the light node have no receipts
+		if errors.Is(processErr, storage.ErrNotFound) {
+			f.logger.Debug("receipts of block not found", "hash", header.Hash)
+		} else if processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
 	}
 }
 
-// appendLogsToFilters makes each LogFilters append logs in the header
-func (f *FilterManager) appendLogsToFilters(header *types.Header) error {
+// appendLogsToFilters makes each LogFilters append logs in the header,
+// the logs are flagged as removed if the block has been removed by a reorg
+func (f *FilterManager) appendLogsToFilters(header *types.Header, removed bool) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
 	if err != nil {
 		return err
 	}
 
+	return f.appendReceiptLogsToFilters(header, receipts, removed)
+}
+
+// appendReceiptLogsToFilters makes each LogFilters append the logs of the receipts of the block
+func (f *FilterManager) appendReceiptLogsToFilters(
+	header *types.Header,
+	receipts []*types.Receipt,
+	removed bool,
+) error {
 	// Get logFilters from filters
 	logFilters := f.getLogFilters()
 	if len(logFilters) == 0 {
@@ -683,7 +725,7 @@ func (f *FilterManager) appendLogsToFilters(header *types.Header) error {
 			receipt.TxHash = block.Transactions[indx].Hash
 		}
 		// check the logs with the filters
-		for _, log := range receipt.Logs {
+		for logIndx, log := range receipt.Logs {
 			for _, f := range logFilters {
 				if f.query.Match(log) {
 					f.appendLog(&Log{
@@ -694,7 +736,8 @@ func (f *FilterManager) appendLogsToFilters(header *types.Header) error {
 						BlockHash:   header.Hash,
 						TxHash:      receipt.TxHash,
 						TxIndex:     argUint64(indx),
-						Removed:     false,
+						LogIndex:    argUint64(logIndx),
+						Removed:     removed,
 					})
 				}
 			}
@@ -726,6 +769,13 @@ func (f *FilterManager) flushWsFilters() error {
 				continue
 			}
 
+			// the notifications of slow consumers are dropped, which is accounted by the metrics
+			if errors.Is(flushErr, ErrWSSendQueueFull) {
+				f.logger.Debug(fmt.Sprintf("Dropped notifications of subscription %s", id))
+
+				continue
+			}
+
 			f.logger.Error(fmt.Sprintf("Unable to process flush, %v", flushErr))
 		}
 	}
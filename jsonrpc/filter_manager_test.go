@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"math/big"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -236,6 +237,130 @@ func TestFilterLog(t *testing.T) {
 	}
 }
 
+// mockReorgStore returns the blocks of the receipts of the store
+type mockReorgStore struct {
+	*mockStore
+}
+
+func (m *mockReorgStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	receipts, _ := m.GetReceiptsByHash(hash)
+
+	block := &types.Block{Header: &types.Header{Hash: hash}}
+	for range receipts {
+		block.Transactions = append(block.Transactions, &types.Transaction{})
+	}
+
+	return block, true
+}
+
+func TestFilterLogReorg(t *testing.T) {
+	t.Parallel()
+
+	store := &mockReorgStore{newMockStore()}
+	store.receipts = map[types.Hash][]*types.Receipt{}
+
+	headers := make([]*types.Header, 4)
+
+	for i := range headers {
+		headers[i] = &types.Header{Number: uint64(i%2 + 1), Hash: types.StringToHash(strconv.Itoa(i))}
+
+		store.receipts[headers[i].Hash] = []*types.Receipt{
+			{
+				Logs: []*types.Log{
+					{Topics: []types.Hash{hash1}},
+					{Topics: []types.Hash{hash2}},
+				},
+				TxHash: hash3,
+			},
+		}
+	}
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewLogFilter(&LogQuery{
+		Topics: [][]types.Hash{
+			{hash1},
+		},
+	}, nil)
+
+	// the fork blocks have never been canonical, so no log is removed
+	m.processEvent(&blockchain.Event{
+		Type:     blockchain.EventFork,
+		OldChain: headers[2:],
+	})
+
+	res, err := m.GetFilterChanges(id)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	// the blocks 0 and 1 are replaced by the blocks 2 and 3
+	m.processEvent(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: headers[:2],
+		NewChain: headers[2:],
+	})
+
+	res, err = m.GetFilterChanges(id)
+	assert.NoError(t, err)
+
+	logs, ok := res.([]*Log)
+	assert.True(t, ok)
+	assert.Len(t, logs, 4)
+
+	// the removed logs come first, starting from the latest block
+	for i, expected := range []struct {
+		hash    types.Hash
+		removed bool
+	}{
+		{headers[1].Hash, true},
+		{headers[0].Hash, true},
+		{headers[2].Hash, false},
+		{headers[3].Hash, false},
+	} {
+		assert.Equal(t, expected.hash, logs[i].BlockHash)
+		assert.Equal(t, expected.removed, logs[i].Removed)
+		assert.Equal(t, []types.Hash{hash1}, logs[i].Topics)
+	}
+}
+
+func TestFilterLogSetHead(t *testing.T) {
+	t.Parallel()
+
+	store := &mockReorgStore{newMockStore()}
+
+	header := &types.Header{Number: 1, Hash: hash1}
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewLogFilter(&LogQuery{}, nil)
+
+	// the receipts of the blocks removed by SetHead are gone from the store
+	m.processEvent(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: []*types.Header{header},
+		OldReceipts: map[types.Hash][]*types.Receipt{
+			header.Hash: {
+				{
+					Logs:   []*types.Log{{Topics: []types.Hash{hash2}}},
+					TxHash: hash3,
+				},
+			},
+		},
+	})
+
+	res, err := m.GetFilterChanges(id)
+	assert.NoError(t, err)
+
+	logs, ok := res.([]*Log)
+	assert.True(t, ok)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, header.Hash, logs[0].BlockHash)
+	assert.Equal(t, hash3, logs[0].TxHash)
+	assert.True(t, logs[0].Removed)
+}
+
 func TestFilterBlock(t *testing.T) {
 	t.Parallel()
 
@@ -335,12 +460,17 @@ func TestRemoveFilterByWebsocket(t *testing.T) {
 
 	go m.Run()
 
-	id := m.NewBlockFilter(mock)
+	blockID := m.NewBlockFilter(mock)
+	logID := m.NewLogFilter(&LogQuery{}, mock)
+
+	assert.Len(t, mock.GetSubscriptions(), 2)
 
 	m.RemoveFilterByWs(mock)
 
-	// false because filter was removed
-	assert.False(t, m.Exists(id))
+	// false because the filters were removed
+	assert.False(t, m.Exists(blockID))
+	assert.False(t, m.Exists(logID))
+	assert.Empty(t, mock.GetSubscriptions())
 }
 
 func TestFilterWebsocket(t *testing.T) {
@@ -382,16 +512,36 @@ func TestFilterWebsocket(t *testing.T) {
 }
 
 type mockWsConn struct {
-	msgCh    chan []byte
-	filterID string
+	sync.Mutex
+	msgCh         chan []byte
+	subscriptions []string
 }
 
-func (m *mockWsConn) SetFilterID(filterID string) {
-	m.filterID = filterID
+func (m *mockWsConn) AddSubscription(id string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.subscriptions = append(m.subscriptions, id)
+}
+
+func (m *mockWsConn) RemoveSubscription(id string) {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, subscription := range m.subscriptions {
+		if subscription == id {
+			m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+
+			return
+		}
+	}
 }
 
-func (m *mockWsConn) GetFilterID() string {
-	return m.filterID
+func (m *mockWsConn) GetSubscriptions() []string {
+	m.Lock()
+	defer m.Unlock()
+
+	return append([]string{}, m.subscriptions...)
 }
 
 func (m *mockWsConn) WriteMessage(messageType int, b []byte) error {
@@ -400,6 +550,12 @@ func (m *mockWsConn) WriteMessage(messageType int, b []byte) error {
 	return nil
 }
 
+func (m *mockWsConn) Notify(b []byte) error {
+	m.msgCh <- b
+
+	return nil
+}
+
 func TestHeadStream(t *testing.T) {
 	t.Parallel()
 
@@ -426,16 +582,22 @@ func TestHeadStream(t *testing.T) {
 
 type MockClosedWSConnection struct{}
 
-func (m *MockClosedWSConnection) SetFilterID(_filterID string) {}
+func (m *MockClosedWSConnection) AddSubscription(_id string) {}
+
+func (m *MockClosedWSConnection) RemoveSubscription(_id string) {}
 
-func (m *MockClosedWSConnection) GetFilterID() string {
-	return ""
+func (m *MockClosedWSConnection) GetSubscriptions() []string {
+	return nil
 }
 
 func (m *MockClosedWSConnection) WriteMessage(_messageType int, _data []byte) error {
 	return websocket.ErrCloseSent
 }
 
+func (m *MockClosedWSConnection) Notify(_data []byte) error {
+	return websocket.ErrCloseSent
+}
+
 func TestClosedFilterDeletion(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,1203 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ExzoNetwork/ExzoCoin/graphql"
+	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	// graphQLMethod is the method name the access control applies to the GraphQL queries
+	graphQLMethod = "graphql_query"
+
+	// graphQLMaxRequestSize is the max size of the body of the POST requests
+	graphQLMaxRequestSize = 5 * 1024 * 1024
+)
+
+var (
+	ErrGraphQLBlockRangeTooHigh = errors.New("block range exceeds limit")
+)
+
+// GraphQLConfig is the configuration of the GraphQL endpoint
+type GraphQLConfig struct {
+	// MaxDepth is the max nesting of the queries, 0 disables the limit
+	MaxDepth int
+	// MaxComplexity is the max number of the fields resolved by a query, 0 disables the limit
+	MaxComplexity int
+}
+
+// The scalars of the EIP-1767 schema. Long is serialized as a JSON number,
+// the others as the hex strings. The input values are parsed into uint64, *big.Int,
+// []byte, types.Hash and types.Address
+var (
+	longScalar = &graphql.Scalar{
+		Name: "Long",
+		ParseValue: func(value interface{}) (interface{}, error) {
+			return gqlUint64(value)
+		},
+	}
+	bigIntScalar = &graphql.Scalar{
+		Name: "BigInt",
+		ParseValue: func(value interface{}) (interface{}, error) {
+			return gqlBig(value)
+		},
+	}
+	bytesScalar = &graphql.Scalar{
+		Name: "Bytes",
+		ParseValue: func(value interface{}) (interface{}, error) {
+			return gqlBytes(value)
+		},
+	}
+	bytes32Scalar = &graphql.Scalar{
+		Name: "Bytes32",
+		ParseValue: func(value interface{}) (interface{}, error) {
+			return gqlHash(value)
+		},
+	}
+	addressScalar = &graphql.Scalar{
+		Name: "Address",
+		ParseValue: func(value interface{}) (interface{}, error) {
+			return gqlAddress(value)
+		},
+	}
+)
+
+// The input objects of the EIP-1767 schema
+var (
+	callDataInput = &graphql.InputObject{
+		Name: "CallData",
+		Fields: map[string]*graphql.ArgumentDefinition{
+			"from":     {Type: addressScalar},
+			"to":       {Type: addressScalar},
+			"gas":      {Type: longScalar},
+			"gasPrice": {Type: bigIntScalar},
+			"value":    {Type: bigIntScalar},
+			"data":     {Type: bytesScalar},
+		},
+	}
+	blockFilterCriteriaInput = &graphql.InputObject{
+		Name: "BlockFilterCriteria",
+		Fields: map[string]*graphql.ArgumentDefinition{
+			"addresses": {Type: &graphql.List{OfType: nonNull(addressScalar)}},
+			"topics":    {Type: &graphql.List{OfType: nonNull(&graphql.List{OfType: nonNull(bytes32Scalar)})}},
+		},
+	}
+	filterCriteriaInput = &graphql.InputObject{
+		Name: "FilterCriteria",
+		Fields: map[string]*graphql.ArgumentDefinition{
+			"fromBlock": {Type: longScalar},
+			"toBlock":   {Type: longScalar},
+			"addresses": blockFilterCriteriaInput.Fields["addresses"],
+			"topics":    blockFilterCriteriaInput.Fields["topics"],
+		},
+	}
+
+	// blockArgs are the arguments of the account fields, which read the account at the block number if set
+	blockArgs = map[string]*graphql.ArgumentDefinition{
+		"block": {Type: longScalar},
+	}
+)
+
+// nonNull returns the non-null type of the type
+func nonNull(typ graphql.Type) graphql.Type {
+	return &graphql.NonNull{OfType: typ}
+}
+
+// graphQLHandler executes the GraphQL requests against the EIP-1767 schema
+type graphQLHandler struct {
+	schema *graphql.Schema
+	// querySchema is the schema without mutations which serves the GET requests
+	querySchema   *graphql.Schema
+	limits        graphql.Limits
+	accessControl *accessControl
+}
+
+// newGraphQLHandler returns the GraphQL handler backed by the eth endpoint of the dispatcher,
+// sharing the rate limits of the callers with the dispatcher
+func newGraphQLHandler(d *Dispatcher, config *GraphQLConfig) *graphQLHandler {
+	schema := newGraphQLSchema(d.endpoints.Eth)
+
+	return &graphQLHandler{
+		schema:      schema,
+		querySchema: &graphql.Schema{Query: schema.Query},
+		limits: graphql.Limits{
+			MaxDepth:      config.MaxDepth,
+			MaxComplexity: config.MaxComplexity,
+		},
+		accessControl: d.accessControl,
+	}
+}
+
+// handle executes the request and returns the HTTP status and the response
+func (h *graphQLHandler) handle(w http.ResponseWriter, req *http.Request, caller *Caller) (int, *graphql.Response) {
+	errorResponse := func(status int, err error) (int, *graphql.Response) {
+		return status, &graphql.Response{Errors: []*graphql.Error{{Message: err.Error()}}}
+	}
+
+	if err := h.accessControl.check(caller, graphQLMethod); err != nil {
+		return errorResponse(accessErrorStatus(err), err)
+	}
+
+	var (
+		gqlReq = &graphql.Request{}
+		schema = h.schema
+	)
+
+	switch req.Method {
+	case http.MethodGet:
+		// mutations are not allowed by GET, see GraphQL over HTTP
+		schema = h.querySchema
+		query := req.URL.Query()
+
+		gqlReq.Query = query.Get("query")
+		gqlReq.OperationName = query.Get("operationName")
+
+		if variables := query.Get("variables"); variables != "" {
+			decoder := json.NewDecoder(strings.NewReader(variables))
+			decoder.UseNumber()
+
+			if err := decoder.Decode(&gqlReq.Variables); err != nil {
+				return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid variables: %w", err))
+			}
+		}
+	case http.MethodPost:
+		decoder := json.NewDecoder(http.MaxBytesReader(w, req.Body, graphQLMaxRequestSize))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(gqlReq); err != nil {
+			return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		}
+	default:
+		return errorResponse(http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+	}
+
+	return http.StatusOK, schema.Execute(req.Context(), gqlReq, h.limits)
+}
+
+// accessErrorStatus returns the HTTP status of the access control error
+func accessErrorStatus(err Error) int {
+	switch err.(type) {
+	case *limitExceededError:
+		return http.StatusTooManyRequests
+	case *unauthorizedError:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusForbidden
+	}
+}
+
+// gqlBlock is the source of the Block type
+type gqlBlock struct {
+	eth      *Eth
+	block    *types.Block
+	receipts []*types.Receipt
+}
+
+// getReceipts returns the receipts of the block, which are loaded once
+func (b *gqlBlock) getReceipts() ([]*types.Receipt, error) {
+	if b.receipts == nil {
+		receipts, err := b.eth.store.GetReceiptsByHash(b.block.Hash())
+		if err != nil {
+			return nil, err
+		}
+
+		b.receipts = receipts
+	}
+
+	return b.receipts, nil
+}
+
+// gqlTransaction is the source of the Transaction type, block is nil for the pending transactions
+type gqlTransaction struct {
+	eth   *Eth
+	tx    *types.Transaction
+	block *gqlBlock
+	index int
+}
+
+// getReceipt returns the receipt of the transaction, nil for the pending transactions
+func (t *gqlTransaction) getReceipt() (*types.Receipt, error) {
+	if t.block == nil {
+		return nil, nil
+	}
+
+	receipts, err := t.block.getReceipts()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.index >= len(receipts) {
+		return nil, nil
+	}
+
+	return receipts[t.index], nil
+}
+
+// getHeader returns the header of the block of the transaction, the latest header for the pending transactions
+func (t *gqlTransaction) getHeader() *types.Header {
+	if t.block == nil {
+		return t.eth.store.Header()
+	}
+
+	return t.block.block.Header
+}
+
+// gqlAccount is the source of the Account type, the state is read at the header
+type gqlAccount struct {
+	eth     *Eth
+	address types.Address
+	header  *types.Header
+}
+
+// gqlLog is the source of the Log type
+type gqlLog struct {
+	eth *Eth
+	log *Log
+	tx  *gqlTransaction
+}
+
+// gqlCallResult is the source of the CallResult type
+type gqlCallResult struct {
+	data    []byte
+	gasUsed uint64
+	status  uint64
+}
+
+// graphQLSchema builds the EIP-1767 schema backed by the eth endpoint
+type graphQLSchema struct {
+	eth *Eth
+
+	block       *graphql.Object
+	transaction *graphql.Object
+	account     *graphql.Object
+	log         *graphql.Object
+	callResult  *graphql.Object
+	syncState   *graphql.Object
+}
+
+// newGraphQLSchema returns the EIP-1767 schema of the blocks, the transactions, the accounts and the logs
+func newGraphQLSchema(eth *Eth) *graphql.Schema {
+	s := &graphQLSchema{
+		eth:         eth,
+		block:       &graphql.Object{Name: "Block"},
+		transaction: &graphql.Object{Name: "Transaction"},
+		account:     &graphql.Object{Name: "Account"},
+		log:         &graphql.Object{Name: "Log"},
+		callResult:  &graphql.Object{Name: "CallResult"},
+		syncState:   &graphql.Object{Name: "SyncState"},
+	}
+
+	s.block.Fields = s.blockFields()
+	s.transaction.Fields = s.transactionFields()
+	s.account.Fields = s.accountFields()
+	s.log.Fields = s.logFields()
+	s.callResult.Fields = callResultFields()
+	s.syncState.Fields = syncStateFields()
+
+	return &graphql.Schema{
+		Query:    &graphql.Object{Name: "Query", Fields: s.queryFields()},
+		Mutation: &graphql.Object{Name: "Mutation", Fields: s.mutationFields()},
+	}
+}
+
+func (s *graphQLSchema) queryFields() map[string]*graphql.FieldDefinition {
+	return map[string]*graphql.FieldDefinition{
+		"block": {
+			Type: s.block,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"number": {Type: longScalar},
+				"hash":   {Type: bytes32Scalar},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if hash, ok := p.Args["hash"].(types.Hash); ok {
+					return s.getBlockByHash(hash), nil
+				}
+
+				if number, ok := p.Args["number"].(uint64); ok {
+					return s.getBlockByNumber(number), nil
+				}
+
+				return s.getBlockByNumber(s.eth.store.Header().Number), nil
+			},
+		},
+		"blocks": {
+			Type: &graphql.List{OfType: s.block},
+			Args: map[string]*graphql.ArgumentDefinition{
+				"from": {Type: nonNull(longScalar)},
+				"to":   {Type: longScalar},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				from, _ := p.Args["from"].(uint64)
+
+				to, ok := p.Args["to"].(uint64)
+				if !ok {
+					to = s.eth.store.Header().Number
+				}
+
+				return s.getBlocks(from, to)
+			},
+		},
+		"transaction": {
+			Type: s.transaction,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"hash": {Type: nonNull(bytes32Scalar)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				hash, _ := p.Args["hash"].(types.Hash)
+
+				return s.getTransaction(hash), nil
+			},
+		},
+		"logs": {
+			Type: &graphql.List{OfType: s.log},
+			Args: map[string]*graphql.ArgumentDefinition{
+				"filter": {Type: nonNull(filterCriteriaInput)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				filter, _ := p.Args["filter"].(map[string]interface{})
+
+				query := gqlLogQuery(filter, true)
+
+				return s.getLogs(query)
+			},
+		},
+		"gasPrice": {
+			Type: bigIntScalar,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return s.eth.GasPrice()
+			},
+		},
+		"chainID": {
+			Type: bigIntScalar,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return s.eth.ChainId()
+			},
+		},
+		"syncing": {
+			Type: s.syncState,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return s.eth.store.GetSyncProgression(), nil
+			},
+		},
+	}
+}
+
+func (s *graphQLSchema) mutationFields() map[string]*graphql.FieldDefinition {
+	return map[string]*graphql.FieldDefinition{
+		"sendRawTransaction": {
+			Type: bytes32Scalar,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"data": {Type: nonNull(bytesScalar)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				data, _ := p.Args["data"].([]byte)
+
+				return s.eth.SendRawTransaction(hex.EncodeToHex(data))
+			},
+		},
+	}
+}
+
+// blockResolver returns the resolver of the field of the Block type
+func blockResolver(fn func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error)) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return fn(p.Source.(*gqlBlock), p) //nolint:forcetypeassert
+	}
+}
+
+// headerField returns the field of the Block type which is read from the header
+func headerField(typ graphql.Type, fn func(h *types.Header) interface{}) *graphql.FieldDefinition {
+	return &graphql.FieldDefinition{
+		Type: typ,
+		Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+			return fn(b.block.Header), nil
+		}),
+	}
+}
+
+func (s *graphQLSchema) blockFields() map[string]*graphql.FieldDefinition {
+	return map[string]*graphql.FieldDefinition{
+		"number":           headerField(longScalar, func(h *types.Header) interface{} { return h.Number }),
+		"hash":             headerField(bytes32Scalar, func(h *types.Header) interface{} { return h.Hash }),
+		"nonce":            headerField(bytesScalar, func(h *types.Header) interface{} { return gqlBytesValue(h.Nonce[:]) }),
+		"transactionsRoot": headerField(bytes32Scalar, func(h *types.Header) interface{} { return h.TxRoot }),
+		"stateRoot":        headerField(bytes32Scalar, func(h *types.Header) interface{} { return h.StateRoot }),
+		"receiptsRoot":     headerField(bytes32Scalar, func(h *types.Header) interface{} { return h.ReceiptsRoot }),
+		"extraData":        headerField(bytesScalar, func(h *types.Header) interface{} { return gqlBytesValue(h.ExtraData) }),
+		"gasLimit":         headerField(longScalar, func(h *types.Header) interface{} { return h.GasLimit }),
+		"gasUsed":          headerField(longScalar, func(h *types.Header) interface{} { return h.GasUsed }),
+		"timestamp":        headerField(longScalar, func(h *types.Header) interface{} { return h.Timestamp }),
+		"mixHash":          headerField(bytes32Scalar, func(h *types.Header) interface{} { return h.MixHash }),
+		"ommerHash":        headerField(bytes32Scalar, func(h *types.Header) interface{} { return h.Sha3Uncles }),
+		"logsBloom": headerField(bytesScalar, func(h *types.Header) interface{} {
+			return gqlBytesValue(h.LogsBloom[:])
+		}),
+		"rawHeader": headerField(bytesScalar, func(h *types.Header) interface{} {
+			return gqlBytesValue(h.MarshalRLP())
+		}),
+		"difficulty": headerField(bigIntScalar, func(h *types.Header) interface{} {
+			return argBigPtr(new(big.Int).SetUint64(h.Difficulty))
+		}),
+		"parent": {
+			Type: s.block,
+			Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+				if b.block.Number() == 0 {
+					return nil, nil
+				}
+
+				return s.getBlockByHash(b.block.ParentHash()), nil
+			}),
+		},
+		"miner": {
+			Type: s.account,
+			Args: blockArgs,
+			Resolve: blockResolver(func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error) {
+				return s.getAccount(types.BytesToAddress(b.block.Header.Miner), b.block.Header, p.Args["block"])
+			}),
+		},
+		"transactionCount": {
+			Type: graphql.Int,
+			Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+				return len(b.block.Transactions), nil
+			}),
+		},
+		"transactions": {
+			Type: &graphql.List{OfType: s.transaction},
+			Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+				txs := make([]*gqlTransaction, len(b.block.Transactions))
+				for idx, tx := range b.block.Transactions {
+					txs[idx] = &gqlTransaction{eth: s.eth, tx: tx, block: b, index: idx}
+				}
+
+				return txs, nil
+			}),
+		},
+		"transactionAt": {
+			Type: s.transaction,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"index": {Type: nonNull(longScalar)},
+			},
+			Resolve: blockResolver(func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error) {
+				index, _ := p.Args["index"].(uint64)
+
+				if index >= uint64(len(b.block.Transactions)) {
+					return nil, nil
+				}
+
+				return &gqlTransaction{eth: s.eth, tx: b.block.Transactions[index], block: b, index: int(index)}, nil
+			}),
+		},
+		"ommerCount": {
+			Type: graphql.Int,
+			Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+				return len(b.block.Uncles), nil
+			}),
+		},
+		"ommers": {
+			Type: &graphql.List{OfType: s.block},
+			Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+				ommers := make([]*gqlBlock, len(b.block.Uncles))
+				for idx, uncle := range b.block.Uncles {
+					ommers[idx] = &gqlBlock{eth: s.eth, block: &types.Block{Header: uncle}}
+				}
+
+				return ommers, nil
+			}),
+		},
+		"logs": {
+			Type: &graphql.List{OfType: s.log},
+			Args: map[string]*graphql.ArgumentDefinition{
+				"filter": {Type: nonNull(blockFilterCriteriaInput)},
+			},
+			Resolve: blockResolver(func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error) {
+				filter, _ := p.Args["filter"].(map[string]interface{})
+
+				query := gqlLogQuery(filter, false)
+
+				hash := b.block.Hash()
+				query.BlockHash = &hash
+
+				return s.getLogs(query)
+			}),
+		},
+		"account": {
+			Type: s.account,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"address": {Type: nonNull(addressScalar)},
+			},
+			Resolve: blockResolver(func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error) {
+				address, _ := p.Args["address"].(types.Address)
+
+				return &gqlAccount{eth: s.eth, address: address, header: b.block.Header}, nil
+			}),
+		},
+		"call": {
+			Type: s.callResult,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"data": {Type: nonNull(callDataInput)},
+			},
+			Resolve: blockResolver(func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error) {
+				return s.call(b.block.Header, p.Args["data"])
+			}),
+		},
+		"estimateGas": {
+			Type: longScalar,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"data": {Type: nonNull(callDataInput)},
+			},
+			Resolve: blockResolver(func(b *gqlBlock, p graphql.ResolveParams) (interface{}, error) {
+				return s.estimateGas(b.block.Header, p.Args["data"])
+			}),
+		},
+		"raw": {
+			Type: bytesScalar,
+			Resolve: blockResolver(func(b *gqlBlock, _ graphql.ResolveParams) (interface{}, error) {
+				return gqlBytesValue(b.block.MarshalRLP()), nil
+			}),
+		},
+	}
+}
+
+// transactionResolver returns the resolver of the field of the Transaction type
+func transactionResolver(
+	fn func(t *gqlTransaction, p graphql.ResolveParams) (interface{}, error),
+) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return fn(p.Source.(*gqlTransaction), p) //nolint:forcetypeassert
+	}
+}
+
+// receiptField returns the field of the Transaction type which is read from the receipt,
+// the field is null for the pending transactions
+func receiptField(typ graphql.Type, fn func(r *types.Receipt) interface{}) *graphql.FieldDefinition {
+	return &graphql.FieldDefinition{
+		Type: typ,
+		Resolve: transactionResolver(func(t *gqlTransaction, _ graphql.ResolveParams) (interface{}, error) {
+			receipt, err := t.getReceipt()
+			if err != nil || receipt == nil {
+				return nil, err
+			}
+
+			return fn(receipt), nil
+		}),
+	}
+}
+
+func (s *graphQLSchema) transactionFields() map[string]*graphql.FieldDefinition {
+	txField := func(typ graphql.Type, fn func(tx *types.Transaction) interface{}) *graphql.FieldDefinition {
+		return &graphql.FieldDefinition{
+			Type: typ,
+			Resolve: transactionResolver(func(t *gqlTransaction, _ graphql.ResolveParams) (interface{}, error) {
+				return fn(t.tx), nil
+			}),
+		}
+	}
+
+	return map[string]*graphql.FieldDefinition{
+		"hash":      txField(bytes32Scalar, func(tx *types.Transaction) interface{} { return tx.Hash }),
+		"nonce":     txField(longScalar, func(tx *types.Transaction) interface{} { return tx.Nonce }),
+		"value":     txField(bigIntScalar, func(tx *types.Transaction) interface{} { return gqlBigValue(tx.Value) }),
+		"gasPrice":  txField(bigIntScalar, func(tx *types.Transaction) interface{} { return gqlBigValue(tx.GasPrice) }),
+		"gas":       txField(longScalar, func(tx *types.Transaction) interface{} { return tx.Gas }),
+		"inputData": txField(bytesScalar, func(tx *types.Transaction) interface{} { return gqlBytesValue(tx.Input) }),
+		"r":         txField(bigIntScalar, func(tx *types.Transaction) interface{} { return gqlBigValue(tx.R) }),
+		"s":         txField(bigIntScalar, func(tx *types.Transaction) interface{} { return gqlBigValue(tx.S) }),
+		"v":         txField(bigIntScalar, func(tx *types.Transaction) interface{} { return gqlBigValue(tx.V) }),
+		"raw":       txField(bytesScalar, func(tx *types.Transaction) interface{} { return gqlBytesValue(tx.MarshalRLP()) }),
+		"index": {
+			Type: graphql.Int,
+			Resolve: transactionResolver(func(t *gqlTransaction, _ graphql.ResolveParams) (interface{}, error) {
+				if t.block == nil {
+					return nil, nil
+				}
+
+				return t.index, nil
+			}),
+		},
+		"from": {
+			Type: s.account,
+			Args: blockArgs,
+			Resolve: transactionResolver(func(t *gqlTransaction, p graphql.ResolveParams) (interface{}, error) {
+				return s.getAccount(t.tx.From, t.getHeader(), p.Args["block"])
+			}),
+		},
+		"to": {
+			Type: s.account,
+			Args: blockArgs,
+			Resolve: transactionResolver(func(t *gqlTransaction, p graphql.ResolveParams) (interface{}, error) {
+				if t.tx.To == nil {
+					return nil, nil
+				}
+
+				return s.getAccount(*t.tx.To, t.getHeader(), p.Args["block"])
+			}),
+		},
+		"block": {
+			Type: s.block,
+			Resolve: transactionResolver(func(t *gqlTransaction, _ graphql.ResolveParams) (interface{}, error) {
+				return t.block, nil
+			}),
+		},
+		"status": receiptField(longScalar, func(r *types.Receipt) interface{} {
+			if r.Status == nil {
+				return nil
+			}
+
+			return uint64(*r.Status)
+		}),
+		"gasUsed":           receiptField(longScalar, func(r *types.Receipt) interface{} { return r.GasUsed }),
+		"cumulativeGasUsed": receiptField(longScalar, func(r *types.Receipt) interface{} { return r.CumulativeGasUsed }),
+		"createdContract": {
+			Type: s.account,
+			Args: blockArgs,
+			Resolve: transactionResolver(func(t *gqlTransaction, p graphql.ResolveParams) (interface{}, error) {
+				receipt, err := t.getReceipt()
+				if err != nil || receipt == nil || receipt.ContractAddress == nil {
+					return nil, err
+				}
+
+				return s.getAccount(*receipt.ContractAddress, t.getHeader(), p.Args["block"])
+			}),
+		},
+		"logs": {
+			Type: &graphql.List{OfType: s.log},
+			Resolve: transactionResolver(func(t *gqlTransaction, _ graphql.ResolveParams) (interface{}, error) {
+				receipt, err := t.getReceipt()
+				if err != nil || receipt == nil {
+					return nil, err
+				}
+
+				logs := make([]*gqlLog, len(receipt.Logs))
+				for idx, log := range receipt.Logs {
+					logs[idx] = &gqlLog{
+						eth: s.eth,
+						log: &Log{
+							Address:     log.Address,
+							Topics:      log.Topics,
+							Data:        log.Data,
+							BlockNumber: argUint64(t.block.block.Number()),
+							BlockHash:   t.block.block.Hash(),
+							TxHash:      t.tx.Hash,
+							TxIndex:     argUint64(t.index),
+							LogIndex:    argUint64(idx),
+						},
+						tx: t,
+					}
+				}
+
+				return logs, nil
+			}),
+		},
+	}
+}
+
+// accountResolver returns the resolver of the field of the Account type
+func accountResolver(fn func(a *gqlAccount, p graphql.ResolveParams) (interface{}, error)) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return fn(p.Source.(*gqlAccount), p) //nolint:forcetypeassert
+	}
+}
+
+func (s *graphQLSchema) accountFields() map[string]*graphql.FieldDefinition {
+	filter := func(a *gqlAccount) BlockNumberOrHash {
+		hash := a.header.Hash
+
+		return BlockNumberOrHash{BlockHash: &hash}
+	}
+
+	return map[string]*graphql.FieldDefinition{
+		"address": {
+			Type: addressScalar,
+			Resolve: accountResolver(func(a *gqlAccount, _ graphql.ResolveParams) (interface{}, error) {
+				return a.address, nil
+			}),
+		},
+		"balance": {
+			Type: bigIntScalar,
+			Resolve: accountResolver(func(a *gqlAccount, _ graphql.ResolveParams) (interface{}, error) {
+				return s.eth.GetBalance(a.address, filter(a))
+			}),
+		},
+		"transactionCount": {
+			Type: longScalar,
+			Resolve: accountResolver(func(a *gqlAccount, _ graphql.ResolveParams) (interface{}, error) {
+				nonce, err := s.eth.getNextNonce(a.address, BlockNumber(a.header.Number))
+				if errors.Is(err, ErrStateNotFound) {
+					return uint64(0), nil
+				}
+
+				return nonce, err
+			}),
+		},
+		"code": {
+			Type: bytesScalar,
+			Resolve: accountResolver(func(a *gqlAccount, _ graphql.ResolveParams) (interface{}, error) {
+				return s.eth.GetCode(a.address, filter(a))
+			}),
+		},
+		"storage": {
+			Type: bytes32Scalar,
+			Args: map[string]*graphql.ArgumentDefinition{
+				"slot": {Type: nonNull(bytes32Scalar)},
+			},
+			Resolve: accountResolver(func(a *gqlAccount, p graphql.ResolveParams) (interface{}, error) {
+				slot, _ := p.Args["slot"].(types.Hash)
+
+				return s.getStorage(a, slot)
+			}),
+		},
+	}
+}
+
+// logResolver returns the resolver of the field of the Log type
+func logResolver(fn func(l *gqlLog, p graphql.ResolveParams) (interface{}, error)) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return fn(p.Source.(*gqlLog), p) //nolint:forcetypeassert
+	}
+}
+
+func (s *graphQLSchema) logFields() map[string]*graphql.FieldDefinition {
+	return map[string]*graphql.FieldDefinition{
+		"index": {
+			Type: graphql.Int,
+			Resolve: logResolver(func(l *gqlLog, _ graphql.ResolveParams) (interface{}, error) {
+				return uint64(l.log.LogIndex), nil
+			}),
+		},
+		"account": {
+			Type: s.account,
+			Args: blockArgs,
+			Resolve: logResolver(func(l *gqlLog, p graphql.ResolveParams) (interface{}, error) {
+				tx, err := s.getLogTransaction(l)
+				if err != nil || tx == nil {
+					return nil, err
+				}
+
+				return s.getAccount(l.log.Address, tx.getHeader(), p.Args["block"])
+			}),
+		},
+		"topics": {
+			Type: &graphql.List{OfType: bytes32Scalar},
+			Resolve: logResolver(func(l *gqlLog, _ graphql.ResolveParams) (interface{}, error) {
+				return l.log.Topics, nil
+			}),
+		},
+		"data": {
+			Type: bytesScalar,
+			Resolve: logResolver(func(l *gqlLog, _ graphql.ResolveParams) (interface{}, error) {
+				return gqlBytesValue(l.log.Data), nil
+			}),
+		},
+		"transaction": {
+			Type: s.transaction,
+			Resolve: logResolver(func(l *gqlLog, _ graphql.ResolveParams) (interface{}, error) {
+				return s.getLogTransaction(l)
+			}),
+		},
+	}
+}
+
+// callResultResolver returns the resolver of the field of the CallResult type
+func callResultResolver(fn func(r *gqlCallResult) interface{}) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return fn(p.Source.(*gqlCallResult)), nil //nolint:forcetypeassert
+	}
+}
+
+func callResultFields() map[string]*graphql.FieldDefinition {
+	return map[string]*graphql.FieldDefinition{
+		"data": {
+			Type:    bytesScalar,
+			Resolve: callResultResolver(func(r *gqlCallResult) interface{} { return gqlBytesValue(r.data) }),
+		},
+		"gasUsed": {
+			Type:    longScalar,
+			Resolve: callResultResolver(func(r *gqlCallResult) interface{} { return r.gasUsed }),
+		},
+		"status": {
+			Type:    longScalar,
+			Resolve: callResultResolver(func(r *gqlCallResult) interface{} { return r.status }),
+		},
+	}
+}
+
+func syncStateFields() map[string]*graphql.FieldDefinition {
+	progressionField := func(fn func(p *progress.Progression) uint64) *graphql.FieldDefinition {
+		return &graphql.FieldDefinition{
+			Type: longScalar,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return fn(p.Source.(*progress.Progression)), nil //nolint:forcetypeassert
+			},
+		}
+	}
+
+	return map[string]*graphql.FieldDefinition{
+		"startingBlock": progressionField(func(p *progress.Progression) uint64 { return p.StartingBlock }),
+		"currentBlock":  progressionField(func(p *progress.Progression) uint64 { return p.CurrentBlock }),
+		"highestBlock":  progressionField(func(p *progress.Progression) uint64 { return p.HighestBlock }),
+	}
+}
+
+// getBlockByNumber returns the block of the number, nil if not found
+func (s *graphQLSchema) getBlockByNumber(number uint64) *gqlBlock {
+	block, ok := s.eth.store.GetBlockByNumber(number, true)
+	if !ok {
+		return nil
+	}
+
+	return &gqlBlock{eth: s.eth, block: block}
+}
+
+// getBlockByHash returns the block of the hash, nil if not found
+func (s *graphQLSchema) getBlockByHash(hash types.Hash) *gqlBlock {
+	block, ok := s.eth.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil
+	}
+
+	return &gqlBlock{eth: s.eth, block: block}
+}
+
+// getBlocks returns the blocks in the range, bounded by the block range limit of the filter manager
+func (s *graphQLSchema) getBlocks(from, to uint64) ([]*gqlBlock, error) {
+	if latest := s.eth.store.Header().Number; to > latest {
+		to = latest
+	}
+
+	if to < from {
+		return []*gqlBlock{}, nil
+	}
+
+	if limit := s.eth.filterManager.blockRangeLimit; limit != 0 && to-from > limit {
+		return nil, ErrGraphQLBlockRangeTooHigh
+	}
+
+	blocks := make([]*gqlBlock, 0, to-from+1)
+
+	for number := from; number <= to; number++ {
+		block := s.getBlockByNumber(number)
+		if block == nil {
+			break
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// getTransaction returns the sealed or the pending transaction of the hash, nil if not found
+func (s *graphQLSchema) getTransaction(hash types.Hash) *gqlTransaction {
+	if blockHash, ok := s.eth.store.ReadTxLookup(hash); ok {
+		if block := s.getBlockByHash(blockHash); block != nil {
+			for idx, tx := range block.block.Transactions {
+				if tx.Hash == hash {
+					return &gqlTransaction{eth: s.eth, tx: tx, block: block, index: idx}
+				}
+			}
+		}
+	}
+
+	if tx, ok := s.eth.store.GetPendingTx(hash); ok {
+		return &gqlTransaction{eth: s.eth, tx: tx}
+	}
+
+	return nil
+}
+
+// getLogTransaction returns the transaction which emitted the log
+func (s *graphQLSchema) getLogTransaction(l *gqlLog) (*gqlTransaction, error) {
+	if l.tx == nil {
+		block := s.getBlockByHash(l.log.BlockHash)
+		if block == nil || int(l.log.TxIndex) >= len(block.block.Transactions) {
+			return nil, fmt.Errorf("transaction of the log not found")
+		}
+
+		index := int(l.log.TxIndex)
+		l.tx = &gqlTransaction{eth: s.eth, tx: block.block.Transactions[index], block: block, index: index}
+	}
+
+	return l.tx, nil
+}
+
+// getLogs returns the logs matching the query
+func (s *graphQLSchema) getLogs(query *LogQuery) ([]*gqlLog, error) {
+	logs, err := s.eth.filterManager.GetLogsForQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*gqlLog, len(logs))
+	for idx, log := range logs {
+		res[idx] = &gqlLog{eth: s.eth, log: log}
+	}
+
+	return res, nil
+}
+
+// getAccount returns the account at the header, or at the block of the number if set
+func (s *graphQLSchema) getAccount(
+	address types.Address,
+	header *types.Header,
+	number interface{},
+) (*gqlAccount, error) {
+	if n, ok := number.(uint64); ok {
+		var err error
+
+		if header, err = s.eth.getBlockHeader(BlockNumber(n)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &gqlAccount{eth: s.eth, address: address, header: header}, nil
+}
+
+// getStorage returns the storage value of the account at the slot
+func (s *graphQLSchema) getStorage(a *gqlAccount, slot types.Hash) (interface{}, error) {
+	result, err := s.eth.store.GetStorage(a.header.StateRoot, a.address, slot)
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return types.ZeroHash, nil
+		}
+
+		return nil, err
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(result)
+	if err != nil {
+		return types.ZeroHash, nil
+	}
+
+	data, err := v.Bytes()
+	if err != nil {
+		return types.ZeroHash, nil
+	}
+
+	return types.BytesToHash(data), nil
+}
+
+// call executes the call at the header, the reverted calls are returned with the status 0
+func (s *graphQLSchema) call(header *types.Header, data interface{}) (*gqlCallResult, error) {
+	tx, err := s.eth.decodeTxn(gqlCallData(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Gas == 0 {
+		tx.Gas = header.GasLimit
+	}
+
+	result, err := s.eth.store.ApplyTxn(header, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &gqlCallResult{
+		data:    result.ReturnValue,
+		gasUsed: result.GasUsed,
+		status:  1,
+	}
+
+	if result.Failed() {
+		res.status = 0
+	}
+
+	return res, nil
+}
+
+// estimateGas estimates the gas of the call at the header
+func (s *graphQLSchema) estimateGas(header *types.Header, data interface{}) (interface{}, error) {
+	number := BlockNumber(header.Number)
+
+	res, err := s.eth.EstimateGas(gqlCallData(data), &number, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, ok := res.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected gas estimation %v", res)
+	}
+
+	return hex.DecodeUint64(encoded)
+}
+
+// gqlBigValue returns the BigInt value, nil values are returned as null
+func gqlBigValue(value *big.Int) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	return argBigPtr(value)
+}
+
+// gqlBytesValue returns the Bytes value, nil values are returned as the empty bytes
+func gqlBytesValue(value []byte) argBytes {
+	if value == nil {
+		return argBytes{}
+	}
+
+	return argBytes(value)
+}
+
+// gqlUint64 parses the Long value, which is a JSON number or a hex or decimal string
+func gqlUint64(value interface{}) (uint64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseUint(v.String(), 10, 64)
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			return hex.DecodeUint64(v)
+		}
+
+		return strconv.ParseUint(v, 10, 64)
+	}
+
+	return 0, fmt.Errorf("invalid Long value %v", value)
+}
+
+// gqlBig parses the BigInt value, which is a JSON number or a hex or decimal string
+func gqlBig(value interface{}) (*big.Int, error) {
+	var (
+		res = new(big.Int)
+		ok  bool
+	)
+
+	switch v := value.(type) {
+	case json.Number:
+		_, ok = res.SetString(v.String(), 10)
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			_, ok = res.SetString(v[2:], 16)
+		} else {
+			_, ok = res.SetString(v, 10)
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("invalid BigInt value %v", value)
+	}
+
+	return res, nil
+}
+
+// gqlBytes parses the Bytes value, which is a hex string
+func gqlBytes(value interface{}) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid Bytes value %v", value)
+	}
+
+	return hex.DecodeHex(str)
+}
+
+// gqlHash parses the Bytes32 value
+func gqlHash(value interface{}) (types.Hash, error) {
+	var hash types.Hash
+
+	str, ok := value.(string)
+	if !ok {
+		return hash, fmt.Errorf("invalid Bytes32 value %v", value)
+	}
+
+	err := hash.UnmarshalText([]byte(str))
+
+	return hash, err
+}
+
+// gqlAddress parses the Address value
+func gqlAddress(value interface{}) (types.Address, error) {
+	var address types.Address
+
+	str, ok := value.(string)
+	if !ok {
+		return address, fmt.Errorf("invalid Address value %v", value)
+	}
+
+	err := address.UnmarshalText([]byte(str))
+
+	return address, err
+}
+
+// gqlLogQuery returns the log query of the FilterCriteria or the BlockFilterCriteria input,
+// the block range is read only for FilterCriteria and defaults to the latest block
+func gqlLogQuery(filter map[string]interface{}, withRange bool) *LogQuery {
+	query := &LogQuery{
+		fromBlock: LatestBlockNumber,
+		toBlock:   LatestBlockNumber,
+	}
+
+	if withRange {
+		if from, ok := filter["fromBlock"].(uint64); ok {
+			query.fromBlock = BlockNumber(from)
+		}
+
+		if to, ok := filter["toBlock"].(uint64); ok {
+			query.toBlock = BlockNumber(to)
+		}
+	}
+
+	addresses, _ := filter["addresses"].([]interface{})
+	for _, raw := range addresses {
+		address, _ := raw.(types.Address)
+		query.Addresses = append(query.Addresses, address)
+	}
+
+	topics, _ := filter["topics"].([]interface{})
+	for _, raw := range topics {
+		set, _ := raw.([]interface{})
+		hashes := make([]types.Hash, len(set))
+
+		for idx, hash := range set {
+			hashes[idx], _ = hash.(types.Hash)
+		}
+
+		query.Topics = append(query.Topics, hashes)
+	}
+
+	return query
+}
+
+// gqlCallData returns the call arguments of the CallData input
+func gqlCallData(value interface{}) *txnArgs {
+	data, _ := value.(map[string]interface{})
+	arg := &txnArgs{}
+
+	if from, ok := data["from"].(types.Address); ok {
+		arg.From = &from
+	}
+
+	if to, ok := data["to"].(types.Address); ok {
+		arg.To = &to
+	}
+
+	if gas, ok := data["gas"].(uint64); ok {
+		arg.Gas = argUintPtr(gas)
+	}
+
+	if gasPrice, ok := data["gasPrice"].(*big.Int); ok {
+		arg.GasPrice = argBytesPtr(gasPrice.Bytes())
+	}
+
+	if value, ok := data["value"].(*big.Int); ok {
+		arg.Value = argBytesPtr(value.Bytes())
+	}
+
+	if input, ok := data["data"].([]byte); ok {
+		arg.Data = argBytesPtr(input)
+	}
+
+	return arg
+}
@@ -0,0 +1,312 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/graphql"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGraphQLHandler(t *testing.T, limits graphql.Limits, access *AccessConfig) *graphQLHandler {
+	t.Helper()
+
+	store := newMockBlockStore()
+	store.add(newTestBlock(0, hash1), newTestBlock(1, hash2))
+
+	block := newTestBlock(2, hash3)
+	block.Header.ParentHash = hash2
+	block.Header.Miner = addr0.Bytes()
+	block.Transactions = []*types.Transaction{
+		{Hash: hash1, From: addr0, To: &addr1, Nonce: 3, Gas: 21000},
+	}
+	store.add(block)
+
+	status := types.ReceiptSuccess
+	store.receipts[hash3] = []*types.Receipt{
+		{
+			Status:            &status,
+			GasUsed:           21000,
+			CumulativeGasUsed: 21000,
+			Logs: []*types.Log{
+				{Address: addr1, Topics: []types.Hash{hash1}, Data: []byte{0x1}},
+				{Address: addr1, Topics: []types.Hash{hash2}},
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	eth.filterManager = NewFilterManager(hclog.NewNullLogger(), store, 1)
+
+	schema := newGraphQLSchema(eth)
+
+	return &graphQLHandler{
+		schema:        schema,
+		querySchema:   &graphql.Schema{Query: schema.Query},
+		limits:        limits,
+		accessControl: newAccessControl(access),
+	}
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestGraphQLHandler(t, graphql.Limits{MaxDepth: 4}, nil)
+
+	cases := []struct {
+		name     string
+		method   string
+		query    string
+		expected string
+	}{
+		{
+			name:   "block with transactions and receipts",
+			method: http.MethodPost,
+			query: `{ block(number: 2) { number hash miner { address } transactionCount
+				transactions { index from { address } to { address } nonce gas status gasUsed logs { index data } } } }`,
+			expected: `{"data":{"block":{"number":2,"hash":"` + hash3.String() + `","miner":{"address":"` +
+				addr0.String() + `"},"transactionCount":1,"transactions":[{"index":0,"from":{"address":"` +
+				addr0.String() + `"},"to":{"address":"` + addr1.String() + `"},"nonce":3,"gas":21000,"status":1,` +
+				`"gasUsed":21000,"logs":[{"index":0,"data":"0x01"},{"index":1,"data":"0x"}]}]}}}`,
+		},
+		{
+			name:     "latest block and missing block",
+			method:   http.MethodGet,
+			query:    `{ latest: block { number parent { number } } missing: block(number: "0x10") { number } }`,
+			expected: `{"data":{"latest":{"number":2,"parent":{"number":1}},"missing":null}}`,
+		},
+		{
+			name:     "transaction by hash",
+			method:   http.MethodPost,
+			query:    `{ transaction(hash: "` + hash1.String() + `") { hash block { number } } }`,
+			expected: `{"data":{"transaction":{"hash":"` + hash1.String() + `","block":{"number":2}}}}`,
+		},
+		{
+			name:   "logs filtered by topics",
+			method: http.MethodPost,
+			query: `{ logs(filter: {fromBlock: 1, topics: [["` + hash2.String() + `"]]}) {
+				account { address } topics transaction { hash } } }`,
+			expected: `{"data":{"logs":[{"account":{"address":"` + addr1.String() + `"},"topics":["` +
+				hash2.String() + `"],"transaction":{"hash":"` + hash1.String() + `"}}]}}`,
+		},
+		{
+			name:     "blocks over the range limit",
+			method:   http.MethodPost,
+			query:    `{ blocks(from: 0) { number } }`,
+			expected: `{"data":{"blocks":null},"errors":[{"message":"block range exceeds limit","path":["blocks"]}]}`,
+		},
+		{
+			name:     "mutation by GET",
+			method:   http.MethodGet,
+			query:    `mutation { sendRawTransaction(data: "0x") }`,
+			expected: `{"data":null,"errors":[{"message":"mutation operations are not supported"}]}`,
+		},
+		{
+			name:     "depth limit",
+			method:   http.MethodPost,
+			query:    `{ block { transactions { block { transactions { hash } } } } }`,
+			expected: `{"data":null,"errors":[{"message":"query depth exceeds limit"}]}`,
+		},
+		{
+			name:     "invalid argument",
+			method:   http.MethodPost,
+			query:    `{ transaction(hash: 1) { hash } }`,
+			expected: `{"data":null,"errors":[{"message":"argument hash on field Query.transaction: invalid Bytes32 value 1"}]}`,
+		},
+		{
+			name:     "missing required argument",
+			method:   http.MethodPost,
+			query:    `{ block { account { balance } } }`,
+			expected: `{"data":null,"errors":[{"message":"argument address on field Block.account is required"}]}`,
+		},
+		{
+			name:   "input type introspection",
+			method: http.MethodGet,
+			query:  `{ __type(name: "CallData") { kind inputFields { name type { name } } } }`,
+			expected: `{"data":{"__type":{"kind":"INPUT_OBJECT","inputFields":[` +
+				`{"name":"data","type":{"name":"Bytes"}},{"name":"from","type":{"name":"Address"}},` +
+				`{"name":"gas","type":{"name":"Long"}},{"name":"gasPrice","type":{"name":"BigInt"}},` +
+				`{"name":"to","type":{"name":"Address"}},{"name":"value","type":{"name":"BigInt"}}]}}}`,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var req *http.Request
+
+			if c.method == http.MethodGet {
+				req = httptest.NewRequest(c.method, "/graphql?query="+url.QueryEscape(c.query), nil)
+			} else {
+				body, err := json.Marshal(&graphql.Request{Query: c.query})
+				assert.NoError(t, err)
+
+				req = httptest.NewRequest(c.method, "/graphql", bytes.NewReader(body))
+			}
+
+			status, resp := handler.handle(httptest.NewRecorder(), req, nil)
+			assert.Equal(t, http.StatusOK, status)
+
+			res, err := json.Marshal(resp)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, string(res))
+		})
+	}
+}
+
+func TestGraphQLHandlerAccessControl(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestGraphQLHandler(t, graphql.Limits{}, &AccessConfig{
+		IPLimit: &RateLimit{Rate: 0.001, Burst: 1},
+	})
+
+	caller := &Caller{IP: "1"}
+	query := "/graphql?query=" + url.QueryEscape("{ chainID }")
+
+	status, resp := handler.handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, query, nil), caller)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, resp.Errors)
+
+	status, resp = handler.handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, query, nil), caller)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Equal(t, "request rate limit exceeded for graphql_query", resp.Errors[0].Message)
+
+	req := httptest.NewRequest(http.MethodPut, "/graphql", strings.NewReader("{}"))
+
+	status, _ = handler.handle(httptest.NewRecorder(), req, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestGraphQLHandlerRequestLimits(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestGraphQLHandler(t, graphql.Limits{}, nil)
+
+	// the body over the size limit isn't read
+	body := `{"query":"` + strings.Repeat(" ", graphQLMaxRequestSize) + `{ chainID }"}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+
+	status, resp := handler.handle(httptest.NewRecorder(), req, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Errors[0].Message, "request body too large")
+
+	// the nesting is limited by the parser
+	query := `{ block(number: ` + strings.Repeat("[", 100000) + `) { number } }`
+	req = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(query), nil)
+
+	status, resp = handler.handle(httptest.NewRecorder(), req, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, graphql.ErrNestingLimitExceeded.Error(), resp.Errors[0].Message)
+}
+
+// introspectionQuery is the introspection query sent by GraphiQL
+const introspectionQuery = `query IntrospectionQuery {
+	__schema {
+		queryType { name }
+		mutationType { name }
+		subscriptionType { name }
+		types { ...FullType }
+		directives { name description locations args { ...InputValue } }
+	}
+}
+fragment FullType on __Type {
+	kind name description
+	fields(includeDeprecated: true) {
+		name description args { ...InputValue } type { ...TypeRef } isDeprecated deprecationReason
+	}
+	inputFields { ...InputValue }
+	interfaces { ...TypeRef }
+	enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+	possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+fragment TypeRef on __Type {
+	kind name
+	ofType { kind name ofType { kind name ofType { kind name ofType { kind name
+		ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } } }
+}`
+
+func TestGraphQLIntrospection(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestGraphQLHandler(t, graphql.Limits{MaxDepth: 12, MaxComplexity: 50000}, nil)
+
+	body, err := json.Marshal(&graphql.Request{Query: introspectionQuery})
+	assert.NoError(t, err)
+
+	status, resp := handler.handle(
+		httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)),
+		nil,
+	)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, resp.Errors)
+
+	raw, err := json.Marshal(resp.Data)
+	assert.NoError(t, err)
+
+	var res struct {
+		Schema struct {
+			QueryType    struct{ Name string }
+			MutationType struct{ Name string }
+			Types        []struct {
+				Kind   string
+				Name   string
+				Fields []struct {
+					Name string
+					Args []struct {
+						Name string
+						Type struct {
+							Kind   string
+							OfType struct{ Name string }
+						}
+					}
+				}
+			}
+		} `json:"__schema"`
+	}
+
+	assert.NoError(t, json.Unmarshal(raw, &res))
+	assert.Equal(t, "Query", res.Schema.QueryType.Name)
+	assert.Equal(t, "Mutation", res.Schema.MutationType.Name)
+
+	kinds := make(map[string]string)
+
+	for _, typ := range res.Schema.Types {
+		kinds[typ.Name] = typ.Kind
+
+		if typ.Name != "Query" {
+			continue
+		}
+
+		for _, field := range typ.Fields {
+			if field.Name == "transaction" {
+				// the required argument is reported as non-null
+				assert.Len(t, field.Args, 1)
+				assert.Equal(t, "hash", field.Args[0].Name)
+				assert.Equal(t, "NON_NULL", field.Args[0].Type.Kind)
+				assert.Equal(t, "Bytes32", field.Args[0].Type.OfType.Name)
+			}
+		}
+	}
+
+	for name, kind := range map[string]string{
+		"Block":          "OBJECT",
+		"Long":           "SCALAR",
+		"FilterCriteria": "INPUT_OBJECT",
+		"__TypeKind":     "ENUM",
+	} {
+		assert.Equal(t, kind, kinds[name], name)
+	}
+}
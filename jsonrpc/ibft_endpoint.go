@@ -0,0 +1,201 @@
+package jsonrpc
+
+import (
+	"fmt"
+
+	ibftProto "github.com/ExzoNetwork/ExzoCoin/consensus/ibft/proto"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+)
+
+// ibftStore provides access to the methods needed by ibft endpoint
+type ibftStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetFinalityProof returns the proof of finality of the block at the given height
+	GetFinalityProof(height uint64) (*signer.FinalityProof, error)
+}
+
+// IBFT is the ibft jsonrpc endpoint
+type IBFT struct {
+	store ibftStore
+}
+
+type finalityProofValidator struct {
+	Address      types.Address `json:"address"`
+	BLSPublicKey argBytes      `json:"blsPublicKey"`
+}
+
+type finalityProof struct {
+	Number     argUint64                 `json:"number"`
+	Hash       types.Hash                `json:"hash"`
+	Message    argBytes                  `json:"message"`
+	Validators []*finalityProofValidator `json:"validators"`
+	Bitmap     argBytes                  `json:"bitmap"`
+	Signers    []types.Address           `json:"signers"`
+	Signature  argBytes                  `json:"signature"`
+	Quorum     argUint64                 `json:"quorum"`
+}
+
+func toFinalityProof(p *signer.FinalityProof) *finalityProof {
+	res := &finalityProof{
+		Number:     argUint64(p.Number),
+		Hash:       p.Hash,
+		Message:    argBytes(p.Message),
+		Validators: make([]*finalityProofValidator, 0, p.Validators.Len()),
+		Bitmap:     argBytes(p.Bitmap.Bytes()),
+		Signers:    p.Signers,
+		Signature:  argBytes(p.Signature),
+		Quorum:     argUint64(p.Quorum),
+	}
+
+	for idx := 0; idx < p.Validators.Len(); idx++ {
+		validator := &finalityProofValidator{
+			Address: p.Validators.At(uint64(idx)).Addr(),
+		}
+
+		if blsValidator, ok := p.Validators.At(uint64(idx)).(*validators.BLSValidator); ok {
+			validator.BLSPublicKey = argBytes(blsValidator.BLSPublicKey)
+		}
+
+		res.Validators = append(res.Validators, validator)
+	}
+
+	return res
+}
+
+// GetFinalityProof returns the aggregated committed seal of the block
+// together with the validator set and the signed message,
+// so that light clients can verify the finality of the block with one pairing check
+func (i *IBFT) GetFinalityProof(number BlockNumber) (interface{}, error) {
+	height, err := i.getHeight(number)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := i.store.GetFinalityProof(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFinalityProof(proof), nil
+}
+
+// getHeight resolves the block number to the height of the committed block
+func (i *IBFT) getHeight(number BlockNumber) (uint64, error) {
+	switch number {
+	case LatestBlockNumber:
+		return i.store.Header().Number, nil
+	case EarliestBlockNumber:
+		return 0, nil
+	case PendingBlockNumber:
+		return 0, fmt.Errorf("fetching the pending header is not supported")
+	default:
+		if number < 0 {
+			return 0, fmt.Errorf("invalid argument 0: block number larger than int64")
+		}
+
+		return uint64(number), nil
+	}
+}
+
+// ibftAdminStore provides access to the methods needed by ibft endpoint of the admin listener
+type ibftAdminStore interface {
+	ibftStore
+
+	// GetIBFTSnapshot returns the validators and the votes at the given height
+	GetIBFTSnapshot(height uint64) (*ibftProto.Snapshot, error)
+
+	// ProposeIBFTCandidate proposes the candidate to be added to / removed from the validator set
+	ProposeIBFTCandidate(candidate *ibftProto.Candidate) error
+}
+
+// IBFTAdmin is the ibft jsonrpc endpoint of the admin listener,
+// which adds the voting methods of the IBFT operator to IBFT
+type IBFTAdmin struct {
+	*IBFT
+
+	adminStore ibftAdminStore
+}
+
+type snapshotValidator struct {
+	Type    string        `json:"type"`
+	Address types.Address `json:"address"`
+	Data    argBytes      `json:"data"`
+}
+
+type snapshotVote struct {
+	Validator types.Address `json:"validator"`
+	Proposed  types.Address `json:"proposed"`
+	Auth      bool          `json:"auth"`
+}
+
+type snapshot struct {
+	Number     argUint64            `json:"number"`
+	Hash       types.Hash           `json:"hash"`
+	Validators []*snapshotValidator `json:"validators"`
+	Votes      []*snapshotVote      `json:"votes"`
+}
+
+func toSnapshot(s *ibftProto.Snapshot) *snapshot {
+	res := &snapshot{
+		Number:     argUint64(s.Number),
+		Hash:       types.StringToHash(s.Hash),
+		Validators: make([]*snapshotValidator, 0, len(s.Validators)),
+		Votes:      make([]*snapshotVote, 0, len(s.Votes)),
+	}
+
+	for _, v := range s.Validators {
+		res.Validators = append(res.Validators, &snapshotValidator{
+			Type:    v.Type,
+			Address: types.StringToAddress(v.Address),
+			Data:    argBytes(v.Data),
+		})
+	}
+
+	for _, v := range s.Votes {
+		res.Votes = append(res.Votes, &snapshotVote{
+			Validator: types.StringToAddress(v.Validator),
+			Proposed:  types.StringToAddress(v.Proposed),
+			Auth:      v.Auth,
+		})
+	}
+
+	return res
+}
+
+// GetSnapshot returns the validators and the votes at the given block
+func (i *IBFTAdmin) GetSnapshot(number BlockNumber) (interface{}, error) {
+	height, err := i.getHeight(number)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := i.adminStore.GetIBFTSnapshot(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSnapshot(s), nil
+}
+
+// Propose votes for adding (auth = true) or removing (auth = false) the candidate from the validator set.
+// The BLS public key is required to add a candidate to the BLS validator set
+func (i *IBFTAdmin) Propose(address types.Address, auth bool, blsPublicKey *argBytes) (interface{}, error) {
+	candidate := &ibftProto.Candidate{
+		Address: address.String(),
+		Auth:    auth,
+	}
+
+	if blsPublicKey != nil {
+		candidate.BlsPubkey = *blsPublicKey
+	}
+
+	if err := i.adminStore.ProposeIBFTCandidate(candidate); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
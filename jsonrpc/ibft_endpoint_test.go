@@ -0,0 +1,92 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/ExzoNetwork/ExzoCoin/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIBFTStore struct {
+	header *types.Header
+	proofs map[uint64]*signer.FinalityProof
+}
+
+func (m *mockIBFTStore) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockIBFTStore) GetFinalityProof(height uint64) (*signer.FinalityProof, error) {
+	proof, ok := m.proofs[height]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return proof, nil
+}
+
+func TestIBFTEndpointGetFinalityProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+
+		proof = &signer.FinalityProof{
+			Number:  5,
+			Hash:    types.StringToHash("5"),
+			Message: []byte{0x1, 0x2},
+			Validators: validators.NewBLSValidatorSet(
+				validators.NewBLSValidator(addr1, []byte{0x11}),
+				validators.NewBLSValidator(addr2, []byte{0x22}),
+			),
+			Bitmap:    big.NewInt(0x2),
+			Signers:   []types.Address{addr2},
+			Signature: []byte{0x3},
+			Quorum:    1,
+		}
+
+		endpoint = &IBFT{
+			store: &mockIBFTStore{
+				header: &types.Header{Number: 5},
+				proofs: map[uint64]*signer.FinalityProof{5: proof},
+			},
+		}
+
+		expected = &finalityProof{
+			Number:  argUint64(5),
+			Hash:    types.StringToHash("5"),
+			Message: argBytes{0x1, 0x2},
+			Validators: []*finalityProofValidator{
+				{Address: addr1, BLSPublicKey: argBytes{0x11}},
+				{Address: addr2, BLSPublicKey: argBytes{0x22}},
+			},
+			Bitmap:    argBytes{0x2},
+			Signers:   []types.Address{addr2},
+			Signature: argBytes{0x3},
+			Quorum:    argUint64(1),
+		}
+	)
+
+	res, err := endpoint.GetFinalityProof(BlockNumber(5))
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	res, err = endpoint.GetFinalityProof(LatestBlockNumber)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	_, err = endpoint.GetFinalityProof(BlockNumber(6))
+
+	assert.Error(t, err)
+
+	_, err = endpoint.GetFinalityProof(PendingBlockNumber)
+
+	assert.Error(t, err)
+}
@@ -1,17 +1,20 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 )
 
+// adminMaxRequestSize is the max size of the body of the requests to the admin listener
+const adminMaxRequestSize = 5 * 1024 * 1024
+
 type serverType int
 
 const (
@@ -35,15 +38,17 @@ func (s serverType) String() string {
 
 // JSONRPC is an API consensus
 type JSONRPC struct {
-	logger     hclog.Logger
-	config     *Config
-	dispatcher dispatcher
+	logger          hclog.Logger
+	config          *Config
+	dispatcher      dispatcher
+	adminDispatcher dispatcher
+	graphQL         *graphQLHandler
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(reqBody []byte, conn wsConn, caller *Caller) ([]byte, error)
+	Handle(reqBody []byte, caller *Caller) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -53,6 +58,25 @@ type JSONRPCStore interface {
 	networkStore
 	txPoolStore
 	filterManagerStore
+	ibftStore
+	exzoStore
+}
+
+// JSONRPCAdminStore defines all the methods required
+// by the JSON RPC endpoints of the admin listener
+type JSONRPCAdminStore interface {
+	adminStore
+	txPoolAdminStore
+	ibftAdminStore
+	debugStore
+}
+
+// AdminConfig is the configuration of the admin listener,
+// which serves the node management endpoints to the callers authenticated by JWT
+type AdminConfig struct {
+	Store     JSONRPCAdminStore
+	Addr      *net.TCPAddr
+	JWTSecret []byte
 }
 
 type Config struct {
@@ -63,15 +87,38 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	Access                   *AccessConfig
+	Admin                    *AdminConfig
+	GraphQL                  *GraphQLConfig
+	WebSocket                *WebSocketConfig
+	Metrics                  *Metrics
 }
 
 // NewJSONRPC returns the JSONRPC http server
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	if config.WebSocket == nil {
+		config.WebSocket = DefaultWebSocketConfig()
+	}
+
+	if config.Metrics == nil {
+		config.Metrics = NilMetrics()
+	}
+
+	d := newDispatcher(logger, config.Store, config.ChainID, config.PriceLimit,
+		config.BatchLengthLimit, config.BlockRangeLimit, config.Access)
+
 	srv := &JSONRPC{
-		logger: logger.Named("jsonrpc"),
-		config: config,
-		dispatcher: newDispatcher(logger, config.Store, config.ChainID, config.PriceLimit,
-			config.BatchLengthLimit, config.BlockRangeLimit),
+		logger:     logger.Named("jsonrpc"),
+		config:     config,
+		dispatcher: d,
+	}
+
+	if config.GraphQL != nil {
+		srv.graphQL = newGraphQLHandler(d, config.GraphQL)
+	}
+
+	if config.Admin != nil {
+		srv.adminDispatcher = newAdminDispatcher(logger, config.Admin.Store, config.ChainID, config.BatchLengthLimit)
 	}
 
 	// start http server
@@ -79,6 +126,12 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	if config.Admin != nil {
+		if err := srv.setupAdminHTTP(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
@@ -90,7 +143,7 @@ func (j *JSONRPC) setupHTTP() error {
 		return err
 	}
 
-	mux := http.DefaultServeMux
+	mux := http.NewServeMux()
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
@@ -98,6 +151,10 @@ func (j *JSONRPC) setupHTTP() error {
 
 	mux.HandleFunc("/ws", j.handleWs)
 
+	if j.graphQL != nil {
+		mux.Handle("/graphql", middlewareFactory(j.config)(http.HandlerFunc(j.handleGraphQL)))
+	}
+
 	srv := http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 60 * time.Second,
@@ -112,6 +169,37 @@ func (j *JSONRPC) setupHTTP() error {
 	return nil
 }
 
+// setupAdminHTTP starts the admin listener, which requires the requests to carry
+// a JWT token signed by the shared secret, same as the engine API of Ethereum
+func (j *JSONRPC) setupAdminHTTP() error {
+	if len(j.config.Admin.JWTSecret) != JWTSecretLength {
+		return ErrInvalidJWTSecret
+	}
+
+	j.logger.Info("admin http server started", "addr", j.config.Admin.Addr.String())
+
+	lis, err := net.Listen("tcp", j.config.Admin.Addr.String())
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", jwtAuthHandler(j.config.Admin.JWTSecret, http.HandlerFunc(j.handleAdmin)))
+
+	srv := http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			j.logger.Error("closed admin http connection", "err", err)
+		}
+	}()
+
+	return nil
+}
+
 // The middlewareFactory builds a middleware which enables CORS using the provided config.
 func middlewareFactory(config *Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -146,38 +234,6 @@ var wsUpgrader = websocket.Upgrader{
 	WriteBufferSize: 1024,
 }
 
-// wsWrapper is a wrapping object for the web socket connection and logger
-type wsWrapper struct {
-	sync.Mutex
-
-	ws       *websocket.Conn // the actual WS connection
-	logger   hclog.Logger    // module logger
-	filterID string          // filter ID
-}
-
-func (w *wsWrapper) SetFilterID(filterID string) {
-	w.filterID = filterID
-}
-
-func (w *wsWrapper) GetFilterID() string {
-	return w.filterID
-}
-
-// WriteMessage writes out the message to the WS peer
-func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
-	w.Lock()
-	defer w.Unlock()
-	writeErr := w.ws.WriteMessage(messageType, data)
-
-	if writeErr != nil {
-		w.logger.Error(
-			fmt.Sprintf("Unable to write WS message, %s", writeErr.Error()),
-		)
-	}
-
-	return writeErr
-}
-
 // isSupportedWSType returns a status indicating if the message type is supported
 func isSupportedWSType(messageType int) bool {
 	return messageType == websocket.TextMessage ||
@@ -196,23 +252,20 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// Defer WS closure
-	defer func(ws *websocket.Conn) {
-		err = ws.Close()
-		if err != nil {
-			j.logger.Error(
-				fmt.Sprintf("Unable to gracefully close WS connection, %s", err.Error()),
-			)
-		}
-	}(ws)
+	wrapConn := newWSWrapper(ws, j.logger, j.config.WebSocket, j.config.Metrics)
+	defer wrapConn.close()
 
-	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	caller := j.newCaller(req)
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
 	for {
 		// Read the incoming message
 		msgType, message, err := ws.ReadMessage()
+		if err == nil {
+			err = wrapConn.extendReadDeadline()
+		}
+
 		if err != nil {
 			if websocket.IsCloseError(err,
 				websocket.CloseGoingAway,
@@ -233,7 +286,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, caller)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -249,12 +302,19 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// newCaller returns the caller of the request which the access control applies to
+func (j *JSONRPC) newCaller(req *http.Request) *Caller {
+	trustForwardedFor := j.config.Access != nil && j.config.Access.TrustForwardedFor
+
+	return newCaller(req, trustForwardedFor)
+}
+
 func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
 	w.Header().Set(
 		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+APIKeyHeader,
 	)
 
 	if (*req).Method == "OPTIONS" {
@@ -284,7 +344,7 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(data, j.newCaller(req))
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -294,3 +354,62 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 
 	j.logger.Debug("handle", "response", string(resp))
 }
+
+// handleAdmin handles the requests of the admin listener authenticated by the JWT handler
+func (j *JSONRPC) handleAdmin(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if req.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, adminMaxRequestSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	j.logger.Debug("handle admin", "request", string(data))
+
+	resp, err := j.adminDispatcher.Handle(data, nil)
+	if err != nil {
+		_, _ = w.Write([]byte(err.Error()))
+	} else {
+		_, _ = w.Write(resp)
+	}
+
+	j.logger.Debug("handle admin", "response", string(resp))
+}
+
+// handleGraphQL handles the GraphQL queries sent by POST as JSON, or by GET in the query parameters
+func (j *JSONRPC) handleGraphQL(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set(
+		"Access-Control-Allow-Headers",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+APIKeyHeader,
+	)
+
+	if req.Method == "OPTIONS" {
+		return
+	}
+
+	status, resp := j.graphQL.handle(w, req, j.newCaller(req))
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+
+	j.logger.Debug("handle graphql", "response", string(data))
+}
@@ -0,0 +1,191 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// JWTSecretLength is the length of the HS256 secret in bytes
+	JWTSecretLength = 32
+
+	// jwtIssuedAtTolerance is the max difference between the iat claim and the local time,
+	// same as the engine API of Ethereum
+	jwtIssuedAtTolerance = 60 * time.Second
+
+	// jwtAuthorizationType is the prefix of the token in the Authorization header
+	jwtAuthorizationType = "Bearer "
+)
+
+var (
+	ErrMissingJWT       = errors.New("missing JWT token")
+	ErrInvalidJWT       = errors.New("invalid JWT token")
+	ErrInvalidJWTAlg    = errors.New("invalid JWT algorithm, only HS256 is supported")
+	ErrInvalidJWTSig    = errors.New("invalid JWT signature")
+	ErrMissingJWTIat    = errors.New("missing JWT issued-at claim")
+	ErrStaleJWT         = errors.New("stale JWT token")
+	ErrInvalidJWTSecret = errors.New("invalid JWT secret")
+)
+
+// jwtBase64Encoding is the encoding of the parts of the token
+var jwtBase64Encoding = base64.RawURLEncoding
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+type jwtClaims struct {
+	IssuedAt *int64 `json:"iat"`
+}
+
+// verifyJWT verifies the HS256 signature of the token with the secret
+// and checks that the token was issued within the tolerance of the given time
+func verifyJWT(secret []byte, token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidJWT
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return err
+	}
+
+	if header.Alg != "HS256" {
+		return ErrInvalidJWTAlg
+	}
+
+	signature, err := jwtBase64Encoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidJWT
+	}
+
+	if !hmac.Equal(signature, signJWT(secret, parts[0]+"."+parts[1])) {
+		return ErrInvalidJWTSig
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return err
+	}
+
+	if claims.IssuedAt == nil {
+		return ErrMissingJWTIat
+	}
+
+	diff := now.Sub(time.Unix(*claims.IssuedAt, 0))
+	if diff > jwtIssuedAtTolerance || diff < -jwtIssuedAtTolerance {
+		return ErrStaleJWT
+	}
+
+	return nil
+}
+
+// NewJWT returns the HS256 token issued at the given time, signed by the secret
+func NewJWT(secret []byte, issuedAt time.Time) (string, error) {
+	header, err := json.Marshal(&jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	iat := issuedAt.Unix()
+
+	claims, err := json.Marshal(&jwtClaims{IssuedAt: &iat})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtBase64Encoding.EncodeToString(header) + "." + jwtBase64Encoding.EncodeToString(claims)
+
+	return unsigned + "." + jwtBase64Encoding.EncodeToString(signJWT(secret, unsigned)), nil
+}
+
+func signJWT(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return mac.Sum(nil)
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	raw, err := jwtBase64Encoding.DecodeString(part)
+	if err != nil {
+		return ErrInvalidJWT
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return ErrInvalidJWT
+	}
+
+	return nil
+}
+
+// jwtAuthHandler rejects the requests without a valid token in the Authorization header
+func jwtAuthHandler(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := verifyRequestJWT(secret, r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func verifyRequestJWT(secret []byte, r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, jwtAuthorizationType) {
+		return ErrMissingJWT
+	}
+
+	return verifyJWT(secret, strings.TrimPrefix(auth, jwtAuthorizationType), time.Now())
+}
+
+// LoadJWTSecret reads the hex encoded secret from the file,
+// and generates the file with a random secret if it doesn't exist
+func LoadJWTSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return generateJWTSecret(path)
+	} else if err != nil {
+		return nil, err
+	}
+
+	secret, err := hex.DecodeString(strings.TrimPrefix(string(bytes.TrimSpace(data)), "0x"))
+	if err != nil || len(secret) != JWTSecretLength {
+		return nil, fmt.Errorf("%w in %s, expected %d bytes in hex", ErrInvalidJWTSecret, path, JWTSecretLength)
+	}
+
+	return secret, nil
+}
+
+func generateJWTSecret(path string) ([]byte, error) {
+	secret := make([]byte, JWTSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(secret)), 0600); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,107 @@
+package jsonrpc
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyJWT(t *testing.T) {
+	t.Parallel()
+
+	var (
+		secret = make([]byte, JWTSecretLength)
+		now    = time.Unix(1700000000, 0)
+	)
+
+	signed, err := NewJWT(secret, now)
+	assert.NoError(t, err)
+
+	stale, err := NewJWT(secret, now.Add(-2*jwtIssuedAtTolerance))
+	assert.NoError(t, err)
+
+	otherSecret, err := NewJWT([]byte("other"), now)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"valid", signed, nil},
+		{"stale", stale, ErrStaleJWT},
+		{"wrong secret", otherSecret, ErrInvalidJWTSig},
+		{"malformed", "abc", ErrInvalidJWT},
+		{
+			// {"alg":"none"}.{"iat":1700000000}.
+			"alg none",
+			"eyJhbGciOiJub25lIn0.eyJpYXQiOjE3MDAwMDAwMDB9.",
+			ErrInvalidJWTAlg,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, verifyJWT(secret, c.token, now), c.err)
+		})
+	}
+}
+
+func TestJWTAuthHandler(t *testing.T) {
+	t.Parallel()
+
+	secret := make([]byte, JWTSecretLength)
+	handler := jwtAuthHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	token, err := NewJWT(secret, time.Now())
+	assert.NoError(t, err)
+
+	for auth, status := range map[string]int{
+		"":                http.StatusUnauthorized,
+		"Bearer abc":      http.StatusUnauthorized,
+		token:             http.StatusUnauthorized,
+		"Bearer " + token: http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, status, rec.Code, auth)
+	}
+}
+
+func TestLoadJWTSecret(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+
+	// generated on the first load
+	secret, err := LoadJWTSecret(path)
+	assert.NoError(t, err)
+	assert.Len(t, secret, JWTSecretLength)
+
+	loaded, err := LoadJWTSecret(path)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, loaded)
+
+	assert.NoError(t, os.WriteFile(path, []byte("0x"+hex.EncodeToString(secret[:16])), 0600))
+
+	_, err = LoadJWTSecret(path)
+	assert.ErrorIs(t, err, ErrInvalidJWTSecret)
+}
@@ -0,0 +1,49 @@
+package jsonrpc
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics represents the jsonrpc metrics
+type Metrics struct {
+	// Active subscriptions of the websocket connections
+	WSSubscriptions metrics.Gauge
+
+	// Subscription notifications dropped for the slow websocket consumers
+	WSDroppedMessages metrics.Counter
+}
+
+// GetPrometheusMetrics return the jsonrpc metrics instance
+func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics {
+	labels := []string{}
+
+	for i := 0; i < len(labelsWithValues); i += 2 {
+		labels = append(labels, labelsWithValues[i])
+	}
+
+	return &Metrics{
+		WSSubscriptions: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "jsonrpc",
+			Name:      "ws_subscriptions",
+			Help:      "Active subscriptions of the websocket connections",
+		}, labels).With(labelsWithValues...),
+		WSDroppedMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "jsonrpc",
+			Name:      "ws_dropped_messages",
+			Help:      "Subscription notifications dropped for the slow websocket consumers",
+		}, labels).With(labelsWithValues...),
+	}
+}
+
+// NilMetrics will return the non operational jsonrpc metrics
+func NilMetrics() *Metrics {
+	return &Metrics{
+		WSSubscriptions:   discard.NewGauge(),
+		WSDroppedMessages: discard.NewCounter(),
+	}
+}
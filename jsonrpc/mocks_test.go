@@ -123,6 +123,14 @@ func (m *mockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 	return nil, false
 }
 
+func (m *mockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
+	if num != m.header.Number {
+		return nil, false
+	}
+
+	return m.header, true
+}
+
 func (m *mockStore) GetTxs(inclQueued bool) (
 	map[types.Address][]*types.Transaction,
 	map[types.Address][]*types.Transaction,
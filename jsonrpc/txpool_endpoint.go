@@ -21,6 +21,25 @@ type TxPool struct {
 	store txPoolStore
 }
 
+// txPoolAdminStore provides access to the methods needed by txpool endpoint of the admin listener
+type txPoolAdminStore interface {
+	txPoolStore
+
+	// DropTx removes the transaction and the following transactions of its sender from the pool
+	DropTx(hash types.Hash) []types.Hash
+
+	// DropAccount removes all transactions of the account from the pool
+	DropAccount(addr types.Address) []types.Hash
+}
+
+// TxPoolAdmin is the txpool jsonrpc endpoint of the admin listener,
+// which adds the methods removing transactions from the pool to TxPool
+type TxPoolAdmin struct {
+	*TxPool
+
+	adminStore txPoolAdminStore
+}
+
 type ContentResponse struct {
 	Pending map[types.Address]map[uint64]*txpoolTransaction `json:"pending"`
 	Queued  map[types.Address]map[uint64]*txpoolTransaction `json:"queued"`
@@ -175,3 +194,25 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// DropTransaction removes the transaction with the given hash from the pool,
+// together with the transactions of its sender with higher nonces,
+// and returns the hashes of the removed transactions
+func (t *TxPoolAdmin) DropTransaction(hash types.Hash) (interface{}, error) {
+	return toDroppedHashes(t.adminStore.DropTx(hash)), nil
+}
+
+// DropAccount removes all transactions of the given account from the pool
+// and returns the hashes of the removed transactions
+func (t *TxPoolAdmin) DropAccount(address types.Address) (interface{}, error) {
+	return toDroppedHashes(t.adminStore.DropAccount(address)), nil
+}
+
+// toDroppedHashes returns the hashes encoded as an empty list instead of null
+func toDroppedHashes(hashes []types.Hash) []types.Hash {
+	if hashes == nil {
+		return []types.Hash{}
+	}
+
+	return hashes
+}
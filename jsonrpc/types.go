@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
+	"github.com/ExzoNetwork/ExzoCoin/state"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 )
 
@@ -171,6 +172,44 @@ type receipt struct {
 	ToAddr            *types.Address `json:"to"`
 }
 
+func toReceipt(raw *types.Receipt, txn *types.Transaction, txIndex int, header *types.Header) *receipt {
+	logs := make([]*Log, len(raw.Logs))
+	for logIndex, elem := range raw.Logs {
+		logs[logIndex] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   header.Hash,
+			BlockNumber: argUint64(header.Number),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(logIndex),
+			Removed:     false,
+		}
+	}
+
+	res := &receipt{
+		Root:              raw.Root,
+		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+		LogsBloom:         raw.LogsBloom,
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         header.Hash,
+		BlockNumber:       argUint64(header.Number),
+		GasUsed:           argUint64(raw.GasUsed),
+		ContractAddress:   raw.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+	}
+
+	if raw.Status != nil {
+		res.Status = argUint64(*raw.Status)
+	}
+
+	return res
+}
+
 type Log struct {
 	Address     types.Address `json:"address"`
 	Topics      []types.Hash  `json:"topics"`
@@ -293,6 +332,33 @@ func encodeToHex(b []byte) []byte {
 }
 
 // txnArgs is the transaction argument for the rpc endpoints
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, len(list))
+	for i, b := range list {
+		res[i] = argBytes(b)
+	}
+
+	return res
+}
+
+// accountProof is the proof of the account and its storage defined in EIP-1186
+type accountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []argBytes      `json:"accountProof"`
+	Balance      argBig          `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        argUint64       `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []*storageProof `json:"storageProof"`
+}
+
+// storageProof is the proof of the storage value in the account storage trie
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
 type txnArgs struct {
 	From     *types.Address
 	To       *types.Address
@@ -304,6 +370,85 @@ type txnArgs struct {
 	Nonce    *argUint64
 }
 
+// overrideAccount is the override of the account in the state override set of the call
+type overrideAccount struct {
+	Nonce     *argUint64                 `json:"nonce"`
+	Code      *argBytes                  `json:"code"`
+	Balance   *argBig                    `json:"balance"`
+	State     *map[types.Hash]types.Hash `json:"state"`
+	StateDiff *map[types.Hash]types.Hash `json:"stateDiff"`
+}
+
+// stateOverride is the state override set of the call
+type stateOverride map[types.Address]overrideAccount
+
+func (s *stateOverride) toStateOverride() state.StateOverride {
+	if s == nil {
+		return nil
+	}
+
+	res := make(state.StateOverride, len(*s))
+
+	for addr, account := range *s {
+		override := state.Override{}
+
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			override.Nonce = &nonce
+		}
+
+		if account.Code != nil {
+			override.Code = []byte(*account.Code)
+		}
+
+		if account.Balance != nil {
+			override.Balance = new(big.Int).Set((*big.Int)(account.Balance))
+		}
+
+		if account.State != nil {
+			override.State = *account.State
+		}
+
+		if account.StateDiff != nil {
+			override.StateDiff = *account.StateDiff
+		}
+
+		res[addr] = override
+	}
+
+	return res
+}
+
+// blockOverride is the override of the block context of the call
+type blockOverride struct {
+	Number   *argUint64     `json:"number"`
+	Time     *argUint64     `json:"time"`
+	Coinbase *types.Address `json:"coinbase"`
+	GasLimit *argUint64     `json:"gasLimit"`
+}
+
+func (b *blockOverride) toBlockOverride() *state.BlockOverride {
+	if b == nil {
+		return nil
+	}
+
+	return &state.BlockOverride{
+		Number:    (*uint64)(b.Number),
+		Timestamp: (*uint64)(b.Time),
+		Coinbase:  b.Coinbase,
+		GasLimit:  (*uint64)(b.GasLimit),
+	}
+}
+
+// gasLimit returns the block gas limit of the call, taking the override into account
+func (b *blockOverride) gasLimit(header *types.Header) uint64 {
+	if b != nil && b.GasLimit != nil {
+		return uint64(*b.GasLimit)
+	}
+
+	return header.GasLimit
+}
+
 type progression struct {
 	Type          string `json:"type"`
 	StartingBlock string `json:"startingBlock"`
@@ -0,0 +1,245 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultWSSendQueueSize is the default number of the outgoing messages buffered per WS connection
+	DefaultWSSendQueueSize = 256
+
+	// DefaultWSPingInterval is the default interval of the keepalive pings of the WS connections
+	DefaultWSPingInterval = 30 * time.Second
+
+	// wsWriteTimeout is the time allowed to write a message to the WS peer,
+	// and to queue a response while the send queue is full
+	wsWriteTimeout = 10 * time.Second
+)
+
+var (
+	ErrWSSendQueueFull = errors.New("websocket send queue is full")
+)
+
+// WebSocketConfig is the configuration of the connections of the WS endpoint
+type WebSocketConfig struct {
+	// SendQueueSize is the number of the outgoing messages buffered per connection
+	SendQueueSize int
+
+	// DisconnectSlowConsumers closes the connections whose send queue is full,
+	// otherwise the subscription notifications which don't fit in the queue are dropped
+	DisconnectSlowConsumers bool
+
+	// PingInterval is the interval of the keepalive pings, the connection is closed
+	// if the peer doesn't respond within twice the interval. Zero disables the keepalive
+	PingInterval time.Duration
+}
+
+// DefaultWebSocketConfig returns the default configuration of the WS connections
+func DefaultWebSocketConfig() *WebSocketConfig {
+	return &WebSocketConfig{
+		SendQueueSize: DefaultWSSendQueueSize,
+		PingInterval:  DefaultWSPingInterval,
+	}
+}
+
+// wsMessage is an outgoing message in the send queue of a WS connection
+type wsMessage struct {
+	messageType int
+	data        []byte
+}
+
+// wsWrapper is a wrapping object for the web socket connection and logger.
+// The messages are written by a single writer routine from a bounded send queue,
+// so that a slow peer never blocks the senders
+type wsWrapper struct {
+	ws      *websocket.Conn  // the actual WS connection
+	logger  hclog.Logger     // module logger
+	config  *WebSocketConfig // connection configuration
+	metrics *Metrics         // WS metrics
+
+	sendCh    chan wsMessage
+	closeCh   chan struct{}
+	closeOnce sync.Once
+
+	subscriptionsLock sync.Mutex
+	subscriptions     map[string]struct{} // IDs of the subscriptions of the connection
+}
+
+// newWSWrapper wraps the connection and starts its writer routine
+func newWSWrapper(ws *websocket.Conn, logger hclog.Logger, config *WebSocketConfig, metrics *Metrics) *wsWrapper {
+	w := &wsWrapper{
+		ws:            ws,
+		logger:        logger,
+		config:        config,
+		metrics:       metrics,
+		sendCh:        make(chan wsMessage, config.SendQueueSize),
+		closeCh:       make(chan struct{}),
+		subscriptions: make(map[string]struct{}),
+	}
+
+	if config.PingInterval > 0 {
+		// the connection is dropped if it's silent for two ping intervals,
+		// any pong or message from the peer extends the deadline
+		_ = ws.SetReadDeadline(time.Now().Add(2 * config.PingInterval))
+
+		ws.SetPongHandler(func(string) error {
+			return w.extendReadDeadline()
+		})
+	}
+
+	go w.writeLoop()
+
+	return w
+}
+
+// extendReadDeadline postpones the read deadline of the connection if the keepalive is enabled
+func (w *wsWrapper) extendReadDeadline() error {
+	if w.config.PingInterval == 0 {
+		return nil
+	}
+
+	return w.ws.SetReadDeadline(time.Now().Add(2 * w.config.PingInterval))
+}
+
+// AddSubscription adds the subscription to the connection
+func (w *wsWrapper) AddSubscription(id string) {
+	w.subscriptionsLock.Lock()
+	defer w.subscriptionsLock.Unlock()
+
+	if _, ok := w.subscriptions[id]; ok {
+		return
+	}
+
+	w.subscriptions[id] = struct{}{}
+	w.metrics.WSSubscriptions.Add(1)
+}
+
+// RemoveSubscription removes the subscription from the connection
+func (w *wsWrapper) RemoveSubscription(id string) {
+	w.subscriptionsLock.Lock()
+	defer w.subscriptionsLock.Unlock()
+
+	if _, ok := w.subscriptions[id]; !ok {
+		return
+	}
+
+	delete(w.subscriptions, id)
+	w.metrics.WSSubscriptions.Add(-1)
+}
+
+// GetSubscriptions returns the IDs of the subscriptions of the connection
+func (w *wsWrapper) GetSubscriptions() []string {
+	w.subscriptionsLock.Lock()
+	defer w.subscriptionsLock.Unlock()
+
+	ids := make([]string, 0, len(w.subscriptions))
+	for id := range w.subscriptions {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// WriteMessage queues the response to the WS peer. If the send queue stays full
+// for the write timeout, the peer is considered stuck and the connection is closed
+func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
+	timer := time.NewTimer(wsWriteTimeout)
+	defer timer.Stop()
+
+	select {
+	case w.sendCh <- wsMessage{messageType: messageType, data: data}:
+		return nil
+	case <-w.closeCh:
+		return websocket.ErrCloseSent
+	case <-timer.C:
+		w.logger.Warn("Closing WS connection, unable to queue the response")
+		w.close()
+
+		return websocket.ErrCloseSent
+	}
+}
+
+// Notify queues the subscription notification to the WS peer without blocking.
+// If the send queue is full, the notification is dropped, or the connection
+// is closed if the slow consumers are disconnected
+func (w *wsWrapper) Notify(data []byte) error {
+	select {
+	case <-w.closeCh:
+		return websocket.ErrCloseSent
+	default:
+	}
+
+	select {
+	case w.sendCh <- wsMessage{messageType: websocket.TextMessage, data: data}:
+		return nil
+	default:
+	}
+
+	w.metrics.WSDroppedMessages.Add(1)
+
+	if w.config.DisconnectSlowConsumers {
+		w.logger.Warn("Closing WS connection of a slow consumer")
+		w.close()
+
+		return websocket.ErrCloseSent
+	}
+
+	return ErrWSSendQueueFull
+}
+
+// writeLoop writes out the queued messages and the keepalive pings to the WS peer
+func (w *wsWrapper) writeLoop() {
+	var pingCh <-chan time.Time
+
+	if w.config.PingInterval > 0 {
+		ticker := time.NewTicker(w.config.PingInterval)
+		defer ticker.Stop()
+
+		pingCh = ticker.C
+	}
+
+	for {
+		select {
+		case msg := <-w.sendCh:
+			_ = w.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+
+			if err := w.ws.WriteMessage(msg.messageType, msg.data); err != nil {
+				w.logger.Error(fmt.Sprintf("Unable to write WS message, %s", err.Error()))
+				w.close()
+
+				return
+			}
+
+		case <-pingCh:
+			deadline := time.Now().Add(wsWriteTimeout)
+
+			if err := w.ws.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				w.logger.Error(fmt.Sprintf("Unable to write WS ping, %s", err.Error()))
+				w.close()
+
+				return
+			}
+
+		case <-w.closeCh:
+			return
+		}
+	}
+}
+
+// close stops the writer routine and closes the underlying connection,
+// which makes the pending read of the connection fail
+func (w *wsWrapper) close() {
+	w.closeOnce.Do(func() {
+		close(w.closeCh)
+
+		if err := w.ws.Close(); err != nil {
+			w.logger.Error(fmt.Sprintf("Unable to gracefully close WS connection, %s", err.Error()))
+		}
+	})
+}
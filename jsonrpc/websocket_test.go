@@ -0,0 +1,219 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockMetric is a gauge and a counter which keeps its value
+type mockMetric struct {
+	sync.Mutex
+	value float64
+}
+
+func (m *mockMetric) With(...string) metrics.Gauge {
+	return m
+}
+
+func (m *mockMetric) Set(value float64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.value = value
+}
+
+func (m *mockMetric) Add(delta float64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.value += delta
+}
+
+func (m *mockMetric) Value() float64 {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.value
+}
+
+// mockCounter exposes the counter interface of mockMetric
+type mockCounter struct {
+	*mockMetric
+}
+
+func (m mockCounter) With(...string) metrics.Counter {
+	return m
+}
+
+// newTestWSConns returns the server and the client side of a WS connection
+func newTestWSConns(t *testing.T) (*websocket.Conn, *websocket.Conn) {
+	t.Helper()
+
+	connCh := make(chan *websocket.Conn, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		assert.NoError(t, err)
+
+		connCh <- conn
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return <-connCh, client
+}
+
+// newTestWSWrapper wraps the connection without starting the writer routine,
+// so the queued messages stay in the send queue
+func newTestWSWrapper(ws *websocket.Conn, config *WebSocketConfig) (*wsWrapper, *mockMetric, *mockMetric) {
+	subscriptions, dropped := &mockMetric{}, &mockMetric{}
+
+	return &wsWrapper{
+		ws:            ws,
+		logger:        hclog.NewNullLogger(),
+		config:        config,
+		metrics:       &Metrics{WSSubscriptions: subscriptions, WSDroppedMessages: mockCounter{dropped}},
+		sendCh:        make(chan wsMessage, config.SendQueueSize),
+		closeCh:       make(chan struct{}),
+		subscriptions: make(map[string]struct{}),
+	}, subscriptions, dropped
+}
+
+func TestWSWrapper_Subscriptions(t *testing.T) {
+	t.Parallel()
+
+	w, subscriptions, _ := newTestWSWrapper(nil, &WebSocketConfig{})
+
+	w.AddSubscription("a")
+	w.AddSubscription("b")
+	w.AddSubscription("b")
+
+	assert.ElementsMatch(t, []string{"a", "b"}, w.GetSubscriptions())
+	assert.Equal(t, float64(2), subscriptions.Value())
+
+	w.RemoveSubscription("a")
+	w.RemoveSubscription("c")
+
+	assert.Equal(t, []string{"b"}, w.GetSubscriptions())
+	assert.Equal(t, float64(1), subscriptions.Value())
+}
+
+func TestWSWrapper_SlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drops the notifications which don't fit in the queue", func(t *testing.T) {
+		t.Parallel()
+
+		w, _, dropped := newTestWSWrapper(nil, &WebSocketConfig{SendQueueSize: 2})
+
+		assert.NoError(t, w.Notify([]byte("1")))
+		assert.NoError(t, w.Notify([]byte("2")))
+		assert.ErrorIs(t, w.Notify([]byte("3")), ErrWSSendQueueFull)
+
+		assert.Len(t, w.sendCh, 2)
+		assert.Equal(t, float64(1), dropped.Value())
+	})
+
+	t.Run("disconnects the slow consumer", func(t *testing.T) {
+		t.Parallel()
+
+		ws, _ := newTestWSConns(t)
+		w, _, dropped := newTestWSWrapper(ws, &WebSocketConfig{SendQueueSize: 1, DisconnectSlowConsumers: true})
+
+		assert.NoError(t, w.Notify([]byte("1")))
+		assert.ErrorIs(t, w.Notify([]byte("2")), websocket.ErrCloseSent)
+		assert.Equal(t, float64(1), dropped.Value())
+
+		// the connection is closed for any further message
+		assert.ErrorIs(t, w.Notify([]byte("3")), websocket.ErrCloseSent)
+		assert.ErrorIs(t, w.WriteMessage(websocket.TextMessage, []byte("4")), websocket.ErrCloseSent)
+	})
+}
+
+func TestWSWrapper_WriteLoop(t *testing.T) {
+	t.Parallel()
+
+	ws, client := newTestWSConns(t)
+	w := newWSWrapper(ws, hclog.NewNullLogger(), &WebSocketConfig{
+		SendQueueSize: 4,
+		PingInterval:  50 * time.Millisecond,
+	}, NilMetrics())
+
+	defer w.close()
+
+	pingCh := make(chan struct{}, 1)
+
+	client.SetPingHandler(func(string) error {
+		select {
+		case pingCh <- struct{}{}:
+		default:
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, w.WriteMessage(websocket.TextMessage, []byte("response")))
+	assert.NoError(t, w.Notify([]byte("notification")))
+
+	for _, expected := range []string{"response", "notification"} {
+		_, data, err := client.ReadMessage()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	// the pings are handled while the client reads
+	go func() {
+		for {
+			if _, _, err := client.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pingCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("ping not received in 2 seconds")
+	}
+}
+
+func TestWSWrapper_Keepalive(t *testing.T) {
+	t.Parallel()
+
+	ws, _ := newTestWSConns(t)
+	w := newWSWrapper(ws, hclog.NewNullLogger(), &WebSocketConfig{
+		SendQueueSize: 1,
+		PingInterval:  50 * time.Millisecond,
+	}, NilMetrics())
+
+	defer w.close()
+
+	// the client never reads, so it doesn't respond to the pings and the read times out
+	errCh := make(chan error, 1)
+
+	go func() {
+		_, _, err := ws.ReadMessage()
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection not timed out in 2 seconds")
+	}
+}
@@ -66,8 +66,17 @@ func SetupGCPSSM(
 
 // InitECDSAValidatorKey creates new ECDSA key and set as a validator key
 func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
-	if secretsManager.HasSecret(secrets.ValidatorKey) {
-		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorKey)
+	return initECDSAKey(secretsManager, secrets.ValidatorKey)
+}
+
+// InitECDSASuccessorKey creates new ECDSA key and set as a key the validator key is rotated to
+func InitECDSASuccessorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
+	return initECDSAKey(secretsManager, secrets.ValidatorSuccessorKey)
+}
+
+func initECDSAKey(secretsManager secrets.SecretsManager, name string) (types.Address, error) {
+	if secretsManager.HasSecret(name) {
+		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, name)
 	}
 
 	validatorKey, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
@@ -79,7 +88,7 @@ func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address
 
 	// Write the validator private key to the secrets manager storage
 	if setErr := secretsManager.SetSecret(
-		secrets.ValidatorKey,
+		name,
 		validatorKeyEncoded,
 	); setErr != nil {
 		return types.ZeroAddress, setErr
@@ -89,8 +98,17 @@ func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address
 }
 
 func InitBLSValidatorKey(secretsManager secrets.SecretsManager) ([]byte, error) {
-	if secretsManager.HasSecret(secrets.ValidatorBLSKey) {
-		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorBLSKey)
+	return initBLSKey(secretsManager, secrets.ValidatorBLSKey)
+}
+
+// InitBLSSuccessorKey creates new BLS key and set as a BLS key the validator key is rotated to
+func InitBLSSuccessorKey(secretsManager secrets.SecretsManager) ([]byte, error) {
+	return initBLSKey(secretsManager, secrets.ValidatorSuccessorBLSKey)
+}
+
+func initBLSKey(secretsManager secrets.SecretsManager, name string) ([]byte, error) {
+	if secretsManager.HasSecret(name) {
+		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, name)
 	}
 
 	blsSecretKey, blsSecretKeyEncoded, err := crypto.GenerateAndEncodeBLSSecretKey()
@@ -100,7 +118,7 @@ func InitBLSValidatorKey(secretsManager secrets.SecretsManager) ([]byte, error)
 
 	// Write the validator private key to the secrets manager storage
 	if setErr := secretsManager.SetSecret(
-		secrets.ValidatorBLSKey,
+		name,
 		blsSecretKeyEncoded,
 	); setErr != nil {
 		return nil, setErr
@@ -85,6 +85,20 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.ValidatorBLSKeyLocal,
 	)
 
+	// baseDir/consensus/validator-successor.key
+	l.secretPathMap[secrets.ValidatorSuccessorKey] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorSuccessorKeyLocal,
+	)
+
+	// baseDir/consensus/validator-successor-bls.key
+	l.secretPathMap[secrets.ValidatorSuccessorBLSKey] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorSuccessorBLSKeyLocal,
+	)
+
 	// baseDir/libp2p/libp2p.key
 	l.secretPathMap[secrets.NetworkKey] = filepath.Join(
 		l.path,
@@ -154,16 +168,15 @@ func (l *LocalSecretsManager) HasSecret(name string) bool {
 
 // RemoveSecret removes the local SecretsManager's secret from disk
 func (l *LocalSecretsManager) RemoveSecret(name string) error {
-	l.secretPathMapLock.Lock()
+	l.secretPathMapLock.RLock()
 	secretPath, ok := l.secretPathMap[name]
-	defer l.secretPathMapLock.Unlock()
+	l.secretPathMapLock.RUnlock()
 
 	if !ok {
 		return secrets.ErrSecretNotFound
 	}
 
-	delete(l.secretPathMap, name)
-
+	// the path is kept so that the secret can be set again
 	if removeErr := os.Remove(secretPath); removeErr != nil {
 		return fmt.Errorf("unable to remove secret, %w", removeErr)
 	}
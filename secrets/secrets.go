@@ -29,6 +29,12 @@ const (
 	// ValidatorBLSKey is the bls secret key of the validator node
 	ValidatorBLSKey = "validator-bls-key"
 
+	// ValidatorSuccessorKey is the private key the validator rotates its key to
+	ValidatorSuccessorKey = "validator-successor-key"
+
+	// ValidatorSuccessorBLSKey is the bls secret key the validator rotates its key to
+	ValidatorSuccessorBLSKey = "validator-successor-bls-key"
+
 	// NetworkKey is the libp2p private key secret used for networking
 	NetworkKey = "network-key"
 )
@@ -38,6 +44,9 @@ const (
 	ValidatorKeyLocal    = "validator.key"
 	ValidatorBLSKeyLocal = "validator-bls.key"
 	NetworkKeyLocal      = "libp2p.key"
+
+	ValidatorSuccessorKeyLocal    = "validator-successor.key"
+	ValidatorSuccessorBLSKeyLocal = "validator-successor-bls.key"
 )
 
 // Define constant folder names for the local StorageManager
@@ -1,6 +1,9 @@
 package server
 
 import (
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/leveldb"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/pebble"
 	"github.com/ExzoNetwork/ExzoCoin/consensus"
 	consensusDev "github.com/ExzoNetwork/ExzoCoin/consensus/dev"
 	consensusDummy "github.com/ExzoNetwork/ExzoCoin/consensus/dummy"
@@ -10,6 +13,8 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/secrets/gcpssm"
 	"github.com/ExzoNetwork/ExzoCoin/secrets/hashicorpvault"
 	"github.com/ExzoNetwork/ExzoCoin/secrets/local"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
 )
 
 type ConsensusType string
@@ -40,3 +45,32 @@ func ConsensusSupported(value string) bool {
 
 	return ok
 }
+
+// DBEngine is the key-value database which stores the blockchain and the state
+type DBEngine string
+
+const (
+	LevelDBEngine DBEngine = "leveldb"
+	PebbleEngine  DBEngine = "pebble"
+)
+
+// stateStorageFactory creates the storage of the state trie at the given path
+type stateStorageFactory func(path string, logger hclog.Logger) (itrie.Storage, error)
+
+// blockchainStorageBackends defines the blockchain storage factories for the database engines
+var blockchainStorageBackends = map[DBEngine]storage.Factory{
+	LevelDBEngine: leveldb.Factory,
+	PebbleEngine:  pebble.Factory,
+}
+
+// stateStorageBackends defines the state storage factories for the database engines
+var stateStorageBackends = map[DBEngine]stateStorageFactory{
+	LevelDBEngine: itrie.NewLevelDBStorage,
+	PebbleEngine:  itrie.NewPebbleStorage,
+}
+
+func DBEngineSupported(value string) bool {
+	_, ok := blockchainStorageBackends[DBEngine(value)]
+
+	return ok
+}
@@ -5,7 +5,10 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/ExzoNetwork/ExzoCoin/archive"
 	"github.com/ExzoNetwork/ExzoCoin/chain"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
+	"github.com/ExzoNetwork/ExzoCoin/jsonrpc"
 	"github.com/ExzoNetwork/ExzoCoin/network"
 	"github.com/ExzoNetwork/ExzoCoin/secrets"
 )
@@ -30,11 +33,20 @@ type Config struct {
 	Network   *network.Config
 
 	DataDir     string
+	DBEngine    DBEngine
+	Ancient     *Ancient
 	RestoreFile *string
+	Backup      *archive.BackupConfig
 
-	Seal bool
+	Seal  bool
+	Light bool
+
+	// RecordPreimages is set if the preimages of the keys of the state tries are written,
+	// they are read only by the debug state dumps
+	RecordPreimages bool
 
 	SecretsManager *secrets.SecretsManagerConfig
+	RemoteSigner   *signer.RemoteSignerConfig
 
 	LogLevel hclog.Level
 
@@ -46,10 +58,22 @@ type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
 }
 
+// Ancient holds the config details for the ancient store of the finalized blocks
+type Ancient struct {
+	Dir       string
+	Threshold uint64
+	Compress  bool
+}
+
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	Access                   *jsonrpc.AccessConfig
+	AdminAddr                *net.TCPAddr
+	JWTSecretPath            string
+	GraphQL                  *jsonrpc.GraphQLConfig
+	WebSocket                *jsonrpc.WebSocketConfig
 }
@@ -33,6 +33,8 @@ type SystemClient interface {
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// ExportState returns the state snapshot of the block
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (System_ExportStateClient, error)
 }
 
 type systemClient struct {
@@ -152,6 +154,38 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (System_ExportStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[2], "/v1.System/ExportState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemExportStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_ExportStateClient interface {
+	Recv() (*ExportStateEvent, error)
+	grpc.ClientStream
+}
+
+type systemExportStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemExportStateClient) Recv() (*ExportStateEvent, error) {
+	m := new(ExportStateEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -170,6 +204,8 @@ type SystemServer interface {
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// ExportState returns the state snapshot of the block
+	ExportState(*ExportStateRequest, System_ExportStateServer) error
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -198,6 +234,9 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) ExportState(*ExportStateRequest, System_ExportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -343,6 +382,27 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_ExportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).ExportState(m, &systemExportStateServer{stream})
+}
+
+type System_ExportStateServer interface {
+	Send(*ExportStateEvent) error
+	grpc.ServerStream
+}
+
+type systemExportStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemExportStateServer) Send(m *ExportStateEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -382,6 +442,11 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _System_Export_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportState",
+			Handler:       _System_ExportState_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "system.proto",
 }
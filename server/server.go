@@ -13,8 +13,14 @@ import (
 
 	"github.com/ExzoNetwork/ExzoCoin/archive"
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/ancient"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage/dbtool"
 	"github.com/ExzoNetwork/ExzoCoin/chain"
 	"github.com/ExzoNetwork/ExzoCoin/consensus"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/fork"
+	ibftProto "github.com/ExzoNetwork/ExzoCoin/consensus/ibft/proto"
+	"github.com/ExzoNetwork/ExzoCoin/consensus/ibft/signer"
 	"github.com/ExzoNetwork/ExzoCoin/crypto"
 	"github.com/ExzoNetwork/ExzoCoin/helper/common"
 	configHelper "github.com/ExzoNetwork/ExzoCoin/helper/config"
@@ -32,6 +38,7 @@ import (
 	"github.com/ExzoNetwork/ExzoCoin/txpool"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -74,6 +81,12 @@ type Server struct {
 
 	// restore
 	restoreProgression *progress.ProgressionWrapper
+
+	// incremental backups
+	backupScheduler *archive.BackupScheduler
+
+	// subscription forwarding the reorgs to the txpool
+	reorgSub blockchain.Subscription
 }
 
 var dirPaths = []string{
@@ -169,8 +182,22 @@ func NewServer(config *Config) (*Server, error) {
 		m.network = network
 	}
 
-	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	// open the storages of the database engine
+	stateStorageFactory, ok := stateStorageBackends[config.DBEngine]
+	if !ok {
+		return nil, fmt.Errorf("database engine '%s' not found", config.DBEngine)
+	}
+
+	// the engine is recorded when the databases are created, so another engine fails clearly
+	if err := dbtool.CheckEngine(m.config.DataDir, string(config.DBEngine)); err != nil {
+		return nil, err
+	}
+
+	if err := dbtool.WriteEngine(m.config.DataDir, string(config.DBEngine)); err != nil {
+		return nil, fmt.Errorf("failed to record the database engine: %w", err)
+	}
+
+	stateStorage, err := stateStorageFactory(filepath.Join(m.config.DataDir, "trie"), logger)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +205,7 @@ func NewServer(config *Config) (*Server, error) {
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
+	st.SetRecordPreimages(config.RecordPreimages)
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
@@ -188,8 +216,30 @@ func NewServer(config *Config) (*Server, error) {
 	genesisRoot := m.executor.WriteGenesis(config.Chain.Genesis.Alloc)
 	config.Chain.Genesis.StateRoot = genesisRoot
 
+	ancients, err := ancient.NewFreezer(config.Ancient.Dir, config.Ancient.Compress, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	blockchainStorage, err := blockchainStorageBackends[config.DBEngine](map[string]interface{}{
+		"path":                    filepath.Join(m.config.DataDir, "blockchain"),
+		storage.AncientsConfigKey: ancients,
+	}, logger)
+	if err != nil {
+		_ = ancients.Close()
+
+		return nil, err
+	}
+
+	// migrate the database to the current schema before anything reads it
+	if err := upgradeStorageSchema(blockchainStorage, logger); err != nil {
+		_ = blockchainStorage.Close()
+
+		return nil, err
+	}
+
 	// blockchain object
-	m.blockchain, err = blockchain.NewBlockchain(logger, m.config.DataDir, config.Chain, nil, m.executor)
+	m.blockchain, err = blockchain.NewBlockchain(logger, blockchainStorage, config.Chain, nil, m.executor)
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +260,7 @@ func NewServer(config *Config) (*Server, error) {
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
-			m.chain.Params.Forks.At(0),
+			m.chain.Params.GetForks().At(0),
 			hub,
 			m.grpcServer,
 			m.network,
@@ -247,6 +297,13 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// the light node has no bodies to freeze
+	if config.Ancient.Threshold > 0 && !config.Light {
+		if err := m.blockchain.EnableFreezer(config.Ancient.Threshold); err != nil {
+			return nil, err
+		}
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -271,6 +328,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// start the incremental backups from the restored chain
+	if err := m.setupBackupScheduler(); err != nil {
+		return nil, err
+	}
+
 	// start consensus
 	if err := m.consensus.Start(); err != nil {
 		return nil, err
@@ -278,18 +340,92 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.txpool.Start()
 
+	// return the transactions of the removed blocks to the pool
+	m.forwardReorgs()
+
 	return m, nil
 }
 
+// upgradeStorageSchema runs the pending migrations of the blockchain database schema,
+// it refuses the databases written by a newer node
+func upgradeStorageSchema(s storage.Storage, logger hclog.Logger) error {
+	kv, ok := s.(*storage.KeyValueStorage)
+	if !ok {
+		return nil
+	}
+
+	upgrade, err := kv.UpgradeSchema(logger.Named("schema"))
+	if err != nil {
+		return err
+	}
+
+	if upgrade.From != upgrade.To {
+		logger.Info("Upgraded database schema", "from", upgrade.From, "to", upgrade.To)
+	}
+
+	return nil
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
 	}
 
-	if err := archive.RestoreChain(s.blockchain, *s.config.RestoreFile, s.restoreProgression); err != nil {
+	// without the state storage, the archives with the state snapshot are refused
+	stateStorage := s.stateStorage
+
+	supported, err := s.stateRestoreSupported()
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		stateStorage = nil
+	}
+
+	if err := archive.RestoreChain(s.blockchain, stateStorage, *s.config.RestoreFile, s.restoreProgression); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// stateRestoreSupported returns true if the blocks below a restored state snapshot can be verified.
+// The validators of the PoS forks are read from the state at the ends of the epochs, which the
+// snapshot doesn't have, so only the chains with the PoA forks are supported
+func (s *Server) stateRestoreSupported() (bool, error) {
+	engineName := s.config.Chain.Params.GetEngine()
+	if ConsensusType(engineName) != IBFTConsensus {
+		return true, nil
+	}
+
+	engineConfig, ok := s.config.Chain.Params.Engine[engineName].(map[string]interface{})
+	if !ok {
+		engineConfig = map[string]interface{}{}
+	}
+
+	forks, err := fork.GetIBFTForks(engineConfig)
+	if err != nil {
+		return false, err
+	}
+
+	return forks.IsPoAOnly(), nil
+}
+
+func (s *Server) setupBackupScheduler() error {
+	if s.config.Backup == nil {
+		return nil
+	}
+
+	scheduler, err := archive.NewBackupScheduler(s.logger, s.blockchain, s.config.Backup)
+	if err != nil {
 		return err
 	}
 
+	scheduler.Start()
+
+	s.backupScheduler = scheduler
+
 	return nil
 }
 
@@ -390,6 +526,11 @@ func (s *Server) setupConsensus() error {
 		return fmt.Errorf("consensus engine '%s' not found", engineName)
 	}
 
+	// only IBFT can verify the headers with their committed seals
+	if s.config.Light && ConsensusType(engineName) != IBFTConsensus {
+		return fmt.Errorf("light mode isn't supported by the consensus engine '%s'", engineName)
+	}
+
 	engineConfig, ok := s.config.Chain.Params.Engine[engineName].(map[string]interface{})
 	if !ok {
 		engineConfig = map[string]interface{}{}
@@ -405,6 +546,7 @@ func (s *Server) setupConsensus() error {
 		&consensus.Params{
 			Context:        context.Background(),
 			Seal:           s.config.Seal,
+			Light:          s.config.Light,
 			Config:         config,
 			TxPool:         s.txpool,
 			Network:        s.network,
@@ -414,6 +556,7 @@ func (s *Server) setupConsensus() error {
 			Logger:         s.logger,
 			Metrics:        s.serverMetrics.consensus,
 			SecretsManager: s.secretsManager,
+			RemoteSigner:   s.config.RemoteSigner,
 			BlockTime:      s.config.BlockTime,
 		},
 	)
@@ -430,6 +573,7 @@ func (s *Server) setupConsensus() error {
 type jsonRPCHub struct {
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
+	light              bool
 
 	*blockchain.Blockchain
 	*txpool.TxPool
@@ -440,14 +584,200 @@ type jsonRPCHub struct {
 
 // HELPER + WRAPPER METHODS //
 
+// finalityProver is implemented by the consensus that can prove the finality of blocks
+type finalityProver interface {
+	GetFinalityProof(height uint64) (*signer.FinalityProof, error)
+}
+
+func (j *jsonRPCHub) GetFinalityProof(height uint64) (*signer.FinalityProof, error) {
+	prover, ok := j.Consensus.(finalityProver)
+	if !ok {
+		return nil, errors.New("finality proof is not supported by the consensus")
+	}
+
+	return prover.GetFinalityProof(height)
+}
+
+// stateProver is implemented by the state that can prove the values in its tries
+type stateProver interface {
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
+}
+
+func (j *jsonRPCHub) GetStateProof(root types.Hash, slot []byte) ([][]byte, error) {
+	prover, ok := j.state.(stateProver)
+	if !ok {
+		return nil, errors.New("state proof is not supported by the state")
+	}
+
+	// the values in the trie are the hashed objects of the keys
+	return prover.GetProof(root, keccak.Keccak256(nil, slot))
+}
+
+// stateOverlayer is implemented by the state that can be changed in memory only
+type stateOverlayer interface {
+	NewOverlay() *itrie.State
+}
+
+// GetStateDumper returns the reader of the entries of the state tries
+func (j *jsonRPCHub) GetStateDumper() (jsonrpc.StateDumper, error) {
+	dumper, ok := j.state.(jsonrpc.StateDumper)
+	if !ok {
+		return nil, errors.New("state dump is not supported by the state")
+	}
+
+	return dumper, nil
+}
+
+// ReplayBlock executes the first txIndex transactions of the block on top of its parent state,
+// the changes are kept in memory only. It returns the reader of the state and the state root
+func (j *jsonRPCHub) ReplayBlock(block *types.Block, txIndex int) (jsonrpc.StateDumper, types.Hash, error) {
+	overlayer, ok := j.state.(stateOverlayer)
+	if !ok {
+		return nil, types.ZeroHash, errors.New("state replay is not supported by the state")
+	}
+
+	parent, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, types.ZeroHash, fmt.Errorf("parent of block %d not found", block.Number())
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	overlay := overlayer.NewOverlay()
+
+	transition, err := j.Executor.WithState(overlay).ProcessBlock(parent.StateRoot, &types.Block{
+		Header:       block.Header,
+		Transactions: block.Transactions[:txIndex],
+	}, blockCreator)
+	if err != nil {
+		return nil, types.ZeroHash, err
+	}
+
+	_, root := transition.Commit()
+
+	return overlay, root, nil
+}
+
 func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+func (j *jsonRPCHub) GetPeersInfo() ([]*jsonrpc.PeerInfo, error) {
+	peers := j.Server.Peers()
+	res := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		protocols, err := j.Server.GetProtocols(p.Info.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		info := &jsonrpc.PeerInfo{
+			ID:        p.Info.ID.String(),
+			Addrs:     make([]string, 0, len(p.Info.Addrs)),
+			Protocols: protocols,
+		}
+
+		for _, addr := range p.Info.Addrs {
+			info.Addrs = append(info.Addrs, addr.String())
+		}
+
+		res = append(res, info)
+	}
+
+	return res, nil
+}
+
+func (j *jsonRPCHub) AddPeer(rawPeerMultiaddr string) error {
+	return j.Server.JoinPeer(rawPeerMultiaddr)
+}
+
+func (j *jsonRPCHub) RemovePeer(rawPeerID string) error {
+	peerID, err := peer.Decode(rawPeerID)
+	if err != nil {
+		return err
+	}
+
+	if !j.Server.IsConnected(peerID) {
+		return fmt.Errorf("peer %s is not connected", peerID)
+	}
+
+	j.Server.DisconnectFromPeer(peerID, "removed by the admin")
+
+	return nil
+}
+
+func (j *jsonRPCHub) GetNodeAddrs() (string, []string) {
+	info := j.Server.AddrInfo()
+	addrs := make([]string, 0, len(info.Addrs))
+
+	for _, addr := range info.Addrs {
+		addrs = append(addrs, fmt.Sprintf("%s/p2p/%s", addr.String(), info.ID.String()))
+	}
+
+	return info.ID.String(), addrs
+}
+
+// ibftOperator is implemented by the consensus that supports the voting of the validators
+type ibftOperator interface {
+	GetSnapshot(height uint64) (*ibftProto.Snapshot, error)
+	Propose(candidate *ibftProto.Candidate) error
+}
+
+func (j *jsonRPCHub) getIBFTOperator() (ibftOperator, error) {
+	operator, ok := j.Consensus.(ibftOperator)
+	if !ok {
+		return nil, errors.New("IBFT operations are not supported by the consensus")
+	}
+
+	return operator, nil
+}
+
+func (j *jsonRPCHub) GetIBFTSnapshot(height uint64) (*ibftProto.Snapshot, error) {
+	operator, err := j.getIBFTOperator()
+	if err != nil {
+		return nil, err
+	}
+
+	return operator.GetSnapshot(height)
+}
+
+func (j *jsonRPCHub) ProposeIBFTCandidate(candidate *ibftProto.Candidate) error {
+	operator, err := j.getIBFTOperator()
+	if err != nil {
+		return err
+	}
+
+	return operator.Propose(candidate)
+}
+
+// lightStateReader is implemented by the consensus that can read the state from the peers
+type lightStateReader interface {
+	GetStateValue(root types.Hash, key []byte) ([]byte, error)
+}
+
+// GetBlockByNumber returns the block by the number, only its header in the light mode
+func (j *jsonRPCHub) GetBlockByNumber(blockNumber uint64, full bool) (*types.Block, bool) {
+	return j.Blockchain.GetBlockByNumber(blockNumber, full && !j.light)
+}
+
+// GetBlockByHash returns the block by the hash, only its header in the light mode
+func (j *jsonRPCHub) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	return j.Blockchain.GetBlockByHash(hash, full && !j.light)
+}
+
 func (j *jsonRPCHub) getState(root types.Hash, slot []byte) ([]byte, error) {
 	// the values in the trie are the hashed objects of the keys
 	key := keccak.Keccak256(nil, slot)
 
+	// the light node has no state, so the values are proven by the full peers
+	if j.light {
+		return j.getProvenState(root, key)
+	}
+
 	snap, err := j.state.NewSnapshotAt(root)
 	if err != nil {
 		return nil, err
@@ -462,6 +792,24 @@ func (j *jsonRPCHub) getState(root types.Hash, slot []byte) ([]byte, error) {
 	return result, nil
 }
 
+func (j *jsonRPCHub) getProvenState(root types.Hash, key []byte) ([]byte, error) {
+	reader, ok := j.Consensus.(lightStateReader)
+	if !ok {
+		return nil, errors.New("light state is not supported by the consensus")
+	}
+
+	result, err := reader.GetStateValue(root, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, jsonrpc.ErrStateNotFound
+	}
+
+	return result, nil
+}
+
 func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*state.Account, error) {
 	obj, err := j.getState(root, addr.Bytes())
 	if err != nil {
@@ -511,15 +859,28 @@ func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
 ) (result *runtime.ExecutionResult, err error) {
-	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	return j.ApplyTxnWithOverrides(header, txn, nil, nil)
+}
+
+// ApplyTxnWithOverrides applies the transaction on a throwaway transition
+// whose state and block context are overridden first
+func (j *jsonRPCHub) ApplyTxnWithOverrides(
+	header *types.Header,
+	txn *types.Transaction,
+	stateOverride state.StateOverride,
+	blockOverride *state.BlockOverride,
+) (result *runtime.ExecutionResult, err error) {
+	transition, err := j.BeginSimulation(header)
 	if err != nil {
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	if err = transition.ApplyStateOverride(stateOverride); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
-		return
+	if blockOverride != nil {
+		transition.ApplyBlockOverride(blockOverride)
 	}
 
 	result, err = transition.Apply(txn)
@@ -527,6 +888,16 @@ func (j *jsonRPCHub) ApplyTxn(
 	return
 }
 
+// BeginSimulation creates a throwaway transition on top of the state of the header
+func (j *jsonRPCHub) BeginSimulation(header *types.Header) (*state.Transition, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.BeginTxn(header.StateRoot, header, blockCreator)
+}
+
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	// restore progression
 	if restoreProg := j.restoreProgression.GetProgression(); restoreProg != nil {
@@ -548,6 +919,7 @@ func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
+		light:              s.config.Light,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
@@ -563,6 +935,23 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		Access:                   s.config.JSONRPC.Access,
+		GraphQL:                  s.config.JSONRPC.GraphQL,
+		WebSocket:                s.config.JSONRPC.WebSocket,
+		Metrics:                  s.serverMetrics.jsonrpc,
+	}
+
+	if s.config.JSONRPC.AdminAddr != nil {
+		secret, err := jsonrpc.LoadJWTSecret(s.config.JSONRPC.JWTSecretPath)
+		if err != nil {
+			return fmt.Errorf("unable to load JWT secret, %w", err)
+		}
+
+		conf.Admin = &jsonrpc.AdminConfig{
+			Store:     hub,
+			Addr:      s.config.JSONRPC.AdminAddr,
+			JWTSecret: secret,
+		}
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -605,8 +994,35 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 	return s.network.JoinPeer(rawPeerMultiaddr)
 }
 
+// forwardReorgs passes the reorg events of the blockchain, including the ones of SetHead, to the txpool
+func (s *Server) forwardReorgs() {
+	s.reorgSub = s.blockchain.SubscribeEvents()
+
+	go func() {
+		for {
+			evnt := s.reorgSub.GetEvent()
+			if evnt == nil {
+				return
+			}
+
+			if evnt.Type == blockchain.EventReorg {
+				s.txpool.ResetWithReorg(evnt)
+			}
+		}
+	}()
+}
+
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop the incremental backups before the blockchain they're written from
+	if s.backupScheduler != nil {
+		s.backupScheduler.Close()
+	}
+
+	if s.reorgSub != nil {
+		s.reorgSub.Close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -2,6 +2,7 @@ package server
 
 import (
 	"github.com/ExzoNetwork/ExzoCoin/consensus"
+	"github.com/ExzoNetwork/ExzoCoin/jsonrpc"
 	"github.com/ExzoNetwork/ExzoCoin/network"
 	"github.com/ExzoNetwork/ExzoCoin/txpool"
 )
@@ -11,6 +12,7 @@ type serverMetrics struct {
 	consensus *consensus.Metrics
 	network   *network.Metrics
 	txpool    *txpool.Metrics
+	jsonrpc   *jsonrpc.Metrics
 }
 
 // metricProvider serverMetric instance for the given ChainID and nameSpace
@@ -20,6 +22,7 @@ func metricProvider(nameSpace string, chainID string, metricsRequired bool) *ser
 			consensus: consensus.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 			network:   network.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 			txpool:    txpool.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
+			jsonrpc:   jsonrpc.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 		}
 	}
 
@@ -27,5 +30,6 @@ func metricProvider(nameSpace string, chainID string, metricsRequired bool) *ser
 		consensus: consensus.NilMetrics(),
 		network:   network.NilMetrics(),
 		txpool:    txpool.NilMetrics(),
+		jsonrpc:   jsonrpc.NilMetrics(),
 	}
 }
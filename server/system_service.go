@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ExzoNetwork/ExzoCoin/archive"
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/blockchain/storage"
+	"github.com/ExzoNetwork/ExzoCoin/export"
 	"github.com/ExzoNetwork/ExzoCoin/network/common"
 	"github.com/ExzoNetwork/ExzoCoin/server/proto"
 	"github.com/ExzoNetwork/ExzoCoin/types"
@@ -172,21 +175,9 @@ func (s *systemService) BlockByNumber(
 }
 
 func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_ExportServer) error {
-	var (
-		from uint64 = 0
-		to   *uint64
-	)
-
-	if req.From != from {
-		from = req.From
-	}
-
-	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
-		}
-
-		to = &req.To
+	from, to, err := exportRange(req)
+	if err != nil {
+		return err
 	}
 
 	canLoop := func(i uint64) bool {
@@ -199,8 +190,10 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 		}
 	}
 
-	writer := newBlockStreamWriter(stream, s.server.blockchain, defaultMaxGRPCPayloadSize)
-	i := from
+	var (
+		writer = newBlockStreamWriter(stream, s.server.blockchain, defaultMaxGRPCPayloadSize)
+		i      = from
+	)
 
 	for canLoop(i) {
 		block, ok := s.server.blockchain.GetBlockByNumber(i, true)
@@ -208,7 +201,13 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 			break
 		}
 
-		if err := writer.appendBlock(block); err != nil {
+		if req.Receipts {
+			err = writer.appendEntry(block)
+		} else {
+			err = writer.appendBlock(block)
+		}
+
+		if err != nil {
 			return err
 		}
 
@@ -222,6 +221,42 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	return nil
 }
 
+// exportRange returns the range of the blocks of the export request, the range is open-ended if to is nil.
+// The block exports of the backup clients require to to be greater than from,
+// while the exports with the receipts may cover a single block
+func exportRange(req *proto.ExportRequest) (uint64, *uint64, error) {
+	if req.To == 0 {
+		return req.From, nil, nil
+	}
+
+	if req.From > req.To || (req.From == req.To && !req.Receipts) {
+		return 0, nil, errors.New("to must be greater than from")
+	}
+
+	to := req.To
+
+	return req.From, &to, nil
+}
+
+// ExportState implements the 'ExportState' operator service
+func (s *systemService) ExportState(req *proto.ExportStateRequest, stream proto.System_ExportStateServer) error {
+	header, ok := s.server.blockchain.GetHeaderByNumber(req.Number)
+	if !ok {
+		return fmt.Errorf("block %d not found", req.Number)
+	}
+
+	reader, ok := s.server.state.(archive.StateReader)
+	if !ok {
+		return errors.New("state doesn't support the export")
+	}
+
+	return archive.ExportState(reader, header.StateRoot, defaultMaxGRPCPayloadSize, func(data []byte) error {
+		return stream.Send(&proto.ExportStateEvent{
+			Data: data,
+		})
+	})
+}
+
 const (
 	defaultMaxGRPCPayloadSize uint64 = 512 * 1024 // 4MB
 )
@@ -249,8 +284,25 @@ func newBlockStreamWriter(
 }
 
 func (w *blockStreamWriter) appendBlock(b *types.Block) error {
-	data := b.MarshalRLP()
+	return w.append(b.Number(), b.MarshalRLP())
+}
+
+// appendEntry appends the block with its receipts
+func (w *blockStreamWriter) appendEntry(b *types.Block) error {
+	receipts, err := w.blockchain.GetReceiptsByHash(b.Hash())
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	entry := &export.Entry{
+		Block:    b,
+		Receipts: receipts,
+	}
+
+	return w.append(b.Number(), entry.MarshalRLP())
+}
 
+func (w *blockStreamWriter) append(n uint64, data []byte) error {
 	if uint64(w.buf.Len()+len(data)) >= w.maxPayload {
 		// send buffered data to client first
 		if err := w.flush(); err != nil {
@@ -260,7 +312,6 @@ func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 
 	w.buf.Write(data)
 
-	n := b.Number()
 	if w.pendingFrom == nil {
 		w.pendingFrom = &n
 	}
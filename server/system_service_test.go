@@ -0,0 +1,71 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/server/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportRange(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		req  *proto.ExportRequest
+		from uint64
+		to   *uint64
+		err  bool
+	}{
+		{
+			name: "open-ended range",
+			req:  &proto.ExportRequest{From: 5},
+			from: 5,
+		},
+		{
+			name: "closed range",
+			req:  &proto.ExportRequest{From: 5, To: 10},
+			from: 5,
+			to:   uint64Ptr(10),
+		},
+		{
+			name: "single block",
+			req:  &proto.ExportRequest{From: 5, To: 5},
+			err:  true,
+		},
+		{
+			name: "single block with receipts",
+			req:  &proto.ExportRequest{From: 5, To: 5, Receipts: true},
+			from: 5,
+			to:   uint64Ptr(5),
+		},
+		{
+			name: "to less than from",
+			req:  &proto.ExportRequest{From: 5, To: 4, Receipts: true},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			from, to, err := exportRange(c.req)
+			if c.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.from, from)
+			assert.Equal(t, c.to, to)
+		})
+	}
+}
+
+func uint64Ptr(n uint64) *uint64 {
+	return &n
+}
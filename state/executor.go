@@ -87,6 +87,18 @@ type BlockResult struct {
 	TotalGas uint64
 }
 
+// WithState returns a copy of the executor which executes on top of the given state
+func (e *Executor) WithState(s State) *Executor {
+	return &Executor{
+		logger:   e.logger,
+		config:   e.config,
+		runtimes: e.runtimes,
+		state:    s,
+		GetHash:  e.GetHash,
+		PostHook: e.PostHook,
+	}
+}
+
 // ProcessBlock already does all the handling of the whole process
 func (e *Executor) ProcessBlock(
 	parentRoot types.Hash,
@@ -129,7 +141,7 @@ func (e *Executor) StateAt(root types.Hash) (Snapshot, error) {
 
 // GetForksInTime returns the active forks at the given block height
 func (e *Executor) GetForksInTime(blockNumber uint64) chain.ForksInTime {
-	return e.config.Forks.At(blockNumber)
+	return e.config.GetForks().At(blockNumber)
 }
 
 func (e *Executor) BeginTxn(
@@ -137,7 +149,7 @@ func (e *Executor) BeginTxn(
 	header *types.Header,
 	coinbaseReceiver types.Address,
 ) (*Transition, error) {
-	config := e.config.Forks.At(header.Number)
+	config := e.config.GetForks().At(header.Number)
 
 	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
 	if err != nil {
@@ -352,6 +364,24 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 	return &t.ctx
 }
 
+// ApplyWithLogs applies the message like Apply and returns the logs it emitted,
+// so that several messages can be executed one after another on the same transition
+func (t *Transition) ApplyWithLogs(msg *types.Transaction) (*runtime.ExecutionResult, []*types.Log, error) {
+	result, err := t.Apply(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logs := t.state.Logs()
+
+	// The suicided accounts are set as deleted for the next message
+	t.state.CleanDeleteObjects(t.config.EIP158)
+
+	t.totalGas += result.GasUsed
+
+	return result, logs, nil
+}
+
 func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	// deduct the upfront max gas cost
 	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
@@ -0,0 +1,46 @@
+package itrie
+
+import (
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+// TrieBuilder builds a trie from its raw entries, like the ones visited by Iterate,
+// and writes its nodes to the storage
+type TrieBuilder struct {
+	txn   *Txn
+	batch Batch
+}
+
+// NewTrieBuilder returns the builder of a new trie in the storage
+func NewTrieBuilder(storage Storage) *TrieBuilder {
+	batch := storage.Batch()
+
+	trie := NewTrie()
+	trie.storage = storage
+
+	txn := trie.Txn()
+	txn.batch = batch
+
+	return &TrieBuilder{
+		txn:   txn,
+		batch: batch,
+	}
+}
+
+// Insert adds the entry to the trie, along with the preimage of the hashed key if known
+func (b *TrieBuilder) Insert(key, value, preimage []byte) {
+	b.txn.Insert(key, value)
+
+	if len(preimage) > 0 {
+		b.batch.Put(preimageKey(key), preimage)
+	}
+}
+
+// Commit writes the nodes of the trie to the storage and returns its root
+func (b *TrieBuilder) Commit() types.Hash {
+	root, _ := b.txn.Hash()
+
+	b.batch.Write()
+
+	return types.BytesToHash(root)
+}
@@ -0,0 +1,60 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrieBuilder(t *testing.T) {
+	t.Parallel()
+
+	for _, numAccounts := range []int{1, 2, 100} {
+		st, root := newTestProofState(t, numAccounts)
+
+		// rebuild the state from its entries in another storage
+		storage := NewMemoryStorage()
+		builder := NewTrieBuilder(storage)
+
+		assert.NoError(t, st.Iterate(root, nil, func(key, value []byte) bool {
+			var account state.Account
+			assert.NoError(t, account.UnmarshalRlp(value))
+
+			storageBuilder := NewTrieBuilder(storage)
+
+			assert.NoError(t, st.Iterate(account.Root, nil, func(slotKey, slotValue []byte) bool {
+				storageBuilder.Insert(slotKey, slotValue, nil)
+
+				return true
+			}))
+
+			assert.Equal(t, account.Root, storageBuilder.Commit())
+
+			preimage, _ := st.GetPreimage(types.BytesToHash(key))
+			builder.Insert(key, value, preimage)
+
+			return true
+		}))
+
+		assert.Equal(t, root, builder.Commit())
+
+		// the rebuilt state is readable from the storage
+		rebuilt := NewState(storage)
+
+		count := 0
+		assert.NoError(t, rebuilt.Iterate(root, nil, func(key, _ []byte) bool {
+			_, ok := rebuilt.GetPreimage(types.BytesToHash(key))
+			assert.True(t, ok)
+
+			count++
+
+			return true
+		}))
+		assert.Equal(t, numAccounts, count)
+	}
+
+	// an empty trie
+	assert.Equal(t, types.EmptyRootHash, NewTrieBuilder(NewMemoryStorage()).Commit())
+}
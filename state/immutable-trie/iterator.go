@@ -0,0 +1,126 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+// Iterate walks over the entries of the trie with the given root in the order of their keys,
+// starting from the given key. The keys of the state tries are the hashes of the addresses
+// and of the storage slots. The walk stops when fn returns false
+func Iterate(storage Storage, root types.Hash, start []byte, fn func(key, value []byte) bool) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("trie node %s not found", root)
+	}
+
+	it := &iterator{
+		storage: storage,
+		start:   trimTerminator(bytesToHexNibbles(start)),
+		fn:      fn,
+	}
+
+	_, err = it.walk(node, []byte{})
+
+	return err
+}
+
+// iterator walks over the nodes of the trie depth-first, which visits the keys in order
+type iterator struct {
+	storage Storage
+	start   []byte
+	fn      func(key, value []byte) bool
+}
+
+// walk visits the entries under the node whose path is given in nibbles,
+// it returns false once the walk has to stop
+func (it *iterator) walk(node Node, path []byte) (bool, error) {
+	switch n := node.(type) {
+	case nil:
+		return true, nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, it.storage)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				return false, fmt.Errorf("trie node %s not found", types.BytesToHash(n.buf))
+			}
+
+			return it.walk(nc, path)
+		}
+
+		key := trimTerminator(path)
+		if bytes.Compare(key, it.start) < 0 {
+			return true, nil
+		}
+
+		return it.fn(hexNibblesToBytes(key), n.buf), nil
+
+	case *ShortNode:
+		return it.walkChild(n.child, concat(path, n.key))
+
+	case *FullNode:
+		// the value of the node has the shortest key of the subtree
+		if cont, err := it.walkChild(n.value, path); !cont || err != nil {
+			return cont, err
+		}
+
+		for i, child := range n.children {
+			if cont, err := it.walkChild(child, concat(path, []byte{byte(i)})); !cont || err != nil {
+				return cont, err
+			}
+		}
+
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// walkChild walks the child unless all of its keys are before the start key
+func (it *iterator) walkChild(child Node, path []byte) (bool, error) {
+	prefix := trimTerminator(path)
+	if len(prefix) > len(it.start) {
+		prefix = prefix[:len(it.start)]
+	}
+
+	if bytes.Compare(prefix, it.start[:len(prefix)]) < 0 {
+		return true, nil
+	}
+
+	return it.walk(child, path)
+}
+
+// trimTerminator removes the terminator flag from the nibbles
+func trimTerminator(nibbles []byte) []byte {
+	if hasTerminator(nibbles) {
+		return nibbles[:len(nibbles)-1]
+	}
+
+	return nibbles
+}
+
+// hexNibblesToBytes joins the nibbles into bytes
+func hexNibblesToBytes(nibbles []byte) []byte {
+	res := make([]byte, len(nibbles)/2)
+	for i := range res {
+		res[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return res
+}
@@ -0,0 +1,150 @@
+package itrie
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterate(t *testing.T) {
+	t.Parallel()
+
+	for _, numAccounts := range []int{1, 2, 100} {
+		st, root := newTestProofState(t, numAccounts)
+
+		expected := make([][]byte, numAccounts)
+		for i := range expected {
+			expected[i] = hashit(types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()).Bytes())
+		}
+
+		sort.Slice(expected, func(i, j int) bool {
+			return bytes.Compare(expected[i], expected[j]) < 0
+		})
+
+		collect := func(start []byte, limit int) [][]byte {
+			keys := [][]byte{}
+
+			assert.NoError(t, st.Iterate(root, start, func(key, value []byte) bool {
+				var account state.Account
+				assert.NoError(t, account.UnmarshalRlp(value))
+
+				keys = append(keys, key)
+
+				return len(keys) < limit
+			}))
+
+			return keys
+		}
+
+		// all the keys are visited in order
+		assert.Equal(t, expected, collect(nil, numAccounts+1))
+
+		// the walk starts from the key and stops when asked
+		middle := numAccounts / 2
+		assert.Equal(t, expected[middle:], collect(expected[middle], numAccounts+1))
+		assert.Equal(t, expected[:1], collect(nil, 1))
+
+		// the start key between two keys
+		start := append(append([]byte{}, expected[middle]...), 0x0)
+		assert.Equal(t, expected[middle+1:], collect(start, numAccounts+1))
+	}
+}
+
+func TestIterateStorage(t *testing.T) {
+	t.Parallel()
+
+	st, root := newTestProofState(t, 1)
+	snap, err := st.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	addr := types.BytesToAddress(big.NewInt(1).Bytes())
+
+	data, ok := snap.Get(hashit(addr.Bytes()))
+	assert.True(t, ok)
+
+	var account state.Account
+	assert.NoError(t, account.UnmarshalRlp(data))
+
+	slots := map[types.Hash][]byte{}
+
+	assert.NoError(t, st.Iterate(account.Root, nil, func(key, value []byte) bool {
+		slot, ok := st.GetPreimage(types.BytesToHash(key))
+		assert.True(t, ok)
+
+		slots[types.BytesToHash(slot)] = value
+
+		return true
+	}))
+
+	assert.Len(t, slots, 2)
+	assert.Contains(t, slots, types.StringToHash("1"))
+	assert.Contains(t, slots, types.StringToHash("2"))
+
+	preimage, ok := st.GetPreimage(types.BytesToHash(hashit(addr.Bytes())))
+	assert.True(t, ok)
+	assert.Equal(t, addr.Bytes(), preimage)
+}
+
+func TestPreimages_NotRecorded(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	addr := types.StringToAddress("1")
+
+	_, root := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr,
+			Balance:  big.NewInt(1),
+			CodeHash: types.BytesToHash(hashit(nil)),
+			Root:     types.EmptyRootHash,
+			Storage:  []*state.StorageObject{{Key: types.StringToHash("1").Bytes(), Val: []byte{0x1}}},
+		},
+	})
+
+	// the state is committed without the preimages of the address and the slot
+	count := 0
+
+	assert.NoError(t, st.Iterate(types.BytesToHash(root), nil, func(key, _ []byte) bool {
+		_, ok := st.GetPreimage(types.BytesToHash(key))
+		assert.False(t, ok)
+
+		count++
+
+		return true
+	}))
+	assert.Equal(t, 1, count)
+
+	_, ok := st.GetPreimage(types.BytesToHash(hashit(types.StringToHash("1").Bytes())))
+	assert.False(t, ok)
+}
+
+func TestOverlay(t *testing.T) {
+	t.Parallel()
+
+	st, root := newTestProofState(t, 2)
+	overlay := st.NewOverlay()
+
+	snap, err := overlay.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	addr := types.StringToAddress("100")
+
+	_, newRoot := snap.Commit([]*state.Object{
+		{Address: addr, Balance: big.NewInt(1), CodeHash: types.BytesToHash(hashit(nil)), Root: types.EmptyRootHash},
+	})
+
+	// the changes are visible on the overlay only
+	_, err = overlay.NewSnapshotAt(types.BytesToHash(newRoot))
+	assert.NoError(t, err)
+
+	_, err = st.NewSnapshotAt(types.BytesToHash(newRoot))
+	assert.Error(t, err)
+
+	_, ok := st.GetPreimage(types.BytesToHash(hashit(addr.Bytes())))
+	assert.False(t, ok)
+}
@@ -0,0 +1,181 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrProofNodeNotFound = errors.New("trie node not found in proof")
+	ErrInvalidProofNode  = errors.New("invalid trie node in proof")
+)
+
+// Prove returns the Merkle-Patricia proof of the key in the trie with the given root.
+// The proof consists of the RLP-encoded nodes on the path from the root to the key,
+// and it proves the absence of the key if the key doesn't exist in the trie
+func Prove(storage Storage, root types.Hash, key []byte) ([][]byte, error) {
+	proof := [][]byte{}
+
+	if root == types.EmptyRootHash {
+		return proof, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	var (
+		nodeHash = root.Bytes()
+		path     = bytesToHexNibbles(key)
+	)
+
+	for nodeHash != nil {
+		data, ok := storage.Get(nodeHash)
+		if !ok {
+			return nil, fmt.Errorf("trie node %s not found", types.BytesToHash(nodeHash))
+		}
+
+		proof = append(proof, data)
+
+		node, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if nodeHash, _, path, err = walkProofNode(node, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyProof verifies the Merkle-Patricia proof of the key against the root of the trie
+// and returns the value of the key. It returns nil value if the proof shows the key doesn't exist
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, data := range proof {
+		nodes[types.BytesToHash(hashit(data))] = data
+	}
+
+	var (
+		p        = &fastrlp.Parser{}
+		nodeHash = root.Bytes()
+		path     = bytesToHexNibbles(key)
+	)
+
+	for {
+		data, ok := nodes[types.BytesToHash(nodeHash)]
+		if !ok {
+			return nil, ErrProofNodeNotFound
+		}
+
+		node, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		next, value, rest, err := walkProofNode(node, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if next == nil {
+			return value, nil
+		}
+
+		nodeHash, path = next, rest
+	}
+}
+
+// walkProofNode follows the path from the RLP-encoded node including its embedded children.
+// It returns the hash of the next node and the path left if the path continues to another stored node,
+// otherwise it returns the value at the path which is nil if the path doesn't exist
+func walkProofNode(node *fastrlp.Value, path []byte) ([]byte, []byte, []byte, error) {
+	for {
+		child, rest, isValue, err := stepProofNode(node, path)
+		if err != nil || child == nil {
+			return nil, nil, nil, err
+		}
+
+		if isValue {
+			return nil, child.Raw(), nil, nil
+		}
+
+		path = rest
+
+		if child.Type() == fastrlp.TypeArray {
+			// embedded node
+			node = child
+
+			continue
+		}
+
+		if len(child.Raw()) != types.HashLength {
+			return nil, nil, nil, ErrInvalidProofNode
+		}
+
+		return child.Raw(), nil, path, nil
+	}
+}
+
+// stepProofNode returns the child of the node on the path, the path left
+// and whether the child is the value of the path.
+// nil child is returned if the path doesn't exist in the node
+func stepProofNode(node *fastrlp.Value, path []byte) (*fastrlp.Value, []byte, bool, error) {
+	if node.Type() != fastrlp.TypeArray {
+		return nil, nil, false, ErrInvalidProofNode
+	}
+
+	switch node.Elems() {
+	case 2:
+		keyValue := node.Get(0)
+		if keyValue.Type() != fastrlp.TypeBytes {
+			return nil, nil, false, ErrInvalidProofNode
+		}
+
+		key := decodeCompact(keyValue.Raw())
+		if hasTerminator(key) {
+			// leaf node, the key includes the terminator
+			if !bytes.Equal(key, path) {
+				return nil, nil, false, nil
+			}
+
+			return node.Get(1), nil, true, nil
+		}
+
+		// extension node
+		if len(key) > len(path) || !bytes.Equal(key, path[:len(key)]) {
+			return nil, nil, false, nil
+		}
+
+		return node.Get(1), path[len(key):], false, nil
+
+	case 17:
+		if len(path) == 0 || path[0] == 16 {
+			value := node.Get(16)
+			if value.Type() != fastrlp.TypeBytes || len(value.Raw()) == 0 {
+				return nil, nil, false, nil
+			}
+
+			return value, nil, true, nil
+		}
+
+		child := node.Get(int(path[0]))
+		if child.Type() == fastrlp.TypeBytes && len(child.Raw()) == 0 {
+			return nil, nil, false, nil
+		}
+
+		return child, path[1:], false, nil
+
+	default:
+		return nil, nil, false, ErrInvalidProofNode
+	}
+}
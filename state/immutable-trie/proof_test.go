@@ -0,0 +1,144 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProofState(t *testing.T, numAccounts int) (*State, types.Hash) {
+	t.Helper()
+
+	st := NewState(NewMemoryStorage())
+	st.SetRecordPreimages(true)
+
+	objs := make([]*state.Object, numAccounts)
+
+	for i := range objs {
+		objs[i] = &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i * 100)),
+			Nonce:    uint64(i),
+			CodeHash: types.BytesToHash(hashit(nil)),
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%2 == 0 {
+			objs[i].Storage = []*state.StorageObject{
+				{Key: types.StringToHash("1").Bytes(), Val: types.StringToHash("100").Bytes()},
+				{Key: types.StringToHash("2").Bytes(), Val: types.StringToHash("200").Bytes()},
+			}
+		}
+	}
+
+	_, root := st.NewSnapshot().Commit(objs)
+
+	return st, types.BytesToHash(root)
+}
+
+func TestProof(t *testing.T) {
+	t.Parallel()
+
+	for _, numAccounts := range []int{1, 2, 100} {
+		st, root := newTestProofState(t, numAccounts)
+
+		snap, err := st.NewSnapshotAt(root)
+		assert.NoError(t, err)
+
+		for i := 0; i < numAccounts; i++ {
+			key := hashit(types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()).Bytes())
+
+			proof, err := st.GetProof(root, key)
+			assert.NoError(t, err)
+
+			value, err := VerifyProof(root, key, proof)
+			assert.NoError(t, err)
+
+			expected, ok := snap.Get(key)
+			assert.True(t, ok)
+			assert.Equal(t, expected, value)
+
+			var account state.Account
+			assert.NoError(t, account.UnmarshalRlp(value))
+
+			if i%2 != 0 {
+				continue
+			}
+
+			// storage proof in the account storage trie
+			slotKey := hashit(types.StringToHash("2").Bytes())
+
+			slotProof, err := st.GetProof(account.Root, slotKey)
+			assert.NoError(t, err)
+
+			slotValue, err := VerifyProof(account.Root, slotKey, slotProof)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, slotValue)
+		}
+
+		// proof of absence
+		missingKey := hashit(types.StringToAddress("ff").Bytes())
+
+		proof, err := st.GetProof(root, missingKey)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, proof)
+
+		value, err := VerifyProof(root, missingKey, proof)
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	}
+}
+
+func TestVerifyProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	st, root := newTestProofState(t, 100)
+	key := hashit(types.BytesToAddress(big.NewInt(1).Bytes()).Bytes())
+
+	proof, err := st.GetProof(root, key)
+	assert.NoError(t, err)
+
+	t.Run("should fail if a node is missing", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifyProof(root, key, proof[:len(proof)-1])
+
+		assert.ErrorIs(t, err, ErrProofNodeNotFound)
+	})
+
+	t.Run("should fail if a node is modified", func(t *testing.T) {
+		t.Parallel()
+
+		modified := make([][]byte, len(proof))
+		for i, node := range proof {
+			modified[i] = append([]byte{}, node...)
+		}
+
+		last := modified[len(modified)-1]
+		last[len(last)-1]++
+
+		_, err := VerifyProof(root, key, modified)
+
+		assert.ErrorIs(t, err, ErrProofNodeNotFound)
+	})
+
+	t.Run("should fail against another root", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifyProof(types.StringToHash("1"), key, proof)
+
+		assert.ErrorIs(t, err, ErrProofNodeNotFound)
+	})
+
+	t.Run("should return no value for the empty trie", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := VerifyProof(types.EmptyRootHash, key, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
@@ -13,6 +13,9 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// recordPreimages is set if the preimages of the keys of the tries are written on commit
+	recordPreimages bool
 }
 
 func NewState(storage Storage) *State {
@@ -87,3 +90,35 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// SetRecordPreimages sets whether the preimages of the keys of the tries, the addresses and the storage slots,
+// are written on commit. They are read only by the state dumps, so they are not recorded by default
+func (s *State) SetRecordPreimages(record bool) {
+	s.recordPreimages = record
+}
+
+// GetProof returns the Merkle-Patricia proof of the key in the trie with the given root
+func (s *State) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return Prove(s.storage, root, key)
+}
+
+// Iterate walks over the entries of the trie with the given root in the order of their keys,
+// starting from the given key. The walk stops when fn returns false
+func (s *State) Iterate(root types.Hash, start []byte, fn func(key, value []byte) bool) error {
+	return Iterate(s.storage, root, start, fn)
+}
+
+// GetPreimage returns the key whose hash is the given key of the tries, if it is known.
+// Only the keys committed while the preimages are recorded are known
+func (s *State) GetPreimage(hash types.Hash) ([]byte, bool) {
+	return s.storage.Get(preimageKey(hash.Bytes()))
+}
+
+// NewOverlay creates a state on top of this one whose writes are kept in memory
+// and dropped with it, so that the state can be changed without persisting the changes
+func (s *State) NewOverlay() *State {
+	overlay := NewState(NewOverlayStorage(s.storage))
+	overlay.recordPreimages = s.recordPreimages
+
+	return overlay
+}
@@ -1,12 +1,16 @@
 package itrie
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/ExzoNetwork/ExzoCoin/helper/hex"
 	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/cockroachdb/pebble"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -15,8 +19,17 @@ var parserPool fastrlp.ParserPool
 var (
 	// codePrefix is the code prefix for leveldb
 	codePrefix = []byte("code")
+
+	// preimagePrefix is the prefix of the keys whose hashes are the keys of the tries
+	preimagePrefix = []byte("preimage")
 )
 
+// EntryPrefixes are the prefixes of the entries of the storage which aren't trie nodes, by their kind
+var EntryPrefixes = map[string][]byte{
+	"code":     codePrefix,
+	"preimage": preimagePrefix,
+}
+
 type Batch interface {
 	Put(k, v []byte)
 	Write()
@@ -81,6 +94,20 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+// Iterate calls fn with the entries whose key starts with the prefix in the order of their keys
+func (kv *KVStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter := kv.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -94,6 +121,87 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (Storage, error) {
 	return &KVStorage{db}, nil
 }
 
+// pebbleStorage is a k/v storage using pebble
+type pebbleStorage struct {
+	db *pebble.DB
+}
+
+// pebbleBatch is a batch write for pebble
+type pebbleBatch struct {
+	batch *pebble.Batch
+}
+
+func (b *pebbleBatch) Put(k, v []byte) {
+	_ = b.batch.Set(k, v, nil)
+}
+
+func (b *pebbleBatch) Write() {
+	_ = b.batch.Commit(pebble.NoSync)
+}
+
+func (kv *pebbleStorage) SetCode(hash types.Hash, code []byte) {
+	kv.Put(append(codePrefix, hash.Bytes()...), code)
+}
+
+func (kv *pebbleStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	return kv.Get(append(codePrefix, hash.Bytes()...))
+}
+
+func (kv *pebbleStorage) Batch() Batch {
+	return &pebbleBatch{batch: kv.db.NewBatch()}
+}
+
+func (kv *pebbleStorage) Put(k, v []byte) {
+	_ = kv.db.Set(k, v, pebble.NoSync)
+}
+
+func (kv *pebbleStorage) Get(k []byte) ([]byte, bool) {
+	data, closer, err := kv.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false
+		} else {
+			panic(err)
+		}
+	}
+
+	// the value is valid until the closer is closed
+	res := make([]byte, len(data))
+	copy(res, data)
+
+	_ = closer.Close()
+
+	return res, true
+}
+
+// Iterate calls fn with the entries whose key starts with the prefix in the order of their keys
+func (kv *pebbleStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter := kv.db.NewIter(&pebble.IterOptions{LowerBound: prefix})
+
+	for iter.SeekGE(prefix); iter.Valid() && bytes.HasPrefix(iter.Key(), prefix); iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			_ = iter.Close()
+
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
+func (kv *pebbleStorage) Close() error {
+	return kv.db.Close()
+}
+
+func NewPebbleStorage(path string, logger hclog.Logger) (Storage, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleStorage{db}, nil
+}
+
 type memStorage struct {
 	db   map[string][]byte
 	code map[string][]byte
@@ -150,6 +258,50 @@ func (m *memBatch) Put(p, v []byte) {
 func (m *memBatch) Write() {
 }
 
+// overlayStorage keeps the writes in memory on top of a storage which is only read
+type overlayStorage struct {
+	base Storage
+	mem  Storage
+}
+
+// NewOverlayStorage creates a storage which reads through to the base storage
+// but never writes to it, the writes are kept in memory and dropped with the storage
+func NewOverlayStorage(base Storage) Storage {
+	return &overlayStorage{base: base, mem: NewMemoryStorage()}
+}
+
+func (o *overlayStorage) Put(k, v []byte) {
+	o.mem.Put(k, v)
+}
+
+func (o *overlayStorage) Get(k []byte) ([]byte, bool) {
+	if v, ok := o.mem.Get(k); ok {
+		return v, true
+	}
+
+	return o.base.Get(k)
+}
+
+func (o *overlayStorage) Batch() Batch {
+	return o.mem.Batch()
+}
+
+func (o *overlayStorage) SetCode(hash types.Hash, code []byte) {
+	o.mem.SetCode(hash, code)
+}
+
+func (o *overlayStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	if code, ok := o.mem.GetCode(hash); ok {
+		return code, true
+	}
+
+	return o.base.GetCode(hash)
+}
+
+func (o *overlayStorage) Close() error {
+	return o.mem.Close()
+}
+
 // GetNode retrieves a node from storage
 func GetNode(root []byte, storage Storage) (Node, bool, error) {
 	data, ok := storage.Get(root)
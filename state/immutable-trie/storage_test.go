@@ -0,0 +1,50 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPebbleStorage(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	s, err := NewPebbleStorage(path, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	code := []byte{0x60, 0x01}
+	codeHash := types.BytesToHash(hashit(code))
+
+	s.Put([]byte{0x1}, []byte{0x1})
+	s.SetCode(codeHash, code)
+
+	batch := s.Batch()
+	batch.Put([]byte{0x2}, []byte{0x2})
+	batch.Put([]byte{0x3}, []byte{0x3})
+	batch.Write()
+
+	assert.NoError(t, s.Close())
+
+	// the entries are persisted
+	s, err = NewPebbleStorage(path, hclog.NewNullLogger())
+	assert.NoError(t, err)
+
+	defer s.Close()
+
+	for _, k := range []byte{0x1, 0x2, 0x3} {
+		v, ok := s.Get([]byte{k})
+		assert.True(t, ok)
+		assert.Equal(t, []byte{k}, v)
+	}
+
+	_, ok := s.Get([]byte{0x4})
+	assert.False(t, ok)
+
+	res, ok := s.GetCode(codeHash)
+	assert.True(t, ok)
+	assert.Equal(t, code, res)
+}
@@ -107,6 +107,11 @@ func (t *Trie) Get(k []byte) ([]byte, bool) {
 	return res, res != nil
 }
 
+// preimageKey is the key of the preimage of the hashed key in the storage
+func preimageKey(hash []byte) []byte {
+	return append(append([]byte{}, preimagePrefix...), hash...)
+}
+
 func hashit(k []byte) []byte {
 	h := sha3.NewLegacyKeccak256()
 	h.Write(k)
@@ -163,6 +168,10 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 					} else {
 						vv := ar1.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
 						localTxn.Insert(k, vv.MarshalTo(nil))
+
+						if t.state.recordPreimages {
+							batch.Put(preimageKey(k), entry.Key)
+						}
 					}
 				}
 
@@ -182,7 +191,13 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			key := hashit(obj.Address.Bytes())
+
+			tt.Insert(key, data)
+
+			if t.state.recordPreimages {
+				batch.Put(preimageKey(key), obj.Address.Bytes())
+			}
 			arena.Reset()
 		}
 	}
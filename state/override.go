@@ -0,0 +1,99 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	iradix "github.com/hashicorp/go-immutable-radix"
+
+	"github.com/ExzoNetwork/ExzoCoin/types"
+)
+
+// Override is the override of the account applied before the execution of a call,
+// the fields left nil are not overridden
+type Override struct {
+	Nonce   *uint64
+	Code    []byte
+	Balance *big.Int
+	// State replaces the whole storage of the account
+	State map[types.Hash]types.Hash
+	// StateDiff replaces the given slots of the storage of the account
+	StateDiff map[types.Hash]types.Hash
+}
+
+// StateOverride is the set of the overrides of the accounts
+type StateOverride map[types.Address]Override
+
+// BlockOverride is the override of the block context applied before the execution of a call,
+// the fields left nil are not overridden
+type BlockOverride struct {
+	Number    *uint64
+	Timestamp *uint64
+	Coinbase  *types.Address
+	GasLimit  *uint64
+}
+
+// ApplyStateOverride applies the overrides to the state of the transition.
+// NOTE: ApplyStateOverride changes the world state without a transaction
+func (t *Transition) ApplyStateOverride(override StateOverride) error {
+	for addr, account := range override {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both state and stateDiff overrides", addr)
+		}
+
+		if account.Nonce != nil {
+			t.state.SetNonce(addr, *account.Nonce)
+		}
+
+		if account.Code != nil {
+			t.state.SetCode(addr, account.Code)
+		}
+
+		if account.Balance != nil {
+			t.state.SetBalance(addr, account.Balance)
+		}
+
+		if account.State != nil {
+			t.state.resetStorage(addr)
+
+			for key, value := range account.State {
+				t.state.SetState(addr, key, value)
+			}
+		}
+
+		for key, value := range account.StateDiff {
+			t.state.SetState(addr, key, value)
+		}
+	}
+
+	return nil
+}
+
+// ApplyBlockOverride applies the override to the block context of the transition
+func (t *Transition) ApplyBlockOverride(override *BlockOverride) {
+	if override.Number != nil {
+		t.ctx.Number = int64(*override.Number)
+	}
+
+	if override.Timestamp != nil {
+		t.ctx.Timestamp = int64(*override.Timestamp)
+	}
+
+	if override.Coinbase != nil {
+		t.ctx.Coinbase = *override.Coinbase
+	}
+
+	if override.GasLimit != nil {
+		t.ctx.GasLimit = int64(*override.GasLimit)
+		t.gasPool = *override.GasLimit
+	}
+}
+
+// resetStorage drops the whole storage of the address
+func (txn *Txn) resetStorage(addr types.Address) {
+	txn.upsertAccount(addr, true, func(object *StateObject) {
+		object.Account.Trie = txn.state.NewSnapshot()
+		object.Account.Root = emptyStateHash
+		object.Txn = iradix.New().Txn()
+	})
+}
@@ -159,3 +159,74 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransition_ApplyStateOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should override the account", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestTransition(nil)
+		nonce := uint64(5)
+
+		assert.NoError(t, transition.ApplyStateOverride(StateOverride{
+			addr1: {
+				Nonce:     &nonce,
+				Code:      []byte{0x1},
+				Balance:   big.NewInt(100),
+				StateDiff: map[types.Hash]types.Hash{hash1: hash2},
+			},
+		}))
+
+		assert.Equal(t, nonce, transition.GetNonce(addr1))
+		assert.Equal(t, []byte{0x1}, transition.GetCode(addr1))
+		assert.Equal(t, big.NewInt(100), transition.GetBalance(addr1))
+		assert.Equal(t, hash2, transition.GetStorage(addr1, hash1))
+
+		// the full state drops the slots which are not in the override
+		assert.NoError(t, transition.ApplyStateOverride(StateOverride{
+			addr1: {
+				State: map[types.Hash]types.Hash{hash2: hash1},
+			},
+		}))
+
+		assert.Equal(t, types.Hash{}, transition.GetStorage(addr1, hash1))
+		assert.Equal(t, hash1, transition.GetStorage(addr1, hash2))
+		assert.Equal(t, nonce, transition.GetNonce(addr1))
+	})
+
+	t.Run("should reject both state and stateDiff", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTestTransition(nil)
+
+		assert.Error(t, transition.ApplyStateOverride(StateOverride{
+			addr2: {
+				State:     map[types.Hash]types.Hash{},
+				StateDiff: map[types.Hash]types.Hash{},
+			},
+		}))
+	})
+}
+
+func TestTransition_ApplyBlockOverride(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(nil)
+	number, timestamp, gasLimit := uint64(10), uint64(20), uint64(30)
+
+	transition.ApplyBlockOverride(&BlockOverride{
+		Number:    &number,
+		Timestamp: &timestamp,
+		Coinbase:  &addr2,
+		GasLimit:  &gasLimit,
+	})
+
+	assert.Equal(t, runtime.TxContext{
+		Number:    10,
+		Timestamp: 20,
+		Coinbase:  addr2,
+		GasLimit:  30,
+	}, transition.GetTxContext())
+	assert.Equal(t, gasLimit, transition.gasPool)
+}
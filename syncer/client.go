@@ -132,6 +132,8 @@ func (m *syncPeerClient) GetConnectedPeerStatuses() []*NoForkPeer {
 			status, err := m.GetPeerStatus(peerID)
 			if err != nil {
 				m.logger.Warn("failed to get status from a peer, skip", "id", peerID, "err", err)
+
+				return
 			}
 
 			syncPeersLock.Lock()
@@ -294,6 +296,88 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetHeaders returns a stream of headers from given height to peer's latest
+func (m *syncPeerClient) GetHeaders(
+	peerID peer.ID,
+	from uint64,
+	timeoutPerHeader time.Duration,
+) (<-chan *types.Header, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := clt.GetHeaders(ctx, &proto.GetHeadersRequest{
+		From: from,
+	})
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to open GetHeaders stream: %w", err)
+	}
+
+	// input channel
+	streamHeaderCh, streamErrorCh := headerStreamToChannel(stream)
+
+	// output channel
+	headerCh := make(chan *types.Header, 1)
+
+	go func() {
+		defer cancel()
+		defer close(headerCh)
+
+		for {
+			select {
+			case header, ok := <-streamHeaderCh:
+				if !ok {
+					return
+				}
+
+				headerCh <- header
+			case err := <-streamErrorCh:
+				m.logger.Error("failed to get header from gRPC stream", "peer", peerID, "err", err)
+
+				return
+			case <-time.After(timeoutPerHeader):
+				m.logger.Warn("header doesn't reach within timeout", "timeout", timeoutPerHeader)
+
+				return
+			}
+		}
+	}()
+
+	return headerCh, nil
+}
+
+// GetStateProof fetches the proof of the key in the trie with the root from peer.
+// The connection of the request isn't saved as the protocol stream of the peer, which would replace
+// the one of a running sync, and is closed once the proof is received
+func (m *syncPeerClient) GetStateProof(peerID peer.ID, root types.Hash, key []byte) ([][]byte, error) {
+	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	defer conn.Close()
+
+	clt := proto.NewSyncPeerClient(conn)
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForStatus)
+	defer cancel()
+
+	resp, err := clt.GetStateProof(timeoutCtx, &proto.GetStateProofRequest{
+		Root: root.Bytes(),
+		Key:  key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Proof, nil
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -348,3 +432,46 @@ func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.
 
 	return blockCh, errorCh
 }
+
+// fromProtoHeader gets header from gRPC response data
+func fromProtoHeader(protoHeader *proto.Header) (*types.Header, error) {
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(protoHeader.Header); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func headerStreamToChannel(stream proto.SyncPeer_GetHeadersClient) (<-chan *types.Header, <-chan error) {
+	headerCh := make(chan *types.Header)
+	errorCh := make(chan error, 1)
+
+	go func() {
+		defer close(headerCh)
+
+		for {
+			protoHeader, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				errorCh <- err
+
+				break
+			}
+
+			header, err := fromProtoHeader(protoHeader)
+			if err != nil {
+				errorCh <- err
+
+				break
+			}
+
+			headerCh <- header
+		}
+	}()
+
+	return headerCh, errorCh
+}
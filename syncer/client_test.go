@@ -2,19 +2,25 @@ package syncer
 
 import (
 	"context"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
 	"github.com/ExzoNetwork/ExzoCoin/network"
 	"github.com/ExzoNetwork/ExzoCoin/network/event"
 	"github.com/ExzoNetwork/ExzoCoin/network/grpc"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
 	"github.com/ExzoNetwork/ExzoCoin/syncer/proto"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
+	rawGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 var (
@@ -521,3 +527,102 @@ func Test_syncPeerClient_GetBlocks(t *testing.T) {
 
 	assert.Equal(t, expected, blocks)
 }
+
+// connRecordingNetwork records the connections opened and saved by the client
+type connRecordingNetwork struct {
+	Network
+
+	lock   sync.Mutex
+	opened []*rawGrpc.ClientConn
+	saved  []*rawGrpc.ClientConn
+}
+
+func (n *connRecordingNetwork) NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error) {
+	conn, err := n.Network.NewProtoConnection(protocol, peerID)
+	if err == nil {
+		n.lock.Lock()
+		n.opened = append(n.opened, conn)
+		n.lock.Unlock()
+	}
+
+	return conn, err
+}
+
+func (n *connRecordingNetwork) SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID) {
+	n.lock.Lock()
+	n.saved = append(n.saved, stream)
+	n.lock.Unlock()
+
+	n.Network.SaveProtocolStream(protocol, stream, peerID)
+}
+
+func Test_syncPeerClient_GetStateProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		st   = itrie.NewState(itrie.NewMemoryStorage())
+		addr = types.StringToAddress("1")
+		key  = keccak.Keccak256(nil, addr.Bytes())
+	)
+
+	_, rawRoot := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr,
+			Balance:  big.NewInt(100),
+			CodeHash: types.BytesToHash(keccak.Keccak256(nil, nil)),
+			Root:     types.EmptyRootHash,
+		},
+	})
+
+	root := types.BytesToHash(rawRoot)
+
+	clientSrv := newTestNetwork(t)
+	clientNetwork := &connRecordingNetwork{Network: clientSrv}
+	client := newTestSyncPeerClient(clientNetwork, nil)
+
+	service, peerSrv := createTestSyncerService(t, &mockBlockchain{
+		headerHandler: newSimpleHeaderHandler(10),
+	})
+	service.prover = st
+
+	err := network.JoinAndWait(
+		clientSrv,
+		peerSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	)
+
+	assert.NoError(t, err)
+
+	peerID := peerSrv.AddrInfo().ID
+
+	// the connection of a running sync
+	_, err = client.newSyncPeerClient(peerID)
+	assert.NoError(t, err)
+
+	syncer := &syncer{
+		logger:         hclog.NewNullLogger(),
+		syncPeerClient: client,
+		peerMap:        new(PeerMap),
+	}
+
+	syncer.peerMap.Put(&NoForkPeer{ID: peerID, Number: 10, Distance: big.NewInt(1)})
+
+	for i := 0; i < 5; i++ {
+		value, err := syncer.GetStateValue(root, key)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, value)
+	}
+
+	clientNetwork.lock.Lock()
+	defer clientNetwork.lock.Unlock()
+
+	// the proof requests neither replace the connection of the sync nor leave their connections open
+	assert.Equal(t, 1, len(clientNetwork.saved))
+	assert.Equal(t, 6, len(clientNetwork.opened))
+	assert.NotEqual(t, connectivity.Shutdown, clientNetwork.saved[0].GetState())
+
+	for _, conn := range clientNetwork.opened[1:] {
+		assert.Equal(t, connectivity.Shutdown, conn.GetState())
+	}
+}
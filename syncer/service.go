@@ -11,7 +11,9 @@ import (
 )
 
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound          = errors.New("block not found")
+	ErrStateProofNotSupported = errors.New("state proof is not supported")
+	ErrLightPeer              = errors.New("light peer has no blocks")
 )
 
 type syncPeerService struct {
@@ -19,16 +21,33 @@ type syncPeerService struct {
 
 	blockchain Blockchain       // reference to the blockchain module
 	network    Network          // reference to the network module
+	prover     StateProver      // reference to the state to prove the values, optional
 	stream     *grpc.GrpcStream // reference to the grpc stream
+	light      bool             // flag indicating if the node keeps only headers
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	prover StateProver,
 ) SyncPeerService {
 	return &syncPeerService{
 		blockchain: blockchain,
 		network:    network,
+		prover:     prover,
+	}
+}
+
+// NewLightSyncPeerService creates the service of the node keeping only headers.
+// It serves only the headers, and refuses the status requests so that the peers don't sync from it
+func NewLightSyncPeerService(
+	network Network,
+	blockchain Blockchain,
+) SyncPeerService {
+	return &syncPeerService{
+		blockchain: blockchain,
+		network:    network,
+		light:      true,
 	}
 }
 
@@ -56,6 +75,10 @@ func (s *syncPeerService) GetBlocks(
 	req *proto.GetBlocksRequest,
 	stream proto.SyncPeer_GetBlocksServer,
 ) error {
+	if s.light {
+		return ErrLightPeer
+	}
+
 	// from to latest
 	for i := req.From; i <= s.blockchain.Header().Number; i++ {
 		block, ok := s.blockchain.GetBlockByNumber(i, true)
@@ -74,11 +97,55 @@ func (s *syncPeerService) GetBlocks(
 	return nil
 }
 
+// GetHeaders is a gRPC endpoint to return headers from the specific height via stream
+func (s *syncPeerService) GetHeaders(
+	req *proto.GetHeadersRequest,
+	stream proto.SyncPeer_GetHeadersServer,
+) error {
+	// from to latest
+	for i := req.From; i <= s.blockchain.Header().Number; i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return ErrBlockNotFound
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(toProtoHeader(header)); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetStateProof is a gRPC endpoint to return the proof of the key in the trie with the root
+func (s *syncPeerService) GetStateProof(
+	ctx context.Context,
+	req *proto.GetStateProofRequest,
+) (*proto.StateProof, error) {
+	if s.prover == nil {
+		return nil, ErrStateProofNotSupported
+	}
+
+	proof, err := s.prover.GetProof(types.BytesToHash(req.Root), req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.StateProof{
+		Proof: proof,
+	}, nil
+}
+
 // GetStatus is a gRPC endpoint to return the latest block number as a node status
 func (s *syncPeerService) GetStatus(
 	ctx context.Context,
 	req *empty.Empty,
 ) (*proto.SyncPeerStatus, error) {
+	if s.light {
+		return nil, ErrLightPeer
+	}
+
 	var number uint64
 	if header := s.blockchain.Header(); header != nil {
 		number = header.Number
@@ -95,3 +162,10 @@ func toProtoBlock(block *types.Block) *proto.Block {
 		Block: block.MarshalRLP(),
 	}
 }
+
+// toProtoHeader converts type.Header -> proto.Header
+func toProtoHeader(header *types.Header) *proto.Header {
+	return &proto.Header{
+		Header: header.MarshalRLP(),
+	}
+}
@@ -153,3 +153,131 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+func Test_syncPeerService_GetHeaders(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(10)
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(10),
+			getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+				if u < 1 || u > uint64(len(blocks)) {
+					return nil, false
+				}
+
+				return blocks[u-1].Header, true
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	stream, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{
+		From: 5,
+	})
+
+	assert.NoError(t, err)
+
+	received := make([][]byte, 0, 6)
+
+	for {
+		protoHeader, err := stream.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+
+			break
+		}
+
+		received = append(received, protoHeader.Header)
+	}
+
+	expected := make([][]byte, 0, 6)
+	for _, b := range blocks[4:] {
+		expected = append(expected, b.Header.MarshalRLP())
+	}
+
+	assert.Equal(t, expected, received)
+}
+
+type mockStateProver struct {
+	getProofHandler func(types.Hash, []byte) ([][]byte, error)
+}
+
+func (m *mockStateProver) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return m.getProofHandler(root, key)
+}
+
+func TestGetStateProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		root  = types.StringToHash("root")
+		key   = []byte{0x1}
+		proof = [][]byte{{0x2}, {0x3}}
+	)
+
+	t.Run("should return the proof of the prover", func(t *testing.T) {
+		t.Parallel()
+
+		service := &syncPeerService{
+			prover: &mockStateProver{
+				getProofHandler: func(r types.Hash, k []byte) ([][]byte, error) {
+					assert.Equal(t, root, r)
+					assert.Equal(t, key, k)
+
+					return proof, nil
+				},
+			},
+		}
+
+		client := newMockGrpcClient(t, service)
+
+		resp, err := client.GetStateProof(context.Background(), &proto.GetStateProofRequest{
+			Root: root.Bytes(),
+			Key:  key,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, proof, resp.Proof)
+	})
+
+	t.Run("should return error without prover", func(t *testing.T) {
+		t.Parallel()
+
+		client := newMockGrpcClient(t, &syncPeerService{})
+
+		_, err := client.GetStateProof(context.Background(), &proto.GetStateProofRequest{
+			Root: root.Bytes(),
+			Key:  key,
+		})
+
+		assert.ErrorContains(t, err, ErrStateProofNotSupported.Error())
+	})
+}
+
+func TestLightSyncPeerService(t *testing.T) {
+	t.Parallel()
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(10),
+		},
+		light: true,
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	// the light peer refuses the status request so that the peers don't sync blocks from it
+	_, err := client.GetStatus(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, err, ErrLightPeer.Error())
+
+	stream, err := client.GetBlocks(context.Background(), &proto.GetBlocksRequest{
+		From: 1,
+	})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.ErrorContains(t, err, ErrLightPeer.Error())
+}
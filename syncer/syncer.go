@@ -7,6 +7,7 @@ import (
 
 	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
 	"github.com/ExzoNetwork/ExzoCoin/network/event"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -19,6 +20,8 @@ const (
 
 var (
 	errTimeout = errors.New("timeout awaiting block from peer")
+
+	ErrNoStateProofPeer = errors.New("no peer could prove the state")
 )
 
 // XXX: Don't use this syncer for the consensus that may cause fork.
@@ -43,13 +46,14 @@ func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	prover StateProver,
 	blockTimeout time.Duration,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, prover),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
@@ -57,6 +61,29 @@ func NewSyncer(
 	}
 }
 
+// NewLightSyncer creates the syncer of the node keeping only headers.
+// It neither serves the blocks nor publishes its status, as it has no bodies and no state
+func NewLightSyncer(
+	logger hclog.Logger,
+	network Network,
+	blockchain Blockchain,
+	blockTimeout time.Duration,
+) Syncer {
+	syncPeerClient := NewSyncPeerClient(logger, network, blockchain)
+	syncPeerClient.DisablePublishingPeerStatus()
+
+	return &syncer{
+		logger:          logger.Named(syncerName),
+		blockchain:      blockchain,
+		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
+		syncPeerService: NewLightSyncPeerService(network, blockchain),
+		syncPeerClient:  syncPeerClient,
+		blockTimeout:    blockTimeout,
+		newStatusCh:     make(chan struct{}),
+		peerMap:         new(PeerMap),
+	}
+}
+
 // Start starts goroutine processes
 func (s *syncer) Start() error {
 	if err := s.syncPeerClient.Start(); err != nil {
@@ -160,6 +187,20 @@ func (s *syncer) HasSyncPeer() bool {
 
 // Sync syncs block with the best peer until callback returns true
 func (s *syncer) Sync(callback func(*types.Block) bool) error {
+	return s.sync(func(peerID peer.ID) (uint64, bool, error) {
+		return s.bulkSyncWithPeer(peerID, callback)
+	})
+}
+
+// SyncHeaders syncs header with the best peer until callback returns true
+func (s *syncer) SyncHeaders(callback func(*types.Header) bool) error {
+	return s.sync(func(peerID peer.ID) (uint64, bool, error) {
+		return s.bulkSyncHeadersWithPeer(peerID, callback)
+	})
+}
+
+// sync picks the best peer and bulk syncs with it until bulkSync returns true
+func (s *syncer) sync(bulkSync func(peer.ID) (uint64, bool, error)) error {
 	localLatest := s.blockchain.Header().Number
 	skipList := make(map[peer.ID]bool)
 
@@ -187,7 +228,7 @@ func (s *syncer) Sync(callback func(*types.Block) bool) error {
 		}
 
 		// fetch block from the peer
-		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, callback)
+		lastNumber, shouldTerminate, err := bulkSync(bestPeer.ID)
 		if err != nil {
 			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
 		}
@@ -254,3 +295,86 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.B
 		}
 	}
 }
+
+// bulkSyncHeadersWithPeer syncs header with a given peer
+func (s *syncer) bulkSyncHeadersWithPeer(
+	peerID peer.ID,
+	newHeaderCallback func(*types.Header) bool,
+) (uint64, bool, error) {
+	localLatest := s.blockchain.Header().Number
+	shouldTerminate := false
+
+	headerCh, err := s.syncPeerClient.GetHeaders(peerID, localLatest+1, s.blockTimeout)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer func() {
+		err := s.syncPeerClient.CloseStream(peerID)
+		if err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	var lastReceivedNumber uint64
+
+	for {
+		select {
+		case header, ok := <-headerCh:
+			if !ok {
+				return lastReceivedNumber, shouldTerminate, nil
+			}
+
+			// safe check
+			if header.Number == 0 {
+				continue
+			}
+
+			if err := s.blockchain.VerifyFinalizedHeader(header); err != nil {
+				return lastReceivedNumber, false, fmt.Errorf("unable to verify header, %w", err)
+			}
+
+			if err := s.blockchain.WriteFinalizedHeader(header, syncerName); err != nil {
+				return lastReceivedNumber, false, fmt.Errorf("failed to write header while bulk syncing: %w", err)
+			}
+
+			shouldTerminate = newHeaderCallback(header)
+
+			lastReceivedNumber = header.Number
+		case <-time.After(s.blockTimeout):
+			return lastReceivedNumber, shouldTerminate, errTimeout
+		}
+	}
+}
+
+// GetStateValue fetches the proof of the key in the trie with the root from the peers,
+// and returns the value of the key once a proof is verified against the root.
+// It returns nil value if the proof shows the key doesn't exist
+func (s *syncer) GetStateValue(root types.Hash, key []byte) ([]byte, error) {
+	skipList := make(map[peer.ID]bool)
+
+	for {
+		bestPeer := s.peerMap.BestPeer(skipList)
+		if bestPeer == nil {
+			return nil, ErrNoStateProofPeer
+		}
+
+		skipList[bestPeer.ID] = true
+
+		proof, err := s.syncPeerClient.GetStateProof(bestPeer.ID, root, key)
+		if err != nil {
+			s.logger.Debug("failed to get state proof from peer, try to next one", "peer", bestPeer.ID, "err", err)
+
+			continue
+		}
+
+		value, err := itrie.VerifyProof(root, key, proof)
+		if err != nil {
+			s.logger.Warn("invalid state proof from peer, try to next one", "peer", bestPeer.ID, "err", err)
+
+			continue
+		}
+
+		return value, nil
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/ExzoNetwork/ExzoCoin/blockchain"
+	"github.com/ExzoNetwork/ExzoCoin/helper/keccak"
 	"github.com/ExzoNetwork/ExzoCoin/helper/progress"
 	"github.com/ExzoNetwork/ExzoCoin/network/event"
+	"github.com/ExzoNetwork/ExzoCoin/state"
+	itrie "github.com/ExzoNetwork/ExzoCoin/state/immutable-trie"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -36,11 +39,14 @@ func (m *mockProgression) GetProgression() *progress.Progression {
 }
 
 type mockBlockchain struct {
-	subscription                blockchain.Subscription
-	headerHandler               func() *types.Header
-	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
-	verifyFinalizedBlockHandler func(*types.Block) error
-	writeBlockHandler           func(*types.Block) error
+	subscription                 blockchain.Subscription
+	headerHandler                func() *types.Header
+	getBlockByNumberHandler      func(uint64, bool) (*types.Block, bool)
+	getHeaderByNumberHandler     func(uint64) (*types.Header, bool)
+	verifyFinalizedBlockHandler  func(*types.Block) error
+	writeBlockHandler            func(*types.Block) error
+	verifyFinalizedHeaderHandler func(*types.Header) error
+	writeFinalizedHeaderHandler  func(*types.Header) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -55,6 +61,10 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	return m.getHeaderByNumberHandler(number)
+}
+
 func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) error {
 	return m.verifyFinalizedBlockHandler(b)
 }
@@ -63,6 +73,14 @@ func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedHeader(h *types.Header) error {
+	return m.verifyFinalizedHeaderHandler(h)
+}
+
+func (m *mockBlockchain) WriteFinalizedHeader(h *types.Header, s string) error {
+	return m.writeFinalizedHeaderHandler(h)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -85,6 +103,8 @@ type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getHeadersHandler                     func(peer.ID, uint64, time.Duration) (<-chan *types.Header, error)
+	getStateProofHandler                  func(peer.ID, types.Hash, []byte) ([][]byte, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
 }
@@ -115,6 +135,18 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetHeaders(
+	id peer.ID,
+	start uint64,
+	timeoutPerHeader time.Duration,
+) (<-chan *types.Header, error) {
+	return m.getHeadersHandler(id, start, timeoutPerHeader)
+}
+
+func (m *mockSyncPeerClient) GetStateProof(id peer.ID, root types.Hash, key []byte) ([][]byte, error) {
+	return m.getStateProofHandler(id, root, key)
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -852,3 +884,195 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		})
 	}
 }
+
+func headersToCh(headers []*types.Header) <-chan *types.Header {
+	ch := make(chan *types.Header)
+
+	go func() {
+		for _, h := range headers {
+			ch <- h
+		}
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+func Test_bulkSyncHeadersWithPeer(t *testing.T) {
+	t.Parallel()
+
+	headers := make([]*types.Header, 10) // 1 to 10
+
+	for i := range headers {
+		headers[i] = &types.Header{
+			Number: uint64(i + 1),
+		}
+	}
+
+	errInvalidHeader := errors.New("invalid header")
+
+	tests := []struct {
+		name string
+
+		verifyFinalizedHeaderHandler func(*types.Header) error
+
+		// results
+		headers               []*types.Header
+		lastSyncedBlockNumber uint64
+		err                   error
+	}{
+		{
+			name: "should sync headers to the latest successfully",
+			verifyFinalizedHeaderHandler: func(h *types.Header) error {
+				return nil
+			},
+			headers:               headers,
+			lastSyncedBlockNumber: 10,
+			err:                   nil,
+		},
+		{
+			name: "should return error if verification is failed",
+			verifyFinalizedHeaderHandler: func(h *types.Header) error {
+				if h.Number > 5 {
+					return errInvalidHeader
+				}
+
+				return nil
+			},
+			headers:               headers[:5],
+			lastSyncedBlockNumber: 5,
+			err:                   errInvalidHeader,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				syncedHeaders = make([]*types.Header, 0, len(test.headers))
+
+				syncer = NewTestSyncer(
+					nil,
+					&mockBlockchain{
+						headerHandler:                newSimpleHeaderHandler(0),
+						verifyFinalizedHeaderHandler: test.verifyFinalizedHeaderHandler,
+						writeFinalizedHeaderHandler: func(h *types.Header) error {
+							syncedHeaders = append(syncedHeaders, h)
+
+							return nil
+						},
+					},
+					time.Second,
+					&mockSyncPeerClient{
+						getHeadersHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Header, error) {
+							assert.Equal(t, uint64(1), start)
+
+							return headersToCh(headers), nil
+						},
+					},
+					&mockProgression{},
+				)
+			)
+
+			lastSynced, shouldTerminate, err := syncer.bulkSyncHeadersWithPeer(
+				peer.ID("X"),
+				func(h *types.Header) bool { return false },
+			)
+
+			assert.Equal(t, test.lastSyncedBlockNumber, lastSynced)
+			assert.False(t, shouldTerminate)
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.headers, syncedHeaders)
+		})
+	}
+}
+
+func TestGetStateValue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		st   = itrie.NewState(itrie.NewMemoryStorage())
+		addr = types.StringToAddress("1")
+		key  = keccak.Keccak256(nil, addr.Bytes())
+	)
+
+	_, rawRoot := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr,
+			Balance:  big.NewInt(100),
+			CodeHash: types.BytesToHash(keccak.Keccak256(nil, nil)),
+			Root:     types.EmptyRootHash,
+		},
+	})
+
+	root := types.BytesToHash(rawRoot)
+
+	snap, err := st.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	expected, ok := snap.Get(key)
+	assert.True(t, ok)
+
+	proof, err := st.GetProof(root, key)
+	assert.NoError(t, err)
+
+	t.Run("should skip the peers failing to prove the value", func(t *testing.T) {
+		t.Parallel()
+
+		requested := make([]peer.ID, 0, len(peerStatuses))
+
+		syncer := NewTestSyncer(
+			nil,
+			nil,
+			0,
+			&mockSyncPeerClient{
+				getStateProofHandler: func(id peer.ID, r types.Hash, k []byte) ([][]byte, error) {
+					requested = append(requested, id)
+
+					switch id {
+					case peer.ID("C"):
+						return nil, errors.New("state not found")
+					case peer.ID("B"):
+						// the proof without the nodes
+						return [][]byte{}, nil
+					default:
+						return proof, nil
+					}
+				},
+			},
+			&mockProgression{},
+		)
+
+		syncer.peerMap.Put(peerStatuses...)
+
+		value, err := syncer.GetStateValue(root, key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+		assert.Equal(t, []peer.ID{"C", "B", "A"}, requested)
+	})
+
+	t.Run("should return error if no peer proves the value", func(t *testing.T) {
+		t.Parallel()
+
+		syncer := NewTestSyncer(
+			nil,
+			nil,
+			0,
+			&mockSyncPeerClient{
+				getStateProofHandler: func(id peer.ID, r types.Hash, k []byte) ([][]byte, error) {
+					return nil, errors.New("state not found")
+				},
+			},
+			&mockProgression{},
+		)
+
+		syncer.peerMap.Put(peerStatuses...)
+
+		_, err := syncer.GetStateValue(root, key)
+		assert.ErrorIs(t, err, ErrNoStateProofPeer)
+	})
+}
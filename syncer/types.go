@@ -22,10 +22,22 @@ type Blockchain interface {
 	Header() *types.Header
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(*types.Block) error
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
+	// VerifyFinalizedHeader verifies finalized header
+	VerifyFinalizedHeader(*types.Header) error
+	// WriteFinalizedHeader writes a given header to chain without the body
+	WriteFinalizedHeader(*types.Header, string) error
+}
+
+// StateProver is implemented by the state that can prove the values in its tries
+type StateProver interface {
+	// GetProof returns the proof of the key in the trie with the root
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
 }
 
 type Network interface {
@@ -63,6 +75,10 @@ type Syncer interface {
 	HasSyncPeer() bool
 	// Sync starts routine to sync blocks
 	Sync(func(*types.Block) bool) error
+	// SyncHeaders starts routine to sync headers only
+	SyncHeaders(func(*types.Header) bool) error
+	// GetStateValue returns the value of the key in the trie with the root, proven by a peer
+	GetStateValue(root types.Hash, key []byte) ([]byte, error)
 }
 
 type Progression interface {
@@ -94,6 +110,10 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetHeaders returns a stream of headers from given height to peer's latest
+	GetHeaders(peer.ID, uint64, time.Duration) (<-chan *types.Header, error)
+	// GetStateProof fetches the proof of the key in the trie with the root from peer
+	GetStateProof(peer.ID, types.Hash, []byte) ([][]byte, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
@@ -218,6 +218,39 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	return
 }
 
+// rewind moves the account back to the lower nonce after the chain has been rolled back.
+// The promoted transactions are demoted to the enqueued queue, so that they're promoted
+// again once the transactions of the removed blocks are re-injected, filling the nonce gap.
+func (a *account) rewind(nonce uint64, promoteCh chan<- promoteRequest) (
+	demoted []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	defer a.promoted.unlock()
+
+	if nonce >= a.getNonce() {
+		return
+	}
+
+	a.enqueued.lock(true)
+	defer a.enqueued.unlock()
+
+	// the cleared queue shares its backing array with the promoted queue
+	demoted = append(demoted, a.promoted.clear()...)
+
+	for _, tx := range demoted {
+		a.enqueued.push(tx)
+	}
+
+	a.setNonce(nonce)
+
+	if first := a.enqueued.peek(); first != nil &&
+		first.Nonce == nonce {
+		promoteCh <- promoteRequest{account: first.From}
+	}
+
+	return
+}
+
 // enqueue attempts tp push the transaction onto the enqueued queue.
 func (a *account) enqueue(tx *types.Transaction) error {
 	a.enqueued.lock(true)
@@ -66,3 +66,21 @@ type mockSigner struct {
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+// reorgMockStore serves the blocks removed by a reorg and the nonces of the new head
+type reorgMockStore struct {
+	defaultMockStore
+
+	nonces map[types.Address]uint64
+	blocks map[types.Hash]*types.Block
+}
+
+func (m *reorgMockStore) GetNonce(_ types.Hash, addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func (m *reorgMockStore) GetBlockByHash(hash types.Hash, _ bool) (*types.Block, bool) {
+	block, ok := m.blocks[hash]
+
+	return block, ok
+}
@@ -64,6 +64,27 @@ func (q *accountQueue) prune(nonce uint64) (
 	return
 }
 
+// dropFrom removes all transactions from the queue
+// with nonce greater or equal to given.
+func (q *accountQueue) dropFrom(nonce uint64) (
+	dropped []*types.Transaction,
+) {
+	kept := make(minNonceQueue, 0, q.queue.Len())
+
+	for _, tx := range q.queue {
+		if tx.Nonce >= nonce {
+			dropped = append(dropped, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	q.queue = kept
+	heap.Init(&q.queue)
+
+	return
+}
+
 // clear removes all transactions from the queue.
 func (q *accountQueue) clear() (removed []*types.Transaction) {
 	// store txs
@@ -454,6 +454,76 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	)
 }
 
+// DropTx removes the transaction with the given hash from the pool, together with
+// the transactions of its sender with higher nonces, which can't be executed without it.
+// Returns the hashes of the dropped transactions.
+func (p *TxPool) DropTx(hash types.Hash) []types.Hash {
+	tx, ok := p.index.get(hash)
+	if !ok {
+		return nil
+	}
+
+	return p.dropAccountTxs(tx.From, tx.Nonce, tx.Nonce)
+}
+
+// DropAccount removes all transactions of the given account from the pool
+// and moves the account back to its nonce in the state of the current head.
+// Returns the hashes of the dropped transactions.
+func (p *TxPool) DropAccount(addr types.Address) []types.Hash {
+	if !p.accounts.exists(addr) {
+		return nil
+	}
+
+	stateNonce := p.store.GetNonce(p.store.Header().StateRoot, addr)
+
+	return p.dropAccountTxs(addr, 0, stateNonce)
+}
+
+// dropAccountTxs removes the transactions of the account with nonce greater or
+// equal to fromNonce and rolls the next nonce of the account back to nextNonce.
+func (p *TxPool) dropAccountTxs(addr types.Address, fromNonce, nextNonce uint64) []types.Hash {
+	account := p.accounts.get(addr)
+
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+
+	defer func() {
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	droppedPromoted := account.promoted.dropFrom(fromNonce)
+	droppedEnqueued := account.enqueued.dropFrom(fromNonce)
+
+	// rollback nonce
+	if nextNonce < account.getNonce() {
+		account.setNonce(nextNonce)
+	}
+
+	dropped := append(droppedPromoted, droppedEnqueued...)
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	// pool resource cleanup
+	p.index.remove(dropped...)
+	p.gauge.decrease(slotsRequired(dropped...))
+
+	// update metrics
+	p.metrics.PendingTxs.Add(float64(-1 * len(droppedPromoted)))
+
+	hashes := toHash(dropped...)
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, hashes...)
+	p.logger.Debug("dropped account txs",
+		"num", len(dropped),
+		"next_nonce", account.getNonce(),
+		"address", addr.String(),
+	)
+
+	return hashes
+}
+
 // Demote excludes an account from being further processed during block building
 // due to a recoverable error. If an account has been demoted too many times (maxAccountDemotions),
 // it is Dropped instead.
@@ -490,6 +560,12 @@ func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
 	p.processEvent(e)
 }
 
+// ResetWithReorg returns the transactions of the blocks removed from the canonical chain
+// by the reorg to the pool, and syncs the pool with the state of the new head.
+func (p *TxPool) ResetWithReorg(event *blockchain.Event) {
+	p.processEvent(event)
+}
+
 // processEvent collects the latest nonces for each account containted
 // in the received event. Resets all known accounts with the new nonce.
 func (p *TxPool) processEvent(event *blockchain.Event) {
@@ -557,6 +633,11 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		}
 	}
 
+	// the accounts expect the nonces of the removed transactions again
+	if len(oldTxs) > 0 {
+		p.rewindAccounts(oldTxs, stateRoot)
+	}
+
 	// Legacy reorg logic //
 	for _, tx := range oldTxs {
 		if err := p.addTx(reorg, tx); err != nil {
@@ -881,6 +962,41 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	}
 }
 
+// rewindAccounts moves the accounts of the transactions back to their nonces in the state
+// of the new head, demoting their promoted transactions.
+func (p *TxPool) rewindAccounts(txs map[types.Hash]*types.Transaction, stateRoot types.Hash) {
+	var allDemoted []*types.Transaction
+
+	rewound := make(map[types.Address]struct{})
+
+	for _, tx := range txs {
+		addr := tx.From
+		if addr == types.ZeroAddress {
+			var err error
+
+			if addr, err = p.signer.Sender(tx); err != nil {
+				continue
+			}
+		}
+
+		if _, ok := rewound[addr]; ok || !p.accounts.exists(addr) {
+			continue
+		}
+
+		rewound[addr] = struct{}{}
+
+		account := p.accounts.get(addr)
+		demoted := account.rewind(p.store.GetNonce(stateRoot, addr), p.promoteReqCh)
+
+		allDemoted = append(allDemoted, demoted...)
+	}
+
+	if len(allDemoted) > 0 {
+		p.eventManager.signalEvent(proto.EventType_DEMOTED, toHash(allDemoted...)...)
+		p.metrics.PendingTxs.Add(float64(-1 * len(allDemoted)))
+	}
+}
+
 // createAccountOnce creates an account and
 // ensures it is only initialized once.
 func (p *TxPool) createAccountOnce(newAddr types.Address) *account {
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ExzoNetwork/ExzoCoin/blockchain"
 	"github.com/ExzoNetwork/ExzoCoin/chain"
 	"github.com/ExzoNetwork/ExzoCoin/crypto"
 	"github.com/ExzoNetwork/ExzoCoin/helper/tests"
@@ -1331,6 +1332,97 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
 }
 
+func TestDropTxAndAccount(t *testing.T) {
+	t.Parallel()
+
+	// setupPool returns the pool with the promoted txs of nonces 0, 1, 2
+	// and the enqueued tx of nonce 4
+	setupPool := func(t *testing.T) (*TxPool, []*types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		txs := []*types.Transaction{
+			newTx(addr1, 0, 1),
+			newTx(addr1, 1, 1),
+			newTx(addr1, 2, 1),
+			newTx(addr1, 4, 1),
+		}
+
+		for _, tx := range txs {
+			go func(tx *types.Transaction) {
+				assert.NoError(t, pool.addTx(local, tx))
+			}(tx)
+
+			if tx.Nonce == 4 {
+				pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+				continue
+			}
+
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+		}
+
+		assert.Equal(t, uint64(4), pool.gauge.read())
+		assert.Equal(t, uint64(3), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(3), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+		return pool, txs
+	}
+
+	t.Run("drop promoted tx with the following txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		assert.Equal(t, toHash(txs[1:]...), pool.DropTx(txs[1].Hash))
+
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+
+		_, exists := pool.index.get(txs[2].Hash)
+		assert.False(t, exists)
+
+		// the dropped tx isn't known anymore
+		assert.Empty(t, pool.DropTx(txs[1].Hash))
+	})
+
+	t.Run("drop enqueued tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		assert.Equal(t, toHash(txs[3]), pool.DropTx(txs[3].Hash))
+
+		assert.Equal(t, uint64(3), pool.gauge.read())
+		assert.Equal(t, uint64(3), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(3), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+	})
+
+	t.Run("drop account", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		assert.ElementsMatch(t, toHash(txs...), pool.DropAccount(addr1))
+
+		assert.Equal(t, uint64(0), pool.gauge.read())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+
+		// unknown account
+		assert.Empty(t, pool.DropAccount(addr2))
+	})
+}
+
 func TestDemote(t *testing.T) {
 	t.Parallel()
 
@@ -2426,3 +2518,52 @@ func TestGetTxs(t *testing.T) {
 		})
 	}
 }
+
+func TestResetWithReorg(t *testing.T) {
+	t.Parallel()
+
+	// the tx with nonce 0 is in the head block
+	removedTx := newTx(addr1, 0, 1)
+	removedBlock := &types.Block{
+		Header:       &types.Header{Number: 1, Hash: types.StringToHash("1")},
+		Transactions: []*types.Transaction{removedTx},
+	}
+
+	store := &reorgMockStore{
+		defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+		nonces:           map[types.Address]uint64{addr1: 1},
+		blocks:           map[types.Hash]*types.Block{removedBlock.Hash(): removedBlock},
+	}
+
+	pool, err := newTestPool(store)
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	defer pool.Close()
+
+	assert.NoError(t, pool.addTx(local, newTx(addr1, 1, 1)))
+
+	assert.Eventually(t, func() bool {
+		return pool.Length() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the head block is removed, so the nonce of the account goes back to 0
+	store.nonces[addr1] = 0
+
+	pool.ResetWithReorg(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: []*types.Header{removedBlock.Header},
+	})
+
+	// both txs are promoted again, in the order of the nonces
+	assert.Eventually(t, func() bool {
+		return pool.Length() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	account := pool.accounts.get(addr1)
+	assert.Equal(t, uint64(2), account.getNonce())
+	assert.Equal(t, uint64(0), account.enqueued.length())
+	assert.Equal(t, uint64(0), account.promoted.peek().Nonce)
+	assert.Equal(t, uint64(2), pool.gauge.read())
+}
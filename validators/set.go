@@ -112,6 +112,28 @@ func (s *Set) Del(val Validator) error {
 	return nil
 }
 
+// Replace replaces the validator whose address matches with the given address
+// by the given validator, keeping its position in the collection
+func (s *Set) Replace(addr types.Address, val Validator) error {
+	if s.ValidatorType != val.Type() {
+		return ErrMismatchValidatorType
+	}
+
+	index := s.Index(addr)
+
+	if index == -1 {
+		return ErrValidatorNotFound
+	}
+
+	if val.Addr() != addr && s.Includes(val.Addr()) {
+		return ErrValidatorAlreadyExists
+	}
+
+	s.Validators[index] = val
+
+	return nil
+}
+
 // Merge introduces the given collection into its collection
 func (s *Set) Merge(ss Validators) error {
 	if s.ValidatorType != ss.Type() {
@@ -477,6 +477,104 @@ func TestSetDel(t *testing.T) {
 	}
 }
 
+func TestSetReplace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		validators         Validators
+		address            types.Address
+		newValidator       Validator
+		expectedErr        error
+		expectedValidators Validators
+	}{
+		{
+			name: "should return error in case of type mismatch",
+			validators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+			),
+			address:      addr1,
+			newValidator: NewBLSValidator(addr2, testBLSPubKey2),
+			expectedErr:  ErrMismatchValidatorType,
+			expectedValidators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+			),
+		},
+		{
+			name: "should return error in case of non-existing validator",
+			validators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+			),
+			address:      addr2,
+			newValidator: NewECDSAValidator(addr2),
+			expectedErr:  ErrValidatorNotFound,
+			expectedValidators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+			),
+		},
+		{
+			name: "should return error if new validator exists already",
+			validators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+				NewECDSAValidator(addr2),
+			),
+			address:      addr1,
+			newValidator: NewECDSAValidator(addr2),
+			expectedErr:  ErrValidatorAlreadyExists,
+			expectedValidators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+				NewECDSAValidator(addr2),
+			),
+		},
+		{
+			name: "should replace ECDSA Validator at the same position",
+			validators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr1),
+				NewECDSAValidator(types.StringToAddress("3")),
+			),
+			address:      addr1,
+			newValidator: NewECDSAValidator(addr2),
+			expectedErr:  nil,
+			expectedValidators: NewECDSAValidatorSet(
+				NewECDSAValidator(addr2),
+				NewECDSAValidator(types.StringToAddress("3")),
+			),
+		},
+		{
+			name: "should replace BLS Public Key",
+			validators: NewBLSValidatorSet(
+				NewBLSValidator(addr1, testBLSPubKey1),
+			),
+			address:      addr1,
+			newValidator: NewBLSValidator(addr1, testBLSPubKey2),
+			expectedErr:  nil,
+			expectedValidators: NewBLSValidatorSet(
+				NewBLSValidator(addr1, testBLSPubKey2),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(
+				t,
+				test.validators.Replace(test.address, test.newValidator),
+				test.expectedErr,
+			)
+
+			assert.Equal(
+				t,
+				test.expectedValidators,
+				test.validators,
+			)
+		})
+	}
+}
+
 func TestSetMerge(t *testing.T) {
 	t.Parallel()
 
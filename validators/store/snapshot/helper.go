@@ -1,6 +1,8 @@
 package snapshot
 
 import (
+	"encoding/binary"
+
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/ExzoNetwork/ExzoCoin/validators"
 )
@@ -19,6 +21,30 @@ func isAuthorize(
 	}
 }
 
+// keyRotationNonce is a helper function to return the nonce
+// registering the key rotation taking effect at the given height
+func keyRotationNonce(height uint64) types.Nonce {
+	var nonce types.Nonce
+
+	binary.BigEndian.PutUint64(nonce[:], height)
+	nonce[0] = nonceKeyRotationPrefix
+
+	return nonce
+}
+
+// isKeyRotationNonce is a helper function to return the flag
+// indicating whether the nonce registers a key rotation
+func isKeyRotationNonce(nonce types.Nonce) bool {
+	return nonce[0] == nonceKeyRotationPrefix
+}
+
+// keyRotationHeight is a helper function to return the height in the nonce registering a key rotation
+func keyRotationHeight(nonce types.Nonce) uint64 {
+	nonce[0] = 0
+
+	return binary.BigEndian.Uint64(nonce[:])
+}
+
 // shouldProcessVote is a helper function to return
 // the flag indicating whether vote should be processed or not
 // based on vote action and validator set
@@ -304,3 +304,16 @@ func Test_addsOrDelsCandidate(t *testing.T) {
 		})
 	}
 }
+
+func Test_keyRotationNonce(t *testing.T) {
+	t.Parallel()
+
+	nonce := keyRotationNonce(0x0102030405)
+
+	assert.Equal(t, types.Nonce{0x01, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05}, nonce)
+	assert.True(t, isKeyRotationNonce(nonce))
+	assert.Equal(t, uint64(0x0102030405), keyRotationHeight(nonce))
+
+	assert.False(t, isKeyRotationNonce(nonceAuthVote))
+	assert.False(t, isKeyRotationNonce(nonceDropVote))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ExzoNetwork/ExzoCoin/crypto"
 	"github.com/ExzoNetwork/ExzoCoin/types"
 	"github.com/ExzoNetwork/ExzoCoin/validators"
 	"github.com/ExzoNetwork/ExzoCoin/validators/store"
@@ -21,6 +22,8 @@ type SignerInterface interface {
 	Type() validators.ValidatorType
 	EcrecoverFromHeader(*types.Header) (types.Address, error)
 	GetValidators(*types.Header) (validators.Validators, error)
+	GetKeyRotationProof(*types.Header) ([]byte, error)
+	WriteKeyRotationProof(*types.Header, []byte) error
 }
 
 var (
@@ -29,6 +32,10 @@ var (
 
 	// Magic nonce number to vote on removing a validator.
 	nonceDropVote = types.Nonce{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	// The first byte of the nonce to register the successor key of the proposer.
+	// The rest of the nonce is the height from which the successor key is used, in big endian
+	nonceKeyRotationPrefix byte = 0x01
 )
 
 var (
@@ -41,6 +48,11 @@ var (
 	ErrCandidateNotExistInSet       = errors.New("cannot remove a validator if they're not in the snapshot")
 	ErrAlreadyVoted                 = errors.New("already voted for this address")
 	ErrMultipleVotesBySameValidator = errors.New("more than one proposal per validator per address found")
+	ErrNotValidator                 = errors.New("the address is not a validator")
+	ErrInvalidKeyRotationHeight     = errors.New("key rotation must take effect at a future height")
+	ErrSuccessorIsValidator         = errors.New("the successor is already a validator")
+	ErrKeyRotationNotEnabled        = errors.New("key rotation is not enabled at the height")
+	ErrInvalidKeyRotationProof      = errors.New("invalid proof of possession of the successor key")
 )
 
 type SnapshotValidatorStore struct {
@@ -50,12 +62,17 @@ type SnapshotValidatorStore struct {
 	getSigner  func(uint64) (SignerInterface, error)
 
 	// configuration
-	epochSize uint64
+	epochSize       uint64
+	keyRotationFrom *uint64 // height from which headers can register key rotations, if set
 
 	// data
 	store          *snapshotStore
 	candidates     []*store.Candidate
 	candidatesLock sync.RWMutex
+
+	// key rotation of this node waiting to be put in the header
+	rotation      *store.KeyRotation
+	rotationProof []byte
 }
 
 // NewSnapshotValidatorStore creates and initializes *SnapshotValidatorStore
@@ -64,17 +81,19 @@ func NewSnapshotValidatorStore(
 	blockchain store.HeaderGetter,
 	getSigner func(uint64) (SignerInterface, error),
 	epochSize uint64,
+	keyRotationFrom *uint64,
 	metadata *SnapshotMetadata,
 	snapshots []*Snapshot,
 ) (*SnapshotValidatorStore, error) {
 	set := &SnapshotValidatorStore{
-		logger:         logger.Named(loggerName),
-		store:          newSnapshotStore(metadata, snapshots),
-		blockchain:     blockchain,
-		getSigner:      getSigner,
-		candidates:     make([]*store.Candidate, 0),
-		candidatesLock: sync.RWMutex{},
-		epochSize:      epochSize,
+		logger:          logger.Named(loggerName),
+		store:           newSnapshotStore(metadata, snapshots),
+		blockchain:      blockchain,
+		getSigner:       getSigner,
+		candidates:      make([]*store.Candidate, 0),
+		candidatesLock:  sync.RWMutex{},
+		epochSize:       epochSize,
+		keyRotationFrom: keyRotationFrom,
 	}
 
 	if err := set.initialize(); err != nil {
@@ -142,6 +161,24 @@ func (s *SnapshotValidatorStore) initialize() error {
 	return nil
 }
 
+// Rewind drops the snapshots of the blocks above the height, after they are removed from the chain.
+// The chain head needs to be the block at the height
+func (s *SnapshotValidatorStore) Rewind(height uint64) error {
+	if height >= s.store.getLastBlock() {
+		return nil
+	}
+
+	s.store.deleteUpper(height)
+	s.store.updateLastBlock(height)
+
+	if s.getSnapshot(height) != nil {
+		return nil
+	}
+
+	// the lower snapshots have been purged, rebuild from the beginning of the epoch
+	return s.initialize()
+}
+
 // SourceType returns validator store type
 func (s *SnapshotValidatorStore) SourceType() store.SourceType {
 	return store.Snapshot
@@ -184,6 +221,16 @@ func (s *SnapshotValidatorStore) Votes(height uint64) ([]*store.Vote, error) {
 	return snapshot.Votes, nil
 }
 
+// KeyRotations returns the key rotations registered in the snapshot at the specified height
+func (s *SnapshotValidatorStore) KeyRotations(height uint64) ([]*store.KeyRotation, error) {
+	snapshot := s.getSnapshot(height)
+	if snapshot == nil {
+		return nil, ErrSnapshotNotFound
+	}
+
+	return snapshot.Rotations, nil
+}
+
 // UpdateValidatorSet resets Snapshot with given validators at specified height
 func (s *SnapshotValidatorStore) UpdateValidatorSet(
 	// new validators to be overwritten
@@ -216,6 +263,29 @@ func (s *SnapshotValidatorStore) ModifyHeader(header *types.Header, proposer typ
 		return ErrSnapshotNotFound
 	}
 
+	// key rotation has priority over votes
+	// because it needs to be registered before the height it takes effect
+	if rotation, proof := s.getPendingKeyRotation(snapshot, header.Number, proposer); rotation != nil {
+		var err error
+
+		if header.Miner, err = validatorToMiner(rotation.Successor); err != nil {
+			return err
+		}
+
+		header.Nonce = keyRotationNonce(rotation.Height)
+
+		if len(proof) == 0 {
+			return nil
+		}
+
+		signer, err := s.getSigner(header.Number)
+		if err != nil {
+			return err
+		}
+
+		return signer.WriteKeyRotationProof(header, proof)
+	}
+
 	if candidate := s.getNextCandidate(snapshot, proposer); candidate != nil {
 		var err error
 
@@ -240,11 +310,40 @@ func (s *SnapshotValidatorStore) VerifyHeader(header *types.Header) error {
 	// The nonce field must have either an AUTH or DROP vote value.
 	// Block nonce values are not taken into account when the Miner field is set to zeroes, indicating
 	// no vote casting is taking place within a block
-	if header.Nonce != nonceAuthVote && header.Nonce != nonceDropVote {
+	if header.Nonce == nonceAuthVote || header.Nonce == nonceDropVote {
+		return nil
+	}
+
+	// the nonce registering a key rotation is valid only after key rotation is enabled
+	if !isKeyRotationNonce(header.Nonce) || !s.isKeyRotationEnabled(header.Number) {
 		return ErrInvalidNonce
 	}
 
-	return nil
+	if bytes.Equal(header.Miner, types.ZeroAddress[:]) {
+		return nil
+	}
+
+	signer, err := s.getSigner(header.Number)
+	if err != nil {
+		return err
+	}
+
+	successor, err := minerToValidator(signer.Type(), header.Miner)
+	if err != nil {
+		return err
+	}
+
+	proof, err := signer.GetKeyRotationProof(header)
+	if err != nil {
+		return err
+	}
+
+	return verifyKeyRotationProof(successor, proof)
+}
+
+// isKeyRotationEnabled returns whether the header at the given height can register a key rotation
+func (s *SnapshotValidatorStore) isKeyRotationEnabled(height uint64) bool {
+	return s.keyRotationFrom != nil && height >= *s.keyRotationFrom
 }
 
 // ProcessHeadersInRange is a helper function process headers in the given range
@@ -300,6 +399,7 @@ func (s *SnapshotValidatorStore) ProcessHeader(
 
 	// Reset votes when new epoch
 	if header.Number%s.epochSize == 0 {
+		applyKeyRotations(snap, header.Number+1)
 		s.resetSnapshot(parentSnap, snap, header)
 		s.removeLowerSnapshots(header.Number)
 		s.store.updateLastBlock(header.Number)
@@ -308,16 +408,25 @@ func (s *SnapshotValidatorStore) ProcessHeader(
 	}
 
 	// no vote if miner field is not set
-	if bytes.Equal(header.Miner, types.ZeroAddress[:]) {
-		s.store.updateLastBlock(header.Number)
-
-		return nil
+	if !bytes.Equal(header.Miner, types.ZeroAddress[:]) {
+		if isKeyRotationNonce(header.Nonce) && s.isKeyRotationEnabled(header.Number) {
+			proof, err := signer.GetKeyRotationProof(header)
+			if err != nil {
+				return err
+			}
+
+			// Register the successor key of the proposer
+			if err := processKeyRotation(snap, header, signer.Type(), proposer, proof); err != nil {
+				return err
+			}
+		} else if err := processVote(snap, header, signer.Type(), proposer); err != nil {
+			// Process votes in the middle of epoch
+			return err
+		}
 	}
 
-	// Process votes in the middle of epoch
-	if err := processVote(snap, header, signer.Type(), proposer); err != nil {
-		return err
-	}
+	// Replace the validators rotating their keys from the next block
+	applyKeyRotations(snap, header.Number+1)
 
 	s.store.updateLastBlock(header.Number)
 	s.saveSnapshotIfChanged(parentSnap, snap, header)
@@ -365,6 +474,102 @@ func (s *SnapshotValidatorStore) Propose(candidate validators.Validator, auth bo
 	)
 }
 
+// ProposeKeyRotation registers the successor key of the validator to be put in the header
+// the validator proposes next with the proof of possession of the successor key,
+// so that the successor replaces the validator from the given height
+func (s *SnapshotValidatorStore) ProposeKeyRotation(
+	validator types.Address,
+	successor validators.Validator,
+	proof []byte,
+	height uint64,
+) error {
+	if err := verifyKeyRotationProof(successor, proof); err != nil {
+		return err
+	}
+
+	snap := s.getLatestSnapshot()
+	if snap == nil {
+		return ErrSnapshotNotFound
+	}
+
+	if !snap.Set.Includes(validator) {
+		return ErrNotValidator
+	}
+
+	if successor.Addr() != validator && snap.Set.Includes(successor.Addr()) {
+		return ErrSuccessorIsValidator
+	}
+
+	// the rotation needs to be put in a header before the height
+	if height <= s.store.getLastBlock()+2 {
+		return ErrInvalidKeyRotationHeight
+	}
+
+	// the last header that can register the rotation
+	if !s.isKeyRotationEnabled(height - 2) {
+		return ErrKeyRotationNotEnabled
+	}
+
+	rotation := &store.KeyRotation{
+		Validator: validator,
+		Successor: successor,
+		Height:    height,
+	}
+
+	s.candidatesLock.Lock()
+	defer s.candidatesLock.Unlock()
+
+	for _, registered := range snap.Rotations {
+		if registered.Equal(rotation) {
+			// the rotation has been registered already
+			s.rotation, s.rotationProof = nil, nil
+
+			return nil
+		}
+	}
+
+	s.rotation, s.rotationProof = rotation, proof
+
+	return nil
+}
+
+// getPendingKeyRotation returns the key rotation of the proposer that has not been registered yet
+// and the proof of possession of the successor key
+func (s *SnapshotValidatorStore) getPendingKeyRotation(
+	snap *Snapshot,
+	height uint64,
+	proposer types.Address,
+) (*store.KeyRotation, []byte) {
+	s.candidatesLock.Lock()
+	defer s.candidatesLock.Unlock()
+
+	if s.rotation == nil || s.rotation.Validator != proposer || !s.isKeyRotationEnabled(height) {
+		return nil, nil
+	}
+
+	for _, registered := range snap.Rotations {
+		if registered.Equal(s.rotation) {
+			s.rotation, s.rotationProof = nil, nil
+
+			return nil, nil
+		}
+	}
+
+	if s.rotation.Height <= height+1 {
+		s.logger.Error(
+			"key rotation couldn't be registered before the height",
+			"successor", s.rotation.Successor.Addr(),
+			"height", s.rotation.Height,
+		)
+
+		s.rotation, s.rotationProof = nil, nil
+
+		return nil, nil
+	}
+
+	return s.rotation, s.rotationProof
+}
+
 // AddCandidate adds new candidate to candidate list
 // unsafe against concurrent access
 func (s *SnapshotValidatorStore) addCandidate(
@@ -587,6 +792,97 @@ func processVote(
 	return nil
 }
 
+// processKeyRotation registers the successor key of the proposer in the given header
+func processKeyRotation(
+	snapshot *Snapshot,
+	header *types.Header,
+	candidateType validators.ValidatorType,
+	proposer types.Address,
+	proof []byte,
+) error {
+	height := keyRotationHeight(header.Nonce)
+
+	// the successor can't sign the next block
+	// because the next proposer and validators are determined already
+	if height <= header.Number+1 {
+		return ErrInvalidKeyRotationHeight
+	}
+
+	successor, err := minerToValidator(candidateType, header.Miner)
+	if err != nil {
+		return err
+	}
+
+	if successor.Addr() != proposer && snapshot.Set.Includes(successor.Addr()) {
+		return ErrSuccessorIsValidator
+	}
+
+	if err := verifyKeyRotationProof(successor, proof); err != nil {
+		return err
+	}
+
+	snapshot.AddKeyRotation(&store.KeyRotation{
+		Validator: proposer,
+		Successor: successor,
+		Height:    height,
+	})
+
+	return nil
+}
+
+// verifyKeyRotationProof verifies the proof of possession of the successor BLS key.
+// The committed seals are aggregated, so that the successor could cancel the keys of other validators
+// in the aggregated public key by a rogue key without the proof. ECDSA keys need no proof
+func verifyKeyRotationProof(successor validators.Validator, proof []byte) error {
+	blsSuccessor, ok := successor.(*validators.BLSValidator)
+	if !ok {
+		return nil
+	}
+
+	if err := crypto.VerifyBLSProofOfPossession(blsSuccessor.BLSPublicKey, proof); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidKeyRotationProof, err)
+	}
+
+	return nil
+}
+
+// applyKeyRotations replaces the validators by the successors
+// whose keys are used from the given height
+func applyKeyRotations(
+	snapshot *Snapshot,
+	height uint64,
+) {
+	for _, rotation := range snapshot.Rotations {
+		if rotation.Height > height {
+			continue
+		}
+
+		// the rotation is dropped if the validator has been removed
+		// or the successor has joined as another validator in the meantime
+		if err := snapshot.Set.Replace(rotation.Validator, rotation.Successor); err != nil {
+			continue
+		}
+
+		successor := rotation.Successor.Addr()
+
+		// the votes by the validator are carried over to the successor
+		for _, vote := range snapshot.Votes {
+			if vote.Validator == rotation.Validator {
+				vote.Validator = successor
+			}
+		}
+
+		// the votes for the validator are obsolete
+		snapshot.RemoveVotes(func(v *store.Vote) bool {
+			return v.Candidate.Addr() == rotation.Validator || v.Candidate.Addr() == successor
+		})
+	}
+
+	snapshot.RemoveKeyRotations(func(r *store.KeyRotation) bool {
+		return r.Height <= height
+	})
+}
+
 // validatorToMiner converts validator to bytes for miner field in header
 func validatorToMiner(validator validators.Validator) ([]byte, error) {
 	switch validator.(type) {
@@ -30,9 +30,11 @@ func (f *fakeValidator) Addr() types.Address {
 }
 
 type mockSigner struct {
-	TypeFn                func() validators.ValidatorType
-	EcrecoverFromHeaderFn func(*types.Header) (types.Address, error)
-	GetValidatorsFn       func(*types.Header) (validators.Validators, error)
+	TypeFn                  func() validators.ValidatorType
+	EcrecoverFromHeaderFn   func(*types.Header) (types.Address, error)
+	GetValidatorsFn         func(*types.Header) (validators.Validators, error)
+	GetKeyRotationProofFn   func(*types.Header) ([]byte, error)
+	WriteKeyRotationProofFn func(*types.Header, []byte) error
 }
 
 func (m *mockSigner) Type() validators.ValidatorType {
@@ -47,6 +49,30 @@ func (m *mockSigner) GetValidators(h *types.Header) (validators.Validators, erro
 	return m.GetValidatorsFn(h)
 }
 
+func (m *mockSigner) GetKeyRotationProof(h *types.Header) ([]byte, error) {
+	return m.GetKeyRotationProofFn(h)
+}
+
+func (m *mockSigner) WriteKeyRotationProof(h *types.Header, proof []byte) error {
+	return m.WriteKeyRotationProofFn(h, proof)
+}
+
+// newTestBLSSuccessor returns the BLS validator with a new key and the proof of possession of the key
+func newTestBLSSuccessor(t *testing.T, addr types.Address) (*validators.BLSValidator, []byte) {
+	t.Helper()
+
+	key, err := crypto.GenerateBLSKey()
+	assert.NoError(t, err)
+
+	pubkey, err := crypto.BLSSecretKeyToPubkeyBytes(key)
+	assert.NoError(t, err)
+
+	proof, err := crypto.CreateBLSProofOfPossession(key)
+	assert.NoError(t, err)
+
+	return validators.NewBLSValidator(addr, pubkey), proof
+}
+
 func newTestHeaderHash(height uint64) types.Hash {
 	return types.BytesToHash(crypto.Keccak256(big.NewInt(int64(height)).Bytes()))
 }
@@ -130,6 +156,7 @@ func TestNewSnapshotValidatorStore(t *testing.T) {
 				return nil, errTest
 			},
 			epochSize,
+			nil,
 			metadata,
 			snapshots,
 		)
@@ -167,6 +194,7 @@ func TestNewSnapshotValidatorStore(t *testing.T) {
 			blockchain,
 			getSigner,
 			epochSize,
+			nil,
 			metadata,
 			snapshots,
 		)
@@ -1116,6 +1144,110 @@ func TestSnapshotValidatorStoreVerifyHeader(t *testing.T) {
 	}
 }
 
+func TestSnapshotValidatorStoreVerifyHeaderKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		keyRotationFrom uint64 = 10
+
+		successor, proof = newTestBLSSuccessor(t, addr3)
+		_, otherProof    = newTestBLSSuccessor(t, addr3)
+	)
+
+	tests := []struct {
+		name            string
+		keyRotationFrom *uint64
+		height          uint64
+		miner           []byte
+		proof           []byte
+		expectedErr     error
+	}{
+		{
+			name:            "should return ErrInvalidNonce if key rotation is not enabled",
+			keyRotationFrom: nil,
+			height:          10,
+			miner:           successor.Bytes(),
+			proof:           proof,
+			expectedErr:     ErrInvalidNonce,
+		},
+		{
+			name:            "should return ErrInvalidNonce before key rotation is enabled",
+			keyRotationFrom: &keyRotationFrom,
+			height:          9,
+			miner:           successor.Bytes(),
+			proof:           proof,
+			expectedErr:     ErrInvalidNonce,
+		},
+		{
+			name:            "should return ErrInvalidKeyRotationProof without proof",
+			keyRotationFrom: &keyRotationFrom,
+			height:          10,
+			miner:           successor.Bytes(),
+			proof:           nil,
+			expectedErr:     ErrInvalidKeyRotationProof,
+		},
+		{
+			name:            "should return ErrInvalidKeyRotationProof for the proof of another key",
+			keyRotationFrom: &keyRotationFrom,
+			height:          10,
+			miner:           successor.Bytes(),
+			proof:           otherProof,
+			expectedErr:     ErrInvalidKeyRotationProof,
+		},
+		{
+			name:            "should return nil for the proof of the successor key",
+			keyRotationFrom: &keyRotationFrom,
+			height:          10,
+			miner:           successor.Bytes(),
+			proof:           proof,
+			expectedErr:     nil,
+		},
+		{
+			name:            "should return nil without successor",
+			keyRotationFrom: &keyRotationFrom,
+			height:          10,
+			miner:           types.ZeroAddress.Bytes(),
+			proof:           nil,
+			expectedErr:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := newTestHeader(test.height, test.miner, keyRotationNonce(20))
+
+			snapshotStore := newTestSnapshotValidatorStore(
+				nil,
+				func(u uint64) (SignerInterface, error) {
+					return &mockSigner{
+						TypeFn: func() validators.ValidatorType {
+							return validators.BLSValidatorType
+						},
+						GetKeyRotationProofFn: func(h *types.Header) ([]byte, error) {
+							return test.proof, nil
+						},
+					}, nil
+				},
+				20,
+				nil,
+				nil,
+				0,
+			)
+			snapshotStore.keyRotationFrom = test.keyRotationFrom
+
+			assert.ErrorIs(
+				t,
+				snapshotStore.VerifyHeader(header),
+				test.expectedErr,
+			)
+		})
+	}
+}
+
 func TestSnapshotValidatorStoreProcessHeadersInRange(t *testing.T) {
 	t.Parallel()
 
@@ -1349,6 +1481,218 @@ func TestSnapshotValidatorStoreProcessHeadersInRange(t *testing.T) {
 	}
 }
 
+func TestSnapshotValidatorStoreRewind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize     uint64 = 10
+		validatorType        = validators.ECDSAValidatorType
+
+		genesisValidators = validators.NewECDSAValidatorSet(
+			ecdsaValidator1,
+		)
+	)
+
+	createHeaderWithVote := func(height uint64, candidate validators.Validator, nonce types.Nonce) *types.Header {
+		candidateBytes, _ := validatorToMiner(candidate)
+
+		return &types.Header{
+			Number: height,
+			Hash:   newTestHeaderHash(height),
+			Miner:  candidateBytes,
+			Nonce:  nonce,
+		}
+	}
+
+	newSigner := func(headerCreators map[uint64]types.Address) func(uint64) (SignerInterface, error) {
+		return func(u uint64) (SignerInterface, error) {
+			return &mockSigner{
+				TypeFn: func() validators.ValidatorType {
+					return validatorType
+				},
+				EcrecoverFromHeaderFn: func(h *types.Header) (types.Address, error) {
+					creator, ok := headerCreators[h.Number]
+					if !ok {
+						return types.ZeroAddress, errTest
+					}
+
+					return creator, nil
+				},
+				GetValidatorsFn: func(h *types.Header) (validators.Validators, error) {
+					return genesisValidators, nil
+				},
+			}, nil
+		}
+	}
+
+	t.Run("should drop the snapshots of the removed blocks", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			head    uint64 = 4
+			headers        = map[uint64]*types.Header{
+				0: newTestHeader(0, types.ZeroAddress.Bytes(), nonceDropVote),
+				1: createHeaderWithVote(1, ecdsaValidator2, nonceAuthVote),
+				2: createHeaderWithVote(2, ecdsaValidator3, nonceAuthVote),
+				3: createHeaderWithVote(3, ecdsaValidator3, nonceAuthVote),
+				4: createHeaderWithVote(4, ecdsaValidator1, nonceDropVote),
+			}
+			headerCreators = map[uint64]types.Address{
+				1: ecdsaValidator1.Address,
+				2: ecdsaValidator2.Address,
+				3: ecdsaValidator1.Address,
+				4: ecdsaValidator2.Address,
+			}
+		)
+
+		snapshotStore := newTestSnapshotValidatorStore(
+			&store.MockBlockchain{
+				HeaderFn: func() *types.Header {
+					return headers[head]
+				},
+				GetHeaderByNumberFn: func(height uint64) (*types.Header, bool) {
+					header, ok := headers[height]
+
+					return header, ok
+				},
+			},
+			newSigner(headerCreators),
+			0,
+			[]*Snapshot{
+				{
+					Number: 0,
+					Hash:   headers[0].Hash.String(),
+					Set:    genesisValidators,
+					Votes:  []*store.Vote{},
+				},
+			},
+			[]*store.Candidate{},
+			epochSize,
+		)
+
+		assert.NoError(t, snapshotStore.ProcessHeadersInRange(1, 4))
+		assert.Len(t, snapshotStore.GetSnapshots(), 5)
+
+		// the chain is rewound to the block 2
+		head = 2
+
+		assert.NoError(t, snapshotStore.Rewind(head))
+
+		assert.Equal(
+			t,
+			[]uint64{0, 1, 2},
+			snapshotNumbers(snapshotStore.GetSnapshots()),
+		)
+		assert.Equal(t, head, snapshotStore.GetSnapshotMetadata().LastBlock)
+
+		// the validator voted in at the block 3 isn't in the set anymore
+		set, err := snapshotStore.GetValidatorsByHeight(4)
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			validators.NewECDSAValidatorSet(
+				ecdsaValidator1,
+				ecdsaValidator2,
+			),
+			set,
+		)
+
+		// the blocks above the head can be processed again
+		headers[3] = createHeaderWithVote(3, ecdsaValidator2, nonceDropVote)
+		head = 3
+
+		assert.NoError(t, snapshotStore.ProcessHeader(headers[3]))
+		assert.Equal(t, head, snapshotStore.GetSnapshotMetadata().LastBlock)
+	})
+
+	t.Run("should rebuild the snapshot from the beginning of the epoch if all are dropped", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			head    uint64 = 12
+			headers        = map[uint64]*types.Header{
+				10: newTestHeader(10, types.ZeroAddress.Bytes(), nonceDropVote),
+				11: newTestHeader(11, types.ZeroAddress.Bytes(), nonceDropVote),
+				12: newTestHeader(12, types.ZeroAddress.Bytes(), nonceDropVote),
+			}
+			headerCreators = map[uint64]types.Address{
+				11: ecdsaValidator1.Address,
+				12: ecdsaValidator1.Address,
+			}
+		)
+
+		snapshotStore := newTestSnapshotValidatorStore(
+			newMockBlockchain(head, headers),
+			newSigner(headerCreators),
+			15,
+			[]*Snapshot{
+				{
+					Number: 14,
+					Set: validators.NewECDSAValidatorSet(
+						ecdsaValidator1,
+						ecdsaValidator2,
+					),
+					Votes: []*store.Vote{},
+				},
+			},
+			[]*store.Candidate{},
+			epochSize,
+		)
+
+		assert.NoError(t, snapshotStore.Rewind(head))
+
+		assert.Equal(
+			t,
+			[]*Snapshot{
+				{
+					Number: 10,
+					Hash:   headers[10].Hash.String(),
+					Set:    genesisValidators,
+					Votes:  []*store.Vote{},
+				},
+			},
+			snapshotStore.GetSnapshots(),
+		)
+		assert.Equal(t, head, snapshotStore.GetSnapshotMetadata().LastBlock)
+	})
+
+	t.Run("should do nothing if the height isn't below the last block", func(t *testing.T) {
+		t.Parallel()
+
+		snapshots := []*Snapshot{
+			{
+				Number: 5,
+				Set:    genesisValidators,
+				Votes:  []*store.Vote{},
+			},
+		}
+
+		snapshotStore := newTestSnapshotValidatorStore(
+			newMockBlockchain(5, map[uint64]*types.Header{}),
+			newSigner(nil),
+			5,
+			snapshots,
+			[]*store.Candidate{},
+			epochSize,
+		)
+
+		assert.NoError(t, snapshotStore.Rewind(5))
+
+		assert.Equal(t, snapshots, snapshotStore.GetSnapshots())
+		assert.Equal(t, uint64(5), snapshotStore.GetSnapshotMetadata().LastBlock)
+	})
+}
+
+func snapshotNumbers(snapshots []*Snapshot) []uint64 {
+	numbers := make([]uint64, len(snapshots))
+
+	for i, snap := range snapshots {
+		numbers[i] = snap.Number
+	}
+
+	return numbers
+}
+
 func TestSnapshotValidatorStoreProcessHeader(t *testing.T) {
 	t.Parallel()
 
@@ -2800,3 +3144,304 @@ func Test_minerToValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotValidatorStoreKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		epochSize uint64 = 100
+		lastBlock uint64 = 10
+
+		// validator 1 rotates its key to validator 3 from height 15
+		rotationHeight uint64 = 15
+
+		headers = map[uint64]*types.Header{}
+	)
+
+	snapshotStore := newTestSnapshotValidatorStore(
+		newMockBlockchain(lastBlock, headers),
+		func(u uint64) (SignerInterface, error) {
+			return &mockSigner{
+				TypeFn: func() validators.ValidatorType {
+					return validators.ECDSAValidatorType
+				},
+				EcrecoverFromHeaderFn: func(h *types.Header) (types.Address, error) {
+					return types.BytesToAddress(h.ExtraData), nil
+				},
+				// ECDSA key needs no proof
+				GetKeyRotationProofFn: func(h *types.Header) ([]byte, error) {
+					return nil, nil
+				},
+			}, nil
+		},
+		lastBlock,
+		[]*Snapshot{
+			{
+				Number: lastBlock,
+				Hash:   newTestHeaderHash(lastBlock).String(),
+				Votes: []*store.Vote{
+					newTestVote(ecdsaValidator2, addr1, false),
+					newTestVote(ecdsaValidator1, addr2, false),
+				},
+				Set: validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2),
+			},
+		},
+		[]*store.Candidate{},
+		epochSize,
+	)
+
+	// key rotation is enabled from the next block
+	assert.ErrorIs(
+		t,
+		snapshotStore.ProposeKeyRotation(addr1, ecdsaValidator3, nil, rotationHeight),
+		ErrKeyRotationNotEnabled,
+	)
+
+	keyRotationFrom := lastBlock + 1
+	snapshotStore.keyRotationFrom = &keyRotationFrom
+
+	// processes the header proposed by the given validator
+	processHeader := func(height uint64, proposer types.Address) *types.Header {
+		header := newTestHeader(height, types.ZeroAddress.Bytes(), types.Nonce{})
+		header.ExtraData = proposer.Bytes()
+
+		assert.NoError(t, snapshotStore.ModifyHeader(header, proposer))
+		assert.NoError(t, snapshotStore.VerifyHeader(header))
+		assert.NoError(t, snapshotStore.ProcessHeader(header))
+
+		return header
+	}
+
+	assert.ErrorIs(
+		t,
+		snapshotStore.ProposeKeyRotation(addr3, ecdsaValidator1, nil, rotationHeight),
+		ErrNotValidator,
+	)
+	assert.ErrorIs(
+		t,
+		snapshotStore.ProposeKeyRotation(addr1, ecdsaValidator2, nil, rotationHeight),
+		ErrSuccessorIsValidator,
+	)
+	assert.ErrorIs(
+		t,
+		snapshotStore.ProposeKeyRotation(addr1, ecdsaValidator3, nil, lastBlock+2),
+		ErrInvalidKeyRotationHeight,
+	)
+	assert.NoError(t, snapshotStore.ProposeKeyRotation(addr1, ecdsaValidator3, nil, rotationHeight))
+
+	// the rotation is not put in the header proposed by other validators
+	header := processHeader(11, addr2)
+	assert.Equal(t, types.Nonce{}, header.Nonce)
+
+	header = processHeader(12, addr1)
+	assert.Equal(t, addr3.Bytes(), header.Miner)
+	assert.Equal(t, keyRotationNonce(rotationHeight), header.Nonce)
+
+	rotations, err := snapshotStore.KeyRotations(12)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*store.KeyRotation{
+			{Validator: addr1, Successor: ecdsaValidator3, Height: rotationHeight},
+		},
+		rotations,
+	)
+
+	// the rotation is put in the header only once
+	header = processHeader(13, addr1)
+	assert.Equal(t, types.Nonce{}, header.Nonce)
+
+	vals, err := snapshotStore.GetValidatorsByHeight(13)
+	assert.NoError(t, err)
+	assert.Equal(t, validators.NewECDSAValidatorSet(ecdsaValidator1, ecdsaValidator2), vals)
+
+	// the successor replaces the validator from the rotation height
+	processHeader(14, addr1)
+
+	vals, err = snapshotStore.GetValidatorsByHeight(14)
+	assert.NoError(t, err)
+	assert.Equal(t, validators.NewECDSAValidatorSet(ecdsaValidator3, ecdsaValidator2), vals)
+
+	rotations, err = snapshotStore.KeyRotations(14)
+	assert.NoError(t, err)
+	assert.Empty(t, rotations)
+
+	// the vote by the validator is carried over and the vote for the validator is dropped
+	votes, err := snapshotStore.Votes(14)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*store.Vote{
+			newTestVote(ecdsaValidator2, addr3, false),
+		},
+		votes,
+	)
+}
+
+func Test_processKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	newSnapshot := func() *Snapshot {
+		return &Snapshot{
+			Votes: []*store.Vote{},
+			Set:   validators.NewBLSValidatorSet(blsValidator1, blsValidator2),
+		}
+	}
+
+	t.Run("should return error for the height too low", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := newSnapshot()
+		header := newTestHeader(10, blsValidator3.Bytes(), keyRotationNonce(11))
+
+		assert.ErrorIs(
+			t,
+			processKeyRotation(snapshot, header, validators.BLSValidatorType, addr1, nil),
+			ErrInvalidKeyRotationHeight,
+		)
+	})
+
+	t.Run("should return error if the successor is another validator", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := newSnapshot()
+		header := newTestHeader(10, blsValidator2.Bytes(), keyRotationNonce(20))
+
+		assert.ErrorIs(
+			t,
+			processKeyRotation(snapshot, header, validators.BLSValidatorType, addr1, nil),
+			ErrSuccessorIsValidator,
+		)
+	})
+
+	t.Run("should return error without the proof of possession of the successor key", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			snapshot         = newSnapshot()
+			successor, _     = newTestBLSSuccessor(t, addr1)
+			_, rogueKeyProof = newTestBLSSuccessor(t, addr1)
+			header           = newTestHeader(10, successor.Bytes(), keyRotationNonce(20))
+		)
+
+		assert.ErrorIs(
+			t,
+			processKeyRotation(snapshot, header, validators.BLSValidatorType, addr1, nil),
+			ErrInvalidKeyRotationProof,
+		)
+
+		assert.ErrorIs(
+			t,
+			processKeyRotation(snapshot, header, validators.BLSValidatorType, addr1, rogueKeyProof),
+			ErrInvalidKeyRotationProof,
+		)
+
+		assert.Empty(t, snapshot.Rotations)
+	})
+
+	t.Run("should register the rotation of BLS key only", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			snapshot         = newSnapshot()
+			successor, proof = newTestBLSSuccessor(t, addr1)
+		)
+
+		assert.NoError(
+			t,
+			processKeyRotation(
+				snapshot,
+				newTestHeader(10, successor.Bytes(), keyRotationNonce(20)),
+				validators.BLSValidatorType,
+				addr1,
+				proof,
+			),
+		)
+
+		// the later registration replaces the former one
+		assert.NoError(
+			t,
+			processKeyRotation(
+				snapshot,
+				newTestHeader(11, successor.Bytes(), keyRotationNonce(30)),
+				validators.BLSValidatorType,
+				addr1,
+				proof,
+			),
+		)
+
+		assert.Equal(
+			t,
+			[]*store.KeyRotation{
+				{Validator: addr1, Successor: successor, Height: 30},
+			},
+			snapshot.Rotations,
+		)
+
+		applyKeyRotations(snapshot, 29)
+		assert.Equal(t, validators.NewBLSValidatorSet(blsValidator1, blsValidator2), snapshot.Set)
+
+		applyKeyRotations(snapshot, 30)
+		assert.Equal(t, validators.NewBLSValidatorSet(successor, blsValidator2), snapshot.Set)
+		assert.Empty(t, snapshot.Rotations)
+	})
+}
+
+func TestSnapshotValidatorStoreModifyHeaderKeyRotationProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lastBlock       uint64 = 10
+		keyRotationFrom uint64 = 12
+
+		successor, proof = newTestBLSSuccessor(t, addr3)
+		writtenProofs    = make(map[uint64][]byte)
+	)
+
+	snapshotStore := newTestSnapshotValidatorStore(
+		nil,
+		func(u uint64) (SignerInterface, error) {
+			return &mockSigner{
+				WriteKeyRotationProofFn: func(h *types.Header, proof []byte) error {
+					writtenProofs[h.Number] = proof
+
+					return nil
+				},
+			}, nil
+		},
+		lastBlock,
+		[]*Snapshot{
+			{
+				Number: lastBlock,
+				Hash:   newTestHeaderHash(lastBlock).String(),
+				Votes:  []*store.Vote{},
+				Set:    validators.NewBLSValidatorSet(blsValidator1, blsValidator2),
+			},
+		},
+		[]*store.Candidate{},
+		100,
+	)
+	snapshotStore.keyRotationFrom = &keyRotationFrom
+
+	// the proof of another key is rejected
+	_, otherProof := newTestBLSSuccessor(t, addr3)
+	assert.ErrorIs(
+		t,
+		snapshotStore.ProposeKeyRotation(addr1, successor, otherProof, 20),
+		ErrInvalidKeyRotationProof,
+	)
+
+	assert.NoError(t, snapshotStore.ProposeKeyRotation(addr1, successor, proof, 20))
+
+	// the header before key rotation is enabled doesn't register the rotation
+	header := newTestHeader(11, types.ZeroAddress.Bytes(), types.Nonce{})
+	assert.NoError(t, snapshotStore.ModifyHeader(header, addr1))
+	assert.Equal(t, types.Nonce{}, header.Nonce)
+	assert.NotContains(t, writtenProofs, uint64(11))
+
+	header = newTestHeader(12, types.ZeroAddress.Bytes(), types.Nonce{})
+	assert.NoError(t, snapshotStore.ModifyHeader(header, addr1))
+	assert.Equal(t, successor.Bytes(), header.Miner)
+	assert.Equal(t, keyRotationNonce(20), header.Nonce)
+	assert.Equal(t, proof, writtenProofs[12])
+}
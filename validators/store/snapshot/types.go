@@ -30,21 +30,26 @@ type Snapshot struct {
 
 	// current set of validators
 	Set validators.Validators
+
+	// key rotations registered but not applied yet
+	Rotations []*store.KeyRotation
 }
 
 func (s *Snapshot) MarshalJSON() ([]byte, error) {
 	jsonData := struct {
-		Number uint64
-		Hash   string
-		Votes  []*store.Vote
-		Type   validators.ValidatorType
-		Set    validators.Validators
+		Number    uint64
+		Hash      string
+		Votes     []*store.Vote
+		Type      validators.ValidatorType
+		Set       validators.Validators
+		Rotations []*store.KeyRotation `json:",omitempty"`
 	}{
-		Number: s.Number,
-		Hash:   s.Hash,
-		Votes:  s.Votes,
-		Type:   s.Set.Type(),
-		Set:    s.Set,
+		Number:    s.Number,
+		Hash:      s.Hash,
+		Votes:     s.Votes,
+		Type:      s.Set.Type(),
+		Set:       s.Set,
+		Rotations: s.Rotations,
 	}
 
 	return json.Marshal(jsonData)
@@ -52,11 +57,12 @@ func (s *Snapshot) MarshalJSON() ([]byte, error) {
 
 func (s *Snapshot) UnmarshalJSON(data []byte) error {
 	raw := struct {
-		Number uint64
-		Hash   string
-		Type   string
-		Votes  []json.RawMessage
-		Set    json.RawMessage
+		Number    uint64
+		Hash      string
+		Type      string
+		Votes     []json.RawMessage
+		Set       json.RawMessage
+		Rotations []json.RawMessage
 	}{}
 
 	var err error
@@ -88,6 +94,10 @@ func (s *Snapshot) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if err := s.unmarshalRotationsJSON(valType, raw.Rotations); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -117,6 +127,36 @@ func (s *Snapshot) unmarshalVotesJSON(
 	return nil
 }
 
+// unmarshalRotationsJSON is a helper function to unmarshal for Rotations field
+func (s *Snapshot) unmarshalRotationsJSON(
+	valType validators.ValidatorType,
+	rawRotations []json.RawMessage,
+) error {
+	if len(rawRotations) == 0 {
+		return nil
+	}
+
+	rotations := make([]*store.KeyRotation, len(rawRotations))
+	for idx := range rotations {
+		successor, err := validators.NewValidatorFromType(valType)
+		if err != nil {
+			return err
+		}
+
+		rotations[idx] = &store.KeyRotation{
+			Successor: successor,
+		}
+
+		if err := json.Unmarshal(rawRotations[idx], rotations[idx]); err != nil {
+			return err
+		}
+	}
+
+	s.Rotations = rotations
+
+	return nil
+}
+
 // unmarshalSetJSON is a helper function to unmarshal for Set field
 func (s *Snapshot) unmarshalSetJSON(
 	valType validators.ValidatorType,
@@ -159,6 +199,16 @@ func (s *Snapshot) Equal(ss *Snapshot) bool {
 		}
 	}
 
+	if len(s.Rotations) != len(ss.Rotations) {
+		return false
+	}
+
+	for indx := range s.Rotations {
+		if !s.Rotations[indx].Equal(ss.Rotations[indx]) {
+			return false
+		}
+	}
+
 	return s.Set.Equal(ss.Set)
 }
 
@@ -199,6 +249,14 @@ func (s *Snapshot) Copy() *Snapshot {
 		ss.Votes[indx] = vote.Copy()
 	}
 
+	if len(s.Rotations) > 0 {
+		ss.Rotations = make([]*store.KeyRotation, len(s.Rotations))
+
+		for indx, rotation := range s.Rotations {
+			ss.Rotations[indx] = rotation.Copy()
+		}
+	}
+
 	return ss
 }
 
@@ -255,6 +313,31 @@ func (s *Snapshot) RemoveVotesByCandidate(
 	})
 }
 
+// AddKeyRotation registers the key rotation
+// replacing the rotation of the same validator registered before
+func (s *Snapshot) AddKeyRotation(rotation *store.KeyRotation) {
+	s.RemoveKeyRotations(func(r *store.KeyRotation) bool {
+		return r.Validator == rotation.Validator
+	})
+
+	s.Rotations = append(s.Rotations, rotation)
+}
+
+// RemoveKeyRotations removes the Rotations that meet condition defined in the given function
+func (s *Snapshot) RemoveKeyRotations(shouldRemoveFn func(r *store.KeyRotation) bool) {
+	var newRotations []*store.KeyRotation
+
+	for _, rotation := range s.Rotations {
+		if shouldRemoveFn(rotation) {
+			continue
+		}
+
+		newRotations = append(newRotations, rotation)
+	}
+
+	s.Rotations = newRotations
+}
+
 // snapshotStore defines the structure of the stored snapshots
 type snapshotStore struct {
 	sync.RWMutex
@@ -315,6 +398,18 @@ func (s *snapshotStore) deleteLower(num uint64) {
 	s.list = s.list[i:]
 }
 
+// deleteUpper deletes snapshots that have a block number higher than the passed in parameter
+func (s *snapshotStore) deleteUpper(num uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	i := sort.Search(len(s.list), func(i int) bool {
+		return s.list[i].Number > num
+	})
+
+	s.list = s.list[:i]
+}
+
 // find returns the index of the first closest snapshot to the number specified
 func (s *snapshotStore) find(num uint64) *Snapshot {
 	s.RLock()
@@ -1133,6 +1133,46 @@ func Test_snapshotStore_deleteLower(t *testing.T) {
 	)
 }
 
+func Test_snapshotStore_deleteUpper(t *testing.T) {
+	t.Parallel()
+
+	var (
+		metadata = &SnapshotMetadata{
+			LastBlock: 30,
+		}
+
+		snapshots = []*Snapshot{
+			{Number: 10},
+			{Number: 19},
+			{Number: 20},
+			{Number: 21},
+			{Number: 30},
+		}
+
+		boundary = uint64(20)
+	)
+
+	store := newSnapshotStore(
+		metadata,
+		snapshots,
+	)
+
+	store.deleteUpper(boundary)
+
+	assert.Equal(
+		t,
+		&snapshotStore{
+			lastNumber: metadata.LastBlock,
+			list: []*Snapshot{
+				{Number: 10},
+				{Number: 19},
+				{Number: 20},
+			},
+		},
+		store,
+	)
+}
+
 func Test_snapshotStore_find(t *testing.T) {
 	t.Parallel()
 
@@ -1328,3 +1368,31 @@ func Test_snapshotStore_putByNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotJSONWithKeyRotations(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &Snapshot{
+		Number: testNumber,
+		Hash:   testHash.String(),
+		Votes:  []*store.Vote{},
+		Set:    validators.NewBLSValidatorSet(blsValidator1, blsValidator2),
+		Rotations: []*store.KeyRotation{
+			{
+				Validator: blsValidator1.Addr(),
+				Successor: blsValidator3,
+				Height:    100,
+			},
+		},
+	}
+
+	data, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+
+	restored := &Snapshot{}
+
+	assert.NoError(t, json.Unmarshal(data, restored))
+	assert.Equal(t, snapshot, restored)
+	assert.True(t, snapshot.Equal(restored))
+	assert.True(t, snapshot.Equal(snapshot.Copy()))
+}
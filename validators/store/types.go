@@ -111,3 +111,46 @@ type Candidate struct {
 	Validator validators.Validator
 	Authorize bool
 }
+
+// KeyRotation is the registration of the successor key of a validator,
+// which replaces the validator in the set from the given height
+type KeyRotation struct {
+	Validator types.Address        // Validator rotating its key
+	Successor validators.Validator // Validator with the successor key
+	Height    uint64               // First height the successor signs
+}
+
+// Equal checks if two key rotations are equal
+func (r *KeyRotation) Equal(rr *KeyRotation) bool {
+	return r.Validator == rr.Validator &&
+		r.Height == rr.Height &&
+		r.Successor.Equal(rr.Successor)
+}
+
+// Copy makes a copy of the key rotation, and returns it
+func (r *KeyRotation) Copy() *KeyRotation {
+	return &KeyRotation{
+		Validator: r.Validator,
+		Successor: r.Successor.Copy(),
+		Height:    r.Height,
+	}
+}
+
+// UnmarshalJSON is JSON unmarshaler
+// Successor needs to be initialized with the validator type before unmarshalling
+func (r *KeyRotation) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Validator types.Address
+		Successor json.RawMessage
+		Height    uint64
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.Validator = raw.Validator
+	r.Height = raw.Height
+
+	return json.Unmarshal(raw.Successor, r.Successor)
+}
@@ -82,6 +82,8 @@ type Validators interface {
 	Add(Validator) error
 	// Remove a validator from collection
 	Del(Validator) error
+	// Replace the validator that has specified address at the same position
+	Replace(types.Address, Validator) error
 	// Merge 2 collections into one collection
 	Merge(Validators) error
 	// RLP Marshaller to encode to bytes